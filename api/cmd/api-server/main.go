@@ -7,6 +7,7 @@ import (
 	"flowcraft-api/internal/adapters/realtime"
 	"flowcraft-api/internal/adapters/websocket"
 	"flowcraft-api/internal/config"
+	"flowcraft-api/internal/egress"
 	"flowcraft-api/internal/temporal"
 	"flowcraft-api/internal/utils"
 )
@@ -15,6 +16,13 @@ func main() {
 	cfg := config.Load()
 	logger := utils.NewLogger()
 
+	// Flows call user-supplied URLs; hold all outbound HTTP to the egress policy.
+	policy, err := egress.FromConfig(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("invalid egress policy")
+	}
+	egress.Install(policy)
+
 	db, err := postgres.Connect(cfg.DatabaseURL)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to connect db")
@@ -39,9 +47,9 @@ func main() {
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to initialize postgres listener")
 	}
-	go pgListener.ListenWithRetry(context.Background())
 
-	router := httpadapter.NewRouter(cfg, db, logger, temporalClient, hub)
+	router := httpadapter.NewRouter(cfg, db, logger, temporalClient, hub, pgListener)
+	go pgListener.ListenWithRetry(context.Background())
 	logger.Info().Msgf("api server listening on :%s", cfg.AppPort)
 	if err := router.Run(":" + cfg.AppPort); err != nil {
 		logger.Fatal().Err(err).Msg("server error")
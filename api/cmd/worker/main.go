@@ -3,6 +3,7 @@ package main
 import (
 	"flowcraft-api/internal/adapters/database/postgres"
 	"flowcraft-api/internal/config"
+	"flowcraft-api/internal/egress"
 	"flowcraft-api/internal/temporal"
 	"flowcraft-api/internal/utils"
 )
@@ -11,6 +12,13 @@ func main() {
 	cfg := config.Load()
 	logger := utils.NewLogger()
 
+	// Flows call user-supplied URLs; hold all outbound HTTP to the egress policy.
+	policy, err := egress.FromConfig(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("invalid egress policy")
+	}
+	egress.Install(policy)
+
 	db, err := postgres.Connect(cfg.DatabaseURL)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to connect db")
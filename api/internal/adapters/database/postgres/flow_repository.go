@@ -43,9 +43,9 @@ func (r *FlowRepository) Create(ctx context.Context, flow domain.Flow) error {
 }
 
 func (r *FlowRepository) List(ctx context.Context) ([]domain.Flow, error) {
-	rows, err := r.db.QueryContext(ctx, `
+	return r.queryFlows(ctx, `
         SELECT f.id, f.name, f.description, f.scope, f.owner_user_id, f.project_id,
-               f.status, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
+               f.status, f.status_reason, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
                u.id, u.name, u.email,
                p.id, p.name
         FROM flows f
@@ -53,6 +53,30 @@ func (r *FlowRepository) List(ctx context.Context) ([]domain.Flow, error) {
         LEFT JOIN projects p ON p.id = f.project_id
         ORDER BY f.updated_at DESC
     `)
+}
+
+// ListFlowEventCandidates returns the active flows whose definition has a
+// flowEvent node and mentions upstreamFlowID, so finishing a run does not load
+// every flow. Callers still parse the triggers to match them exactly.
+func (r *FlowRepository) ListFlowEventCandidates(ctx context.Context, upstreamFlowID string) ([]domain.Flow, error) {
+	return r.queryFlows(ctx, `
+        SELECT f.id, f.name, f.description, f.scope, f.owner_user_id, f.project_id,
+               f.status, f.status_reason, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
+               u.id, u.name, u.email,
+               p.id, p.name
+        FROM flows f
+        LEFT JOIN users u ON u.id = f.created_by
+        LEFT JOIN projects p ON p.id = f.project_id
+        WHERE f.status = 'active'
+          AND strpos(f.definition_json::text, '"flowEvent"') > 0
+          AND strpos(f.definition_json::text, $1) > 0
+        ORDER BY f.updated_at DESC
+    `, upstreamFlowID)
+}
+
+// queryFlows runs a flow SELECT with the columns of List.
+func (r *FlowRepository) queryFlows(ctx context.Context, query string, args ...any) ([]domain.Flow, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +102,7 @@ func (r *FlowRepository) List(ctx context.Context) ([]domain.Flow, error) {
 			&ownerUserID,
 			&projectID,
 			&f.Status,
+			&f.StatusReason,
 			&f.Version,
 			&f.DefinitionJSON,
 			&f.UpdatedAt,
@@ -121,7 +146,7 @@ func (r *FlowRepository) List(ctx context.Context) ([]domain.Flow, error) {
 func (r *FlowRepository) ListByOwner(ctx context.Context, userID string) ([]domain.Flow, error) {
 	rows, err := r.db.QueryContext(ctx, `
         SELECT f.id, f.name, f.description, f.scope, f.owner_user_id, f.project_id,
-               f.status, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
+               f.status, f.status_reason, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
                u.id, u.name, u.email,
                p.id, p.name
         FROM flows f
@@ -155,6 +180,7 @@ func (r *FlowRepository) ListByOwner(ctx context.Context, userID string) ([]doma
 			&ownerUserID,
 			&projectID,
 			&f.Status,
+			&f.StatusReason,
 			&f.Version,
 			&f.DefinitionJSON,
 			&f.UpdatedAt,
@@ -198,7 +224,7 @@ func (r *FlowRepository) ListByOwner(ctx context.Context, userID string) ([]doma
 func (r *FlowRepository) ListByProject(ctx context.Context, projectID string) ([]domain.Flow, error) {
 	rows, err := r.db.QueryContext(ctx, `
         SELECT f.id, f.name, f.description, f.scope, f.owner_user_id, f.project_id,
-               f.status, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
+               f.status, f.status_reason, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
                u.id, u.name, u.email,
                p.id, p.name
         FROM flows f
@@ -232,6 +258,7 @@ func (r *FlowRepository) ListByProject(ctx context.Context, projectID string) ([
 			&ownerUserID,
 			&flowProjectID,
 			&f.Status,
+			&f.StatusReason,
 			&f.Version,
 			&f.DefinitionJSON,
 			&f.UpdatedAt,
@@ -285,7 +312,7 @@ func (r *FlowRepository) Get(ctx context.Context, id string) (*domain.Flow, erro
 	var projName sql.NullString
 	err := r.db.QueryRowContext(ctx, `
         SELECT f.id, f.name, f.description, f.scope, f.owner_user_id, f.project_id,
-               f.status, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
+               f.status, f.status_reason, f.version, f.definition_json, f.updated_at, f.created_by, f.updated_by,
                u.id, u.name, u.email,
                p.id, p.name
         FROM flows f
@@ -300,6 +327,7 @@ func (r *FlowRepository) Get(ctx context.Context, id string) (*domain.Flow, erro
 		&ownerUserID,
 		&projectID,
 		&f.Status,
+		&f.StatusReason,
 		&f.Version,
 		&f.DefinitionJSON,
 		&f.UpdatedAt,
@@ -351,6 +379,8 @@ func (r *FlowRepository) Update(ctx context.Context, flow domain.Flow) error {
         UPDATE flows
         SET name=$2,
             description=$3,
+            status_reason = CASE WHEN status = $4 THEN status_reason ELSE '' END,
+            consecutive_failures = CASE WHEN status = $4 THEN consecutive_failures ELSE 0 END,
             status=$4,
             version=$5,
             definition_json=$6::jsonb,
@@ -368,6 +398,74 @@ func (r *FlowRepository) Update(ctx context.Context, flow domain.Flow) error {
 	return nil
 }
 
+// SetStatus changes the status of a flow, clears the status reason and
+// resets the consecutive failure count.
+func (r *FlowRepository) SetStatus(ctx context.Context, id string, status string, updatedBy string) error {
+	var updater any
+	if strings.TrimSpace(updatedBy) != "" {
+		updater = updatedBy
+	}
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE flows
+        SET status=$2, status_reason='', consecutive_failures=0, updated_by=COALESCE($3, updated_by), updated_at=NOW()
+        WHERE id=$1
+    `, id, status, updater)
+	if err != nil {
+		return err
+	}
+	count, _ := res.RowsAffected()
+	if count == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// RecordRunOutcome counts a finished run towards the flow's consecutive
+// failures: a failure increments the count and a success resets it. Each run
+// is counted once; a repeated call returns the current count unchanged.
+func (r *FlowRepository) RecordRunOutcome(ctx context.Context, id string, runID string, failed bool) (int, error) {
+	var failures int
+	err := r.db.QueryRowContext(ctx, `
+        WITH updated AS (
+            UPDATE flows
+            SET consecutive_failures = CASE WHEN $3 THEN consecutive_failures + 1 ELSE 0 END,
+                last_counted_run_id = $2
+            WHERE id=$1 AND last_counted_run_id IS DISTINCT FROM $2
+            RETURNING consecutive_failures
+        )
+        SELECT consecutive_failures FROM updated
+        UNION ALL
+        SELECT consecutive_failures FROM flows WHERE id=$1 AND NOT EXISTS (SELECT 1 FROM updated)
+    `, id, runID, failed).Scan(&failures)
+	if err == sql.ErrNoRows {
+		return 0, utils.ErrNotFound
+	}
+	return failures, err
+}
+
+// DeactivateAfterFailures marks an active flow inactive with reason and
+// notifies flow_updates listeners. It reports false when the flow was not
+// active, e.g. because another worker deactivated it first.
+func (r *FlowRepository) DeactivateAfterFailures(ctx context.Context, id string, reason string) (bool, error) {
+	var returnedID string
+	err := r.db.QueryRowContext(ctx, `
+        WITH updated AS (
+            UPDATE flows
+            SET status='inactive', status_reason=$2, updated_at=NOW()
+            WHERE id=$1 AND status='active'
+            RETURNING id, status, status_reason
+        )
+        SELECT id FROM updated, pg_notify('flow_updates', json_build_object('flowId', id, 'status', status, 'reason', status_reason)::text)
+    `, id, reason).Scan(&returnedID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *FlowRepository) Delete(ctx context.Context, id string) error {
 	_, err := r.db.ExecContext(ctx, `DELETE FROM flows WHERE id = $1`, id)
 	return err
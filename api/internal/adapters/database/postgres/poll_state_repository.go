@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"flowcraft-api/internal/core/domain"
+)
+
+type PollStateRepository struct {
+	db *sql.DB
+}
+
+func NewPollStateRepository(db *sql.DB) *PollStateRepository {
+	return &PollStateRepository{db: db}
+}
+
+func (r *PollStateRepository) List(ctx context.Context) ([]domain.PollState, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT flow_id, node_id, cursor, seen_ids, last_polled_at, last_error
+        FROM poll_states
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.PollState
+	for rows.Next() {
+		var state domain.PollState
+		var seen []byte
+		var lastPolled sql.NullTime
+		if err := rows.Scan(&state.FlowID, &state.NodeID, &state.Cursor, &seen, &lastPolled, &state.LastError); err != nil {
+			return nil, err
+		}
+		if len(seen) > 0 {
+			if err := json.Unmarshal(seen, &state.SeenIDs); err != nil {
+				return nil, err
+			}
+		}
+		if lastPolled.Valid {
+			state.LastPolledAt = &lastPolled.Time
+		}
+		out = append(out, state)
+	}
+	return out, rows.Err()
+}
+
+func (r *PollStateRepository) Save(ctx context.Context, state domain.PollState) error {
+	seenIDs := state.SeenIDs
+	if seenIDs == nil {
+		seenIDs = []string{}
+	}
+	seen, err := json.Marshal(seenIDs)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `
+        INSERT INTO poll_states (flow_id, node_id, cursor, seen_ids, last_polled_at, last_error, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, NOW())
+        ON CONFLICT (flow_id, node_id) DO UPDATE SET
+            cursor = EXCLUDED.cursor,
+            seen_ids = EXCLUDED.seen_ids,
+            last_polled_at = EXCLUDED.last_polled_at,
+            last_error = EXCLUDED.last_error,
+            updated_at = NOW()
+    `, state.FlowID, state.NodeID, state.Cursor, seen, state.LastPolledAt, state.LastError)
+	return err
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"strings"
 
 	"flowcraft-api/internal/core/domain"
@@ -107,3 +108,55 @@ func (r *ProjectRepository) Delete(ctx context.Context, projectID string) error
 	return nil
 }
 
+
+func (r *ProjectRepository) GetEgressPolicy(ctx context.Context, projectID string) (domain.EgressPolicy, error) {
+	var allow, deny []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT egress_allow_hosts, egress_deny_hosts FROM projects WHERE id = $1
+	`, projectID).Scan(&allow, &deny)
+	if err == sql.ErrNoRows {
+		return domain.EgressPolicy{}, utils.ErrNotFound
+	}
+	if err != nil {
+		return domain.EgressPolicy{}, err
+	}
+	var policy domain.EgressPolicy
+	if err := json.Unmarshal(allow, &policy.AllowHosts); err != nil {
+		return domain.EgressPolicy{}, err
+	}
+	if err := json.Unmarshal(deny, &policy.DenyHosts); err != nil {
+		return domain.EgressPolicy{}, err
+	}
+	return policy, nil
+}
+
+func (r *ProjectRepository) SetEgressPolicy(ctx context.Context, projectID string, policy domain.EgressPolicy) error {
+	allow, deny := policy.AllowHosts, policy.DenyHosts
+	if allow == nil {
+		allow = []string{}
+	}
+	if deny == nil {
+		deny = []string{}
+	}
+	allowJSON, err := json.Marshal(allow)
+	if err != nil {
+		return err
+	}
+	denyJSON, err := json.Marshal(deny)
+	if err != nil {
+		return err
+	}
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE projects
+		SET egress_allow_hosts=$2, egress_deny_hosts=$3, updated_at=NOW()
+		WHERE id=$1
+	`, projectID, allowJSON, denyJSON)
+	if err != nil {
+		return err
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"flowcraft-api/internal/core/domain"
@@ -19,12 +20,28 @@ func NewRunRepository(db *sql.DB) *RunRepository {
 
 func (r *RunRepository) Create(ctx context.Context, run domain.Run) error {
 	_, err := r.db.ExecContext(ctx, `
-        INSERT INTO runs (id, flow_id, status, started_at, finished_at, log, temporal_workflow_id)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
-    `, run.ID, run.FlowID, run.Status, run.StartedAt, run.FinishedAt, run.Log, run.TemporalWorkflow)
+        INSERT INTO runs (id, flow_id, status, started_at, finished_at, log, temporal_workflow_id, trigger_node_id, trigger_payload)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9)
+    `, run.ID, run.FlowID, run.Status, run.StartedAt, run.FinishedAt, run.Log, run.TemporalWorkflow, run.TriggerNodeID, nullJSON(run.TriggerPayload))
 	return err
 }
 
+// GetTrigger returns the trigger node ID and payload recorded when the run was created.
+func (r *RunRepository) GetTrigger(ctx context.Context, id string) (string, json.RawMessage, error) {
+	var nodeID sql.NullString
+	var payload []byte
+	err := r.db.QueryRowContext(ctx, `
+        SELECT trigger_node_id, trigger_payload FROM runs WHERE id=$1
+    `, id).Scan(&nodeID, &payload)
+	if err == sql.ErrNoRows {
+		return "", nil, utils.ErrNotFound
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return nodeID.String, json.RawMessage(payload), nil
+}
+
 func (r *RunRepository) List(ctx context.Context) ([]domain.Run, error) {
 	rows, err := r.db.QueryContext(ctx, `
         SELECT id, flow_id, status, started_at, finished_at, log, temporal_workflow_id, created_at, updated_at
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+)
+
+type ScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) *ScheduleRepository {
+	return &ScheduleRepository{db: db}
+}
+
+func (r *ScheduleRepository) Get(ctx context.Context, flowID string) (domain.ScheduleState, error) {
+	state := domain.ScheduleState{FlowID: flowID, LastFiredAt: map[string]time.Time{}}
+
+	var pausedAt sql.NullTime
+	var pausedBy sql.NullString
+	err := r.db.QueryRowContext(ctx, `
+        SELECT paused_at, paused_by FROM flow_schedules WHERE flow_id=$1
+    `, flowID).Scan(&pausedAt, &pausedBy)
+	if err != nil && err != sql.ErrNoRows {
+		return state, err
+	}
+	if pausedAt.Valid {
+		state.PausedAt = &pausedAt.Time
+		state.PausedBy = pausedBy.String
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT node_id, last_fired_at FROM schedule_fires WHERE flow_id=$1
+    `, flowID)
+	if err != nil {
+		return state, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var nodeID string
+		var firedAt time.Time
+		if err := rows.Scan(&nodeID, &firedAt); err != nil {
+			return state, err
+		}
+		state.LastFiredAt[nodeID] = firedAt
+	}
+	return state, rows.Err()
+}
+
+func (r *ScheduleRepository) ListLastFired(ctx context.Context) (map[string]time.Time, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT flow_id, node_id, last_fired_at FROM schedule_fires`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]time.Time{}
+	for rows.Next() {
+		var flowID, nodeID string
+		var firedAt time.Time
+		if err := rows.Scan(&flowID, &nodeID, &firedAt); err != nil {
+			return nil, err
+		}
+		out[flowID+"/"+nodeID] = firedAt
+	}
+	return out, rows.Err()
+}
+
+func (r *ScheduleRepository) ListPausedFlowIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT flow_id FROM flow_schedules`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var flowID string
+		if err := rows.Scan(&flowID); err != nil {
+			return nil, err
+		}
+		out = append(out, flowID)
+	}
+	return out, rows.Err()
+}
+
+// RecordFire moves the trigger's last fire time forward; older times are ignored.
+func (r *ScheduleRepository) RecordFire(ctx context.Context, flowID string, nodeID string, firedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO schedule_fires (flow_id, node_id, last_fired_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (flow_id, node_id)
+        DO UPDATE SET last_fired_at = GREATEST(schedule_fires.last_fired_at, EXCLUDED.last_fired_at)
+    `, flowID, nodeID, firedAt)
+	return err
+}
+
+func (r *ScheduleRepository) Pause(ctx context.Context, flowID string, userID string) error {
+	var pausedBy any
+	if userID != "" {
+		pausedBy = userID
+	}
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO flow_schedules (flow_id, paused_at, paused_by)
+        VALUES ($1, NOW(), $2)
+        ON CONFLICT (flow_id) DO NOTHING
+    `, flowID, pausedBy)
+	return err
+}
+
+func (r *ScheduleRepository) ClearFires(ctx context.Context, flowID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM schedule_fires WHERE flow_id=$1`, flowID)
+	return err
+}
+
+func (r *ScheduleRepository) Resume(ctx context.Context, flowID string) error {
+	return RunInTx(r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `DELETE FROM flow_schedules WHERE flow_id=$1`, flowID)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM schedule_fires WHERE flow_id=$1`, flowID)
+		return err
+	})
+}
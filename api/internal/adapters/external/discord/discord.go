@@ -0,0 +1,253 @@
+// Package discord posts messages to Discord through incoming webhooks and
+// calls the bot REST API with a bot token.
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
+)
+
+const defaultBaseURL = "https://discord.com/api/v10"
+
+// apiURL is the API root for calls under ctx, set by endpoints.WithAPIURL.
+func apiURL(ctx context.Context) string {
+	return endpoints.APIURL(ctx, "discord", defaultBaseURL)
+}
+
+const (
+	// MaxContentLength is Discord's limit on message content.
+	MaxContentLength = 2000
+	// MaxEmbeds is Discord's limit on embeds per message.
+	MaxEmbeds = 10
+)
+
+// defaultRetryAfter is used when Discord rate limits a call without saying for how long.
+const defaultRetryAfter = 5 * time.Second
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// RateLimitError is returned when Discord answers 429. RetryAfter comes from
+// the response body's retry_after or the Retry-After header.
+type RateLimitError struct {
+	Route      string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("discord api error: %s rate limited, retry after %s", e.Route, e.RetryAfter)
+}
+
+// RetryDelay reports how long the caller should wait before calling again.
+func (e *RateLimitError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// Message is an outgoing message. Username and AvatarURL only apply to
+// webhooks; ReplyTo (a message ID) only to bot messages.
+type Message struct {
+	Content   string
+	Embeds    []any
+	TTS       bool
+	Username  string
+	AvatarURL string
+	ReplyTo   string
+}
+
+func (m Message) body(webhook bool) (map[string]any, error) {
+	if strings.TrimSpace(m.Content) == "" && len(m.Embeds) == 0 {
+		return nil, errors.New("content or embeds are required")
+	}
+	if n := len([]rune(m.Content)); n > MaxContentLength {
+		return nil, fmt.Errorf("content is %d characters; Discord allows %d", n, MaxContentLength)
+	}
+	if len(m.Embeds) > MaxEmbeds {
+		return nil, fmt.Errorf("%d embeds; Discord allows %d", len(m.Embeds), MaxEmbeds)
+	}
+	body := map[string]any{}
+	if m.Content != "" {
+		body["content"] = m.Content
+	}
+	if len(m.Embeds) > 0 {
+		body["embeds"] = m.Embeds
+	}
+	if m.TTS {
+		body["tts"] = true
+	}
+	if webhook {
+		if u := strings.TrimSpace(m.Username); u != "" {
+			body["username"] = u
+		}
+		if a := strings.TrimSpace(m.AvatarURL); a != "" {
+			body["avatar_url"] = a
+		}
+	} else if r := strings.TrimSpace(m.ReplyTo); r != "" {
+		body["message_reference"] = map[string]any{"message_id": r, "fail_if_not_exists": false}
+	}
+	return body, nil
+}
+
+// ExecuteWebhook posts msg through an incoming webhook and returns the
+// created message. threadID posts into a thread of the webhook's channel.
+func ExecuteWebhook(ctx context.Context, webhookURL string, msg Message, threadID string) (map[string]any, error) {
+	target, err := webhookTarget(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+	body, err := msg.body(true)
+	if err != nil {
+		return nil, err
+	}
+	q := target.Query()
+	q.Set("wait", "true")
+	if t := strings.TrimSpace(threadID); t != "" {
+		q.Set("thread_id", t)
+	}
+	target.RawQuery = q.Encode()
+	return call(ctx, http.MethodPost, target.String(), "", body, "webhook")
+}
+
+// GetWebhook returns the webhook's name, channel and guild without posting.
+func GetWebhook(ctx context.Context, webhookURL string) (map[string]any, error) {
+	target, err := webhookTarget(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+	return call(ctx, http.MethodGet, target.String(), "", nil, "webhook")
+}
+
+// SendMessage posts msg to a channel as the bot.
+func SendMessage(ctx context.Context, botToken string, channelID string, msg Message) (map[string]any, error) {
+	body, err := msg.body(false)
+	if err != nil {
+		return nil, err
+	}
+	route := "/channels/" + url.PathEscape(channelID) + "/messages"
+	return call(ctx, http.MethodPost, apiURL(ctx)+route, botToken, body, route)
+}
+
+// ThreadInput describes a thread to create. With MessageID the thread is
+// started from that message; otherwise a standalone thread is created,
+// private when Private is set. AutoArchiveMinutes is 60, 1440, 4320 or 10080.
+type ThreadInput struct {
+	Name               string
+	MessageID          string
+	AutoArchiveMinutes int
+	Private            bool
+}
+
+// CreateThread creates a thread in a channel.
+func CreateThread(ctx context.Context, botToken string, channelID string, input ThreadInput) (map[string]any, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("thread name is required")
+	}
+	body := map[string]any{"name": name}
+	if minutes := input.AutoArchiveMinutes; minutes != 0 {
+		switch minutes {
+		case 60, 1440, 4320, 10080:
+			body["auto_archive_duration"] = minutes
+		default:
+			return nil, fmt.Errorf("auto archive duration must be 60, 1440, 4320 or 10080 minutes, got %d", minutes)
+		}
+	}
+	route := "/channels/" + url.PathEscape(channelID)
+	if messageID := strings.TrimSpace(input.MessageID); messageID != "" {
+		route += "/messages/" + url.PathEscape(messageID) + "/threads"
+	} else {
+		route += "/threads"
+		body["type"] = 11 // PUBLIC_THREAD
+		if input.Private {
+			body["type"] = 12 // PRIVATE_THREAD
+		}
+	}
+	return call(ctx, http.MethodPost, apiURL(ctx)+route, botToken, body, route)
+}
+
+// GetCurrentUser returns the bot user a token belongs to.
+func GetCurrentUser(ctx context.Context, botToken string) (map[string]any, error) {
+	return call(ctx, http.MethodGet, apiURL(ctx)+"/users/@me", botToken, nil, "/users/@me")
+}
+
+// webhookTarget checks that raw looks like a Discord webhook URL
+// (.../webhooks/{id}/{token}).
+func webhookTarget(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, errors.New("discord webhook URL is not a valid URL")
+	}
+	if !strings.Contains(u.Path, "/webhooks/") {
+		return nil, errors.New("discord webhook URL must look like https://discord.com/api/webhooks/{id}/{token}")
+	}
+	return u, nil
+}
+
+func call(ctx context.Context, method string, target string, botToken string, payload map[string]any, route string) (map[string]any, error) {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if botToken != "" {
+		req.Header.Set("Authorization", "Bot "+botToken)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]any
+	if len(bytes.TrimSpace(raw)) > 0 {
+		_ = json.Unmarshal(raw, &result)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &RateLimitError{Route: route, RetryAfter: retryAfter(result, resp.Header.Get("Retry-After"))}
+	}
+	if resp.StatusCode >= 300 {
+		if msg, _ := result["message"].(string); msg != "" {
+			return nil, fmt.Errorf("discord api error: %s: %s (status %d)", route, msg, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("discord api error: %s status %d", route, resp.StatusCode)
+	}
+	if result == nil {
+		result = map[string]any{}
+	}
+	return result, nil
+}
+
+func retryAfter(body map[string]any, header string) time.Duration {
+	if secs, ok := body["retry_after"].(float64); ok && secs > 0 {
+		return time.Duration(math.Ceil(secs*1000)) * time.Millisecond
+	}
+	if secs, err := strconv.ParseFloat(strings.TrimSpace(header), 64); err == nil && secs > 0 {
+		return time.Duration(math.Ceil(secs*1000)) * time.Millisecond
+	}
+	return defaultRetryAfter
+}
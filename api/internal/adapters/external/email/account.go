@@ -0,0 +1,126 @@
+// Package email sends mail over SMTP and reads mailboxes over IMAP with the
+// settings stored in smtp and imap credentials.
+package email
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"flowcraft-api/internal/mailer"
+)
+
+// Connection security of an account.
+const (
+	// SecurityTLS connects with implicit TLS (SMTP 465, IMAP 993).
+	SecurityTLS = "tls"
+	// SecurityStartTLS connects in plain text and requires an upgrade with
+	// STARTTLS (SMTP 587, IMAP 143).
+	SecurityStartTLS = "starttls"
+	// SecurityNone does not require TLS. Credentials are then only sent to
+	// localhost, which is meant for local test servers.
+	SecurityNone = "none"
+)
+
+// Account is a mail server login as stored in an smtp or imap credential.
+type Account struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Security string
+	// From is the default sender of an smtp credential.
+	From string
+}
+
+// AccountFromPayload reads an account from a credential payload with the
+// keys host, port, username, password, security and from.
+func AccountFromPayload(payload map[string]any) Account {
+	acct := Account{
+		Host:     strings.TrimSpace(payloadString(payload["host"])),
+		Username: payloadString(payload["username"]),
+		Password: payloadString(payload["password"]),
+		Security: strings.ToLower(strings.TrimSpace(payloadString(payload["security"]))),
+		From:     strings.TrimSpace(payloadString(payload["from"])),
+	}
+	switch v := payload["port"].(type) {
+	case float64:
+		acct.Port = int(v)
+	case int:
+		acct.Port = v
+	case string:
+		acct.Port, _ = strconv.Atoi(strings.TrimSpace(v))
+	}
+	return acct
+}
+
+// withDefaults validates the account and fills in the port and security.
+// Without a security setting, tlsPort implies TLS and any other port
+// STARTTLS; without a port, the one matching the security is used.
+func (a Account) withDefaults(tlsPort int, startTLSPort int, plainPort int) (Account, error) {
+	if a.Host == "" {
+		return a, errors.New("host is required")
+	}
+	if a.Security == "" {
+		if a.Port == 0 || a.Port == tlsPort {
+			a.Security = SecurityTLS
+		} else {
+			a.Security = SecurityStartTLS
+		}
+	}
+	switch a.Security {
+	case SecurityTLS:
+		if a.Port == 0 {
+			a.Port = tlsPort
+		}
+	case SecurityStartTLS:
+		if a.Port == 0 {
+			a.Port = startTLSPort
+		}
+	case SecurityNone:
+		if a.Port == 0 {
+			a.Port = plainPort
+		}
+	default:
+		return a, fmt.Errorf("security must be %s, %s or %s, got %q", SecurityTLS, SecurityStartTLS, SecurityNone, a.Security)
+	}
+	if a.Port < 1 || a.Port > 65535 {
+		return a, fmt.Errorf("port %d is out of range", a.Port)
+	}
+	return a, nil
+}
+
+// SMTPTransport returns the transport for an smtp account. Without port or
+// security it uses STARTTLS on 587.
+func (a Account) SMTPTransport() (mailer.Transport, error) {
+	if a.Port == 0 && a.Security == "" {
+		a.Security = SecurityStartTLS
+	}
+	a, err := a.withDefaults(465, 587, 25)
+	if err != nil {
+		return mailer.Transport{}, fmt.Errorf("smtp: %w", err)
+	}
+	return mailer.Transport{
+		Host:        a.Host,
+		Port:        a.Port,
+		Username:    a.Username,
+		Password:    a.Password,
+		UseTLS:      a.Security == SecurityTLS,
+		UseStartTLS: a.Security == SecurityStartTLS,
+	}, nil
+}
+
+func isLocalhost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
+
+func payloadString(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
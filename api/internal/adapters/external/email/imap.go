@@ -0,0 +1,261 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-imap/v2"
+	"github.com/emersion/go-imap/v2/imapclient"
+	"github.com/emersion/go-sasl"
+)
+
+// ErrUIDValidityChanged is returned by Fetch when the folder's UIDVALIDITY
+// differs from FetchOptions.UIDValidity, meaning earlier UIDs are void.
+var ErrUIDValidityChanged = errors.New("imap: folder UIDVALIDITY changed")
+
+// Mailbox describes a selected folder.
+type Mailbox struct {
+	Name        string
+	Exists      int
+	UIDValidity uint32
+	UIDNext     uint32
+}
+
+// FetchOptions selects messages in a folder. Without AfterUID the newest
+// Limit messages are fetched; with it, the oldest Limit messages whose UID
+// is greater. MarkAsRead sets \Seen on the fetched messages; otherwise the
+// folder is opened read-only and flags are left untouched.
+type FetchOptions struct {
+	Folder             string
+	UnseenOnly         bool
+	MarkAsRead         bool
+	Limit              int
+	AfterUID           uint32
+	UIDValidity        uint32
+	IncludeAttachments bool
+}
+
+// FetchResult holds the folder state and the parsed messages, oldest first.
+type FetchResult struct {
+	Mailbox  Mailbox
+	Messages []map[string]any
+}
+
+// Fetch logs into the imap account and fetches messages from a folder.
+func Fetch(ctx context.Context, acct Account, opts FetchOptions) (FetchResult, error) {
+	c, err := dialIMAP(ctx, acct)
+	if err != nil {
+		return FetchResult{}, err
+	}
+	defer logout(c)
+
+	box, err := selectMailbox(c, opts.Folder, !opts.MarkAsRead)
+	if err != nil {
+		return FetchResult{}, err
+	}
+	result := FetchResult{Mailbox: box, Messages: []map[string]any{}}
+	if opts.UIDValidity != 0 && box.UIDValidity != opts.UIDValidity {
+		return result, ErrUIDValidityChanged
+	}
+
+	criteria := &imap.SearchCriteria{}
+	if opts.AfterUID > 0 {
+		criteria.UID = []imap.UIDSet{{imap.UIDRange{Start: imap.UID(opts.AfterUID + 1)}}}
+	}
+	if opts.UnseenOnly {
+		criteria.NotFlag = []imap.Flag{imap.FlagSeen}
+	}
+	uids, err := search(c, criteria)
+	if err != nil {
+		return result, err
+	}
+	// "n:*" always matches the highest UID, even when it is below n.
+	kept := uids[:0]
+	for _, uid := range uids {
+		if uid > imap.UID(opts.AfterUID) {
+			kept = append(kept, uid)
+		}
+	}
+	uids = kept
+	if opts.Limit > 0 && len(uids) > opts.Limit {
+		if opts.AfterUID > 0 {
+			uids = uids[:opts.Limit]
+		} else {
+			uids = uids[len(uids)-opts.Limit:]
+		}
+	}
+	if len(uids) == 0 {
+		return result, nil
+	}
+
+	body := &imap.FetchItemBodySection{Peek: true}
+	fetched, err := c.Fetch(imap.UIDSetNum(uids...), &imap.FetchOptions{
+		UID:          true,
+		Flags:        true,
+		InternalDate: true,
+		RFC822Size:   true,
+		BodySection:  []*imap.FetchItemBodySection{body},
+	}).Collect()
+	if err != nil {
+		return result, err
+	}
+	if opts.MarkAsRead {
+		store := &imap.StoreFlags{Op: imap.StoreFlagsAdd, Silent: true, Flags: []imap.Flag{imap.FlagSeen}}
+		if err := c.Store(imap.UIDSetNum(uids...), store, nil).Close(); err != nil {
+			return result, err
+		}
+	}
+	sort.Slice(fetched, func(i, j int) bool { return fetched[i].UID < fetched[j].UID })
+	for _, f := range fetched {
+		raw := f.FindBodySection(body)
+		// Servers may send unsolicited FETCH responses (flag updates) without a body.
+		if f.UID == 0 || raw == nil {
+			continue
+		}
+		msg, err := ParseMessage(raw, opts.IncludeAttachments)
+		if err != nil {
+			msg = map[string]any{"parseError": err.Error()}
+		}
+		flags := make([]string, len(f.Flags))
+		seen := opts.MarkAsRead
+		for i, flag := range f.Flags {
+			flags[i] = string(flag)
+			seen = seen || strings.EqualFold(flags[i], string(imap.FlagSeen))
+		}
+		msg["uid"] = uint32(f.UID)
+		msg["folder"] = box.Name
+		msg["flags"] = flags
+		msg["seen"] = seen
+		msg["size"] = int(f.RFC822Size)
+		if !f.InternalDate.IsZero() {
+			msg["receivedAt"] = f.InternalDate.UTC().Format(time.RFC3339)
+		}
+		result.Messages = append(result.Messages, msg)
+	}
+	return result, nil
+}
+
+// Status logs in and returns the folder's state without fetching anything.
+func Status(ctx context.Context, acct Account, folder string) (Mailbox, error) {
+	c, err := dialIMAP(ctx, acct)
+	if err != nil {
+		return Mailbox{}, err
+	}
+	defer logout(c)
+	box, err := selectMailbox(c, folder, true)
+	if err != nil {
+		return box, err
+	}
+	if box.UIDNext == 0 {
+		// UIDNEXT is optional in older servers; derive it from the highest UID.
+		uids, err := search(c, &imap.SearchCriteria{})
+		if err != nil {
+			return box, err
+		}
+		box.UIDNext = 1
+		if len(uids) > 0 {
+			box.UIDNext = uint32(uids[len(uids)-1]) + 1
+		}
+	}
+	return box, nil
+}
+
+func dialIMAP(ctx context.Context, acct Account) (*imapclient.Client, error) {
+	acct, err := acct.withDefaults(993, 143, 143)
+	if err != nil {
+		return nil, fmt.Errorf("imap: %w", err)
+	}
+	addr := net.JoinHostPort(acct.Host, strconv.Itoa(acct.Port))
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	tlsConfig := &tls.Config{ServerName: acct.Host}
+	var conn net.Conn
+	if acct.Security == SecurityTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(2 * time.Minute)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	options := &imapclient.Options{TLSConfig: tlsConfig}
+	var c *imapclient.Client
+	if acct.Security == SecurityStartTLS {
+		// NewStartTLS refuses PREAUTH before the upgrade.
+		if c, err = imapclient.NewStartTLS(conn, options); err != nil {
+			return nil, err
+		}
+	} else {
+		c = imapclient.New(conn, options)
+		if err := c.WaitGreeting(); err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+	}
+	if c.State() == imap.ConnStateAuthenticated {
+		return c, nil
+	}
+	if acct.Security == SecurityNone && !isLocalhost(acct.Host) {
+		_ = c.Close()
+		return nil, errors.New("imap: refusing to send credentials over an unencrypted connection")
+	}
+	// Errors only name the command so credentials never end up in them.
+	if c.Caps().Has(imap.CapLoginDisabled) {
+		err = c.Authenticate(sasl.NewPlainClient("", acct.Username, acct.Password))
+		if err != nil {
+			err = fmt.Errorf("imap: AUTHENTICATE failed: %w", err)
+		}
+	} else if err = c.Login(acct.Username, acct.Password).Wait(); err != nil {
+		err = fmt.Errorf("imap: LOGIN failed: %w", err)
+	}
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func selectMailbox(c *imapclient.Client, folder string, readOnly bool) (Mailbox, error) {
+	folder = strings.TrimSpace(folder)
+	if folder == "" {
+		folder = "INBOX"
+	}
+	data, err := c.Select(folder, &imap.SelectOptions{ReadOnly: readOnly}).Wait()
+	if err != nil {
+		return Mailbox{}, err
+	}
+	return Mailbox{
+		Name:        folder,
+		Exists:      int(data.NumMessages),
+		UIDValidity: data.UIDValidity,
+		UIDNext:     uint32(data.UIDNext),
+	}, nil
+}
+
+// search returns the UIDs matching criteria in ascending order.
+func search(c *imapclient.Client, criteria *imap.SearchCriteria) ([]imap.UID, error) {
+	data, err := c.UIDSearch(criteria, nil).Wait()
+	if err != nil {
+		return nil, err
+	}
+	uids := data.AllUIDs()
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	return uids, nil
+}
+
+func logout(c *imapclient.Client) {
+	_ = c.Logout().Wait()
+	_ = c.Close()
+}
@@ -0,0 +1,214 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// maxPartBytes caps the decoded size of a single body part or attachment.
+const maxPartBytes = 16 << 20
+
+// maxPartDepth bounds nested multiparts.
+const maxPartDepth = 10
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// ParseMessage parses a raw RFC 5322 message into from, to, cc, replyTo,
+// subject, date, messageId, inReplyTo, references, text, html, headers and
+// attachments (filename, mimeType, size, contentId). Attachment content is
+// included as contentBase64 only when includeAttachments is set.
+func ParseMessage(raw []byte, includeAttachments bool) (map[string]any, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parse message: %w", err)
+	}
+	h := msg.Header
+	out := map[string]any{
+		"subject":    decodeHeader(h.Get("Subject")),
+		"from":       decodeHeader(h.Get("From")),
+		"to":         headerAddresses(h, "To"),
+		"cc":         headerAddresses(h, "Cc"),
+		"replyTo":    headerAddresses(h, "Reply-To"),
+		"messageId":  strings.TrimSpace(h.Get("Message-Id")),
+		"inReplyTo":  strings.TrimSpace(h.Get("In-Reply-To")),
+		"references": strings.Fields(h.Get("References")),
+	}
+	if from, err := mail.ParseAddress(h.Get("From")); err == nil {
+		out["fromAddress"] = from.Address
+		out["fromName"] = from.Name
+	}
+	if date, err := h.Date(); err == nil {
+		out["date"] = date.UTC().Format(time.RFC3339)
+	}
+	headers := make(map[string]any, len(h))
+	for key, values := range h {
+		if len(values) > 0 {
+			headers[strings.ToLower(key)] = decodeHeader(values[0])
+		}
+	}
+	out["headers"] = headers
+
+	p := &partCollector{includeAttachments: includeAttachments, attachments: []map[string]any{}}
+	if err := p.walk(partHeader(h), msg.Body, 0); err != nil {
+		return nil, err
+	}
+	out["text"] = strings.Join(p.text, "\n")
+	out["html"] = strings.Join(p.html, "\n")
+	out["attachments"] = p.attachments
+	return out, nil
+}
+
+type partHeader map[string][]string
+
+func (h partHeader) get(key string) string {
+	for k, v := range h {
+		if strings.EqualFold(k, key) && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+type partCollector struct {
+	includeAttachments bool
+	text               []string
+	html               []string
+	attachments        []map[string]any
+}
+
+func (p *partCollector) walk(h partHeader, body io.Reader, depth int) error {
+	if depth > maxPartDepth {
+		return errors.New("parse message: too many nested parts")
+	}
+	mediaType, params, err := mime.ParseMediaType(h.get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("parse message: %w", err)
+			}
+			if err := p.walk(partHeader(part.Header), part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(io.LimitReader(decodeTransfer(h.get("Content-Transfer-Encoding"), body), maxPartBytes+1))
+	if err != nil {
+		return fmt.Errorf("parse message: %w", err)
+	}
+	if len(content) > maxPartBytes {
+		return fmt.Errorf("parse message: a part exceeds %d bytes", maxPartBytes)
+	}
+
+	disposition, dparams, _ := mime.ParseMediaType(h.get("Content-Disposition"))
+	filename := decodeHeader(dparams["filename"])
+	if filename == "" {
+		filename = decodeHeader(params["name"])
+	}
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+	if disposition != "attachment" && filename == "" && isText {
+		text := decodeCharset(params["charset"], content)
+		if mediaType == "text/html" {
+			p.html = append(p.html, text)
+		} else {
+			p.text = append(p.text, text)
+		}
+		return nil
+	}
+	if filename == "" && mediaType == "message/rfc822" {
+		filename = "message.eml"
+	}
+	att := map[string]any{
+		"filename":  filename,
+		"mimeType":  mediaType,
+		"size":      len(content),
+		"contentId": strings.Trim(h.get("Content-Id"), "<> "),
+		"inline":    disposition == "inline",
+	}
+	if p.includeAttachments {
+		att["contentBase64"] = base64.StdEncoding.EncodeToString(content)
+	}
+	p.attachments = append(p.attachments, att)
+	return nil
+}
+
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+	return body
+}
+
+func headerAddresses(h mail.Header, key string) []string {
+	list, err := h.AddressList(key)
+	if err != nil {
+		if v := strings.TrimSpace(h.Get(key)); v != "" {
+			return []string{decodeHeader(v)}
+		}
+		return []string{}
+	}
+	out := make([]string, 0, len(list))
+	for _, addr := range list {
+		out = append(out, addr.Address)
+	}
+	return out
+}
+
+func decodeHeader(v string) string {
+	decoded, err := wordDecoder.DecodeHeader(v)
+	if err != nil {
+		return v
+	}
+	return decoded
+}
+
+// decodeCharset converts Latin-1 and Windows-1252 text to UTF-8; other
+// charsets are returned as is.
+func decodeCharset(charset string, content []byte) string {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "iso-8859-1", "latin1", "windows-1252", "cp1252":
+		return latin1ToUTF8(content)
+	}
+	return string(content)
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "iso-8859-1", "latin1", "windows-1252", "cp1252":
+		raw, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(latin1ToUTF8(raw)), nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", charset)
+}
+
+func latin1ToUTF8(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
@@ -0,0 +1,314 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// MaxMessageBytes caps a composed message, attachments included. Most
+// providers reject anything larger than 25MB.
+const MaxMessageBytes = 25 << 20
+
+// Attachment is a file attached to an outgoing message.
+type Attachment struct {
+	Filename string
+	MimeType string
+	Content  []byte
+}
+
+// Message is an outgoing message. Addresses may carry a display name
+// ("Ada <ada@example.com>"). Bcc recipients get the message but are not
+// listed in its headers.
+type Message struct {
+	From        string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	ReplyTo     string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []Attachment
+}
+
+// Send composes msg and delivers it through the smtp account. From
+// defaults to the account's from address, then to its username.
+func Send(ctx context.Context, acct Account, msg Message) (map[string]any, error) {
+	transport, err := acct.SMTPTransport()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(msg.From) == "" {
+		msg.From = acct.From
+	}
+	if strings.TrimSpace(msg.From) == "" {
+		msg.From = acct.Username
+	}
+	composed, err := compose(msg, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := transport.Send(ctx, composed.from, composed.recipients, composed.raw); err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"messageId":  composed.messageID,
+		"from":       composed.from,
+		"recipients": composed.recipients,
+		"size":       len(composed.raw),
+	}, nil
+}
+
+type composedMessage struct {
+	raw        []byte
+	from       string
+	recipients []string
+	messageID  string
+}
+
+func compose(msg Message, now time.Time) (composedMessage, error) {
+	from, err := mail.ParseAddress(strings.TrimSpace(msg.From))
+	if err != nil {
+		return composedMessage{}, fmt.Errorf("invalid from address: %w", err)
+	}
+	to, err := parseAddresses("to", msg.To)
+	if err != nil {
+		return composedMessage{}, err
+	}
+	cc, err := parseAddresses("cc", msg.Cc)
+	if err != nil {
+		return composedMessage{}, err
+	}
+	bcc, err := parseAddresses("bcc", msg.Bcc)
+	if err != nil {
+		return composedMessage{}, err
+	}
+	if len(to)+len(cc)+len(bcc) == 0 {
+		return composedMessage{}, errors.New("at least one recipient is required")
+	}
+	if msg.Text == "" && msg.HTML == "" && len(msg.Attachments) == 0 {
+		return composedMessage{}, errors.New("text, html or an attachment is required")
+	}
+
+	messageID := newMessageID(from.Address)
+	var buf bytes.Buffer
+	header := func(name string, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", from.String())
+	if len(to) > 0 {
+		header("To", joinAddresses(to))
+	}
+	if len(cc) > 0 {
+		header("Cc", joinAddresses(cc))
+	}
+	if replyTo := strings.TrimSpace(msg.ReplyTo); replyTo != "" {
+		addr, err := mail.ParseAddress(replyTo)
+		if err != nil {
+			return composedMessage{}, fmt.Errorf("invalid reply-to address: %w", err)
+		}
+		header("Reply-To", addr.String())
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", singleLine(msg.Subject)))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", messageID)
+	header("MIME-Version", "1.0")
+
+	if len(msg.Attachments) == 0 {
+		if err := writeBody(&buf, nil, msg.Text, msg.HTML); err != nil {
+			return composedMessage{}, err
+		}
+	} else {
+		mixed := multipart.NewWriter(&buf)
+		header("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mixed.Boundary()}))
+		buf.WriteString("\r\n")
+		if msg.Text != "" || msg.HTML != "" {
+			if err := writeBody(nil, mixed, msg.Text, msg.HTML); err != nil {
+				return composedMessage{}, err
+			}
+		}
+		for _, att := range msg.Attachments {
+			if err := writeAttachment(mixed, att); err != nil {
+				return composedMessage{}, err
+			}
+		}
+		if err := mixed.Close(); err != nil {
+			return composedMessage{}, err
+		}
+	}
+	if buf.Len() > MaxMessageBytes {
+		return composedMessage{}, fmt.Errorf("message is %d bytes; the limit is %d", buf.Len(), MaxMessageBytes)
+	}
+
+	recipients := make([]string, 0, len(to)+len(cc)+len(bcc))
+	seen := map[string]bool{}
+	for _, group := range [][]*mail.Address{to, cc, bcc} {
+		for _, addr := range group {
+			key := strings.ToLower(addr.Address)
+			if !seen[key] {
+				seen[key] = true
+				recipients = append(recipients, addr.Address)
+			}
+		}
+	}
+	return composedMessage{raw: buf.Bytes(), from: from.Address, recipients: recipients, messageID: messageID}, nil
+}
+
+// writeBody writes the text and/or html body, either as the top-level body
+// (headers go to top) or as a part of parent.
+func writeBody(top *bytes.Buffer, parent *multipart.Writer, text string, html string) error {
+	if text != "" && html != "" {
+		var inner bytes.Buffer
+		alt := multipart.NewWriter(&inner)
+		for _, p := range []struct{ ctype, body string }{{"text/plain", text}, {"text/html", html}} {
+			w, err := alt.CreatePart(textHeader(p.ctype))
+			if err != nil {
+				return err
+			}
+			if err := writeQuotedPrintable(w, p.body); err != nil {
+				return err
+			}
+		}
+		if err := alt.Close(); err != nil {
+			return err
+		}
+		ctype := mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": alt.Boundary()})
+		if top != nil {
+			top.WriteString("Content-Type: " + ctype + "\r\n\r\n")
+			_, err := top.Write(inner.Bytes())
+			return err
+		}
+		w, err := parent.CreatePart(textproto.MIMEHeader{"Content-Type": {ctype}})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(inner.Bytes())
+		return err
+	}
+
+	ctype, body := "text/plain", text
+	if html != "" {
+		ctype, body = "text/html", html
+	}
+	if top != nil {
+		for key, values := range textHeader(ctype) {
+			top.WriteString(key + ": " + values[0] + "\r\n")
+		}
+		top.WriteString("\r\n")
+		return writeQuotedPrintable(top, body)
+	}
+	w, err := parent.CreatePart(textHeader(ctype))
+	if err != nil {
+		return err
+	}
+	return writeQuotedPrintable(w, body)
+}
+
+func textHeader(ctype string) textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Type":              {ctype + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	}
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func writeAttachment(mixed *multipart.Writer, att Attachment) error {
+	filename := singleLine(att.Filename)
+	if filename == "" {
+		return errors.New("attachment filename is required")
+	}
+	mimeType := strings.TrimSpace(att.MimeType)
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filenameExt(filename))
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return fmt.Errorf("attachment %s: invalid mime type %q", filename, att.MimeType)
+	}
+	params["name"] = filename
+	w, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(mediaType, params)},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(att.Content)
+	for len(encoded) > 76 {
+		if _, err := w.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = w.Write([]byte(encoded + "\r\n"))
+	return err
+}
+
+func parseAddresses(field string, values []string) ([]*mail.Address, error) {
+	var out []*mail.Address
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		list, err := mail.ParseAddressList(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s address %q: %w", field, value, err)
+		}
+		out = append(out, list...)
+	}
+	return out, nil
+}
+
+func joinAddresses(list []*mail.Address) string {
+	parts := make([]string, len(list))
+	for i, addr := range list {
+		parts[i] = addr.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// singleLine drops line breaks so a value cannot inject headers.
+func singleLine(s string) string {
+	return strings.TrimSpace(strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(s))
+}
+
+func filenameExt(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i:]
+	}
+	return ""
+}
+
+func newMessageID(from string) string {
+	domain := "flowcraft.local"
+	if i := strings.LastIndex(from, "@"); i >= 0 && i < len(from)-1 {
+		domain = from[i+1:]
+	}
+	var b [12]byte
+	_, _ = rand.Read(b[:])
+	return "<" + hex.EncodeToString(b[:]) + "@" + domain + ">"
+}
@@ -0,0 +1,25 @@
+package endpoints
+
+import (
+	"context"
+	"strings"
+)
+
+type apiURLKey struct{ provider string }
+
+// WithAPIURL returns a context under which calls of provider's adapter use
+// apiURL. It is for connectors with a single API root; google and github,
+// which have several, carry their own Endpoints.
+func WithAPIURL(ctx context.Context, provider string, apiURL string) context.Context {
+	return context.WithValue(ctx, apiURLKey{strings.ToLower(strings.TrimSpace(provider))}, apiURL)
+}
+
+// APIURL returns the API root set for provider with WithAPIURL, without a
+// trailing slash, or fallback when none is set.
+func APIURL(ctx context.Context, provider string, fallback string) string {
+	v, _ := ctx.Value(apiURLKey{strings.ToLower(strings.TrimSpace(provider))}).(string)
+	if u := strings.TrimRight(strings.TrimSpace(v), "/"); u != "" {
+		return u
+	}
+	return fallback
+}
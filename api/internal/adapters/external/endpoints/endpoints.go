@@ -0,0 +1,126 @@
+// Package endpoints chooses the API endpoints a connector call uses: the
+// credential's "api_url" when set and allowed, otherwise the server config,
+// otherwise the connector's public default. OAuth endpoints, which receive the
+// server's client secret, only ever come from the server config.
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"flowcraft-api/internal/adapters/external/github"
+	"flowcraft-api/internal/adapters/external/google"
+	"flowcraft-api/internal/config"
+	"flowcraft-api/internal/egress"
+)
+
+// CredentialKey is the credential payload field overriding a connector's API URL.
+const CredentialKey = "api_url"
+
+// ErrAPIURLNotAllowed is returned for a credential whose api_url the server
+// does not allow.
+var ErrAPIURLNotAllowed = errors.New("api_url is not allowed")
+
+// Apply returns ctx carrying the endpoints for provider. payload may be nil
+// (OAuth flows before a credential exists). Unknown providers get ctx back.
+// An api_url that Check rejects is ignored.
+func Apply(ctx context.Context, cfg config.Config, provider string, payload map[string]any) context.Context {
+	override := ""
+	if v, ok := payload[CredentialKey].(string); ok && Check(cfg, provider, payload) == nil {
+		override = strings.TrimSpace(v)
+	}
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "github":
+		return github.WithEndpoints(ctx, GitHub(cfg, override))
+	case "google", "gmail", "googlesheets":
+		e := google.EndpointsForBaseURL(pick(override, cfg.GoogleAPIURL))
+		server := google.EndpointsForBaseURL(cfg.GoogleAPIURL)
+		e.AuthURL, e.TokenURL, e.UserInfoURL = server.AuthURL, server.TokenURL, server.UserInfoURL
+		return google.WithEndpoints(ctx, e)
+	case "slack", "notion", "discord", "telegram":
+		return WithAPIURL(ctx, provider, pick(override, configuredURL(cfg, provider)))
+	}
+	return ctx
+}
+
+// GitHub returns the GitHub endpoints for an API URL override and the server
+// config. The OAuth host comes from the server config only: GITHUB_OAUTH_URL,
+// else the host of a GitHub Enterprise Server GITHUB_API_URL
+// (https://host/api/v3).
+func GitHub(cfg config.Config, apiURL string) github.Endpoints {
+	e := github.Endpoints{
+		APIURL:   pick(apiURL, cfg.GitHubAPIURL),
+		OAuthURL: strings.TrimSpace(cfg.GitHubOAuthURL),
+	}
+	if e.OAuthURL == "" {
+		if host, ok := strings.CutSuffix(strings.TrimRight(strings.TrimSpace(cfg.GitHubAPIURL), "/"), "/api/v3"); ok {
+			e.OAuthURL = host
+		}
+	}
+	return e
+}
+
+// Check rejects a credential payload whose api_url is not an http(s) URL on
+// a host the server allows: one on CREDENTIAL_API_URL_HOSTS, or the host of
+// the provider's configured API URL. Payloads without api_url pass.
+func Check(cfg config.Config, provider string, payload map[string]any) error {
+	raw, ok := payload[CredentialKey]
+	if !ok || raw == nil {
+		return nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return fmt.Errorf("%w: must be a string", ErrAPIURLNotAllowed)
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%w: %q is not an http(s) URL", ErrAPIURLNotAllowed, s)
+	}
+	host := strings.ToLower(u.Hostname())
+	if configured, err := url.Parse(configuredURL(cfg, provider)); err == nil && configured.Hostname() != "" && strings.EqualFold(configured.Hostname(), host) {
+		return nil
+	}
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	}
+	allowed, err := egress.ParseHostList(strings.Split(cfg.CredentialAPIURLHosts, ","))
+	if err == nil && allowed.Match(host, ips...) {
+		return nil
+	}
+	return fmt.Errorf("%w: host %s is not on CREDENTIAL_API_URL_HOSTS", ErrAPIURLNotAllowed, host)
+}
+
+// configuredURL is the server-configured API URL of provider.
+func configuredURL(cfg config.Config, provider string) string {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "github":
+		return strings.TrimSpace(cfg.GitHubAPIURL)
+	case "google", "gmail", "googlesheets":
+		return strings.TrimSpace(cfg.GoogleAPIURL)
+	case "slack":
+		return strings.TrimSpace(cfg.SlackAPIURL)
+	case "notion":
+		return strings.TrimSpace(cfg.NotionAPIURL)
+	case "discord":
+		return strings.TrimSpace(cfg.DiscordAPIURL)
+	case "telegram":
+		return strings.TrimSpace(cfg.TelegramAPIURL)
+	}
+	return ""
+}
+
+func pick(override string, configured string) string {
+	if override != "" {
+		return override
+	}
+	return strings.TrimSpace(configured)
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DispatchWorkflow triggers a workflow_dispatch event. workflow is the
+// workflow file name (release.yml) or its numeric ID; ref is the branch or tag
+// to run on, defaulting to the repository's default branch. GitHub does not
+// return the run it starts.
+func DispatchWorkflow(ctx context.Context, accessToken string, owner string, repo string, workflow string, ref string, inputs map[string]any) (map[string]any, error) {
+	workflow = strings.TrimSpace(workflow)
+	if workflow == "" {
+		return nil, errors.New("workflow is required")
+	}
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		info, err := GetRepo(ctx, accessToken, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		ref = readAnyString(info["default_branch"])
+		if ref == "" {
+			return nil, errors.New("unable to resolve default branch")
+		}
+	}
+	payload := map[string]any{"ref": ref}
+	if len(inputs) > 0 {
+		payload["inputs"] = inputs
+	}
+	target := fmt.Sprintf("%s/actions/workflows/%s/dispatches", repoURL(ctx, owner, repo), url.PathEscape(workflow))
+	decoded, _, err := doJSON(ctx, accessToken, http.MethodPost, target, payload)
+	if err != nil {
+		return nil, err
+	}
+	out := coerceMap(decoded)
+	out["workflow"] = workflow
+	out["ref"] = ref
+	return out, nil
+}
+
+func GetWorkflowRun(ctx context.Context, accessToken string, owner string, repo string, runID int64) (map[string]any, error) {
+	target := fmt.Sprintf("%s/actions/runs/%d", repoURL(ctx, owner, repo), runID)
+	decoded, _, err := doJSON(ctx, accessToken, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	return coerceMap(decoded), nil
+}
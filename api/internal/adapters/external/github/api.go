@@ -6,16 +6,57 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
 
+// maxListPages bounds how many pages doJSON follows for one list request.
+const maxListPages = 10
+
+// doJSON sends a GitHub API request and decodes the JSON response. A GET that
+// returns an array follows the Link header's rel="next" pages, up to
+// maxListPages, and returns all items as one array.
 func doJSON(ctx context.Context, accessToken string, method string, target string, payload any) (any, int, error) {
+	return doJSONPages(ctx, accessToken, method, target, payload, maxListPages)
+}
+
+// doJSONPages is doJSON with an explicit page limit; 1 returns the first page only.
+func doJSONPages(ctx context.Context, accessToken string, method string, target string, payload any, maxPages int) (any, int, error) {
+	decoded, status, header, err := doRequest(ctx, accessToken, method, target, payload)
+	if err != nil || method != http.MethodGet {
+		return decoded, status, err
+	}
+	items, ok := decoded.([]any)
+	if !ok {
+		return decoded, status, nil
+	}
+	next := nextPageURL(header, target)
+	for page := 1; next != "" && page < maxPages; page++ {
+		more, _, moreHeader, err := doRequest(ctx, accessToken, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, status, err
+		}
+		list, _ := more.([]any)
+		items = append(items, list...)
+		next = nextPageURL(moreHeader, next)
+	}
+	return items, status, nil
+}
+
+func pageLimit(pages int) int {
+	if pages <= 0 || pages > maxListPages {
+		return maxListPages
+	}
+	return pages
+}
+
+func doRequest(ctx context.Context, accessToken string, method string, target string, payload any) (any, int, http.Header, error) {
 	var body *bytes.Reader
 	if payload != nil {
 		raw, err := json.Marshal(payload)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
 		body = bytes.NewReader(raw)
 	} else {
@@ -24,7 +65,7 @@ func doJSON(ctx context.Context, accessToken string, method string, target strin
 
 	req, err := http.NewRequestWithContext(ctx, method, target, body)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 	if accessToken != "" {
 		req.Header.Set("Authorization", "Bearer "+accessToken)
@@ -38,7 +79,7 @@ func doJSON(ctx context.Context, accessToken string, method string, target strin
 	client := &http.Client{Timeout: 15 * time.Second}
 	res, err := client.Do(req)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 	defer res.Body.Close()
 
@@ -47,21 +88,53 @@ func doJSON(ctx context.Context, accessToken string, method string, target strin
 		_ = json.NewDecoder(res.Body).Decode(&decoded)
 		if m, ok := decoded.(map[string]any); ok {
 			if msg, ok := m["message"].(string); ok && msg != "" {
-				return nil, res.StatusCode, fmt.Errorf("github error: %s", msg)
+				return nil, res.StatusCode, res.Header, fmt.Errorf("github error: %s", msg)
 			}
 		}
-		return nil, res.StatusCode, fmt.Errorf("github error: %s", res.Status)
+		return nil, res.StatusCode, res.Header, fmt.Errorf("github error: %s", res.Status)
 	}
 
 	if res.ContentLength == 0 || res.StatusCode == http.StatusNoContent {
-		return map[string]any{"status": res.StatusCode}, res.StatusCode, nil
+		return map[string]any{"status": res.StatusCode}, res.StatusCode, res.Header, nil
 	}
 
 	var decoded any
 	if err := json.NewDecoder(res.Body).Decode(&decoded); err != nil {
-		return nil, res.StatusCode, err
+		return nil, res.StatusCode, res.Header, err
+	}
+	return decoded, res.StatusCode, res.Header, nil
+}
+
+// nextPageURL returns the rel="next" URL from a Link header. It is ignored
+// unless it points at the same host as current, so the token is never sent
+// elsewhere.
+func nextPageURL(header http.Header, current string) string {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		isNext := false
+		for _, param := range parts[1:] {
+			if strings.EqualFold(strings.TrimSpace(param), `rel="next"`) {
+				isNext = true
+			}
+		}
+		if !isNext {
+			continue
+		}
+		raw := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+		next, err := url.Parse(raw)
+		if err != nil {
+			return ""
+		}
+		base, err := url.Parse(current)
+		if err != nil || next.Host != base.Host || next.Scheme != base.Scheme {
+			return ""
+		}
+		return raw
 	}
-	return decoded, res.StatusCode, nil
+	return ""
 }
 
 func coerceMap(decoded any) map[string]any {
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ListCommitsOptions filters ListCommits. SHA is the branch, tag or SHA to
+// start from (default: the default branch); Since and Until are ISO 8601 times.
+type ListCommitsOptions struct {
+	SHA    string
+	Path   string
+	Author string
+	Since  string
+	Until  string
+	Pages  int
+}
+
+func ListCommits(ctx context.Context, accessToken string, owner string, repo string, opts ListCommitsOptions) (map[string]any, error) {
+	query := url.Values{}
+	query.Set("per_page", "100")
+	for key, value := range map[string]string{
+		"sha": opts.SHA, "path": opts.Path, "author": opts.Author, "since": opts.Since, "until": opts.Until,
+	} {
+		if strings.TrimSpace(value) != "" {
+			query.Set(key, strings.TrimSpace(value))
+		}
+	}
+	target := repoURL(ctx, owner, repo) + "/commits?" + query.Encode()
+	decoded, _, err := doJSONPages(ctx, accessToken, http.MethodGet, target, nil, pageLimit(opts.Pages))
+	if err != nil {
+		return nil, err
+	}
+	return coerceMap(decoded), nil
+}
+
+// CreateBranch creates branch pointing at fromRef, a branch, tag or commit
+// SHA. An empty fromRef uses the repository's default branch.
+func CreateBranch(ctx context.Context, accessToken string, owner string, repo string, branch string, fromRef string) (map[string]any, error) {
+	branch = strings.TrimPrefix(strings.TrimSpace(branch), "refs/heads/")
+	if branch == "" {
+		return nil, errors.New("branch is required")
+	}
+	fromRef = strings.TrimSpace(fromRef)
+	if fromRef == "" {
+		info, err := GetRepo(ctx, accessToken, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		fromRef = readAnyString(info["default_branch"])
+		if fromRef == "" {
+			return nil, errors.New("unable to resolve default branch")
+		}
+	}
+	commit, _, err := doJSON(ctx, accessToken, http.MethodGet, repoURL(ctx, owner, repo)+"/commits/"+escapeGitHubPath(fromRef), nil)
+	if err != nil {
+		return nil, err
+	}
+	sha := readAnyString(coerceMap(commit)["sha"])
+	if sha == "" {
+		return nil, errors.New("unable to resolve ref sha")
+	}
+	decoded, _, err := doJSON(ctx, accessToken, http.MethodPost, repoURL(ctx, owner, repo)+"/git/refs", map[string]any{
+		"ref": "refs/heads/" + branch,
+		"sha": sha,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return coerceMap(decoded), nil
+}
@@ -1,9 +1,6 @@
 package github
 
 const (
-	BaseURL       = "https://api.github.com"
-	AuthEndpoint  = "https://github.com/login/oauth/authorize"
-	TokenEndpoint = "https://github.com/login/oauth/access_token"
-	UserEndpoint  = "https://api.github.com/user"
-	EmailEndpoint = "https://api.github.com/user/emails"
+	BaseURL  = "https://api.github.com"
+	OAuthURL = "https://github.com"
 )
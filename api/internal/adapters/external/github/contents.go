@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
-	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -19,7 +18,7 @@ type ContentInfo struct {
 }
 
 func GetFile(ctx context.Context, accessToken string, owner string, repo string, path string, ref string) (map[string]any, error) {
-	target := contentsURL(owner, repo, path, ref)
+	target := contentsURL(ctx, owner, repo, path, ref)
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodGet, target, nil)
 	if err != nil {
 		return nil, err
@@ -28,7 +27,7 @@ func GetFile(ctx context.Context, accessToken string, owner string, repo string,
 }
 
 func ListFiles(ctx context.Context, accessToken string, owner string, repo string, path string, ref string) (map[string]any, error) {
-	target := contentsURL(owner, repo, path, ref)
+	target := contentsURL(ctx, owner, repo, path, ref)
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodGet, target, nil)
 	if err != nil {
 		return nil, err
@@ -47,7 +46,7 @@ func CreateFile(ctx context.Context, accessToken string, owner string, repo stri
 	if strings.TrimSpace(branch) != "" {
 		payload["branch"] = branch
 	}
-	target := contentsURL(owner, repo, path, "")
+	target := contentsURL(ctx, owner, repo, path, "")
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodPut, target, payload)
 	if err != nil {
 		return nil, err
@@ -74,7 +73,7 @@ func EditFile(ctx context.Context, accessToken string, owner string, repo string
 	if strings.TrimSpace(branch) != "" {
 		payload["branch"] = branch
 	}
-	target := contentsURL(owner, repo, path, "")
+	target := contentsURL(ctx, owner, repo, path, "")
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodPut, target, payload)
 	if err != nil {
 		return nil, err
@@ -100,7 +99,7 @@ func DeleteFile(ctx context.Context, accessToken string, owner string, repo stri
 	if strings.TrimSpace(branch) != "" {
 		payload["branch"] = branch
 	}
-	target := contentsURL(owner, repo, path, "")
+	target := contentsURL(ctx, owner, repo, path, "")
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodDelete, target, payload)
 	if err != nil {
 		return nil, err
@@ -108,8 +107,8 @@ func DeleteFile(ctx context.Context, accessToken string, owner string, repo stri
 	return coerceMap(decoded), nil
 }
 
-func contentsURL(owner string, repo string, path string, ref string) string {
-	base := fmt.Sprintf("%s/repos/%s/%s/contents", BaseURL, url.PathEscape(owner), url.PathEscape(repo))
+func contentsURL(ctx context.Context, owner string, repo string, path string, ref string) string {
+	base := repoURL(ctx, owner, repo) + "/contents"
 	escaped := escapeGitHubPath(path)
 	if escaped != "" {
 		base += "/" + escaped
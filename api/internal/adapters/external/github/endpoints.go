@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+	"strings"
+)
+
+// Endpoints are the GitHub hosts the adapter talks to. Empty fields fall back
+// to github.com (BaseURL and OAuthURL).
+type Endpoints struct {
+	// APIURL is the REST API root, e.g. https://ghe.example.com/api/v3.
+	APIURL string
+	// OAuthURL is the web root serving /login/oauth/*, e.g. https://ghe.example.com.
+	OAuthURL string
+}
+
+type endpointsKey struct{}
+
+// WithEndpoints returns a context under which adapter calls use e.
+func WithEndpoints(ctx context.Context, e Endpoints) context.Context {
+	return context.WithValue(ctx, endpointsKey{}, e)
+}
+
+func endpointsFrom(ctx context.Context) Endpoints {
+	e, _ := ctx.Value(endpointsKey{}).(Endpoints)
+	e.APIURL = strings.TrimRight(strings.TrimSpace(e.APIURL), "/")
+	if e.APIURL == "" {
+		e.APIURL = BaseURL
+	}
+	e.OAuthURL = strings.TrimRight(strings.TrimSpace(e.OAuthURL), "/")
+	if e.OAuthURL == "" {
+		e.OAuthURL = OAuthURL
+	}
+	return e
+}
+
+func apiURL(ctx context.Context) string {
+	return endpointsFrom(ctx).APIURL
+}
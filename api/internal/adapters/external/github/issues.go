@@ -16,7 +16,7 @@ func CreateIssue(ctx context.Context, accessToken string, owner string, repo str
 		"title": title,
 		"body":  body,
 	}
-	target := fmt.Sprintf("%s/repos/%s/%s/issues", BaseURL, url.PathEscape(owner), url.PathEscape(repo))
+	target := repoURL(ctx, owner, repo) + "/issues"
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodPost, target, payload)
 	if err != nil {
 		return nil, err
@@ -25,12 +25,7 @@ func CreateIssue(ctx context.Context, accessToken string, owner string, repo str
 }
 
 func GetIssue(ctx context.Context, accessToken string, owner string, repo string, issueNumber int) (map[string]any, error) {
-	target := fmt.Sprintf(
-		fmt.Sprintf("%s/repos/%s/%s/issues/%d", BaseURL, owner, repo, issueNumber),
-		url.PathEscape(owner),
-		url.PathEscape(repo),
-		issueNumber,
-	)
+	target := fmt.Sprintf("%s/issues/%d", repoURL(ctx, owner, repo), issueNumber)
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodGet, target, nil)
 	if err != nil {
 		return nil, err
@@ -49,12 +44,7 @@ func EditIssue(ctx context.Context, accessToken string, owner string, repo strin
 	if strings.TrimSpace(state) != "" {
 		payload["state"] = state
 	}
-	target := fmt.Sprintf(
-		fmt.Sprintf("%s/repos/%s/%s/issues/%d", BaseURL, owner, repo, issueNumber),
-		url.PathEscape(owner),
-		url.PathEscape(repo),
-		issueNumber,
-	)
+	target := fmt.Sprintf("%s/issues/%d", repoURL(ctx, owner, repo), issueNumber)
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodPatch, target, payload)
 	if err != nil {
 		return nil, err
@@ -66,12 +56,7 @@ func CreateIssueComment(ctx context.Context, accessToken string, owner string, r
 	payload := map[string]any{
 		"body": body,
 	}
-	target := fmt.Sprintf(
-		fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", BaseURL, owner, repo, issueNumber),
-		url.PathEscape(owner),
-		url.PathEscape(repo),
-		issueNumber,
-	)
+	target := fmt.Sprintf("%s/issues/%d/comments", repoURL(ctx, owner, repo), issueNumber)
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodPost, target, payload)
 	if err != nil {
 		return nil, err
@@ -84,12 +69,7 @@ func LockIssue(ctx context.Context, accessToken string, owner string, repo strin
 	if strings.TrimSpace(lockReason) != "" {
 		payload["lock_reason"] = lockReason
 	}
-	target := fmt.Sprintf(
-		fmt.Sprintf("%s/repos/%s/%s/issues/%d/lock", BaseURL, owner, repo, issueNumber),
-		url.PathEscape(owner),
-		url.PathEscape(repo),
-		issueNumber,
-	)
+	target := fmt.Sprintf("%s/issues/%d/lock", repoURL(ctx, owner, repo), issueNumber)
 	decoded, status, err := doJSON(ctx, accessToken, http.MethodPut, target, payload)
 	if err != nil {
 		return nil, err
@@ -99,3 +79,54 @@ func LockIssue(ctx context.Context, accessToken string, owner string, repo strin
 	}
 	return coerceMap(decoded), nil
 }
+
+// ListIssuesOptions filters ListIssues. Empty fields use GitHub's defaults.
+// Pages limits how many pages are followed; 0 follows up to maxListPages.
+type ListIssuesOptions struct {
+	State     string
+	Labels    string
+	Sort      string
+	Direction string
+	PerPage   int
+	Pages     int
+}
+
+// ListIssues lists repository issues. GitHub returns pull requests from this
+// endpoint too; they carry a "pull_request" key.
+func ListIssues(ctx context.Context, accessToken string, owner string, repo string, opts ListIssuesOptions) ([]map[string]any, error) {
+	if strings.TrimSpace(owner) == "" || strings.TrimSpace(repo) == "" {
+		return nil, fmt.Errorf("missing repo owner or name")
+	}
+	query := url.Values{}
+	if opts.State != "" {
+		query.Set("state", opts.State)
+	}
+	if opts.Labels != "" {
+		query.Set("labels", opts.Labels)
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
+	if opts.Direction != "" {
+		query.Set("direction", opts.Direction)
+	}
+	if opts.PerPage > 0 {
+		query.Set("per_page", fmt.Sprint(opts.PerPage))
+	}
+	target := repoURL(ctx, owner, repo) + "/issues"
+	if encoded := query.Encode(); encoded != "" {
+		target += "?" + encoded
+	}
+	decoded, _, err := doJSONPages(ctx, accessToken, http.MethodGet, target, nil, pageLimit(opts.Pages))
+	if err != nil {
+		return nil, err
+	}
+	list, _ := decoded.([]any)
+	out := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
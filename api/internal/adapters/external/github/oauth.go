@@ -11,13 +11,6 @@ import (
 	"time"
 )
 
-const (
-	authEndpoint  = AuthEndpoint
-	tokenEndpoint = TokenEndpoint
-	userEndpoint  = UserEndpoint
-	emailEndpoint = EmailEndpoint
-)
-
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
 	Scope       string `json:"scope"`
@@ -31,7 +24,8 @@ type UserProfile struct {
 	Email string `json:"email"`
 }
 
-func BuildAuthURL(clientID string, redirectURL string, state string, scopes []string) string {
+// BuildAuthURL returns the authorize URL on the OAuth host configured in ctx (see WithEndpoints).
+func BuildAuthURL(ctx context.Context, clientID string, redirectURL string, state string, scopes []string) string {
 	q := url.Values{}
 	q.Set("client_id", clientID)
 	q.Set("redirect_uri", redirectURL)
@@ -41,7 +35,7 @@ func BuildAuthURL(clientID string, redirectURL string, state string, scopes []st
 	if len(scopes) > 0 {
 		q.Set("scope", strings.Join(scopes, " "))
 	}
-	return authEndpoint + "?" + q.Encode()
+	return endpointsFrom(ctx).OAuthURL + "/login/oauth/authorize?" + q.Encode()
 }
 
 func ExchangeCode(ctx context.Context, clientID string, clientSecret string, redirectURL string, code string) (TokenResponse, error) {
@@ -52,7 +46,7 @@ func ExchangeCode(ctx context.Context, clientID string, clientSecret string, red
 	if redirectURL != "" {
 		values.Set("redirect_uri", redirectURL)
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(values.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointsFrom(ctx).OAuthURL+"/login/oauth/access_token", strings.NewReader(values.Encode()))
 	if err != nil {
 		return TokenResponse{}, err
 	}
@@ -78,7 +72,7 @@ func ExchangeCode(ctx context.Context, clientID string, clientSecret string, red
 }
 
 func FetchUserProfile(ctx context.Context, accessToken string) (UserProfile, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, userEndpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL(ctx)+"/user", nil)
 	if err != nil {
 		return UserProfile{}, err
 	}
@@ -106,7 +100,7 @@ func FetchUserProfile(ctx context.Context, accessToken string) (UserProfile, err
 }
 
 func fetchPrimaryEmail(ctx context.Context, accessToken string) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, emailEndpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL(ctx)+"/user/emails", nil)
 	if err != nil {
 		return "", err
 	}
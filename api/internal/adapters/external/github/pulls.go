@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PullRequestInput describes a pull request to open. Head is the branch with
+// the changes ("feature" or "fork-owner:feature"); Base is the branch to merge into.
+type PullRequestInput struct {
+	Title               string
+	Head                string
+	Base                string
+	Body                string
+	Draft               bool
+	MaintainerCanModify *bool
+}
+
+func CreatePullRequest(ctx context.Context, accessToken string, owner string, repo string, input PullRequestInput) (map[string]any, error) {
+	if strings.TrimSpace(input.Title) == "" || strings.TrimSpace(input.Head) == "" || strings.TrimSpace(input.Base) == "" {
+		return nil, errors.New("title, head and base are required")
+	}
+	payload := map[string]any{
+		"title": input.Title,
+		"head":  input.Head,
+		"base":  input.Base,
+	}
+	if input.Body != "" {
+		payload["body"] = input.Body
+	}
+	if input.Draft {
+		payload["draft"] = true
+	}
+	if input.MaintainerCanModify != nil {
+		payload["maintainer_can_modify"] = *input.MaintainerCanModify
+	}
+	decoded, _, err := doJSON(ctx, accessToken, http.MethodPost, repoURL(ctx, owner, repo)+"/pulls", payload)
+	if err != nil {
+		return nil, err
+	}
+	return coerceMap(decoded), nil
+}
+
+// ListPullRequestsOptions filters ListPullRequests. Empty fields use GitHub's
+// defaults (open pull requests, newest first).
+type ListPullRequestsOptions struct {
+	State     string
+	Head      string
+	Base      string
+	Sort      string
+	Direction string
+	Pages     int
+}
+
+func ListPullRequests(ctx context.Context, accessToken string, owner string, repo string, opts ListPullRequestsOptions) (map[string]any, error) {
+	query := url.Values{}
+	query.Set("per_page", "100")
+	for key, value := range map[string]string{
+		"state": opts.State, "head": opts.Head, "base": opts.Base, "sort": opts.Sort, "direction": opts.Direction,
+	} {
+		if strings.TrimSpace(value) != "" {
+			query.Set(key, strings.TrimSpace(value))
+		}
+	}
+	target := repoURL(ctx, owner, repo) + "/pulls?" + query.Encode()
+	decoded, _, err := doJSONPages(ctx, accessToken, http.MethodGet, target, nil, pageLimit(opts.Pages))
+	if err != nil {
+		return nil, err
+	}
+	return coerceMap(decoded), nil
+}
+
+// MergeOptions control MergePullRequest. Method is merge, squash or rebase;
+// SHA, when set, makes the merge fail if the head has moved.
+type MergeOptions struct {
+	Method        string
+	CommitTitle   string
+	CommitMessage string
+	SHA           string
+}
+
+func MergePullRequest(ctx context.Context, accessToken string, owner string, repo string, number int, opts MergeOptions) (map[string]any, error) {
+	payload := map[string]any{}
+	switch method := strings.ToLower(strings.TrimSpace(opts.Method)); method {
+	case "":
+	case "merge", "squash", "rebase":
+		payload["merge_method"] = method
+	default:
+		return nil, fmt.Errorf("merge method must be merge, squash or rebase, got %q", opts.Method)
+	}
+	if strings.TrimSpace(opts.CommitTitle) != "" {
+		payload["commit_title"] = opts.CommitTitle
+	}
+	if opts.CommitMessage != "" {
+		payload["commit_message"] = opts.CommitMessage
+	}
+	if strings.TrimSpace(opts.SHA) != "" {
+		payload["sha"] = strings.TrimSpace(opts.SHA)
+	}
+	target := fmt.Sprintf("%s/pulls/%d/merge", repoURL(ctx, owner, repo), number)
+	decoded, _, err := doJSON(ctx, accessToken, http.MethodPut, target, payload)
+	if err != nil {
+		return nil, err
+	}
+	return coerceMap(decoded), nil
+}
+
+// RequestReviewers asks users (by login) and teams (by slug) to review a pull request.
+func RequestReviewers(ctx context.Context, accessToken string, owner string, repo string, number int, reviewers []string, teamReviewers []string) (map[string]any, error) {
+	if len(reviewers) == 0 && len(teamReviewers) == 0 {
+		return nil, errors.New("at least one reviewer or team reviewer is required")
+	}
+	payload := map[string]any{}
+	if len(reviewers) > 0 {
+		payload["reviewers"] = reviewers
+	}
+	if len(teamReviewers) > 0 {
+		payload["team_reviewers"] = teamReviewers
+	}
+	target := fmt.Sprintf("%s/pulls/%d/requested_reviewers", repoURL(ctx, owner, repo), number)
+	decoded, _, err := doJSON(ctx, accessToken, http.MethodPost, target, payload)
+	if err != nil {
+		return nil, err
+	}
+	return coerceMap(decoded), nil
+}
@@ -0,0 +1,47 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ReleaseInput describes a release. TargetCommitish is the branch or SHA the
+// tag is created from when it does not exist yet (default: the default branch).
+// GenerateNotes asks GitHub to write the notes; Body is prepended to them.
+type ReleaseInput struct {
+	TagName         string
+	TargetCommitish string
+	Name            string
+	Body            string
+	Draft           bool
+	Prerelease      bool
+	GenerateNotes   bool
+}
+
+func CreateRelease(ctx context.Context, accessToken string, owner string, repo string, input ReleaseInput) (map[string]any, error) {
+	if strings.TrimSpace(input.TagName) == "" {
+		return nil, errors.New("tag is required")
+	}
+	payload := map[string]any{
+		"tag_name":               strings.TrimSpace(input.TagName),
+		"draft":                  input.Draft,
+		"prerelease":             input.Prerelease,
+		"generate_release_notes": input.GenerateNotes,
+	}
+	if strings.TrimSpace(input.TargetCommitish) != "" {
+		payload["target_commitish"] = strings.TrimSpace(input.TargetCommitish)
+	}
+	if strings.TrimSpace(input.Name) != "" {
+		payload["name"] = input.Name
+	}
+	if input.Body != "" {
+		payload["body"] = input.Body
+	}
+	decoded, _, err := doJSON(ctx, accessToken, http.MethodPost, repoURL(ctx, owner, repo)+"/releases", payload)
+	if err != nil {
+		return nil, err
+	}
+	return coerceMap(decoded), nil
+}
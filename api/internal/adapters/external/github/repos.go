@@ -8,7 +8,7 @@ import (
 )
 
 func GetRepo(ctx context.Context, accessToken string, owner string, repo string) (map[string]any, error) {
-	target := fmt.Sprintf("%s/repos/%s/%s", BaseURL, url.PathEscape(owner), url.PathEscape(repo))
+	target := repoURL(ctx, owner, repo)
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodGet, target, nil)
 	if err != nil {
 		return nil, err
@@ -17,10 +17,14 @@ func GetRepo(ctx context.Context, accessToken string, owner string, repo string)
 }
 
 func ListOrgRepos(ctx context.Context, accessToken string, org string) (map[string]any, error) {
-	target := fmt.Sprintf("%s/orgs/%s/repos?per_page=100", BaseURL, url.PathEscape(org))
+	target := fmt.Sprintf("%s/orgs/%s/repos?per_page=100", apiURL(ctx), url.PathEscape(org))
 	decoded, _, err := doJSON(ctx, accessToken, http.MethodGet, target, nil)
 	if err != nil {
 		return nil, err
 	}
 	return coerceMap(decoded), nil
 }
+
+func repoURL(ctx context.Context, owner string, repo string) string {
+	return fmt.Sprintf("%s/repos/%s/%s", apiURL(ctx), url.PathEscape(owner), url.PathEscape(repo))
+}
@@ -0,0 +1,348 @@
+package google
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxCalendarPages bounds ListEvents with All set and ListCalendars.
+const maxCalendarPages = 20
+
+// ErrCalendarScope is returned when Google rejects a call because the token
+// was granted without the Calendar scope.
+var ErrCalendarScope = errors.New("google calendar: the credential was connected without Calendar access; reconnect the Google credential and grant the Calendar scope")
+
+// ListCalendars returns the calendars on the user's calendar list.
+func ListCalendars(ctx context.Context, accessToken string) (map[string]any, error) {
+	calendars := []any{}
+	pageToken := ""
+	for page := 0; page < maxCalendarPages; page++ {
+		params := url.Values{}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+		out, err := calendarDo(ctx, accessToken, http.MethodGet, endpointsFrom(ctx).CalendarURL+"/users/me/calendarList?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		calendars = append(calendars, asList(out["items"])...)
+		pageToken, _ = out["nextPageToken"].(string)
+		if pageToken == "" {
+			break
+		}
+	}
+	return map[string]any{"calendars": calendars, "count": len(calendars)}, nil
+}
+
+// EventListOptions control ListEvents. TimeMin and TimeMax take RFC 3339
+// times or dates (midnight UTC). SingleEvents expands recurring events into
+// their instances, ordered by start time.
+type EventListOptions struct {
+	CalendarID   string
+	TimeMin      string
+	TimeMax      string
+	Query        string
+	TimeZone     string
+	SingleEvents bool
+	ShowDeleted  bool
+	MaxResults   int
+	PageToken    string
+	All          bool
+}
+
+// ListEvents returns events on a calendar ("primary" by default).
+func ListEvents(ctx context.Context, accessToken string, opts EventListOptions) (map[string]any, error) {
+	timeMin, err := calendarTime(opts.TimeMin)
+	if err != nil {
+		return nil, fmt.Errorf("timeMin: %w", err)
+	}
+	timeMax, err := calendarTime(opts.TimeMax)
+	if err != nil {
+		return nil, fmt.Errorf("timeMax: %w", err)
+	}
+
+	events := []any{}
+	pageToken := opts.PageToken
+	for page := 0; page < maxCalendarPages; page++ {
+		params := url.Values{}
+		if timeMin != "" {
+			params.Set("timeMin", timeMin)
+		}
+		if timeMax != "" {
+			params.Set("timeMax", timeMax)
+		}
+		if q := strings.TrimSpace(opts.Query); q != "" {
+			params.Set("q", q)
+		}
+		if tz := strings.TrimSpace(opts.TimeZone); tz != "" {
+			params.Set("timeZone", tz)
+		}
+		if opts.SingleEvents {
+			params.Set("singleEvents", "true")
+			params.Set("orderBy", "startTime")
+		}
+		if opts.ShowDeleted {
+			params.Set("showDeleted", "true")
+		}
+		if opts.MaxResults > 0 {
+			params.Set("maxResults", fmt.Sprint(opts.MaxResults))
+		}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+		out, err := calendarDo(ctx, accessToken, http.MethodGet, eventsURL(ctx, opts.CalendarID, "")+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, asList(out["items"])...)
+		pageToken, _ = out["nextPageToken"].(string)
+		if !opts.All || pageToken == "" {
+			break
+		}
+	}
+	return map[string]any{
+		"events":        events,
+		"count":         len(events),
+		"nextPageToken": pageToken,
+	}, nil
+}
+
+// Reminder is an event reminder: Method is popup or email.
+type Reminder struct {
+	Method  string
+	Minutes int
+}
+
+// EventInput describes an event to create or update. Start and End take RFC
+// 3339 times, or dates for all-day events; times without an offset are read
+// in TimeZone. On update only the fields that are set are changed.
+// AddConference attaches a Google Meet link. Reminders replace the calendar's
+// default reminders.
+type EventInput struct {
+	Summary       string
+	Description   string
+	Location      string
+	Start         string
+	End           string
+	TimeZone      string
+	Attendees     []string
+	Recurrence    []string
+	Reminders     []Reminder
+	AddConference bool
+	Visibility    string
+	ColorID       string
+}
+
+// CreateEvent creates an event. sendUpdates (all, externalOnly, none)
+// controls invitation emails to attendees.
+func CreateEvent(ctx context.Context, accessToken string, calendarID string, input EventInput, sendUpdates string) (map[string]any, error) {
+	if strings.TrimSpace(input.Start) == "" || strings.TrimSpace(input.End) == "" {
+		return nil, errors.New("start and end are required")
+	}
+	body, err := eventBody(input)
+	if err != nil {
+		return nil, err
+	}
+	target := eventsURL(ctx, calendarID, "") + "?" + eventParams(sendUpdates, input.AddConference).Encode()
+	return calendarDo(ctx, accessToken, http.MethodPost, target, body)
+}
+
+// UpdateEvent patches an event with the fields set in input.
+func UpdateEvent(ctx context.Context, accessToken string, calendarID string, eventID string, input EventInput, sendUpdates string) (map[string]any, error) {
+	body, err := eventBody(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, errors.New("nothing to update")
+	}
+	target := eventsURL(ctx, calendarID, eventID) + "?" + eventParams(sendUpdates, input.AddConference).Encode()
+	return calendarDo(ctx, accessToken, http.MethodPatch, target, body)
+}
+
+// DeleteEvent deletes an event.
+func DeleteEvent(ctx context.Context, accessToken string, calendarID string, eventID string, sendUpdates string) (map[string]any, error) {
+	target := eventsURL(ctx, calendarID, eventID) + "?" + eventParams(sendUpdates, false).Encode()
+	if _, err := calendarDo(ctx, accessToken, http.MethodDelete, target, nil); err != nil {
+		return nil, err
+	}
+	return map[string]any{"id": eventID, "deleted": true}, nil
+}
+
+// FreeBusyQuery asks for the busy intervals of calendars ("primary" by
+// default) between TimeMin and TimeMax.
+type FreeBusyQuery struct {
+	TimeMin   string
+	TimeMax   string
+	TimeZone  string
+	Calendars []string
+}
+
+// FreeBusy returns busy intervals per calendar.
+func FreeBusy(ctx context.Context, accessToken string, query FreeBusyQuery) (map[string]any, error) {
+	timeMin, err := calendarTime(query.TimeMin)
+	if err != nil {
+		return nil, fmt.Errorf("timeMin: %w", err)
+	}
+	timeMax, err := calendarTime(query.TimeMax)
+	if err != nil {
+		return nil, fmt.Errorf("timeMax: %w", err)
+	}
+	if timeMin == "" || timeMax == "" {
+		return nil, errors.New("timeMin and timeMax are required")
+	}
+	calendars := query.Calendars
+	if len(calendars) == 0 {
+		calendars = []string{"primary"}
+	}
+	items := make([]map[string]any, 0, len(calendars))
+	for _, id := range calendars {
+		items = append(items, map[string]any{"id": id})
+	}
+	body := map[string]any{"timeMin": timeMin, "timeMax": timeMax, "items": items}
+	if tz := strings.TrimSpace(query.TimeZone); tz != "" {
+		body["timeZone"] = tz
+	}
+	return calendarDo(ctx, accessToken, http.MethodPost, endpointsFrom(ctx).CalendarURL+"/freeBusy", body)
+}
+
+func eventBody(input EventInput) (map[string]any, error) {
+	body := map[string]any{}
+	for key, value := range map[string]string{
+		"summary":     input.Summary,
+		"description": input.Description,
+		"location":    input.Location,
+		"visibility":  input.Visibility,
+		"colorId":     input.ColorID,
+	} {
+		if strings.TrimSpace(value) != "" {
+			body[key] = value
+		}
+	}
+	for key, value := range map[string]string{"start": input.Start, "end": input.End} {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		when, err := eventTime(value, input.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		body[key] = when
+	}
+	if len(input.Attendees) > 0 {
+		attendees := make([]map[string]any, 0, len(input.Attendees))
+		for _, email := range input.Attendees {
+			attendees = append(attendees, map[string]any{"email": email})
+		}
+		body["attendees"] = attendees
+	}
+	if len(input.Recurrence) > 0 {
+		body["recurrence"] = input.Recurrence
+	}
+	if len(input.Reminders) > 0 {
+		overrides := make([]map[string]any, 0, len(input.Reminders))
+		for _, r := range input.Reminders {
+			method := strings.ToLower(strings.TrimSpace(r.Method))
+			if method == "" {
+				method = "popup"
+			}
+			if method != "popup" && method != "email" {
+				return nil, fmt.Errorf("reminder method must be popup or email, got %q", r.Method)
+			}
+			overrides = append(overrides, map[string]any{"method": method, "minutes": r.Minutes})
+		}
+		body["reminders"] = map[string]any{"useDefault": false, "overrides": overrides}
+	}
+	if input.AddConference {
+		requestID, err := conferenceRequestID()
+		if err != nil {
+			return nil, err
+		}
+		body["conferenceData"] = map[string]any{
+			"createRequest": map[string]any{
+				"requestId":             requestID,
+				"conferenceSolutionKey": map[string]any{"type": "hangoutsMeet"},
+			},
+		}
+	}
+	return body, nil
+}
+
+// eventTime renders an event start or end: {date} for all-day events,
+// {dateTime, timeZone} otherwise.
+func eventTime(value string, timeZone string) (map[string]any, error) {
+	value = strings.TrimSpace(value)
+	if _, err := time.Parse(time.DateOnly, value); err == nil {
+		return map[string]any{"date": value}, nil
+	}
+	when := map[string]any{"dateTime": value}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		if _, err := time.Parse("2006-01-02T15:04:05", value); err != nil {
+			return nil, fmt.Errorf("%q is not a date or RFC 3339 time", value)
+		}
+		if strings.TrimSpace(timeZone) == "" {
+			return nil, fmt.Errorf("%q has no UTC offset; set a time zone", value)
+		}
+	}
+	if tz := strings.TrimSpace(timeZone); tz != "" {
+		when["timeZone"] = tz
+	}
+	return when, nil
+}
+
+// calendarTime normalizes a range bound to RFC 3339; dates become midnight UTC.
+func calendarTime(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if d, err := time.Parse(time.DateOnly, value); err == nil {
+		return d.UTC().Format(time.RFC3339), nil
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return "", fmt.Errorf("%q is not a date or RFC 3339 time", value)
+	}
+	return value, nil
+}
+
+func eventParams(sendUpdates string, conference bool) url.Values {
+	params := url.Values{}
+	if s := strings.TrimSpace(sendUpdates); s != "" {
+		params.Set("sendUpdates", s)
+	}
+	if conference {
+		params.Set("conferenceDataVersion", "1")
+	}
+	return params
+}
+
+func eventsURL(ctx context.Context, calendarID string, eventID string) string {
+	calendarID = strings.TrimSpace(calendarID)
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	target := endpointsFrom(ctx).CalendarURL + "/calendars/" + url.PathEscape(calendarID) + "/events"
+	if eventID != "" {
+		target += "/" + url.PathEscape(eventID)
+	}
+	return target
+}
+
+func conferenceRequestID() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func calendarDo(ctx context.Context, accessToken string, method string, target string, payload any) (map[string]any, error) {
+	return apiDo(ctx, accessToken, method, target, payload, "google calendar", ErrCalendarScope)
+}
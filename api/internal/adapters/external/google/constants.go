@@ -7,7 +7,14 @@ const (
 	GmailBaseURL       = "https://gmail.googleapis.com/gmail/v1"
 	SheetsBaseURL      = "https://sheets.googleapis.com/v4/spreadsheets"
 	DriveFilesBaseURL  = "https://www.googleapis.com/drive/v3/files"
+	DriveUploadBaseURL = "https://www.googleapis.com/upload/drive/v3/files"
+	CalendarBaseURL    = "https://www.googleapis.com/calendar/v3"
 	ScopeUserInfoEmail = "https://www.googleapis.com/auth/userinfo.email"
 	ScopeGmailSend     = "https://www.googleapis.com/auth/gmail.send"
+	ScopeGmailReadonly = "https://www.googleapis.com/auth/gmail.readonly"
+	ScopeGmailModify   = "https://www.googleapis.com/auth/gmail.modify"
+	ScopeGmailCompose  = "https://www.googleapis.com/auth/gmail.compose"
 	ScopeSheets        = "https://www.googleapis.com/auth/spreadsheets"
+	ScopeDrive         = "https://www.googleapis.com/auth/drive"
+	ScopeCalendar      = "https://www.googleapis.com/auth/calendar"
 )
@@ -0,0 +1,460 @@
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxDriveDownloadBytes caps DownloadFile; the content ends up in node outputs.
+	MaxDriveDownloadBytes = 10 << 20
+	// MaxDriveUploadBytes caps UploadFile.
+	MaxDriveUploadBytes = 50 << 20
+
+	FolderMimeType = "application/vnd.google-apps.folder"
+
+	// maxDrivePages bounds ListFiles with All set.
+	maxDrivePages   = 20
+	driveFileFields = "id,name,mimeType,size,parents,createdTime,modifiedTime,webViewLink,webContentLink,trashed,shared,owners(displayName,emailAddress)"
+)
+
+// ErrDriveScope is returned when Google rejects a call because the token was
+// granted without the Drive scope.
+var ErrDriveScope = errors.New("google drive: the credential was connected without Drive access; reconnect the Google credential and grant the Drive scope")
+
+// exportFormats maps short export formats to the MIME types Drive exports to.
+var exportFormats = map[string]string{
+	"pdf":  "application/pdf",
+	"csv":  "text/csv",
+	"tsv":  "text/tab-separated-values",
+	"txt":  "text/plain",
+	"html": "text/html",
+	"docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"odt":  "application/vnd.oasis.opendocument.text",
+	"ods":  "application/vnd.oasis.opendocument.spreadsheet",
+	"png":  "image/png",
+	"jpeg": "image/jpeg",
+	"svg":  "image/svg+xml",
+}
+
+// defaultExports is the export format used for Google Workspace files when none is given.
+var defaultExports = map[string]string{
+	"application/vnd.google-apps.document":     "application/pdf",
+	"application/vnd.google-apps.spreadsheet":  "text/csv",
+	"application/vnd.google-apps.presentation": "application/pdf",
+	"application/vnd.google-apps.drawing":      "image/png",
+}
+
+// ExportMimeType resolves an export format given as a short name (pdf, csv,
+// docx, ...) or a MIME type. It returns "" for unknown short names.
+func ExportMimeType(format string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if strings.Contains(format, "/") {
+		return format
+	}
+	return exportFormats[strings.TrimPrefix(format, ".")]
+}
+
+// DriveListOptions control ListFiles. Query uses Drive search syntax
+// (e.g. "name contains 'invoice'"); FolderID limits results to one folder.
+// Trashed files are skipped unless IncludeTrashed is set.
+type DriveListOptions struct {
+	Query          string
+	FolderID       string
+	OrderBy        string
+	PageSize       int
+	PageToken      string
+	IncludeTrashed bool
+	All            bool
+}
+
+// ListFiles returns files matching opts across My Drive and shared drives.
+func ListFiles(ctx context.Context, accessToken string, opts DriveListOptions) (map[string]any, error) {
+	var clauses []string
+	if q := strings.TrimSpace(opts.Query); q != "" {
+		clauses = append(clauses, "("+q+")")
+	}
+	if folder := strings.TrimSpace(opts.FolderID); folder != "" {
+		clauses = append(clauses, fmt.Sprintf("'%s' in parents", strings.ReplaceAll(folder, "'", `\'`)))
+	}
+	if !opts.IncludeTrashed {
+		clauses = append(clauses, "trashed = false")
+	}
+
+	files := []any{}
+	pageToken := opts.PageToken
+	for page := 0; page < maxDrivePages; page++ {
+		params := url.Values{}
+		params.Set("q", strings.Join(clauses, " and "))
+		params.Set("fields", "nextPageToken,files("+driveFileFields+")")
+		params.Set("supportsAllDrives", "true")
+		params.Set("includeItemsFromAllDrives", "true")
+		if opts.PageSize > 0 {
+			params.Set("pageSize", fmt.Sprint(opts.PageSize))
+		}
+		if strings.TrimSpace(opts.OrderBy) != "" {
+			params.Set("orderBy", strings.TrimSpace(opts.OrderBy))
+		}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+		out, err := driveDo(ctx, accessToken, http.MethodGet, endpointsFrom(ctx).DriveFilesURL+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, asList(out["files"])...)
+		pageToken, _ = out["nextPageToken"].(string)
+		if !opts.All || pageToken == "" {
+			break
+		}
+	}
+	return map[string]any{
+		"files":         files,
+		"count":         len(files),
+		"nextPageToken": pageToken,
+	}, nil
+}
+
+// GetFile returns a file's metadata.
+func GetFile(ctx context.Context, accessToken string, fileID string) (map[string]any, error) {
+	return driveDo(ctx, accessToken, http.MethodGet, driveFileURL(ctx, fileID, url.Values{"fields": {driveFileFields}}), nil)
+}
+
+// DriveDownload is a downloaded or exported file.
+type DriveDownload struct {
+	ID       string
+	Name     string
+	MimeType string
+	Content  []byte
+}
+
+// DownloadFile downloads a file's content. Google Workspace files (Docs,
+// Sheets, Slides, Drawings) cannot be downloaded as-is and are exported to
+// exportMimeType, or to PDF/CSV/PNG by default. exportMimeType is ignored for
+// other files.
+func DownloadFile(ctx context.Context, accessToken string, fileID string, exportMimeType string) (DriveDownload, error) {
+	meta, err := GetFile(ctx, accessToken, fileID)
+	if err != nil {
+		return DriveDownload{}, err
+	}
+	download := DriveDownload{
+		ID:       readString(meta, "id"),
+		Name:     readString(meta, "name"),
+		MimeType: readString(meta, "mimeType"),
+	}
+	var target string
+	if strings.HasPrefix(download.MimeType, "application/vnd.google-apps.") {
+		if download.MimeType == FolderMimeType {
+			return DriveDownload{}, errors.New("cannot download a folder")
+		}
+		exportMimeType = strings.TrimSpace(exportMimeType)
+		if exportMimeType == "" {
+			exportMimeType = defaultExports[download.MimeType]
+		}
+		if exportMimeType == "" {
+			return DriveDownload{}, fmt.Errorf("an export format is required for %s files", download.MimeType)
+		}
+		target = endpointsFrom(ctx).DriveFilesURL + "/" + url.PathEscape(fileID) + "/export?" + url.Values{"mimeType": {exportMimeType}}.Encode()
+		download.MimeType = exportMimeType
+	} else {
+		target = driveFileURL(ctx, fileID, url.Values{"alt": {"media"}})
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return DriveDownload{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	res, err := (&http.Client{Timeout: 60 * time.Second}).Do(req)
+	if err != nil {
+		return DriveDownload{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return DriveDownload{}, driveError(res)
+	}
+	content, err := io.ReadAll(io.LimitReader(res.Body, MaxDriveDownloadBytes+1))
+	if err != nil {
+		return DriveDownload{}, err
+	}
+	if len(content) > MaxDriveDownloadBytes {
+		return DriveDownload{}, fmt.Errorf("file is larger than %d MB", MaxDriveDownloadBytes>>20)
+	}
+	download.Content = content
+	return download, nil
+}
+
+// DriveUpload is a file to create. MimeType defaults to the type implied by
+// the name's extension; ConvertTo (e.g. application/vnd.google-apps.document)
+// asks Drive to convert the upload to a Google Workspace file.
+type DriveUpload struct {
+	Name      string
+	MimeType  string
+	FolderID  string
+	ConvertTo string
+	Content   []byte
+}
+
+// UploadFile creates a file with a resumable upload and returns its metadata.
+func UploadFile(ctx context.Context, accessToken string, upload DriveUpload) (map[string]any, error) {
+	if strings.TrimSpace(upload.Name) == "" {
+		return nil, errors.New("file name is required")
+	}
+	if len(upload.Content) > MaxDriveUploadBytes {
+		return nil, fmt.Errorf("file is larger than %d MB", MaxDriveUploadBytes>>20)
+	}
+	mimeType := strings.TrimSpace(upload.MimeType)
+	if mimeType == "" {
+		mimeType = mimeTypeFor(upload.Name)
+	}
+	metadata := map[string]any{"name": strings.TrimSpace(upload.Name)}
+	if folder := strings.TrimSpace(upload.FolderID); folder != "" {
+		metadata["parents"] = []string{folder}
+	}
+	if convert := strings.TrimSpace(upload.ConvertTo); convert != "" {
+		metadata["mimeType"] = convert
+	}
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{
+		"uploadType":        {"resumable"},
+		"supportsAllDrives": {"true"},
+		"fields":            {driveFileFields},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointsFrom(ctx).DriveUploadURL+"?"+params.Encode(), bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Type", mimeType)
+	req.Header.Set("X-Upload-Content-Length", fmt.Sprint(len(upload.Content)))
+	client := &http.Client{Timeout: 120 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	if res.StatusCode >= 300 {
+		return nil, driveError(res)
+	}
+	session := res.Header.Get("Location")
+	if session == "" {
+		return nil, errors.New("google drive: upload session URL missing")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPut, session, bytes.NewReader(upload.Content))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mimeType)
+	res, err = client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return nil, driveError(res)
+	}
+	var out map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateFolder creates a folder, inside parentID when set.
+func CreateFolder(ctx context.Context, accessToken string, name string, parentID string) (map[string]any, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("folder name is required")
+	}
+	metadata := map[string]any{
+		"name":     strings.TrimSpace(name),
+		"mimeType": FolderMimeType,
+	}
+	if parent := strings.TrimSpace(parentID); parent != "" {
+		metadata["parents"] = []string{parent}
+	}
+	params := url.Values{"supportsAllDrives": {"true"}, "fields": {driveFileFields}}
+	return driveDo(ctx, accessToken, http.MethodPost, endpointsFrom(ctx).DriveFilesURL+"?"+params.Encode(), metadata)
+}
+
+// MoveFile moves a file into folderID, removing it from its current folders.
+func MoveFile(ctx context.Context, accessToken string, fileID string, folderID string) (map[string]any, error) {
+	if strings.TrimSpace(folderID) == "" {
+		return nil, errors.New("folder ID is required")
+	}
+	meta, err := driveDo(ctx, accessToken, http.MethodGet, driveFileURL(ctx, fileID, url.Values{"fields": {"parents"}}), nil)
+	if err != nil {
+		return nil, err
+	}
+	var current []string
+	for _, p := range asList(meta["parents"]) {
+		if id, ok := p.(string); ok {
+			current = append(current, id)
+		}
+	}
+	params := url.Values{
+		"addParents": {strings.TrimSpace(folderID)},
+		"fields":     {driveFileFields},
+	}
+	if len(current) > 0 {
+		params.Set("removeParents", strings.Join(current, ","))
+	}
+	return driveDo(ctx, accessToken, http.MethodPatch, driveFileURL(ctx, fileID, params), map[string]any{})
+}
+
+// DrivePermission grants access to a file. Type is user, group, domain or
+// anyone; Role is reader, commenter, writer, fileOrganizer, organizer or owner.
+// EmailAddress is required for user and group, Domain for domain.
+type DrivePermission struct {
+	Type             string
+	Role             string
+	EmailAddress     string
+	Domain           string
+	SendNotification bool
+	Message          string
+}
+
+// ShareFile adds a permission to a file.
+func ShareFile(ctx context.Context, accessToken string, fileID string, perm DrivePermission) (map[string]any, error) {
+	permType := strings.ToLower(strings.TrimSpace(perm.Type))
+	role := strings.TrimSpace(perm.Role)
+	if permType == "" {
+		permType = "user"
+	}
+	if role == "" {
+		role = "reader"
+	}
+	body := map[string]any{"type": permType, "role": role}
+	switch permType {
+	case "user", "group":
+		if strings.TrimSpace(perm.EmailAddress) == "" {
+			return nil, fmt.Errorf("email address is required to share with a %s", permType)
+		}
+		body["emailAddress"] = strings.TrimSpace(perm.EmailAddress)
+	case "domain":
+		if strings.TrimSpace(perm.Domain) == "" {
+			return nil, errors.New("domain is required to share with a domain")
+		}
+		body["domain"] = strings.TrimSpace(perm.Domain)
+	case "anyone":
+	default:
+		return nil, fmt.Errorf("permission type must be user, group, domain or anyone, got %q", perm.Type)
+	}
+	params := url.Values{"supportsAllDrives": {"true"}}
+	if permType == "user" || permType == "group" {
+		params.Set("sendNotificationEmail", fmt.Sprint(perm.SendNotification))
+		if perm.SendNotification && strings.TrimSpace(perm.Message) != "" {
+			params.Set("emailMessage", perm.Message)
+		}
+	}
+	if role == "owner" {
+		params.Set("transferOwnership", "true")
+	}
+	target := endpointsFrom(ctx).DriveFilesURL + "/" + url.PathEscape(fileID) + "/permissions?" + params.Encode()
+	return driveDo(ctx, accessToken, http.MethodPost, target, body)
+}
+
+// DeleteFile moves a file to the trash, or deletes it permanently (skipping
+// the trash) when permanent is set.
+func DeleteFile(ctx context.Context, accessToken string, fileID string, permanent bool) (map[string]any, error) {
+	if permanent {
+		if _, err := driveDo(ctx, accessToken, http.MethodDelete, driveFileURL(ctx, fileID, nil), nil); err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": fileID, "deleted": true}, nil
+	}
+	return driveDo(ctx, accessToken, http.MethodPatch, driveFileURL(ctx, fileID, url.Values{"fields": {driveFileFields}}), map[string]any{"trashed": true})
+}
+
+func driveFileURL(ctx context.Context, fileID string, params url.Values) string {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("supportsAllDrives", "true")
+	return endpointsFrom(ctx).DriveFilesURL + "/" + url.PathEscape(fileID) + "?" + params.Encode()
+}
+
+func driveDo(ctx context.Context, accessToken string, method string, target string, payload any) (map[string]any, error) {
+	return apiDo(ctx, accessToken, method, target, payload, "google drive", ErrDriveScope)
+}
+
+// driveError turns an error response into an error, mapping missing scopes to ErrDriveScope.
+func driveError(res *http.Response) error {
+	return apiError(res, "google drive", ErrDriveScope)
+}
+
+// apiDo sends a JSON request to a Google REST API. Empty responses (204)
+// decode to an empty map; errors are reported by apiError.
+func apiDo(ctx context.Context, accessToken string, method string, target string, payload any, service string, scopeErr error) (map[string]any, error) {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return nil, apiError(res, service, scopeErr)
+	}
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return map[string]any{}, nil
+	}
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// apiError turns an error response into an error, returning scopeErr when
+// the token was granted without the scope the call needs.
+func apiError(res *http.Response, service string, scopeErr error) error {
+	raw, _ := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	var payload map[string]any
+	if err := json.Unmarshal(raw, &payload); err == nil {
+		if errObj, ok := payload["error"].(map[string]any); ok {
+			msg := readString(errObj, "message")
+			if res.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(msg), "insufficient authentication scopes") {
+				return scopeErr
+			}
+			if msg != "" {
+				return fmt.Errorf("%s error: %s", service, msg)
+			}
+		}
+	}
+	return fmt.Errorf("%s error: %s", service, res.Status)
+}
@@ -0,0 +1,68 @@
+package google
+
+import (
+	"context"
+	"strings"
+)
+
+// Endpoints are the Google hosts the adapter talks to. Empty fields fall back
+// to the public Google endpoints.
+type Endpoints struct {
+	AuthURL        string
+	TokenURL       string
+	UserInfoURL    string
+	GmailURL       string
+	SheetsURL      string
+	DriveFilesURL  string
+	DriveUploadURL string
+	CalendarURL    string
+}
+
+// EndpointsForBaseURL serves every Google API and the OAuth endpoints from
+// one root (a proxy or a stand-in server), keeping Google's paths below it.
+func EndpointsForBaseURL(root string) Endpoints {
+	root = strings.TrimRight(strings.TrimSpace(root), "/")
+	if root == "" {
+		return Endpoints{}
+	}
+	return Endpoints{
+		AuthURL:        root + "/o/oauth2/v2/auth",
+		TokenURL:       root + "/token",
+		UserInfoURL:    root + "/oauth2/v2/userinfo",
+		GmailURL:       root + "/gmail/v1",
+		SheetsURL:      root + "/v4/spreadsheets",
+		DriveFilesURL:  root + "/drive/v3/files",
+		DriveUploadURL: root + "/upload/drive/v3/files",
+		CalendarURL:    root + "/calendar/v3",
+	}
+}
+
+type endpointsKey struct{}
+
+// WithEndpoints returns a context under which adapter calls use e.
+func WithEndpoints(ctx context.Context, e Endpoints) context.Context {
+	return context.WithValue(ctx, endpointsKey{}, e)
+}
+
+func endpointsFrom(ctx context.Context) Endpoints {
+	e, _ := ctx.Value(endpointsKey{}).(Endpoints)
+	for _, field := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&e.AuthURL, AuthEndpoint},
+		{&e.TokenURL, TokenEndpoint},
+		{&e.UserInfoURL, UserInfoEndpoint},
+		{&e.GmailURL, GmailBaseURL},
+		{&e.SheetsURL, SheetsBaseURL},
+		{&e.DriveFilesURL, DriveFilesBaseURL},
+		{&e.DriveUploadURL, DriveUploadBaseURL},
+		{&e.CalendarURL, CalendarBaseURL},
+	} {
+		*field.value = strings.TrimRight(strings.TrimSpace(*field.value), "/")
+		if *field.value == "" {
+			*field.value = field.fallback
+		}
+	}
+	return e
+}
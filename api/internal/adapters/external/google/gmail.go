@@ -1,6 +1,7 @@
 package google
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -8,22 +9,33 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
 
-func SendEmail(ctx context.Context, accessToken string, from string, to string, subject string, bodyText string, bodyHTML string) (map[string]any, error) {
-	raw := buildRawEmail(from, to, subject, bodyText, bodyHTML)
-	payload := map[string]string{
+// SendEmail sends msg, in msg.ThreadID's conversation when set.
+func SendEmail(ctx context.Context, accessToken string, msg Email) (map[string]any, error) {
+	raw, err := buildMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]any{
 		"raw": base64.RawURLEncoding.EncodeToString([]byte(raw)),
 	}
-	body, _ := json.Marshal(payload)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, GmailBaseURL+"/users/me/messages/send", strings.NewReader(string(body)))
+	if msg.ThreadID != "" {
+		payload["threadId"] = msg.ThreadID
+	}
+	return gmailDo(ctx, accessToken, http.MethodPost, endpointsFrom(ctx).GmailURL+"/users/me/messages/send", payload, "gmail send error")
+}
+
+func GetProfile(ctx context.Context, accessToken string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointsFrom(ctx).GmailURL+"/users/me/profile", nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+accessToken)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
 	client := &http.Client{Timeout: 10 * time.Second}
 	res, err := client.Do(req)
 	if err != nil {
@@ -44,9 +56,9 @@ func SendEmail(ctx context.Context, accessToken string, from string, to string,
 					return nil, errors.New(msg)
 				}
 			}
-			return nil, fmt.Errorf("gmail send error: %s (%s)", res.Status, strings.TrimSpace(string(bodyBytes)))
+			return nil, fmt.Errorf("gmail profile error: %s (%s)", res.Status, strings.TrimSpace(string(bodyBytes)))
 		}
-		return nil, fmt.Errorf("gmail send error: %s", res.Status)
+		return nil, fmt.Errorf("gmail profile error: %s", res.Status)
 	}
 	var out map[string]any
 	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
@@ -55,14 +67,64 @@ func SendEmail(ctx context.Context, accessToken string, from string, to string,
 	return out, nil
 }
 
-func GetProfile(ctx context.Context, accessToken string) (map[string]any, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, GmailBaseURL+"/users/me/profile", nil)
+// ListMessages returns the IDs of the newest messages matching query
+// (Gmail search syntax), newest first.
+func ListMessages(ctx context.Context, accessToken string, query string, maxResults int) ([]map[string]any, error) {
+	params := url.Values{}
+	if strings.TrimSpace(query) != "" {
+		params.Set("q", query)
+	}
+	if maxResults > 0 {
+		params.Set("maxResults", fmt.Sprint(maxResults))
+	}
+	out, err := gmailGet(ctx, accessToken, endpointsFrom(ctx).GmailURL+"/users/me/messages?"+params.Encode(), "gmail list error")
+	if err != nil {
+		return nil, err
+	}
+	raw, _ := out["messages"].([]any)
+	messages := make([]map[string]any, 0, len(raw))
+	for _, item := range raw {
+		if m, ok := item.(map[string]any); ok {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// GetMessageMetadata fetches a message's headers, labels and snippet without the body.
+func GetMessageMetadata(ctx context.Context, accessToken string, messageID string) (map[string]any, error) {
+	params := url.Values{}
+	params.Set("format", "metadata")
+	for _, header := range []string{"From", "To", "Cc", "Subject", "Date"} {
+		params.Add("metadataHeaders", header)
+	}
+	target := endpointsFrom(ctx).GmailURL + "/users/me/messages/" + url.PathEscape(messageID) + "?" + params.Encode()
+	return gmailGet(ctx, accessToken, target, "gmail get error")
+}
+
+func gmailGet(ctx context.Context, accessToken string, target string, label string) (map[string]any, error) {
+	return gmailDo(ctx, accessToken, http.MethodGet, target, nil, label)
+}
+
+func gmailDo(ctx context.Context, accessToken string, method string, target string, payload any, label string) (map[string]any, error) {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Accept", "application/json")
-	client := &http.Client{Timeout: 10 * time.Second}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
 	res, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -70,21 +132,15 @@ func GetProfile(ctx context.Context, accessToken string) (map[string]any, error)
 	defer res.Body.Close()
 	if res.StatusCode >= 300 {
 		bodyBytes, _ := io.ReadAll(io.LimitReader(res.Body, 64*1024))
-		if len(bodyBytes) > 0 {
-			var payload map[string]any
-			if err := json.Unmarshal(bodyBytes, &payload); err == nil {
-				if errObj, ok := payload["error"].(map[string]any); ok {
-					if msg, ok := errObj["message"].(string); ok && strings.TrimSpace(msg) != "" {
-						return nil, errors.New(msg)
-					}
-				}
-				if msg, ok := payload["message"].(string); ok && strings.TrimSpace(msg) != "" {
+		var payload map[string]any
+		if err := json.Unmarshal(bodyBytes, &payload); err == nil {
+			if errObj, ok := payload["error"].(map[string]any); ok {
+				if msg, ok := errObj["message"].(string); ok && strings.TrimSpace(msg) != "" {
 					return nil, errors.New(msg)
 				}
 			}
-			return nil, fmt.Errorf("gmail profile error: %s (%s)", res.Status, strings.TrimSpace(string(bodyBytes)))
 		}
-		return nil, fmt.Errorf("gmail profile error: %s", res.Status)
+		return nil, fmt.Errorf("%s: %s", label, res.Status)
 	}
 	var out map[string]any
 	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
@@ -92,30 +148,3 @@ func GetProfile(ctx context.Context, accessToken string) (map[string]any, error)
 	}
 	return out, nil
 }
-
-func buildRawEmail(from string, to string, subject string, bodyText string, bodyHTML string) string {
-	var b strings.Builder
-	if from != "" {
-		b.WriteString(fmt.Sprintf("From: %s\r\n", from))
-	}
-	b.WriteString(fmt.Sprintf("To: %s\r\n", to))
-	b.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
-	b.WriteString("MIME-Version: 1.0\r\n")
-	if strings.TrimSpace(bodyHTML) == "" {
-		b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n\r\n")
-		b.WriteString(bodyText)
-		return b.String()
-	}
-	boundary := fmt.Sprintf("gmail-%d", time.Now().UnixNano())
-	b.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary))
-	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
-	b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n\r\n")
-	b.WriteString(bodyText)
-	b.WriteString("\r\n")
-	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
-	b.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n\r\n")
-	b.WriteString(bodyHTML)
-	b.WriteString("\r\n")
-	b.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
-	return b.String()
-}
@@ -0,0 +1,306 @@
+package google
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxSearchPages bounds SearchMessages with All set.
+const maxSearchPages = 20
+
+// SearchOptions control SearchMessages. Query uses Gmail search syntax
+// (e.g. "from:billing@example.com is:unread").
+type SearchOptions struct {
+	Query            string
+	LabelIDs         []string
+	MaxResults       int
+	PageToken        string
+	IncludeSpamTrash bool
+	All              bool
+}
+
+// SearchMessages returns message and thread IDs matching the query, newest first.
+func SearchMessages(ctx context.Context, accessToken string, opts SearchOptions) (map[string]any, error) {
+	messages := []any{}
+	pageToken := opts.PageToken
+	for page := 0; page < maxSearchPages; page++ {
+		params := url.Values{}
+		if strings.TrimSpace(opts.Query) != "" {
+			params.Set("q", opts.Query)
+		}
+		for _, id := range opts.LabelIDs {
+			params.Add("labelIds", id)
+		}
+		if opts.MaxResults > 0 {
+			params.Set("maxResults", fmt.Sprint(opts.MaxResults))
+		}
+		if opts.IncludeSpamTrash {
+			params.Set("includeSpamTrash", "true")
+		}
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+		out, err := gmailGet(ctx, accessToken, endpointsFrom(ctx).GmailURL+"/users/me/messages?"+params.Encode(), "gmail search error")
+		if err != nil {
+			return nil, err
+		}
+		if items, ok := out["messages"].([]any); ok {
+			messages = append(messages, items...)
+		}
+		pageToken, _ = out["nextPageToken"].(string)
+		if !opts.All || pageToken == "" {
+			break
+		}
+	}
+	return map[string]any{
+		"messages":      messages,
+		"count":         len(messages),
+		"nextPageToken": pageToken,
+	}, nil
+}
+
+// GetMessage fetches a full message and returns it parsed (see ParseMessage).
+func GetMessage(ctx context.Context, accessToken string, messageID string) (map[string]any, error) {
+	target := endpointsFrom(ctx).GmailURL + "/users/me/messages/" + url.PathEscape(messageID) + "?format=full"
+	raw, err := gmailGet(ctx, accessToken, target, "gmail get error")
+	if err != nil {
+		return nil, err
+	}
+	return ParseMessage(raw), nil
+}
+
+// ParseMessage flattens a format=full Gmail message: all headers by name,
+// the common ones (from, to, cc, subject, date, messageId, ...) as fields,
+// the first text/plain and text/html bodies decoded, and attachment metadata.
+// Attachment content is not downloaded.
+func ParseMessage(msg map[string]any) map[string]any {
+	out := map[string]any{
+		"id":           msg["id"],
+		"threadId":     msg["threadId"],
+		"labelIds":     msg["labelIds"],
+		"snippet":      msg["snippet"],
+		"internalDate": msg["internalDate"],
+		"sizeEstimate": msg["sizeEstimate"],
+	}
+	payload, _ := msg["payload"].(map[string]any)
+
+	headers := map[string]any{}
+	for _, h := range asList(payload["headers"]) {
+		header, _ := h.(map[string]any)
+		name := readString(header, "name")
+		if name == "" {
+			continue
+		}
+		headers[name] = readString(header, "value")
+	}
+	out["headers"] = headers
+	for field, name := range map[string]string{
+		"from": "From", "to": "To", "cc": "Cc", "bcc": "Bcc", "replyTo": "Reply-To", "subject": "Subject",
+		"date": "Date", "messageId": "Message-ID", "inReplyTo": "In-Reply-To", "references": "References",
+	} {
+		out[field] = headerLookup(headers, name)
+	}
+
+	var text, html string
+	attachments := []any{}
+	walkParts(payload, func(part map[string]any) {
+		mimeType := strings.ToLower(readString(part, "mimeType"))
+		body, _ := part["body"].(map[string]any)
+		filename := readString(part, "filename")
+		attachmentID := readString(body, "attachmentId")
+		if filename != "" || attachmentID != "" {
+			attachments = append(attachments, map[string]any{
+				"filename":     filename,
+				"mimeType":     readString(part, "mimeType"),
+				"size":         body["size"],
+				"attachmentId": attachmentID,
+				"partId":       readString(part, "partId"),
+			})
+			return
+		}
+		switch mimeType {
+		case "text/plain":
+			if text == "" {
+				text = decodeBody(readString(body, "data"))
+			}
+		case "text/html":
+			if html == "" {
+				html = decodeBody(readString(body, "data"))
+			}
+		}
+	})
+	out["textBody"] = text
+	out["htmlBody"] = html
+	out["attachments"] = attachments
+	return out
+}
+
+// ModifyLabels adds and removes labels on a message. Labels may be given by
+// ID (INBOX, UNREAD, Label_123) or by name; names are resolved with ListLabels.
+// scopes: gmail.modify
+func ModifyLabels(ctx context.Context, accessToken string, messageID string, add []string, remove []string) (map[string]any, error) {
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, fmt.Errorf("no labels to add or remove")
+	}
+	var labels []any
+	if needsLabelLookup(add) || needsLabelLookup(remove) {
+		list, err := ListLabels(ctx, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		labels = asList(list["labels"])
+	}
+	addIDs, err := resolveLabelIDs(labels, add)
+	if err != nil {
+		return nil, err
+	}
+	removeIDs, err := resolveLabelIDs(labels, remove)
+	if err != nil {
+		return nil, err
+	}
+	target := endpointsFrom(ctx).GmailURL + "/users/me/messages/" + url.PathEscape(messageID) + "/modify"
+	return gmailDo(ctx, accessToken, http.MethodPost, target, map[string]any{
+		"addLabelIds":    addIDs,
+		"removeLabelIds": removeIDs,
+	}, "gmail modify error")
+}
+
+// ListLabels returns the mailbox's system and user labels.
+func ListLabels(ctx context.Context, accessToken string) (map[string]any, error) {
+	return gmailGet(ctx, accessToken, endpointsFrom(ctx).GmailURL+"/users/me/labels", "gmail labels error")
+}
+
+// CreateDraft saves msg as a draft, in msg.ThreadID's conversation when set.
+// scopes: gmail.compose
+func CreateDraft(ctx context.Context, accessToken string, msg Email) (map[string]any, error) {
+	raw, err := buildMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	message := map[string]any{
+		"raw": base64.RawURLEncoding.EncodeToString([]byte(raw)),
+	}
+	if msg.ThreadID != "" {
+		message["threadId"] = msg.ThreadID
+	}
+	return gmailDo(ctx, accessToken, http.MethodPost, endpointsFrom(ctx).GmailURL+"/users/me/drafts", map[string]any{"message": message}, "gmail draft error")
+}
+
+// ReplyTo fills the threading fields of reply from original (a parsed
+// message): threadId, In-Reply-To and References, a "Re:" subject when none
+// is set, and the recipient (Reply-To or From) when To is empty.
+func ReplyTo(original map[string]any, reply Email) Email {
+	reply.ThreadID = readString(original, "threadId")
+	messageID := readString(original, "messageId")
+	reply.InReplyTo = messageID
+	references := strings.TrimSpace(readString(original, "references"))
+	if messageID != "" {
+		references = strings.TrimSpace(references + " " + messageID)
+	}
+	reply.References = references
+	if strings.TrimSpace(reply.Subject) == "" {
+		subject := readString(original, "subject")
+		if !strings.HasPrefix(strings.ToLower(subject), "re:") {
+			subject = "Re: " + subject
+		}
+		reply.Subject = subject
+	}
+	if strings.TrimSpace(reply.To) == "" {
+		reply.To = readString(original, "replyTo")
+		if reply.To == "" {
+			reply.To = readString(original, "from")
+		}
+	}
+	return reply
+}
+
+func walkParts(part map[string]any, visit func(map[string]any)) {
+	if part == nil {
+		return
+	}
+	children := asList(part["parts"])
+	if len(children) == 0 {
+		visit(part)
+		return
+	}
+	for _, child := range children {
+		if m, ok := child.(map[string]any); ok {
+			walkParts(m, visit)
+		}
+	}
+}
+
+func decodeBody(data string) string {
+	if data == "" {
+		return ""
+	}
+	decoded, err := base64.URLEncoding.DecodeString(data)
+	if err != nil {
+		decoded, err = base64.RawURLEncoding.DecodeString(data)
+		if err != nil {
+			return ""
+		}
+	}
+	return string(decoded)
+}
+
+func headerLookup(headers map[string]any, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			s, _ := v.(string)
+			return s
+		}
+	}
+	return ""
+}
+
+var systemLabels = map[string]bool{
+	"INBOX": true, "UNREAD": true, "STARRED": true, "IMPORTANT": true, "SPAM": true,
+	"TRASH": true, "SENT": true, "DRAFT": true, "CHAT": true,
+}
+
+// isLabelID reports whether label is a system label ID or a user label ID (Label_...).
+func isLabelID(label string) bool {
+	return systemLabels[label] || strings.HasPrefix(label, "CATEGORY_") || strings.HasPrefix(label, "Label_")
+}
+
+func needsLabelLookup(labels []string) bool {
+	for _, l := range labels {
+		if !isLabelID(l) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveLabelIDs(labels []any, wanted []string) ([]string, error) {
+	ids := make([]string, 0, len(wanted))
+	for _, w := range wanted {
+		if isLabelID(w) {
+			ids = append(ids, w)
+			continue
+		}
+		found := ""
+		for _, raw := range labels {
+			label, _ := raw.(map[string]any)
+			if strings.EqualFold(readString(label, "name"), w) || readString(label, "id") == w {
+				found = readString(label, "id")
+				break
+			}
+		}
+		if found == "" {
+			return nil, fmt.Errorf("gmail label %q not found", w)
+		}
+		ids = append(ids, found)
+	}
+	return ids, nil
+}
+
+func asList(v any) []any {
+	list, _ := v.([]any)
+	return list
+}
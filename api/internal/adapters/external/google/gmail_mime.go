@@ -0,0 +1,151 @@
+package google
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// MaxAttachmentBytes caps the total size of attachments on one message (Gmail's limit is 25MB).
+const MaxAttachmentBytes = 25 << 20
+
+// Email is an outgoing message. ThreadID, InReplyTo and References keep a
+// reply in its conversation.
+type Email struct {
+	From        string
+	To          string
+	Cc          string
+	Bcc         string
+	ReplyTo     string
+	Subject     string
+	BodyText    string
+	BodyHTML    string
+	Attachments []Attachment
+	ThreadID    string
+	InReplyTo   string
+	References  string
+}
+
+// Attachment is a file attached to an Email. MimeType defaults to the type
+// implied by the file extension.
+type Attachment struct {
+	Filename string
+	MimeType string
+	Content  []byte
+}
+
+// buildMessage renders msg as an RFC 2822 message.
+func buildMessage(msg Email) (string, error) {
+	if strings.TrimSpace(msg.To) == "" && strings.TrimSpace(msg.Cc) == "" && strings.TrimSpace(msg.Bcc) == "" {
+		return "", errors.New("at least one recipient is required")
+	}
+	total := 0
+	for _, a := range msg.Attachments {
+		if strings.TrimSpace(a.Filename) == "" {
+			return "", errors.New("attachment filename is required")
+		}
+		total += len(a.Content)
+	}
+	if total > MaxAttachmentBytes {
+		return "", fmt.Errorf("attachments exceed %d MB", MaxAttachmentBytes>>20)
+	}
+
+	var b strings.Builder
+	writeHeader(&b, "From", msg.From)
+	writeHeader(&b, "To", msg.To)
+	writeHeader(&b, "Cc", msg.Cc)
+	writeHeader(&b, "Bcc", msg.Bcc)
+	writeHeader(&b, "Reply-To", msg.ReplyTo)
+	writeHeader(&b, "In-Reply-To", msg.InReplyTo)
+	writeHeader(&b, "References", msg.References)
+	b.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", headerValue(msg.Subject))))
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if len(msg.Attachments) == 0 {
+		writeBody(&b, msg.BodyText, msg.BodyHTML)
+		return b.String(), nil
+	}
+
+	boundary := fmt.Sprintf("mixed-%d", time.Now().UnixNano())
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\n\r\n", boundary))
+	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	writeBody(&b, msg.BodyText, msg.BodyHTML)
+	b.WriteString("\r\n")
+	for _, a := range msg.Attachments {
+		mimeType := strings.TrimSpace(a.MimeType)
+		if mimeType == "" {
+			mimeType = mimeTypeFor(a.Filename)
+		}
+		filename := mime.QEncoding.Encode("UTF-8", headerValue(a.Filename))
+		b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+		b.WriteString(fmt.Sprintf("Content-Type: %s; name=%q\r\n", headerValue(mimeType), filename))
+		b.WriteString(fmt.Sprintf("Content-Disposition: attachment; filename=%q\r\n", filename))
+		b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+		writeBase64Lines(&b, a.Content)
+	}
+	b.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
+	return b.String(), nil
+}
+
+// writeBody writes the Content-Type header and body: plain text, or
+// multipart/alternative when there is an HTML body.
+func writeBody(b *strings.Builder, bodyText string, bodyHTML string) {
+	if strings.TrimSpace(bodyHTML) == "" {
+		b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n\r\n")
+		b.WriteString(bodyText)
+		return
+	}
+	boundary := fmt.Sprintf("alt-%d", time.Now().UnixNano())
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary))
+	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n\r\n")
+	b.WriteString(bodyText)
+	b.WriteString("\r\n")
+	b.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	b.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n\r\n")
+	b.WriteString(bodyHTML)
+	b.WriteString("\r\n")
+	b.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
+}
+
+func writeHeader(b *strings.Builder, name string, value string) {
+	value = headerValue(value)
+	if value == "" {
+		return
+	}
+	b.WriteString(fmt.Sprintf("%s: %s\r\n", name, value))
+}
+
+// headerValue drops line breaks so user input cannot add headers.
+func headerValue(v string) string {
+	return strings.TrimSpace(strings.NewReplacer("\r", " ", "\n", " ").Replace(v))
+}
+
+func writeBase64Lines(b *strings.Builder, content []byte) {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	if encoded != "" {
+		b.WriteString(encoded)
+		b.WriteString("\r\n")
+	}
+}
+
+func mimeTypeFor(filename string) string {
+	if i := strings.LastIndex(filename, "."); i >= 0 {
+		if t := mime.TypeByExtension(filename[i:]); t != "" {
+			return t
+		}
+	}
+	return "application/octet-stream"
+}
+
+func BuildMessageForTest(msg Email) (string, error) {
+	return buildMessage(msg)
+}
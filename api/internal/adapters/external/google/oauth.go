@@ -11,12 +11,6 @@ import (
 	"time"
 )
 
-const (
-	authEndpoint  = AuthEndpoint
-	tokenEndpoint = TokenEndpoint
-	userInfoURL   = UserInfoEndpoint
-)
-
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
@@ -34,7 +28,8 @@ type UserProfile struct {
 	Picture    string `json:"picture"`
 }
 
-func BuildAuthURL(clientID string, redirectURL string, state string, scopes []string, promptConsent bool) string {
+// BuildAuthURL returns the consent URL on the auth host configured in ctx (see WithEndpoints).
+func BuildAuthURL(ctx context.Context, clientID string, redirectURL string, state string, scopes []string, promptConsent bool) string {
 	q := url.Values{}
 	q.Set("client_id", clientID)
 	q.Set("redirect_uri", redirectURL)
@@ -50,7 +45,7 @@ func BuildAuthURL(clientID string, redirectURL string, state string, scopes []st
 	if len(scopes) > 0 {
 		q.Set("scope", strings.Join(scopes, " "))
 	}
-	return authEndpoint + "?" + q.Encode()
+	return endpointsFrom(ctx).AuthURL + "?" + q.Encode()
 }
 
 func ExchangeCode(ctx context.Context, clientID string, clientSecret string, redirectURL string, code string) (TokenResponse, error) {
@@ -69,7 +64,7 @@ func RefreshAccessToken(ctx context.Context, clientID string, clientSecret strin
 }
 
 func FetchUserProfile(ctx context.Context, accessToken string) (UserProfile, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, userInfoURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointsFrom(ctx).UserInfoURL, nil)
 	if err != nil {
 		return UserProfile{}, err
 	}
@@ -96,7 +91,7 @@ func FetchUserProfile(ctx context.Context, accessToken string) (UserProfile, err
 func exchangeToken(ctx context.Context, clientID string, clientSecret string, values url.Values) (TokenResponse, error) {
 	values.Set("client_id", clientID)
 	values.Set("client_secret", clientSecret)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(values.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointsFrom(ctx).TokenURL, strings.NewReader(values.Encode()))
 	if err != nil {
 		return TokenResponse{}, err
 	}
@@ -27,11 +27,11 @@ func CreateSpreadsheet(ctx context.Context, accessToken string, title string, sh
 			},
 		}
 	}
-	return doJSON(ctx, http.MethodPost, SheetsBaseURL, accessToken, payload)
+	return doJSON(ctx, http.MethodPost, endpointsFrom(ctx).SheetsURL, accessToken, payload)
 }
 
 func DeleteSpreadsheet(ctx context.Context, accessToken string, spreadsheetID string) (map[string]any, error) {
-	url := fmt.Sprintf("%s/%s", DriveFilesBaseURL, url.PathEscape(spreadsheetID))
+	url := fmt.Sprintf("%s/%s", endpointsFrom(ctx).DriveFilesURL, url.PathEscape(spreadsheetID))
 	return doJSON(ctx, http.MethodDelete, url, accessToken, nil)
 }
 
@@ -40,7 +40,7 @@ func AppendRow(ctx context.Context, accessToken string, spreadsheetID string, sh
 	if rangeRef == "" {
 		rangeRef = "Sheet1"
 	}
-	path := fmt.Sprintf("%s/%s/values/%s:append", SheetsBaseURL, url.PathEscape(spreadsheetID), url.PathEscape(rangeRef))
+	path := fmt.Sprintf("%s/%s/values/%s:append", endpointsFrom(ctx).SheetsURL, url.PathEscape(spreadsheetID), url.PathEscape(rangeRef))
 	query := url.Values{}
 	query.Set("valueInputOption", "USER_ENTERED")
 	fullURL := path + "?" + query.Encode()
@@ -54,7 +54,7 @@ func UpdateRow(ctx context.Context, accessToken string, spreadsheetID string, ra
 	if strings.TrimSpace(rangeRef) == "" {
 		return nil, errors.New("range is required")
 	}
-	path := fmt.Sprintf("%s/%s/values/%s", SheetsBaseURL, url.PathEscape(spreadsheetID), url.PathEscape(rangeRef))
+	path := fmt.Sprintf("%s/%s/values/%s", endpointsFrom(ctx).SheetsURL, url.PathEscape(spreadsheetID), url.PathEscape(rangeRef))
 	query := url.Values{}
 	query.Set("valueInputOption", "USER_ENTERED")
 	fullURL := path + "?" + query.Encode()
@@ -68,7 +68,7 @@ func GetRows(ctx context.Context, accessToken string, spreadsheetID string, rang
 	if strings.TrimSpace(rangeRef) == "" {
 		return nil, errors.New("range is required")
 	}
-	path := fmt.Sprintf("%s/%s/values/%s", SheetsBaseURL, url.PathEscape(spreadsheetID), url.PathEscape(rangeRef))
+	path := fmt.Sprintf("%s/%s/values/%s", endpointsFrom(ctx).SheetsURL, url.PathEscape(spreadsheetID), url.PathEscape(rangeRef))
 	return doJSON(ctx, http.MethodGet, path, accessToken, nil)
 }
 
@@ -76,7 +76,7 @@ func ClearRange(ctx context.Context, accessToken string, spreadsheetID string, r
 	if strings.TrimSpace(rangeRef) == "" {
 		return nil, errors.New("range is required")
 	}
-	path := fmt.Sprintf("%s/%s/values/%s:clear", SheetsBaseURL, url.PathEscape(spreadsheetID), url.PathEscape(rangeRef))
+	path := fmt.Sprintf("%s/%s/values/%s:clear", endpointsFrom(ctx).SheetsURL, url.PathEscape(spreadsheetID), url.PathEscape(rangeRef))
 	return doJSON(ctx, http.MethodPost, path, accessToken, map[string]any{})
 }
 
@@ -95,7 +95,7 @@ func CreateSheet(ctx context.Context, accessToken string, spreadsheetID string,
 			},
 		},
 	}
-	path := fmt.Sprintf("%s/%s:batchUpdate", SheetsBaseURL, url.PathEscape(spreadsheetID))
+	path := fmt.Sprintf("%s/%s:batchUpdate", endpointsFrom(ctx).SheetsURL, url.PathEscape(spreadsheetID))
 	return doJSON(ctx, http.MethodPost, path, accessToken, payload)
 }
 
@@ -109,7 +109,7 @@ func DeleteSheet(ctx context.Context, accessToken string, spreadsheetID string,
 			},
 		},
 	}
-	path := fmt.Sprintf("%s/%s:batchUpdate", SheetsBaseURL, url.PathEscape(spreadsheetID))
+	path := fmt.Sprintf("%s/%s:batchUpdate", endpointsFrom(ctx).SheetsURL, url.PathEscape(spreadsheetID))
 	return doJSON(ctx, http.MethodPost, path, accessToken, payload)
 }
 
@@ -128,7 +128,7 @@ func DeleteDimensions(ctx context.Context, accessToken string, spreadsheetID str
 			},
 		},
 	}
-	path := fmt.Sprintf("%s/%s:batchUpdate", SheetsBaseURL, url.PathEscape(spreadsheetID))
+	path := fmt.Sprintf("%s/%s:batchUpdate", endpointsFrom(ctx).SheetsURL, url.PathEscape(spreadsheetID))
 	return doJSON(ctx, http.MethodPost, path, accessToken, payload)
 }
 
@@ -136,7 +136,7 @@ func ResolveSheetID(ctx context.Context, accessToken string, spreadsheetID strin
 	if strings.TrimSpace(sheetName) == "" {
 		return 0, errors.New("sheet name is required")
 	}
-	path := fmt.Sprintf("%s/%s?fields=sheets(properties(sheetId,title))", SheetsBaseURL, url.PathEscape(spreadsheetID))
+	path := fmt.Sprintf("%s/%s?fields=sheets(properties(sheetId,title))", endpointsFrom(ctx).SheetsURL, url.PathEscape(spreadsheetID))
 	out, err := doJSON(ctx, http.MethodGet, path, accessToken, nil)
 	if err != nil {
 		return 0, err
@@ -0,0 +1,247 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxChildrenPerRequest is Notion's limit on blocks per create/append call.
+	maxChildrenPerRequest = 100
+	// maxRichTextLength is Notion's limit on the content of one text object.
+	maxRichTextLength = 2000
+)
+
+// AppendBlocks appends children to a page or block, in batches of 100.
+func AppendBlocks(ctx context.Context, token string, blockID string, children []any) (map[string]any, error) {
+	target := fmt.Sprintf("%s/blocks/%s/children", apiURL(ctx), url.PathEscape(blockID))
+	results := []any{}
+	for start := 0; start < len(children); start += maxChildrenPerRequest {
+		end := start + maxChildrenPerRequest
+		if end > len(children) {
+			end = len(children)
+		}
+		resp, err := makeRequest(ctx, token, "PATCH", target, map[string]any{"children": children[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		if items, ok := resp["results"].([]any); ok {
+			results = append(results, items...)
+		}
+	}
+	return map[string]any{"results": results, "count": len(results)}, nil
+}
+
+var (
+	numberedItemRe = regexp.MustCompile(`^\d+[.)]\s+`)
+	todoItemRe     = regexp.MustCompile(`^[-*+]\s+\[([ xX])\]\s+`)
+	bulletItemRe   = regexp.MustCompile(`^[-*+]\s+`)
+	dividerRe      = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+)
+
+// MarkdownToBlocks converts a subset of Markdown to Notion blocks: headings,
+// paragraphs, bulleted, numbered and to-do lists, quotes, fenced code and
+// dividers, with bold, italic, strikethrough, inline code and links inside
+// text. Nested lists are flattened.
+func MarkdownToBlocks(md string) []any {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	blocks := []any{}
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, textBlock("paragraph", strings.Join(paragraph, "\n")))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		if strings.HasPrefix(line, "```") {
+			flush()
+			lang := strings.TrimSpace(strings.TrimPrefix(line, "```"))
+			var code []string
+			for i+1 < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i+1]), "```") {
+				i++
+				code = append(code, lines[i])
+			}
+			i++ // closing fence
+			blocks = append(blocks, map[string]any{
+				"object": "block",
+				"type":   "code",
+				"code": map[string]any{
+					"rich_text": richText(strings.Join(code, "\n")),
+					"language":  codeLanguage(lang),
+				},
+			})
+			continue
+		}
+
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "### ") || strings.HasPrefix(line, "#### "):
+			flush()
+			blocks = append(blocks, textBlock("heading_3", strings.TrimLeft(line, "# ")))
+		case strings.HasPrefix(line, "## "):
+			flush()
+			blocks = append(blocks, textBlock("heading_2", line[3:]))
+		case strings.HasPrefix(line, "# "):
+			flush()
+			blocks = append(blocks, textBlock("heading_1", line[2:]))
+		case dividerRe.MatchString(line):
+			flush()
+			blocks = append(blocks, map[string]any{"object": "block", "type": "divider", "divider": map[string]any{}})
+		case strings.HasPrefix(line, ">"):
+			flush()
+			blocks = append(blocks, textBlock("quote", strings.TrimSpace(strings.TrimPrefix(line, ">"))))
+		case todoItemRe.MatchString(line):
+			flush()
+			m := todoItemRe.FindStringSubmatch(line)
+			block := textBlock("to_do", line[len(m[0]):])
+			block["to_do"].(map[string]any)["checked"] = m[1] != " "
+			blocks = append(blocks, block)
+		case bulletItemRe.MatchString(line):
+			flush()
+			blocks = append(blocks, textBlock("bulleted_list_item", bulletItemRe.ReplaceAllString(line, "")))
+		case numberedItemRe.MatchString(line):
+			flush()
+			blocks = append(blocks, textBlock("numbered_list_item", numberedItemRe.ReplaceAllString(line, "")))
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+func textBlock(blockType string, text string) map[string]any {
+	return map[string]any{
+		"object":  "block",
+		"type":    blockType,
+		blockType: map[string]any{"rich_text": inlineRichText(text)},
+	}
+}
+
+// richText returns plain text as rich text objects, split at Notion's length limit.
+func richText(text string) []any {
+	return textObjects(text, nil, "")
+}
+
+// inlineRichText parses inline Markdown (**bold**, *italic*, ~~strike~~,
+// `code`, [label](url)) into annotated rich text objects.
+func inlineRichText(text string) []any {
+	out := []any{}
+	var plain strings.Builder
+	emit := func(content string, annotations map[string]any, link string) {
+		if plain.Len() > 0 {
+			out = append(out, textObjects(plain.String(), nil, "")...)
+			plain.Reset()
+		}
+		out = append(out, textObjects(content, annotations, link)...)
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		if content, n, ok := delimited(rest, "`"); ok {
+			emit(content, map[string]any{"code": true}, "")
+			i += n
+			continue
+		}
+		if content, n, ok := delimited(rest, "**"); ok {
+			emit(content, map[string]any{"bold": true}, "")
+			i += n
+			continue
+		}
+		if content, n, ok := delimited(rest, "~~"); ok {
+			emit(content, map[string]any{"strikethrough": true}, "")
+			i += n
+			continue
+		}
+		if content, n, ok := delimited(rest, "*"); ok {
+			emit(content, map[string]any{"italic": true}, "")
+			i += n
+			continue
+		}
+		if strings.HasPrefix(rest, "[") {
+			if mid := strings.Index(rest, "]("); mid > 1 {
+				if end := strings.Index(rest[mid:], ")"); end > 2 {
+					emit(rest[1:mid], nil, rest[mid+2:mid+end])
+					i += mid + end + 1
+					continue
+				}
+			}
+		}
+		plain.WriteByte(text[i])
+		i++
+	}
+	if plain.Len() > 0 {
+		out = append(out, textObjects(plain.String(), nil, "")...)
+	}
+	return out
+}
+
+// delimited matches s starting with delim, non-empty content and a closing delim.
+func delimited(s string, delim string) (string, int, bool) {
+	if !strings.HasPrefix(s, delim) {
+		return "", 0, false
+	}
+	end := strings.Index(s[len(delim):], delim)
+	if end <= 0 {
+		return "", 0, false
+	}
+	content := s[len(delim) : len(delim)+end]
+	if strings.TrimSpace(content) != content {
+		return "", 0, false
+	}
+	return content, len(delim)*2 + end, true
+}
+
+func textObjects(content string, annotations map[string]any, link string) []any {
+	out := []any{}
+	runes := []rune(content)
+	for start := 0; start < len(runes); start += maxRichTextLength {
+		end := start + maxRichTextLength
+		if end > len(runes) {
+			end = len(runes)
+		}
+		text := map[string]any{"content": string(runes[start:end])}
+		if link != "" {
+			text["link"] = map[string]any{"url": link}
+		}
+		obj := map[string]any{"type": "text", "text": text}
+		if annotations != nil {
+			obj["annotations"] = annotations
+		}
+		out = append(out, obj)
+	}
+	return out
+}
+
+var codeLanguages = map[string]string{
+	"js": "javascript", "ts": "typescript", "py": "python", "sh": "shell", "bash": "bash",
+	"yml": "yaml", "md": "markdown", "golang": "go", "c++": "c++", "cpp": "c++", "cs": "c#",
+}
+
+var knownCodeLanguages = map[string]bool{
+	"bash": true, "c": true, "c++": true, "c#": true, "css": true, "diff": true, "docker": true,
+	"go": true, "graphql": true, "html": true, "java": true, "javascript": true, "json": true,
+	"kotlin": true, "markdown": true, "php": true, "python": true, "ruby": true, "rust": true,
+	"scala": true, "shell": true, "sql": true, "swift": true, "typescript": true, "xml": true, "yaml": true,
+}
+
+// codeLanguage maps a fence info string to a language Notion accepts.
+func codeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if mapped, ok := codeLanguages[lang]; ok {
+		return mapped
+	}
+	if knownCodeLanguages[lang] {
+		return lang
+	}
+	return "plain text"
+}
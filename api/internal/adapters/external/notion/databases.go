@@ -0,0 +1,100 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	defaultPageSize = 100
+	// maxPages bounds list calls with All set.
+	maxPages = 50
+)
+
+// GetDatabase returns a database object; its "properties" field is the schema
+// (property name -> {id, type, ...}).
+func GetDatabase(ctx context.Context, token string, databaseID string) (map[string]any, error) {
+	return makeRequest(ctx, token, "GET", fmt.Sprintf("%s/databases/%s", apiURL(ctx), url.PathEscape(databaseID)), nil)
+}
+
+// DatabaseSchema returns the properties of a database object.
+func DatabaseSchema(database map[string]any) map[string]any {
+	props, _ := database["properties"].(map[string]any)
+	if props == nil {
+		return map[string]any{}
+	}
+	return props
+}
+
+// TitleProperty returns the name of the title property in a schema, or "".
+func TitleProperty(schema map[string]any) string {
+	for name, raw := range schema {
+		if prop, ok := raw.(map[string]any); ok && prop["type"] == "title" {
+			return name
+		}
+	}
+	return ""
+}
+
+// QueryOptions control a database query. Filter and Sorts use Notion's JSON
+// format and are passed through unchanged.
+type QueryOptions struct {
+	Filter      any
+	Sorts       []any
+	PageSize    int
+	StartCursor string
+	All         bool
+}
+
+// QueryDatabase returns the pages of a database matching the filter.
+func QueryDatabase(ctx context.Context, token string, databaseID string, opts QueryOptions) (map[string]any, error) {
+	target := fmt.Sprintf("%s/databases/%s/query", apiURL(ctx), url.PathEscape(databaseID))
+	return listResult(opts.All, func(cursor string) (map[string]any, error) {
+		body := map[string]any{"page_size": pageSize(opts.PageSize)}
+		if opts.Filter != nil {
+			body["filter"] = opts.Filter
+		}
+		if len(opts.Sorts) > 0 {
+			body["sorts"] = opts.Sorts
+		}
+		if cursor != "" {
+			body["start_cursor"] = cursor
+		}
+		return makeRequest(ctx, token, "POST", target, body)
+	}, opts.StartCursor)
+}
+
+// SearchOptions control a search. Filter is "page", "database" or "" for both.
+type SearchOptions struct {
+	Query       string
+	Filter      string
+	PageSize    int
+	StartCursor string
+	All         bool
+}
+
+// Search finds pages and databases shared with the integration by title.
+func Search(ctx context.Context, token string, opts SearchOptions) (map[string]any, error) {
+	target := fmt.Sprintf("%s/search", apiURL(ctx))
+	return listResult(opts.All, func(cursor string) (map[string]any, error) {
+		body := map[string]any{"page_size": pageSize(opts.PageSize)}
+		if opts.Query != "" {
+			body["query"] = opts.Query
+		}
+		if opts.Filter != "" {
+			body["filter"] = map[string]any{"property": "object", "value": opts.Filter}
+		}
+		if cursor != "" {
+			body["start_cursor"] = cursor
+		}
+		return makeRequest(ctx, token, "POST", target, body)
+	}, opts.StartCursor)
+}
+
+func pageSize(n int) int {
+	if n <= 0 || n > defaultPageSize {
+		return defaultPageSize
+	}
+	return n
+}
@@ -6,65 +6,80 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
 )
 
-const baseURL = "https://api.notion.com/v1"
+const defaultBaseURL = "https://api.notion.com/v1"
 const notionVersion = "2022-06-28"
 
-// CreatePage creates a new page in the specified database or as a child of an existing page.
+// apiURL is the API root for calls under ctx, set by endpoints.WithAPIURL.
+func apiURL(ctx context.Context) string {
+	return endpoints.APIURL(ctx, "notion", defaultBaseURL)
+}
+
+// defaultRetryAfter is used when Notion rate limits a call without a usable Retry-After header.
+const defaultRetryAfter = 30 * time.Second
+
+// RateLimitError is returned for 429 responses. RetryAfter is taken from the Retry-After header.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("notion api error (429): rate limited, retry after %s", e.RetryAfter)
+}
+
+// RetryDelay reports how long the caller should wait before calling again.
+func (e *RateLimitError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// CreatePage creates a new page in the specified database. titleProperty is
+// the name of the database's title property ("Name" when empty); properties
+// must already be in Notion's format (see BuildProperties).
 // scopes: N/A (Internal Integration Token)
-func CreatePage(ctx context.Context, token string, parentID string, title string, content string) (map[string]any, error) {
-	url := fmt.Sprintf("%s/pages", baseURL)
-
-	// Determine if parent is database or page based on ID format isn't strictly possible without API,
-	// but generally user provides ID. We'll support Database Parent for now as it's most common for CMS.
-	// Actually, Notion API requires specifying "database_id" or "page_id" in parent object.
-	// For simplicity, we'll try to guess or just default to database_id for MVP, or allow user to pass type?
-	// Let's assume Database ID for now.
-
-	parent := map[string]string{"database_id": parentID}
-
-	properties := map[string]any{
-		"Name": map[string]any{
-			"title": []map[string]any{
-				{
-					"text": map[string]string{
-						"content": title,
-					},
-				},
-			},
-		},
-	}
+func CreatePage(ctx context.Context, token string, databaseID string, titleProperty string, title string, properties map[string]any, children []any) (map[string]any, error) {
+	url := fmt.Sprintf("%s/pages", apiURL(ctx))
 
-	// Add content blocks if provided
-	var children []map[string]any
-	if content != "" {
-		children = append(children, map[string]any{
-			"object": "block",
-			"type":   "paragraph",
-			"paragraph": map[string]any{
-				"rich_text": []map[string]any{
-					{
-						"type": "text",
-						"text": map[string]string{
-							"content": content,
-						},
-					},
-				},
-			},
-		})
+	if titleProperty == "" {
+		titleProperty = "Name"
+	}
+	props := map[string]any{}
+	for name, value := range properties {
+		props[name] = value
+	}
+	if title != "" {
+		props[titleProperty] = map[string]any{"title": richText(title)}
 	}
 
 	body := map[string]any{
-		"parent":     parent,
-		"properties": properties,
+		"parent":     map[string]string{"database_id": databaseID},
+		"properties": props,
 	}
 	if len(children) > 0 {
-		body["children"] = children
+		// Notion accepts at most 100 children on create; the rest are appended.
+		first := children
+		if len(first) > maxChildrenPerRequest {
+			first = children[:maxChildrenPerRequest]
+		}
+		body["children"] = first
 	}
 
-	return makeRequest(ctx, token, "POST", url, body)
+	page, err := makeRequest(ctx, token, "POST", url, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(children) > maxChildrenPerRequest {
+		pageID, _ := page["id"].(string)
+		if _, err := AppendBlocks(ctx, token, pageID, children[maxChildrenPerRequest:]); err != nil {
+			return nil, fmt.Errorf("page %s created but appending content failed: %w", pageID, err)
+		}
+	}
+	return page, nil
 }
 
 func makeRequest(ctx context.Context, token string, method string, url string, body interface{}) (map[string]any, error) {
@@ -88,13 +103,20 @@ func makeRequest(ctx context.Context, token string, method string, url string, b
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Notion-Version", notionVersion)
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := defaultRetryAfter
+		if secs, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); err == nil && secs > 0 {
+			retryAfter = time.Duration(secs) * time.Second
+		}
+		return nil, &RateLimitError{RetryAfter: retryAfter}
+	}
 	if resp.StatusCode >= 400 {
 		var result map[string]any
 		_ = json.NewDecoder(resp.Body).Decode(&result)
@@ -112,3 +134,33 @@ func makeRequest(ctx context.Context, token string, method string, url string, b
 
 	return result, nil
 }
+
+// listResult collects the results of a paginated list endpoint. With all set,
+// next_cursor is followed for at most maxPages pages.
+func listResult(all bool, fetch func(cursor string) (map[string]any, error), cursor string) (map[string]any, error) {
+	results := []any{}
+	hasMore := false
+	for page := 0; page < maxPages; page++ {
+		resp, err := fetch(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if items, ok := resp["results"].([]any); ok {
+			results = append(results, items...)
+		}
+		hasMore, _ = resp["has_more"].(bool)
+		cursor, _ = resp["next_cursor"].(string)
+		if !all || !hasMore || cursor == "" {
+			break
+		}
+	}
+	if !hasMore {
+		cursor = ""
+	}
+	return map[string]any{
+		"results":     results,
+		"count":       len(results),
+		"has_more":    hasMore,
+		"next_cursor": cursor,
+	}, nil
+}
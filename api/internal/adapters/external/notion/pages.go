@@ -0,0 +1,35 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GetPage returns a page object including its properties.
+func GetPage(ctx context.Context, token string, pageID string) (map[string]any, error) {
+	return makeRequest(ctx, token, "GET", fmt.Sprintf("%s/pages/%s", apiURL(ctx), url.PathEscape(pageID)), nil)
+}
+
+// UpdatePageProperties patches page properties. properties must already be in
+// Notion's format (see BuildProperties).
+func UpdatePageProperties(ctx context.Context, token string, pageID string, properties map[string]any) (map[string]any, error) {
+	return makeRequest(ctx, token, "PATCH", fmt.Sprintf("%s/pages/%s", apiURL(ctx), url.PathEscape(pageID)), map[string]any{
+		"properties": properties,
+	})
+}
+
+// ArchivePage moves a page to the trash, or restores it when archived is false.
+func ArchivePage(ctx context.Context, token string, pageID string, archived bool) (map[string]any, error) {
+	return makeRequest(ctx, token, "PATCH", fmt.Sprintf("%s/pages/%s", apiURL(ctx), url.PathEscape(pageID)), map[string]any{
+		"archived": archived,
+	})
+}
+
+// PageDatabaseID returns the ID of the database a page belongs to, or "" for
+// pages whose parent is another page or the workspace.
+func PageDatabaseID(page map[string]any) string {
+	parent, _ := page["parent"].(map[string]any)
+	id, _ := parent["database_id"].(string)
+	return id
+}
@@ -0,0 +1,210 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BuildProperties converts simple values keyed by property name into
+// Notion's property format, using the database schema to pick each
+// property's type:
+//
+//	{"Status": "Done", "Due": "2024-05-01", "Points": 3, "Done": true, "Blocked by": ["<page id>"]}
+//
+// Unknown properties, read-only types and values that do not fit the type
+// are reported together, so nothing is sent until all of them are valid.
+func BuildProperties(schema map[string]any, values map[string]any) (map[string]any, error) {
+	out := map[string]any{}
+	var problems []string
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, ok := schema[name].(map[string]any)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: no such property", name))
+			continue
+		}
+		propType, _ := prop["type"].(string)
+		value, err := propertyValue(propType, values[name])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		out[name] = value
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid properties: %s", strings.Join(problems, "; "))
+	}
+	return out, nil
+}
+
+func propertyValue(propType string, raw any) (map[string]any, error) {
+	// Values already in Notion's format ({"select": {...}}) are passed through.
+	if m, ok := raw.(map[string]any); ok {
+		if _, ok := m[propType]; ok && len(m) == 1 {
+			return m, nil
+		}
+	}
+
+	switch propType {
+	case "title", "rich_text":
+		return map[string]any{propType: inlineRichText(stringValue(raw))}, nil
+	case "number":
+		if raw == nil || raw == "" {
+			return map[string]any{"number": nil}, nil
+		}
+		n, err := numberValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"number": n}, nil
+	case "checkbox":
+		b, err := boolValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"checkbox": b}, nil
+	case "select", "status":
+		name := strings.TrimSpace(stringValue(raw))
+		if name == "" {
+			return map[string]any{propType: nil}, nil
+		}
+		return map[string]any{propType: map[string]any{"name": name}}, nil
+	case "multi_select":
+		options := []any{}
+		for _, name := range listValue(raw) {
+			options = append(options, map[string]any{"name": name})
+		}
+		return map[string]any{"multi_select": options}, nil
+	case "date":
+		return dateValue(raw)
+	case "relation":
+		ids := []any{}
+		for _, id := range listValue(raw) {
+			ids = append(ids, map[string]any{"id": id})
+		}
+		return map[string]any{"relation": ids}, nil
+	case "people":
+		people := []any{}
+		for _, id := range listValue(raw) {
+			people = append(people, map[string]any{"object": "user", "id": id})
+		}
+		return map[string]any{"people": people}, nil
+	case "url", "email", "phone_number":
+		s := strings.TrimSpace(stringValue(raw))
+		if s == "" {
+			return map[string]any{propType: nil}, nil
+		}
+		return map[string]any{propType: s}, nil
+	case "formula", "rollup", "created_time", "created_by", "last_edited_time", "last_edited_by", "unique_id":
+		return nil, fmt.Errorf("%s properties are read-only", propType)
+	default:
+		return nil, fmt.Errorf("unsupported property type %q", propType)
+	}
+}
+
+func dateValue(raw any) (map[string]any, error) {
+	switch v := raw.(type) {
+	case nil:
+		return map[string]any{"date": nil}, nil
+	case map[string]any:
+		start := strings.TrimSpace(stringValue(v["start"]))
+		if start == "" {
+			return nil, fmt.Errorf("date needs a start")
+		}
+		date := map[string]any{"start": start}
+		if end := strings.TrimSpace(stringValue(v["end"])); end != "" {
+			date["end"] = end
+		}
+		if tz := strings.TrimSpace(stringValue(v["time_zone"])); tz != "" {
+			date["time_zone"] = tz
+		}
+		return map[string]any{"date": date}, nil
+	default:
+		start := strings.TrimSpace(stringValue(v))
+		if start == "" {
+			return map[string]any{"date": nil}, nil
+		}
+		return map[string]any{"date": map[string]any{"start": start}}, nil
+	}
+}
+
+func numberValue(raw any) (float64, error) {
+	switch v := raw.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("%v is not a number", raw)
+	}
+}
+
+func boolValue(raw any) (bool, error) {
+	switch v := raw.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("%q is not true or false", v)
+		}
+		return b, nil
+	default:
+		return false, fmt.Errorf("%v is not true or false", raw)
+	}
+}
+
+// listValue accepts an array or a comma-separated string.
+func listValue(raw any) []string {
+	var parts []string
+	switch v := raw.(type) {
+	case []any:
+		for _, item := range v {
+			parts = append(parts, stringValue(item))
+		}
+	case []string:
+		parts = v
+	default:
+		parts = strings.Split(stringValue(raw), ",")
+	}
+	out := []string{}
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func stringValue(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
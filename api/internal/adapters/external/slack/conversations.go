@@ -0,0 +1,91 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultChannelPageSize = 200
+	maxChannelPageSize     = 1000
+	// maxChannelPages bounds ListChannels with All set.
+	maxChannelPages = 50
+)
+
+// ListChannelsOptions control conversations.list. Types is a comma-separated
+// list (public_channel, private_channel, mpim, im). With All set, pages are
+// followed until next_cursor is empty.
+type ListChannelsOptions struct {
+	Types           string
+	ExcludeArchived bool
+	Limit           int
+	Cursor          string
+	All             bool
+}
+
+// ListChannels lists conversations visible to the token.
+// scopes: channels:read (groups:read, im:read, mpim:read for other types)
+func ListChannels(ctx context.Context, token string, opts ListChannelsOptions) (map[string]any, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultChannelPageSize
+	}
+	if limit > maxChannelPageSize {
+		limit = maxChannelPageSize
+	}
+
+	channels := []any{}
+	cursor := opts.Cursor
+	for page := 0; page < maxChannelPages; page++ {
+		params := url.Values{"limit": {strconv.Itoa(limit)}}
+		if opts.Types != "" {
+			params.Set("types", opts.Types)
+		}
+		if opts.ExcludeArchived {
+			params.Set("exclude_archived", "true")
+		}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		result, err := callForm(ctx, token, "conversations.list", params)
+		if err != nil {
+			return nil, err
+		}
+		if items, ok := result["channels"].([]any); ok {
+			channels = append(channels, items...)
+		}
+		cursor = nextCursor(result)
+		if !opts.All || cursor == "" {
+			break
+		}
+	}
+
+	return map[string]any{
+		"channels":    channels,
+		"count":       len(channels),
+		"next_cursor": cursor,
+	}, nil
+}
+
+// OpenDirectMessage opens (or resumes) a DM with one user, or a group DM with several.
+// scopes: im:write (mpim:write for several users)
+func OpenDirectMessage(ctx context.Context, token string, users []string) (map[string]any, error) {
+	result, err := callJSON(ctx, token, "conversations.open", map[string]any{
+		"users":     strings.Join(users, ","),
+		"return_im": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	channel, _ := result["channel"].(map[string]any)
+	channelID, _ := channel["id"].(string)
+	return map[string]any{"channel": channel, "channelId": channelID}, nil
+}
+
+func nextCursor(result map[string]any) string {
+	meta, _ := result["response_metadata"].(map[string]any)
+	cursor, _ := meta["next_cursor"].(string)
+	return strings.TrimSpace(cursor)
+}
@@ -0,0 +1,72 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// FileUpload describes a file shared with UploadFile. Without ChannelID the
+// file is uploaded but not shared anywhere.
+type FileUpload struct {
+	Filename       string
+	Title          string
+	Content        []byte
+	ChannelID      string
+	InitialComment string
+	ThreadTS       string
+}
+
+// UploadFile uploads content with the external upload flow that replaces
+// files.upload: request an upload URL, send the bytes, then complete the
+// upload to share it.
+// scopes: files:write
+func UploadFile(ctx context.Context, token string, file FileUpload) (map[string]any, error) {
+	if file.Filename == "" {
+		return nil, errors.New("slack: filename is required")
+	}
+	if len(file.Content) == 0 {
+		return nil, errors.New("slack: file content is empty")
+	}
+
+	ticket, err := callForm(ctx, token, "files.getUploadURLExternal", url.Values{
+		"filename": {file.Filename},
+		"length":   {strconv.Itoa(len(file.Content))},
+	})
+	if err != nil {
+		return nil, err
+	}
+	uploadURL, _ := ticket["upload_url"].(string)
+	fileID, _ := ticket["file_id"].(string)
+	if uploadURL == "" || fileID == "" {
+		return nil, errors.New("slack api error: files.getUploadURLExternal returned no upload_url")
+	}
+
+	if err := uploadBytes(ctx, uploadURL, file.Filename, file.Content); err != nil {
+		return nil, err
+	}
+
+	title := file.Title
+	if title == "" {
+		title = file.Filename
+	}
+	body := map[string]any{
+		"files": []any{map[string]any{"id": fileID, "title": title}},
+	}
+	if file.ChannelID != "" {
+		body["channel_id"] = file.ChannelID
+		if file.InitialComment != "" {
+			body["initial_comment"] = file.InitialComment
+		}
+		if file.ThreadTS != "" {
+			body["thread_ts"] = file.ThreadTS
+		}
+	}
+	result, err := callJSON(ctx, token, "files.completeUploadExternal", body)
+	if err != nil {
+		return nil, err
+	}
+	result["file_id"] = fileID
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// MessageOptions are the optional chat.postMessage arguments. Nil unfurl
+// settings keep Slack's defaults.
+type MessageOptions struct {
+	Blocks         []any
+	ThreadTS       string
+	ReplyBroadcast bool
+	UnfurlLinks    *bool
+	UnfurlMedia    *bool
+}
+
+// SendMessage posts a message to a public channel, private channel, or direct message/IM channel.
+// scopes: chat:write
+func SendMessage(ctx context.Context, token string, channel string, text string, opts MessageOptions) (map[string]any, error) {
+	body := map[string]any{
+		"channel": channel,
+		"text":    text,
+	}
+	if len(opts.Blocks) > 0 {
+		body["blocks"] = opts.Blocks
+	}
+	if opts.ThreadTS != "" {
+		body["thread_ts"] = opts.ThreadTS
+		if opts.ReplyBroadcast {
+			body["reply_broadcast"] = true
+		}
+	}
+	if opts.UnfurlLinks != nil {
+		body["unfurl_links"] = *opts.UnfurlLinks
+	}
+	if opts.UnfurlMedia != nil {
+		body["unfurl_media"] = *opts.UnfurlMedia
+	}
+	return callJSON(ctx, token, "chat.postMessage", body)
+}
+
+// UpdateMessage replaces the text and/or blocks of a message posted by the bot.
+// scopes: chat:write
+func UpdateMessage(ctx context.Context, token string, channel string, ts string, text string, blocks []any) (map[string]any, error) {
+	body := map[string]any{
+		"channel": channel,
+		"ts":      ts,
+	}
+	if text != "" {
+		body["text"] = text
+	}
+	if len(blocks) > 0 {
+		body["blocks"] = blocks
+	}
+	return callJSON(ctx, token, "chat.update", body)
+}
+
+// DeleteMessage deletes a message posted by the bot.
+// scopes: chat:write
+func DeleteMessage(ctx context.Context, token string, channel string, ts string) (map[string]any, error) {
+	return callJSON(ctx, token, "chat.delete", map[string]any{
+		"channel": channel,
+		"ts":      ts,
+	})
+}
+
+// AddReaction adds an emoji reaction to a message. Surrounding colons in name are ignored.
+// scopes: reactions:write
+func AddReaction(ctx context.Context, token string, channel string, ts string, name string) (map[string]any, error) {
+	return callJSON(ctx, token, "reactions.add", map[string]any{
+		"channel":   channel,
+		"timestamp": ts,
+		"name":      strings.Trim(strings.TrimSpace(name), ":"),
+	})
+}
+
+// GetUserByEmail looks up a workspace member by email address.
+// scopes: users:read.email
+func GetUserByEmail(ctx context.Context, token string, email string) (map[string]any, error) {
+	result, err := callForm(ctx, token, "users.lookupByEmail", url.Values{"email": {email}})
+	if err != nil {
+		return nil, err
+	}
+	user, _ := result["user"].(map[string]any)
+	return map[string]any{"user": user}, nil
+}
@@ -5,43 +5,88 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
 )
 
-const baseURL = "https://slack.com/api"
+const defaultBaseURL = "https://slack.com/api"
 
-// SendMessage posts a message to a public channel, private channel, or direct message/IM channel.
-// scopes: chat:write
-func SendMessage(ctx context.Context, token string, channel string, text string) (map[string]any, error) {
-	url := fmt.Sprintf("%s/chat.postMessage", baseURL)
+// apiURL is the API root for calls under ctx, set by endpoints.WithAPIURL.
+func apiURL(ctx context.Context) string {
+	return endpoints.APIURL(ctx, "slack", defaultBaseURL)
+}
 
-	body := map[string]any{
-		"channel": channel,
-		"text":    text,
-	}
+// defaultRetryAfter is used when Slack rate limits a call without a usable Retry-After header.
+const defaultRetryAfter = 30 * time.Second
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// RateLimitError is returned when Slack answers 429 (or ok=false with
+// "ratelimited"). RetryAfter is taken from the Retry-After header.
+type RateLimitError struct {
+	Method     string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("slack api error: %s rate limited, retry after %s", e.Method, e.RetryAfter)
+}
+
+// RetryDelay reports how long the caller should wait before calling again.
+func (e *RateLimitError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// AuthTest checks the token and returns the team and bot user it belongs to.
+func AuthTest(ctx context.Context, token string) (map[string]any, error) {
+	return callForm(ctx, token, "auth.test", url.Values{})
+}
+
+// callJSON posts a JSON body to a Web API method.
+func callJSON(ctx context.Context, token string, method string, body map[string]any) (map[string]any, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL(ctx)+"/"+method, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return do(req, token, method)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+// callForm posts form-encoded arguments. Some methods (users.lookupByEmail,
+// files.getUploadURLExternal, ...) do not accept JSON bodies.
+func callForm(ctx context.Context, token string, method string, params url.Values) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL(ctx)+"/"+method, strings.NewReader(params.Encode()))
 	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return do(req, token, method)
+}
 
+func do(req *http.Request, token string, method string) (map[string]any, error) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &RateLimitError{Method: method, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("slack api error: status %d", resp.StatusCode)
+		return nil, fmt.Errorf("slack api error: %s status %d", method, resp.StatusCode)
 	}
 
 	var result map[string]any
@@ -51,8 +96,43 @@ func SendMessage(ctx context.Context, token string, channel string, text string)
 
 	if ok, _ := result["ok"].(bool); !ok {
 		errStr, _ := result["error"].(string)
+		if errStr == "ratelimited" {
+			return nil, &RateLimitError{Method: method, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+		}
 		return nil, fmt.Errorf("slack api error: %s", errStr)
 	}
 
 	return result, nil
 }
+
+// uploadBytes sends file content to an upload URL returned by files.getUploadURLExternal.
+func uploadBytes(ctx context.Context, uploadURL string, filename string, content []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{Method: "files.upload", RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack api error: upload of %s failed with status %d", filename, resp.StatusCode)
+	}
+	return nil
+}
+
+func parseRetryAfter(raw string) time.Duration {
+	secs, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || secs <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,195 @@
+// Package sqldb runs queries against Postgres and MySQL databases configured
+// in postgres and mysql credentials.
+package sqldb
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// Dialect is the SQL flavour of a database.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	MySQL    Dialect = "mysql"
+)
+
+// TLS modes, named after Postgres' sslmode.
+const (
+	TLSDisable    = "disable"
+	TLSPrefer     = "prefer"
+	TLSRequire    = "require"
+	TLSVerifyFull = "verify-full"
+)
+
+const connectTimeout = 10 * time.Second
+
+// Config is a database login as stored in a postgres or mysql credential.
+// ReadOnly makes every statement run in a read-only transaction.
+type Config struct {
+	Dialect  Dialect
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Database string
+	TLSMode  string
+	ReadOnly bool
+}
+
+// DialectFor maps a credential provider to its dialect.
+func DialectFor(provider string) (Dialect, bool) {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "postgres", "postgresql":
+		return Postgres, true
+	case "mysql", "mariadb":
+		return MySQL, true
+	}
+	return "", false
+}
+
+// ConfigFromPayload reads a credential payload with the keys host, port,
+// user, password, database, tls_mode (disable, prefer, require or
+// verify-full; default prefer) and read_only.
+func ConfigFromPayload(provider string, payload map[string]any) (Config, error) {
+	dialect, ok := DialectFor(provider)
+	if !ok {
+		return Config{}, fmt.Errorf("unsupported database provider %q", provider)
+	}
+	cfg := Config{
+		Dialect:  dialect,
+		Host:     strings.TrimSpace(payloadString(payload["host"])),
+		User:     payloadString(payload["user"]),
+		Password: payloadString(payload["password"]),
+		Database: strings.TrimSpace(payloadString(payload["database"])),
+		TLSMode:  strings.ToLower(strings.TrimSpace(payloadString(payload["tls_mode"]))),
+	}
+	if cfg.User == "" {
+		cfg.User = payloadString(payload["username"])
+	}
+	switch v := payload["port"].(type) {
+	case float64:
+		cfg.Port = int(v)
+	case int:
+		cfg.Port = v
+	case string:
+		cfg.Port, _ = strconv.Atoi(strings.TrimSpace(v))
+	}
+	switch v := payload["read_only"].(type) {
+	case bool:
+		cfg.ReadOnly = v
+	case string:
+		cfg.ReadOnly = strings.EqualFold(strings.TrimSpace(v), "true")
+	}
+
+	if cfg.Host == "" {
+		return cfg, fmt.Errorf("%s: host is required", dialect)
+	}
+	if cfg.User == "" {
+		return cfg, fmt.Errorf("%s: user is required", dialect)
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 5432
+		if dialect == MySQL {
+			cfg.Port = 3306
+		}
+	}
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		return cfg, fmt.Errorf("%s: port %d is out of range", dialect, cfg.Port)
+	}
+	switch cfg.TLSMode {
+	case "":
+		cfg.TLSMode = TLSPrefer
+	case TLSDisable, TLSPrefer, TLSRequire, TLSVerifyFull:
+	default:
+		return cfg, fmt.Errorf("%s: tls_mode must be disable, prefer, require or verify-full, got %q", dialect, cfg.TLSMode)
+	}
+	return cfg, nil
+}
+
+// fingerprint changes whenever a setting that affects the connection does.
+func (c Config) fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		string(c.Dialect), c.Host, strconv.Itoa(c.Port), c.User, c.Password, c.Database, c.TLSMode,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Open returns a connection pool for the database. Nothing is dialed until
+// the first query.
+func Open(c Config) (*sql.DB, error) {
+	var db *sql.DB
+	switch c.Dialect {
+	case Postgres:
+		u := url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(c.User, c.Password),
+			Host:   net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+			Path:   "/" + c.Database,
+		}
+		q := url.Values{}
+		q.Set("sslmode", c.TLSMode)
+		q.Set("application_name", "flowcraft")
+		q.Set("connect_timeout", strconv.Itoa(int(connectTimeout.Seconds())))
+		u.RawQuery = q.Encode()
+		pgConfig, err := pgx.ParseConfig(u.String())
+		if err != nil {
+			return nil, fmt.Errorf("postgres: invalid connection settings: %w", err)
+		}
+		db = stdlib.OpenDB(*pgConfig)
+	case MySQL:
+		mc := mysql.NewConfig()
+		mc.User = c.User
+		mc.Passwd = c.Password
+		mc.Net = "tcp"
+		mc.Addr = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+		mc.DBName = c.Database
+		mc.ParseTime = true
+		mc.Loc = time.UTC
+		mc.Timeout = connectTimeout
+		switch c.TLSMode {
+		case TLSDisable:
+			mc.TLSConfig = "false"
+		case TLSPrefer:
+			mc.TLSConfig = "preferred"
+		case TLSRequire:
+			mc.TLSConfig = "skip-verify"
+		case TLSVerifyFull:
+			mc.TLSConfig = "true"
+		}
+		connector, err := mysql.NewConnector(mc)
+		if err != nil {
+			return nil, fmt.Errorf("mysql: invalid connection settings: %w", err)
+		}
+		db = sql.OpenDB(connector)
+	default:
+		return nil, fmt.Errorf("unsupported dialect %q", c.Dialect)
+	}
+	db.SetMaxOpenConns(5)
+	db.SetMaxIdleConns(2)
+	db.SetConnMaxIdleTime(5 * time.Minute)
+	db.SetConnMaxLifetime(30 * time.Minute)
+	return db, nil
+}
+
+func payloadString(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
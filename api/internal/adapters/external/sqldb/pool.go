@@ -0,0 +1,65 @@
+package sqldb
+
+import (
+	"database/sql"
+	"sync"
+)
+
+// Pools caches one connection pool per credential so steps reuse
+// connections. A pool is replaced when the credential's settings change.
+type Pools struct {
+	mu      sync.Mutex
+	entries map[string]poolEntry
+}
+
+type poolEntry struct {
+	fingerprint string
+	db          *sql.DB
+}
+
+func NewPools() *Pools {
+	return &Pools{entries: map[string]poolEntry{}}
+}
+
+// Acquire returns the pool for key (a credential ID). The release func must
+// be called when done; it only closes the pool when p is nil, which opens a
+// one-off pool.
+func (p *Pools) Acquire(key string, cfg Config) (*sql.DB, func(), error) {
+	if p == nil {
+		db, err := Open(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, func() { _ = db.Close() }, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fp := cfg.fingerprint()
+	if entry, ok := p.entries[key]; ok {
+		if entry.fingerprint == fp {
+			return entry.db, func() {}, nil
+		}
+		// Running queries keep their connections; Close waits for them.
+		go entry.db.Close()
+		delete(p.entries, key)
+	}
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.entries[key] = poolEntry{fingerprint: fp, db: db}
+	return db, func() {}, nil
+}
+
+// Close closes every cached pool.
+func (p *Pools) Close() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, entry := range p.entries {
+		_ = entry.db.Close()
+		delete(p.entries, key)
+	}
+}
@@ -0,0 +1,260 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultTimeout applies when Options.Timeout is zero.
+const DefaultTimeout = 30 * time.Second
+
+// Options apply to one step. Timeout becomes the server-side statement
+// timeout (statement_timeout in Postgres, max_execution_time for MySQL
+// SELECTs) and bounds the whole transaction. ReadOnly runs it in a
+// read-only transaction, so the database rejects writes. MaxRows caps the
+// rows returned; further rows are dropped and Truncated is set.
+type Options struct {
+	Timeout  time.Duration
+	ReadOnly bool
+	MaxRows  int
+}
+
+// Execute runs query with positional args ($1.. in Postgres, ? in MySQL).
+// Statements that return rows (see ReturnsRows) produce rows and columns;
+// others produce rowsAffected.
+func Execute(ctx context.Context, db *sql.DB, d Dialect, opts Options, query string, args []any) (map[string]any, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("query is required")
+	}
+	return inTx(ctx, db, d, opts, func(ctx context.Context, tx *sql.Tx) (map[string]any, error) {
+		if ReturnsRows(query) {
+			return queryRows(ctx, tx, opts.MaxRows, query, args)
+		}
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		return execResult(res), nil
+	})
+}
+
+// Select runs a select built from in.
+func Select(ctx context.Context, db *sql.DB, d Dialect, opts Options, in SelectInput) (map[string]any, error) {
+	query, args, err := d.SelectStatement(in)
+	if err != nil {
+		return nil, err
+	}
+	return inTx(ctx, db, d, opts, func(ctx context.Context, tx *sql.Tx) (map[string]any, error) {
+		return queryRows(ctx, tx, opts.MaxRows, query, args)
+	})
+}
+
+// WriteRows inserts rows in batches within one transaction, or upserts them
+// when conflictColumns is set. Either all rows are written or none.
+func WriteRows(ctx context.Context, db *sql.DB, d Dialect, opts Options, table string, columns []string, rows []map[string]any, conflictColumns []string) (map[string]any, error) {
+	columns = RowColumns(columns, rows)
+	batch := BatchSize(len(columns))
+	return inTx(ctx, db, d, opts, func(ctx context.Context, tx *sql.Tx) (map[string]any, error) {
+		var affected int64
+		for start := 0; start < len(rows); start += batch {
+			end := start + batch
+			if end > len(rows) {
+				end = len(rows)
+			}
+			query, args, err := d.InsertStatement(table, columns, rows[start:end], conflictColumns)
+			if err != nil {
+				return nil, err
+			}
+			res, err := tx.ExecContext(ctx, query, args...)
+			if err != nil {
+				return nil, fmt.Errorf("rows %d-%d: %w", start+1, end, err)
+			}
+			n, _ := res.RowsAffected()
+			affected += n
+		}
+		return map[string]any{"rowCount": len(rows), "rowsAffected": affected, "columns": columns}, nil
+	})
+}
+
+// Ping checks the connection and returns the server version.
+func Ping(ctx context.Context, db *sql.DB) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	var version string
+	if err := db.QueryRowContext(ctx, "SELECT version()").Scan(&version); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func inTx(ctx context.Context, db *sql.DB, d Dialect, opts Options, fn func(context.Context, *sql.Tx) (map[string]any, error)) (map[string]any, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	// The client-side deadline leaves the server a moment to report its own timeout.
+	ctx, cancel := context.WithTimeout(ctx, timeout+2*time.Second)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	ms := strconv.FormatInt(timeout.Milliseconds(), 10)
+	if d == MySQL {
+		// MariaDB has no max_execution_time; the client deadline still applies.
+		_, _ = tx.ExecContext(ctx, "SET SESSION max_execution_time = "+ms)
+	} else if _, err := tx.ExecContext(ctx, "SET LOCAL statement_timeout = "+ms); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	out, err := fn(ctx, tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func queryRows(ctx context.Context, tx *sql.Tx, maxRows int, query string, args []any) (map[string]any, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]map[string]any, len(types))
+	for i, t := range types {
+		col := map[string]any{"name": t.Name(), "type": strings.ToLower(t.DatabaseTypeName())}
+		if nullable, ok := t.Nullable(); ok {
+			col["nullable"] = nullable
+		}
+		columns[i] = col
+	}
+
+	out := []map[string]any{}
+	truncated := false
+	values := make([]any, len(types))
+	ptrs := make([]any, len(types))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if maxRows > 0 && len(out) >= maxRows {
+			truncated = true
+			break
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(types))
+		for i, t := range types {
+			row[t.Name()] = ConvertValue(values[i], t.DatabaseTypeName())
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"rows":      out,
+		"rowCount":  len(out),
+		"columns":   columns,
+		"truncated": truncated,
+	}, nil
+}
+
+func execResult(res sql.Result) map[string]any {
+	out := map[string]any{}
+	if n, err := res.RowsAffected(); err == nil {
+		out["rowsAffected"] = n
+	}
+	if id, err := res.LastInsertId(); err == nil && id != 0 {
+		out["lastInsertId"] = id
+	}
+	return out
+}
+
+// ConvertValue turns a scanned value into JSON: text and numbers that
+// drivers return as bytes are decoded by column type, JSON columns are
+// parsed, times use RFC 3339 and binary data becomes base64.
+func ConvertValue(v any, dbType string) any {
+	dbType = strings.ToUpper(dbType)
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return convertText(string(x), x, dbType)
+	case string:
+		return convertText(x, nil, dbType)
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	}
+	return v
+}
+
+func convertText(s string, raw []byte, dbType string) any {
+	switch dbType {
+	case "JSON", "JSONB":
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err == nil {
+			return decoded
+		}
+		return s
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT", "INT2", "INT4", "INT8", "YEAR",
+		"UNSIGNED INT", "UNSIGNED TINYINT", "UNSIGNED SMALLINT", "UNSIGNED MEDIUMINT":
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	case "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case "BYTEA", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY", "BIT":
+		if raw != nil {
+			return base64.StdEncoding.EncodeToString(raw)
+		}
+	}
+	if raw != nil && !utf8.Valid(raw) {
+		return base64.StdEncoding.EncodeToString(raw)
+	}
+	return s
+}
+
+var leadingCommentRe = regexp.MustCompile(`^(?s)(\s+|--[^\n]*\n?|/\*.*?\*/|\()*`)
+
+var returningRe = regexp.MustCompile(`(?i)\bRETURNING\b`)
+
+// ReturnsRows reports whether a statement produces a result set: it starts
+// with SELECT, WITH, SHOW, VALUES, TABLE, EXPLAIN or DESCRIBE, or has a
+// RETURNING clause.
+func ReturnsRows(query string) bool {
+	q := leadingCommentRe.ReplaceAllString(query, "")
+	end := strings.IndexFunc(q, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	if end < 0 {
+		end = len(q)
+	}
+	switch strings.ToUpper(q[:end]) {
+	case "SELECT", "WITH", "SHOW", "VALUES", "TABLE", "EXPLAIN", "DESCRIBE", "DESC":
+		return true
+	}
+	return returningRe.MatchString(q)
+}
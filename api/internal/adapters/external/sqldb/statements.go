@@ -0,0 +1,276 @@
+package sqldb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxParams stays under Postgres' limit of 65535 bind parameters per statement.
+const maxParams = 65535
+
+// maxBatchRows bounds the rows of one INSERT statement.
+const maxBatchRows = 500
+
+// QuoteIdent quotes a column or table name; "schema.table" is quoted per part.
+func (d Dialect) QuoteIdent(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("identifier is empty")
+	}
+	if strings.ContainsRune(name, 0) {
+		return "", fmt.Errorf("identifier %q contains a NUL byte", name)
+	}
+	parts := strings.Split(name, ".")
+	if len(parts) > 3 {
+		return "", fmt.Errorf("identifier %q has too many parts", name)
+	}
+	quote := `"`
+	if d == MySQL {
+		quote = "`"
+	}
+	for i, part := range parts {
+		if part == "" {
+			return "", fmt.Errorf("identifier %q has an empty part", name)
+		}
+		parts[i] = quote + strings.ReplaceAll(part, quote, quote+quote) + quote
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// Placeholder returns the bind parameter marker for the n-th (1-based) argument.
+func (d Dialect) Placeholder(n int) string {
+	if d == Postgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// SelectInput describes a select. Where matches columns by equality (nil
+// matches NULL). OrderBy is "column [asc|desc], ...".
+type SelectInput struct {
+	Table   string
+	Columns []string
+	Where   map[string]any
+	OrderBy string
+	Limit   int
+}
+
+var orderTermRe = regexp.MustCompile(`(?i)^\s*(.+?)(?:\s+(asc|desc))?\s*$`)
+
+// SelectStatement builds the statement and arguments for a select.
+func (d Dialect) SelectStatement(in SelectInput) (string, []any, error) {
+	table, err := d.QuoteIdent(in.Table)
+	if err != nil {
+		return "", nil, fmt.Errorf("table: %w", err)
+	}
+	columns := "*"
+	if len(in.Columns) > 0 {
+		quoted, err := d.quoteAll(in.Columns)
+		if err != nil {
+			return "", nil, err
+		}
+		columns = strings.Join(quoted, ", ")
+	}
+	var sb strings.Builder
+	sb.WriteString("SELECT " + columns + " FROM " + table)
+
+	var args []any
+	if len(in.Where) > 0 {
+		keys := make([]string, 0, len(in.Where))
+		for k := range in.Where {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		conds := make([]string, 0, len(keys))
+		for _, k := range keys {
+			col, err := d.QuoteIdent(k)
+			if err != nil {
+				return "", nil, fmt.Errorf("where: %w", err)
+			}
+			if in.Where[k] == nil {
+				conds = append(conds, col+" IS NULL")
+				continue
+			}
+			args = append(args, argValue(in.Where[k]))
+			conds = append(conds, col+" = "+d.Placeholder(len(args)))
+		}
+		sb.WriteString(" WHERE " + strings.Join(conds, " AND "))
+	}
+	if order := strings.TrimSpace(in.OrderBy); order != "" {
+		var terms []string
+		for _, term := range strings.Split(order, ",") {
+			m := orderTermRe.FindStringSubmatch(term)
+			if m == nil {
+				return "", nil, fmt.Errorf("orderBy: invalid term %q", term)
+			}
+			col, err := d.QuoteIdent(m[1])
+			if err != nil {
+				return "", nil, fmt.Errorf("orderBy: %w", err)
+			}
+			if m[2] != "" {
+				col += " " + strings.ToUpper(m[2])
+			}
+			terms = append(terms, col)
+		}
+		sb.WriteString(" ORDER BY " + strings.Join(terms, ", "))
+	}
+	if in.Limit > 0 {
+		sb.WriteString(" LIMIT " + strconv.Itoa(in.Limit))
+	}
+	return sb.String(), args, nil
+}
+
+// RowColumns returns the columns to write: the given ones, or every key used
+// by rows in sorted order.
+func RowColumns(columns []string, rows []map[string]any) []string {
+	if len(columns) > 0 {
+		return columns
+	}
+	seen := map[string]struct{}{}
+	for _, row := range rows {
+		for k := range row {
+			seen[k] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for k := range seen {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// BatchSize is how many rows of columns fit in one INSERT.
+func BatchSize(columns int) int {
+	if columns < 1 {
+		return maxBatchRows
+	}
+	n := maxParams / columns
+	if n > maxBatchRows {
+		n = maxBatchRows
+	}
+	return n
+}
+
+// InsertStatement builds a multi-row INSERT of rows (missing keys insert
+// NULL). With conflictColumns it becomes an upsert that updates the other
+// columns: ON CONFLICT (...) DO UPDATE in Postgres, ON DUPLICATE KEY UPDATE
+// in MySQL, which matches on any unique key rather than the given columns.
+func (d Dialect) InsertStatement(table string, columns []string, rows []map[string]any, conflictColumns []string) (string, []any, error) {
+	if len(rows) == 0 {
+		return "", nil, errors.New("no rows to insert")
+	}
+	if len(columns) == 0 {
+		return "", nil, errors.New("rows have no columns")
+	}
+	if len(rows)*len(columns) > maxParams {
+		return "", nil, fmt.Errorf("%d values exceed the %d parameter limit of one statement", len(rows)*len(columns), maxParams)
+	}
+	quotedTable, err := d.QuoteIdent(table)
+	if err != nil {
+		return "", nil, fmt.Errorf("table: %w", err)
+	}
+	quoted, err := d.quoteAll(columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO " + quotedTable + " (" + strings.Join(quoted, ", ") + ") VALUES ")
+	args := make([]any, 0, len(rows)*len(columns))
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j, col := range columns {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			args = append(args, argValue(row[col]))
+			sb.WriteString(d.Placeholder(len(args)))
+		}
+		sb.WriteString(")")
+	}
+
+	if len(conflictColumns) > 0 {
+		conflict := map[string]bool{}
+		quotedConflict, err := d.quoteAll(conflictColumns)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, c := range conflictColumns {
+			conflict[c] = true
+		}
+		var updates []string
+		for i, col := range columns {
+			if conflict[col] {
+				continue
+			}
+			if d == Postgres {
+				updates = append(updates, quoted[i]+" = EXCLUDED."+quoted[i])
+			} else {
+				updates = append(updates, quoted[i]+" = VALUES("+quoted[i]+")")
+			}
+		}
+		switch {
+		case d == Postgres && len(updates) == 0:
+			sb.WriteString(" ON CONFLICT (" + strings.Join(quotedConflict, ", ") + ") DO NOTHING")
+		case d == Postgres:
+			sb.WriteString(" ON CONFLICT (" + strings.Join(quotedConflict, ", ") + ") DO UPDATE SET " + strings.Join(updates, ", "))
+		case len(updates) == 0:
+			// A no-op update keeps MySQL from failing on the duplicate.
+			sb.WriteString(" ON DUPLICATE KEY UPDATE " + quotedConflict[0] + " = " + quotedConflict[0])
+		default:
+			sb.WriteString(" ON DUPLICATE KEY UPDATE " + strings.Join(updates, ", "))
+		}
+	}
+	return sb.String(), args, nil
+}
+
+func (d Dialect) quoteAll(names []string) ([]string, error) {
+	out := make([]string, len(names))
+	for i, name := range names {
+		q, err := d.QuoteIdent(name)
+		if err != nil {
+			return nil, fmt.Errorf("column: %w", err)
+		}
+		out[i] = q
+	}
+	return out, nil
+}
+
+// argValue converts a JSON-decoded value into a bind argument: whole numbers
+// become int64 so integer columns accept them, and objects and arrays are
+// encoded as JSON text.
+func argValue(v any) any {
+	switch x := v.(type) {
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+			return int64(x)
+		}
+		return x
+	case map[string]any, []any:
+		raw, err := json.Marshal(x)
+		if err != nil {
+			return fmt.Sprint(x)
+		}
+		return string(raw)
+	}
+	return v
+}
+
+// ArgValues converts query parameters with the same rules as row values.
+func ArgValues(values []any) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = argValue(v)
+	}
+	return out
+}
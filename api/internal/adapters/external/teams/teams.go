@@ -0,0 +1,139 @@
+// Package teams posts messages to Microsoft Teams channels through incoming
+// webhooks and Power Automate "post to a channel when a webhook request is
+// received" workflow URLs. Both accept the same Adaptive Card message.
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// AdaptiveCardContentType is the attachment type of an Adaptive Card.
+	AdaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	// AdaptiveCardVersion is the schema version TextCard uses; Teams supports up to 1.5.
+	AdaptiveCardVersion = "1.4"
+)
+
+// defaultRetryAfter is used when Teams throttles a post without a usable Retry-After header.
+const defaultRetryAfter = 30 * time.Second
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// RateLimitError is returned when the webhook answers 429.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("teams webhook error: rate limited, retry after %s", e.RetryAfter)
+}
+
+// RetryDelay reports how long the caller should wait before calling again.
+func (e *RateLimitError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// TextCard builds an Adaptive Card with an optional bold title and a text body
+// (Teams renders a subset of Markdown in TextBlocks).
+func TextCard(title string, text string) map[string]any {
+	var body []any
+	if t := strings.TrimSpace(title); t != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": t, "weight": "Bolder", "size": "Medium", "wrap": true})
+	}
+	if strings.TrimSpace(text) != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": text, "wrap": true})
+	}
+	return map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": AdaptiveCardVersion,
+		"body":    body,
+	}
+}
+
+// CardMessage wraps an Adaptive Card in the message envelope webhooks expect.
+// A payload that already is a message ({"type": "message", ...}) is returned
+// as is.
+func CardMessage(card map[string]any) (map[string]any, error) {
+	switch t, _ := card["type"].(string); t {
+	case "message":
+		return card, nil
+	case "AdaptiveCard":
+		return map[string]any{
+			"type": "message",
+			"attachments": []any{map[string]any{
+				"contentType": AdaptiveCardContentType,
+				"contentUrl":  nil,
+				"content":     card,
+			}},
+		}, nil
+	default:
+		return nil, errors.New(`card must be an Adaptive Card ({"type": "AdaptiveCard", ...}) or a message ({"type": "message", ...})`)
+	}
+}
+
+// Post sends a message payload to a webhook or workflow URL. Incoming
+// webhooks answer 200 and workflows 202; both count as delivered.
+func Post(ctx context.Context, webhookURL string, message map[string]any) (map[string]any, error) {
+	target, err := ValidateWebhookURL(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &RateLimitError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+	// Legacy incoming webhooks answer 200 with an error text instead of a status code.
+	text := strings.TrimSpace(string(body))
+	if resp.StatusCode >= 300 || (resp.StatusCode == http.StatusOK && text != "" && text != "1" && !strings.HasPrefix(text, "{")) {
+		if text == "" {
+			text = resp.Status
+		}
+		return nil, fmt.Errorf("teams webhook error: %s", text)
+	}
+	return map[string]any{"delivered": true, "statusCode": resp.StatusCode}, nil
+}
+
+// ValidateWebhookURL checks that raw is an absolute http(s) URL and returns it trimmed.
+func ValidateWebhookURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", errors.New("teams webhook URL is not a valid URL")
+	}
+	return raw, nil
+}
+
+func parseRetryAfter(raw string) time.Duration {
+	secs, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || secs <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(secs) * time.Second
+}
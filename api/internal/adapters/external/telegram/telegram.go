@@ -0,0 +1,491 @@
+// Package telegram calls the Telegram Bot API with a bot token: messages,
+// photos and documents, callback query answers and webhook registration.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
+)
+
+const defaultBaseURL = "https://api.telegram.org"
+
+// apiURL is the API root for calls under ctx, set by endpoints.WithAPIURL.
+func apiURL(ctx context.Context) string {
+	return endpoints.APIURL(ctx, "telegram", defaultBaseURL)
+}
+
+const (
+	// MaxTextLength is Telegram's limit on message text.
+	MaxTextLength = 4096
+	// MaxCaptionLength is Telegram's limit on photo and document captions.
+	MaxCaptionLength = 1024
+	// MaxPhotoBytes and MaxDocumentBytes are the upload limits of the Bot API.
+	MaxPhotoBytes    = 10 << 20
+	MaxDocumentBytes = 50 << 20
+)
+
+// defaultRetryAfter is used when Telegram rate limits a call without saying for how long.
+const defaultRetryAfter = 5 * time.Second
+
+var httpClient = &http.Client{Timeout: 60 * time.Second}
+
+// APIError is an error answer ({"ok":false}) from the Bot API.
+type APIError struct {
+	Method      string
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram api error: %s: %s (status %d)", e.Method, e.Description, e.Code)
+}
+
+// RateLimitError is returned when Telegram answers 429. RetryAfter comes
+// from the response's parameters.retry_after.
+type RateLimitError struct {
+	Method     string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("telegram api error: %s rate limited, retry after %s", e.Method, e.RetryAfter)
+}
+
+// RetryDelay reports how long the caller should wait before calling again.
+func (e *RateLimitError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// IsUnauthorized reports whether err is Telegram rejecting the bot token.
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.Code == http.StatusUnauthorized || apiErr.Code == http.StatusNotFound)
+}
+
+// BotToken returns the bot token stored in a telegram credential payload
+// under "bot_token" (or "token").
+func BotToken(payload map[string]any) string {
+	for _, key := range []string{"bot_token", "token"} {
+		if token, ok := payload[key].(string); ok && strings.TrimSpace(token) != "" {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// ParseMode normalizes a parse mode: "markdown", "markdownv2" and "html" in
+// any case, or empty for plain text.
+func ParseMode(mode string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "none", "text":
+		return "", nil
+	case "markdown":
+		return "Markdown", nil
+	case "markdownv2":
+		return "MarkdownV2", nil
+	case "html":
+		return "HTML", nil
+	}
+	return "", fmt.Errorf("parse mode must be Markdown, MarkdownV2 or HTML, got %q", mode)
+}
+
+// Message is an outgoing text message. ReplyMarkup is an inline keyboard,
+// reply keyboard, keyboard removal or force reply object.
+type Message struct {
+	ChatID                string
+	Text                  string
+	ParseMode             string
+	ReplyMarkup           map[string]any
+	DisableNotification   bool
+	DisableWebPagePreview bool
+	ReplyToMessageID      int
+	MessageThreadID       int
+}
+
+// SendMessage sends msg and returns the sent message.
+func SendMessage(ctx context.Context, botToken string, msg Message) (map[string]any, error) {
+	if strings.TrimSpace(msg.ChatID) == "" {
+		return nil, errors.New("chat ID is required")
+	}
+	if strings.TrimSpace(msg.Text) == "" {
+		return nil, errors.New("text is required")
+	}
+	if n := len([]rune(msg.Text)); n > MaxTextLength {
+		return nil, fmt.Errorf("text is %d characters; Telegram allows %d", n, MaxTextLength)
+	}
+	parseMode, err := ParseMode(msg.ParseMode)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]any{"chat_id": strings.TrimSpace(msg.ChatID), "text": msg.Text}
+	setOptional(params, "parse_mode", parseMode)
+	if msg.ReplyMarkup != nil {
+		params["reply_markup"] = msg.ReplyMarkup
+	}
+	if msg.DisableNotification {
+		params["disable_notification"] = true
+	}
+	if msg.DisableWebPagePreview {
+		params["link_preview_options"] = map[string]any{"is_disabled": true}
+	}
+	if msg.ReplyToMessageID > 0 {
+		params["reply_parameters"] = map[string]any{"message_id": msg.ReplyToMessageID, "allow_sending_without_reply": true}
+	}
+	if msg.MessageThreadID > 0 {
+		params["message_thread_id"] = msg.MessageThreadID
+	}
+	return resultObject(call(ctx, botToken, "sendMessage", params))
+}
+
+// File is a photo or document to upload. Content is the file itself; when it
+// is empty FileRef (a file_id of a file Telegram already has, or an HTTP URL)
+// is sent instead.
+type File struct {
+	ChatID              string
+	Filename            string
+	MimeType            string
+	Content             []byte
+	FileRef             string
+	Caption             string
+	ParseMode           string
+	ReplyMarkup         map[string]any
+	DisableNotification bool
+	ReplyToMessageID    int
+	MessageThreadID     int
+}
+
+// SendPhoto sends an image (JPEG, PNG, WebP or GIF, at most 10 MB).
+func SendPhoto(ctx context.Context, botToken string, file File) (map[string]any, error) {
+	return sendFile(ctx, botToken, "sendPhoto", "photo", MaxPhotoBytes, file)
+}
+
+// SendDocument sends any file of at most 50 MB.
+func SendDocument(ctx context.Context, botToken string, file File) (map[string]any, error) {
+	return sendFile(ctx, botToken, "sendDocument", "document", MaxDocumentBytes, file)
+}
+
+func sendFile(ctx context.Context, botToken string, method string, field string, maxBytes int, file File) (map[string]any, error) {
+	if strings.TrimSpace(file.ChatID) == "" {
+		return nil, errors.New("chat ID is required")
+	}
+	ref := strings.TrimSpace(file.FileRef)
+	if len(file.Content) == 0 && ref == "" {
+		return nil, fmt.Errorf("%s content is required", field)
+	}
+	if len(file.Content) > maxBytes {
+		return nil, fmt.Errorf("%s is %d bytes; Telegram allows %d", field, len(file.Content), maxBytes)
+	}
+	if n := len([]rune(file.Caption)); n > MaxCaptionLength {
+		return nil, fmt.Errorf("caption is %d characters; Telegram allows %d", n, MaxCaptionLength)
+	}
+	parseMode, err := ParseMode(file.ParseMode)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]any{"chat_id": strings.TrimSpace(file.ChatID)}
+	setOptional(params, "caption", file.Caption)
+	setOptional(params, "parse_mode", parseMode)
+	if file.ReplyMarkup != nil {
+		params["reply_markup"] = file.ReplyMarkup
+	}
+	if file.DisableNotification {
+		params["disable_notification"] = true
+	}
+	if file.ReplyToMessageID > 0 {
+		params["reply_parameters"] = map[string]any{"message_id": file.ReplyToMessageID, "allow_sending_without_reply": true}
+	}
+	if file.MessageThreadID > 0 {
+		params["message_thread_id"] = file.MessageThreadID
+	}
+	if len(file.Content) == 0 {
+		params[field] = ref
+		return resultObject(call(ctx, botToken, method, params))
+	}
+	filename := strings.TrimSpace(file.Filename)
+	if filename == "" {
+		filename = field
+	}
+	return resultObject(upload(ctx, botToken, method, params, field, filename, file.MimeType, file.Content))
+}
+
+// Edit replaces the text of a message sent by the bot, identified by chat
+// and message ID or by an inline message ID.
+type Edit struct {
+	ChatID          string
+	MessageID       int
+	InlineMessageID string
+	Text            string
+	ParseMode       string
+	ReplyMarkup     map[string]any
+}
+
+// EditMessageText edits a message. It returns the edited message, or
+// {"ok": true} for inline messages.
+func EditMessageText(ctx context.Context, botToken string, edit Edit) (map[string]any, error) {
+	params := map[string]any{"text": edit.Text}
+	if inline := strings.TrimSpace(edit.InlineMessageID); inline != "" {
+		params["inline_message_id"] = inline
+	} else {
+		if strings.TrimSpace(edit.ChatID) == "" || edit.MessageID <= 0 {
+			return nil, errors.New("chat ID and message ID (or an inline message ID) are required")
+		}
+		params["chat_id"] = strings.TrimSpace(edit.ChatID)
+		params["message_id"] = edit.MessageID
+	}
+	if strings.TrimSpace(edit.Text) == "" {
+		return nil, errors.New("text is required")
+	}
+	if n := len([]rune(edit.Text)); n > MaxTextLength {
+		return nil, fmt.Errorf("text is %d characters; Telegram allows %d", n, MaxTextLength)
+	}
+	parseMode, err := ParseMode(edit.ParseMode)
+	if err != nil {
+		return nil, err
+	}
+	setOptional(params, "parse_mode", parseMode)
+	if edit.ReplyMarkup != nil {
+		params["reply_markup"] = edit.ReplyMarkup
+	}
+	return resultObject(call(ctx, botToken, "editMessageText", params))
+}
+
+// CallbackAnswer answers the callback query sent when a user presses an
+// inline keyboard button. Text shows as a notification, or as an alert with
+// ShowAlert.
+type CallbackAnswer struct {
+	CallbackQueryID string
+	Text            string
+	ShowAlert       bool
+	URL             string
+	CacheSeconds    int
+}
+
+// AnswerCallbackQuery answers a callback query.
+func AnswerCallbackQuery(ctx context.Context, botToken string, answer CallbackAnswer) (map[string]any, error) {
+	id := strings.TrimSpace(answer.CallbackQueryID)
+	if id == "" {
+		return nil, errors.New("callback query ID is required")
+	}
+	if n := len([]rune(answer.Text)); n > 200 {
+		return nil, fmt.Errorf("text is %d characters; Telegram allows 200", n)
+	}
+	params := map[string]any{"callback_query_id": id}
+	setOptional(params, "text", answer.Text)
+	setOptional(params, "url", strings.TrimSpace(answer.URL))
+	if answer.ShowAlert {
+		params["show_alert"] = true
+	}
+	if answer.CacheSeconds > 0 {
+		params["cache_time"] = answer.CacheSeconds
+	}
+	return resultObject(call(ctx, botToken, "answerCallbackQuery", params))
+}
+
+// Webhook is a webhook registration. Telegram sends SecretToken in the
+// X-Telegram-Bot-Api-Secret-Token header of every update. AllowedUpdates
+// limits the update types sent; empty keeps Telegram's default.
+type Webhook struct {
+	URL                string
+	SecretToken        string
+	AllowedUpdates     []string
+	DropPendingUpdates bool
+}
+
+// SetWebhook points the bot's updates at hook.URL. A bot has one webhook,
+// so this replaces any earlier registration.
+func SetWebhook(ctx context.Context, botToken string, hook Webhook) error {
+	if strings.TrimSpace(hook.URL) == "" {
+		return errors.New("webhook URL is required")
+	}
+	params := map[string]any{"url": strings.TrimSpace(hook.URL)}
+	setOptional(params, "secret_token", hook.SecretToken)
+	if len(hook.AllowedUpdates) > 0 {
+		params["allowed_updates"] = hook.AllowedUpdates
+	}
+	if hook.DropPendingUpdates {
+		params["drop_pending_updates"] = true
+	}
+	_, err := call(ctx, botToken, "setWebhook", params)
+	return err
+}
+
+// DeleteWebhook removes the bot's webhook registration.
+func DeleteWebhook(ctx context.Context, botToken string) error {
+	_, err := call(ctx, botToken, "deleteWebhook", map[string]any{})
+	return err
+}
+
+// GetWebhookInfo returns the bot's current webhook registration; url is
+// empty when none is set.
+func GetWebhookInfo(ctx context.Context, botToken string) (map[string]any, error) {
+	return resultObject(call(ctx, botToken, "getWebhookInfo", nil))
+}
+
+// GetMe returns the bot user a token belongs to.
+func GetMe(ctx context.Context, botToken string) (map[string]any, error) {
+	return resultObject(call(ctx, botToken, "getMe", nil))
+}
+
+func setOptional(params map[string]any, key string, value string) {
+	if value != "" {
+		params[key] = value
+	}
+}
+
+func call(ctx context.Context, botToken string, method string, params map[string]any) (any, error) {
+	var body io.Reader
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := newRequest(ctx, botToken, method, body)
+	if err != nil {
+		return nil, err
+	}
+	if params != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return do(req, method)
+}
+
+// upload sends params as multipart fields, with objects JSON-encoded, and
+// content as the file part named field.
+func upload(ctx context.Context, botToken string, method string, params map[string]any, field string, filename string, mimeType string, content []byte) (any, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for key, value := range params {
+		var text string
+		switch v := value.(type) {
+		case string:
+			text = v
+		case int:
+			text = strconv.Itoa(v)
+		case bool:
+			text = strconv.FormatBool(v)
+		default:
+			raw, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal %s: %w", key, err)
+			}
+			text = string(raw)
+		}
+		if err := w.WriteField(key, text); err != nil {
+			return nil, err
+		}
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, field, filename))
+	if mimeType = strings.TrimSpace(mimeType); mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	header.Set("Content-Type", mimeType)
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	req, err := newRequest(ctx, botToken, method, &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return do(req, method)
+}
+
+func newRequest(ctx context.Context, botToken string, method string, body io.Reader) (*http.Request, error) {
+	botToken = strings.TrimSpace(botToken)
+	if botToken == "" {
+		return nil, errors.New("bot token is required")
+	}
+	httpMethod := http.MethodGet
+	if body != nil {
+		httpMethod = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, httpMethod, apiURL(ctx)+"/bot"+botToken+"/"+method, body)
+	if err != nil {
+		// The URL carries the token, so it is kept out of the error.
+		return nil, fmt.Errorf("telegram api error: %s: invalid request", method)
+	}
+	return req, nil
+}
+
+func do(req *http.Request, method string) (any, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("telegram api error: %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope struct {
+		OK          bool   `json:"ok"`
+		Result      any    `json:"result"`
+		Description string `json:"description"`
+		ErrorCode   int    `json:"error_code"`
+		Parameters  struct {
+			RetryAfter int `json:"retry_after"`
+		} `json:"parameters"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("telegram api error: %s status %d", method, resp.StatusCode)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || envelope.ErrorCode == http.StatusTooManyRequests {
+		retryAfter := defaultRetryAfter
+		if envelope.Parameters.RetryAfter > 0 {
+			retryAfter = time.Duration(envelope.Parameters.RetryAfter) * time.Second
+		}
+		return nil, &RateLimitError{Method: method, RetryAfter: retryAfter}
+	}
+	if !envelope.OK || resp.StatusCode >= 300 {
+		code := envelope.ErrorCode
+		if code == 0 {
+			code = resp.StatusCode
+		}
+		description := envelope.Description
+		if description == "" {
+			description = http.StatusText(code)
+		}
+		return nil, &APIError{Method: method, Code: code, Description: description}
+	}
+	return envelope.Result, nil
+}
+
+// resultObject returns an object result as is and wraps other results
+// (true for edits of inline messages and answers) as {"ok": result}.
+func resultObject(result any, err error) (map[string]any, error) {
+	if err != nil {
+		return nil, err
+	}
+	if obj, ok := result.(map[string]any); ok {
+		return obj, nil
+	}
+	return map[string]any{"ok": result}, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgconn"
 
+	"flowcraft-api/internal/adapters/external/endpoints"
 	"flowcraft-api/internal/adapters/external/github"
 	"flowcraft-api/internal/adapters/external/google"
 	"flowcraft-api/internal/config"
@@ -201,6 +202,7 @@ func (h *AuthHandler) oauthStart(c *gin.Context) {
 		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
 		return
 	}
+	ctx := endpoints.Apply(c.Request.Context(), h.cfg, provider, nil)
 	var url string
 	switch provider {
 	case "google":
@@ -209,14 +211,14 @@ func (h *AuthHandler) oauthStart(c *gin.Context) {
 			return
 		}
 		scopes := []string{"openid", "email", "profile"}
-		url = google.BuildAuthURL(h.cfg.GoogleClientID, h.cfg.GoogleRedirectURL, state, scopes, false)
+		url = google.BuildAuthURL(ctx, h.cfg.GoogleClientID, h.cfg.GoogleRedirectURL, state, scopes, false)
 	case "github":
 		if h.cfg.GitHubClientID == "" || h.cfg.GitHubClientSecret == "" || h.cfg.GitHubRedirectURL == "" {
 			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, "github oauth not configured", nil)
 			return
 		}
 		scopes := []string{"read:user", "user:email"}
-		url = github.BuildAuthURL(h.cfg.GitHubClientID, h.cfg.GitHubRedirectURL, state, scopes)
+		url = github.BuildAuthURL(ctx, h.cfg.GitHubClientID, h.cfg.GitHubRedirectURL, state, scopes)
 	default:
 		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, "unsupported provider", nil)
 		return
@@ -250,14 +252,15 @@ func (h *AuthHandler) oauthCallback(c *gin.Context) {
 }
 
 func (h *AuthHandler) handleLoginOAuthCallback(c *gin.Context, provider string, code string, payload utils.OAuthStatePayload) {
+	ctx := endpoints.Apply(c.Request.Context(), h.cfg, provider, nil)
 	switch provider {
 	case "google":
-		token, err := google.ExchangeCode(c.Request.Context(), h.cfg.GoogleClientID, h.cfg.GoogleClientSecret, h.cfg.GoogleRedirectURL, code)
+		token, err := google.ExchangeCode(ctx, h.cfg.GoogleClientID, h.cfg.GoogleClientSecret, h.cfg.GoogleRedirectURL, code)
 		if err != nil {
 			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
 			return
 		}
-		profile, err := google.FetchUserProfile(c.Request.Context(), token.AccessToken)
+		profile, err := google.FetchUserProfile(ctx, token.AccessToken)
 		if err != nil {
 			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
 			return
@@ -270,12 +273,12 @@ func (h *AuthHandler) handleLoginOAuthCallback(c *gin.Context, provider string,
 		_ = h.upsertOAuthAccount(c, "google", profile.ID, user.ID, token.AccessToken, token.RefreshToken, token.Scope, token.ExpiresIn)
 		h.redirectWithSession(c, user, payload.Next)
 	case "github":
-		token, err := github.ExchangeCode(c.Request.Context(), h.cfg.GitHubClientID, h.cfg.GitHubClientSecret, h.cfg.GitHubRedirectURL, code)
+		token, err := github.ExchangeCode(ctx, h.cfg.GitHubClientID, h.cfg.GitHubClientSecret, h.cfg.GitHubRedirectURL, code)
 		if err != nil {
 			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
 			return
 		}
-		profile, err := github.FetchUserProfile(c.Request.Context(), token.AccessToken)
+		profile, err := github.FetchUserProfile(ctx, token.AccessToken)
 		if err != nil {
 			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
 			return
@@ -314,15 +317,16 @@ func (h *AuthHandler) handleCredentialOAuthCallback(c *gin.Context, provider str
 	scope := payload.Scope
 	projectID := payload.ProjectID
 	credName := strings.TrimSpace(payload.Name)
+	ctx := endpoints.Apply(c.Request.Context(), h.cfg, provider, nil)
 
 	switch provider {
 	case "google":
-		token, err := google.ExchangeCode(c.Request.Context(), h.cfg.GoogleClientID, h.cfg.GoogleClientSecret, h.cfg.GoogleRedirectURL, code)
+		token, err := google.ExchangeCode(ctx, h.cfg.GoogleClientID, h.cfg.GoogleClientSecret, h.cfg.GoogleRedirectURL, code)
 		if err != nil {
 			h.redirectWithError(c, payload.ReturnTo, err.Error())
 			return
 		}
-		profile, err := google.FetchUserProfile(c.Request.Context(), token.AccessToken)
+		profile, err := google.FetchUserProfile(ctx, token.AccessToken)
 		if err != nil {
 			h.redirectWithError(c, payload.ReturnTo, err.Error())
 			return
@@ -339,6 +343,10 @@ func (h *AuthHandler) handleCredentialOAuthCallback(c *gin.Context, provider str
 			"scopes":        token.Scope,
 			"account_email": profile.Email,
 		}
+		// Pin the endpoints the token was issued by, in case the server config changes later.
+		if apiURL := strings.TrimSpace(h.cfg.GoogleAPIURL); apiURL != "" {
+			data[endpoints.CredentialKey] = apiURL
+		}
 		enc, err := h.creds.EncryptPayload(data)
 		if err != nil {
 			h.redirectWithError(c, payload.ReturnTo, err.Error())
@@ -358,12 +366,12 @@ func (h *AuthHandler) handleCredentialOAuthCallback(c *gin.Context, provider str
 		}
 		h.redirectWithCredential(c, payload.ReturnTo, created.ID, provider)
 	case "github":
-		token, err := github.ExchangeCode(c.Request.Context(), h.cfg.GitHubClientID, h.cfg.GitHubClientSecret, h.cfg.GitHubRedirectURL, code)
+		token, err := github.ExchangeCode(ctx, h.cfg.GitHubClientID, h.cfg.GitHubClientSecret, h.cfg.GitHubRedirectURL, code)
 		if err != nil {
 			h.redirectWithError(c, payload.ReturnTo, err.Error())
 			return
 		}
-		profile, err := github.FetchUserProfile(c.Request.Context(), token.AccessToken)
+		profile, err := github.FetchUserProfile(ctx, token.AccessToken)
 		if err != nil {
 			h.redirectWithError(c, payload.ReturnTo, err.Error())
 			return
@@ -376,6 +384,9 @@ func (h *AuthHandler) handleCredentialOAuthCallback(c *gin.Context, provider str
 			"scopes":        token.Scope,
 			"account_login": profile.Login,
 		}
+		if apiURL := strings.TrimSpace(h.cfg.GitHubAPIURL); apiURL != "" {
+			data[endpoints.CredentialKey] = apiURL
+		}
 		enc, err := h.creds.EncryptPayload(data)
 		if err != nil {
 			h.redirectWithError(c, payload.ReturnTo, err.Error())
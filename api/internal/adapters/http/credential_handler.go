@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"flowcraft-api/internal/adapters/external/endpoints"
 	"flowcraft-api/internal/adapters/external/github"
 	"flowcraft-api/internal/adapters/external/google"
 	"flowcraft-api/internal/config"
@@ -85,6 +86,10 @@ func (h *CredentialHandler) create(c *gin.Context) {
 		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, "credential data is required", nil)
 		return
 	}
+	if err := endpoints.Check(h.cfg, req.Provider, req.Data); err != nil {
+		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+		return
+	}
 	enc, err := h.creds.EncryptPayload(req.Data)
 	if err != nil {
 		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
@@ -120,6 +125,16 @@ func (h *CredentialHandler) update(c *gin.Context) {
 	var enc string
 	var err error
 	if len(req.Data) > 0 {
+		provider := strings.TrimSpace(req.Provider)
+		if provider == "" {
+			if existing, err := h.creds.Get(c.Request.Context(), user, id); err == nil {
+				provider = existing.Provider
+			}
+		}
+		if err := endpoints.Check(h.cfg, provider, req.Data); err != nil {
+			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+			return
+		}
 		enc, err = h.creds.EncryptPayload(req.Data)
 		if err != nil {
 			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
@@ -207,6 +222,7 @@ func (h *CredentialHandler) oauthStart(c *gin.Context) {
 		return
 	}
 
+	ctx := endpoints.Apply(c.Request.Context(), h.cfg, provider, nil)
 	var url string
 	switch provider {
 	case "google":
@@ -217,16 +233,21 @@ func (h *CredentialHandler) oauthStart(c *gin.Context) {
 		scopes := []string{
 			google.ScopeUserInfoEmail,
 			google.ScopeGmailSend,
+			google.ScopeGmailReadonly,
+			google.ScopeGmailModify,
+			google.ScopeGmailCompose,
 			google.ScopeSheets,
+			google.ScopeDrive,
+			google.ScopeCalendar,
 		}
-		url = google.BuildAuthURL(h.cfg.GoogleClientID, h.cfg.GoogleRedirectURL, state, scopes, true)
+		url = google.BuildAuthURL(ctx, h.cfg.GoogleClientID, h.cfg.GoogleRedirectURL, state, scopes, true)
 	case "github":
 		if h.cfg.GitHubClientID == "" || h.cfg.GitHubClientSecret == "" || h.cfg.GitHubRedirectURL == "" {
 			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, "github oauth not configured", nil)
 			return
 		}
 		scopes := []string{"repo", "read:user", "user:email"}
-		url = github.BuildAuthURL(h.cfg.GitHubClientID, h.cfg.GitHubRedirectURL, state, scopes)
+		url = github.BuildAuthURL(ctx, h.cfg.GitHubClientID, h.cfg.GitHubRedirectURL, state, scopes)
 	default:
 		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, "unsupported provider", nil)
 		return
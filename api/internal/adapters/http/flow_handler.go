@@ -1,6 +1,7 @@
 package httpadapter
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -51,6 +52,8 @@ func (h *FlowHandler) Register(r *gin.RouterGroup) {
 	r.GET("/flows/:id", h.get)
 	r.PUT("/flows/:id", h.update)
 	r.DELETE("/flows/:id", h.delete)
+	r.POST("/flows/:id/activate", h.activate)
+	r.POST("/flows/:id/deactivate", h.deactivate)
 
 	// Alias for n8n-style "workflows"
 	r.POST("/workflows", h.create)
@@ -58,6 +61,8 @@ func (h *FlowHandler) Register(r *gin.RouterGroup) {
 	r.GET("/workflows/:id", h.get)
 	r.PUT("/workflows/:id", h.update)
 	r.DELETE("/workflows/:id", h.delete)
+	r.POST("/workflows/:id/activate", h.activate)
+	r.POST("/workflows/:id/deactivate", h.deactivate)
 }
 
 func (h *FlowHandler) create(c *gin.Context) {
@@ -79,6 +84,10 @@ func (h *FlowHandler) create(c *gin.Context) {
 
 	created, err := h.flows.CreateAccessible(c.Request.Context(), user, flow)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidFlowDefinition) || errors.Is(err, services.ErrInvalidFlowStatus) || errors.Is(err, services.ErrTriggerRegistration) {
+			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+			return
+		}
 		if err == utils.ErrForbidden {
 			utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
 			return
@@ -99,6 +108,7 @@ func (h *FlowHandler) create(c *gin.Context) {
 		ProjectID:      full.ProjectID,
 		Project:        projectResponse(full.Project),
 		Status:         full.Status,
+		StatusReason:   full.StatusReason,
 		Version:        full.Version,
 		DefinitionJSON: full.DefinitionJSON,
 		UpdatedAt:      time.Now().UTC().Format(time.RFC3339),
@@ -130,6 +140,7 @@ func (h *FlowHandler) list(c *gin.Context) {
 			ProjectID:      f.ProjectID,
 			Project:        projectResponse(f.Project),
 			Status:         f.Status,
+			StatusReason:   f.StatusReason,
 			Version:        f.Version,
 			DefinitionJSON: f.DefinitionJSON,
 			UpdatedAt:      f.UpdatedAt.UTC().Format(time.RFC3339),
@@ -163,6 +174,7 @@ func (h *FlowHandler) get(c *gin.Context) {
 		ProjectID:      flow.ProjectID,
 		Project:        projectResponse(flow.Project),
 		Status:         flow.Status,
+		StatusReason:   flow.StatusReason,
 		Version:        flow.Version,
 		DefinitionJSON: flow.DefinitionJSON,
 		UpdatedAt:      flow.UpdatedAt.UTC().Format(time.RFC3339),
@@ -198,8 +210,9 @@ func (h *FlowHandler) update(c *gin.Context) {
 	if req.Description != "" {
 		existing.Description = req.Description
 	}
-	if req.Status != "" {
+	if req.Status != "" && req.Status != existing.Status {
 		existing.Status = req.Status
+		existing.StatusReason = ""
 	}
 	if req.Version != 0 {
 		existing.Version = req.Version
@@ -211,6 +224,10 @@ func (h *FlowHandler) update(c *gin.Context) {
 	existing.UpdatedBy = user.ID
 
 	if err := h.flows.UpdateAccessible(c.Request.Context(), user, *existing); err != nil {
+		if errors.Is(err, services.ErrInvalidFlowDefinition) || errors.Is(err, services.ErrInvalidFlowStatus) {
+			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+			return
+		}
 		if err == utils.ErrNotFound {
 			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "flow not found", nil)
 			return
@@ -230,6 +247,7 @@ func (h *FlowHandler) update(c *gin.Context) {
 		ProjectID:      existing.ProjectID,
 		Project:        projectResponse(existing.Project),
 		Status:         existing.Status,
+		StatusReason:   existing.StatusReason,
 		Version:        existing.Version,
 		DefinitionJSON: existing.DefinitionJSON,
 		UpdatedAt:      time.Now().UTC().Format(time.RFC3339),
@@ -241,6 +259,10 @@ func (h *FlowHandler) delete(c *gin.Context) {
 	id := c.Param("id")
 	user, _ := currentAuthUser(c)
 	if err := h.flows.DeleteAccessible(c.Request.Context(), user, id); err != nil {
+		if errors.Is(err, services.ErrTriggerRegistration) {
+			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+			return
+		}
 		if err == utils.ErrNotFound {
 			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "flow not found", nil)
 			return
@@ -254,3 +276,54 @@ func (h *FlowHandler) delete(c *gin.Context) {
 	}
 	utils.JSONResponse(c, http.StatusOK, gin.H{"id": id})
 }
+
+// activate validates the flow definition and turns its triggers on.
+func (h *FlowHandler) activate(c *gin.Context) {
+	user, _ := currentAuthUser(c)
+	flow, err := h.flows.Activate(c.Request.Context(), user, c.Param("id"))
+	h.writeStatusChange(c, flow, err)
+}
+
+// deactivate turns the flow's triggers off; manual runs keep working.
+func (h *FlowHandler) deactivate(c *gin.Context) {
+	user, _ := currentAuthUser(c)
+	flow, err := h.flows.Deactivate(c.Request.Context(), user, c.Param("id"))
+	h.writeStatusChange(c, flow, err)
+}
+
+func (h *FlowHandler) writeStatusChange(c *gin.Context, flow *domain.Flow, err error) {
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFlowDefinition) || errors.Is(err, services.ErrTriggerRegistration) {
+			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, services.ErrFlowArchived) {
+			utils.JSONError(c, http.StatusConflict, apierrors.ErrConflict, "archived flows cannot be activated or deactivated", nil)
+			return
+		}
+		if err == utils.ErrNotFound {
+			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "flow not found", nil)
+			return
+		}
+		if err == utils.ErrForbidden {
+			utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
+			return
+		}
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	utils.JSONResponse(c, http.StatusOK, dto.FlowResponse{
+		ID:             flow.ID,
+		Name:           flow.Name,
+		Description:    flow.Description,
+		Scope:          flow.Scope,
+		ProjectID:      flow.ProjectID,
+		Project:        projectResponse(flow.Project),
+		Status:         flow.Status,
+		StatusReason:   flow.StatusReason,
+		Version:        flow.Version,
+		DefinitionJSON: flow.DefinitionJSON,
+		UpdatedAt:      flow.UpdatedAt.UTC().Format(time.RFC3339),
+		Owner:          ownerResponse(flow.Owner),
+	})
+}
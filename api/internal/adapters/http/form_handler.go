@@ -0,0 +1,442 @@
+package httpadapter
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"go.temporal.io/sdk/client"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/dto"
+	"flowcraft-api/internal/utils"
+	"flowcraft-api/pkg/apierrors"
+)
+
+// formMaxBodyBytes caps a submission including uploaded files.
+const formMaxBodyBytes = 10 << 20
+
+//go:embed templates/form.html
+var formTemplateFS embed.FS
+
+var formTemplate = template.Must(template.ParseFS(formTemplateFS, "templates/form.html"))
+
+type FormHandler struct {
+	forms          *services.FormService
+	flows          *services.FlowService
+	runs           *services.RunService
+	temporalClient client.Client
+	publicURL      string
+}
+
+func NewFormHandler(
+	forms *services.FormService,
+	flows *services.FlowService,
+	runs *services.RunService,
+	temporalClient client.Client,
+	publicURL string,
+) *FormHandler {
+	return &FormHandler{
+		forms:          forms,
+		flows:          flows,
+		runs:           runs,
+		temporalClient: temporalClient,
+		publicURL:      strings.TrimRight(strings.TrimSpace(publicURL), "/"),
+	}
+}
+
+// RegisterPublic mounts the unauthenticated hosted form endpoints.
+func (h *FormHandler) RegisterPublic(r *gin.RouterGroup) {
+	r.GET("/form/:id", h.show)
+	r.POST("/form/:id", h.submit)
+	r.POST("/form/:id/unlock", h.unlock)
+}
+
+func (h *FormHandler) Register(r *gin.RouterGroup) {
+	r.GET("/flows/:id/form", h.get)
+}
+
+// formPage is the data of templates/form.html.
+type formPage struct {
+	Title       string
+	Description string
+	ButtonLabel string
+	// Locked shows only the password prompt of a protected form.
+	Locked    bool
+	UnlockURL string
+	Fields    []formPageField
+	Error     string
+	Completed bool
+	Message   string
+}
+
+type formPageField struct {
+	domain.FormField
+	InputType string
+	Value     string
+	Error     string
+}
+
+// show renders the form, or returns its definition to clients that ask for JSON.
+// A password protected form shows only the password prompt until unlocked.
+func (h *FormHandler) show(c *gin.Context) {
+	flow, trigger, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+	wantsJSON := c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON
+	if !h.unlocked(c, *flow, *trigger) {
+		if wantsJSON {
+			utils.JSONError(c, http.StatusUnauthorized, apierrors.ErrUnauthorized, "form password required", nil)
+			return
+		}
+		h.render(c, http.StatusOK, lockedFormPage(url.PathEscape(flow.ID)+"/unlock", ""))
+		return
+	}
+	if wantsJSON {
+		utils.JSONResponse(c, http.StatusOK, h.definition(*flow, *trigger))
+		return
+	}
+	h.render(c, http.StatusOK, newFormPage(*trigger, domain.FormSubmission{}, nil, ""))
+}
+
+// unlock checks the password of a protected form and sets the session
+// cookie. Browsers are sent back to the form; JSON clients get the definition.
+func (h *FormHandler) unlock(c *gin.Context) {
+	flow, trigger, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+	wantsJSON := isJSONRequest(c.GetHeader("Content-Type")) || c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON
+
+	sub, err := readFormSubmission(c)
+	if err != nil {
+		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+		return
+	}
+	if !h.checkPassword(c, *flow, *trigger, sub, wantsJSON, lockedFormPage("unlock", "")) {
+		return
+	}
+	if trigger.CredentialID != "" {
+		session, err := h.forms.NewSession(c.Request.Context(), *flow, *trigger)
+		if err != nil {
+			utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+			return
+		}
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(formSessionCookie(flow.ID), session, int(services.FormSessionTTL/time.Second), "/", "", isSecureRequest(c), true)
+	}
+	if wantsJSON {
+		utils.JSONResponse(c, http.StatusOK, h.definition(*flow, *trigger))
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "../"+url.PathEscape(flow.ID))
+}
+
+// submit validates a submission and starts a run with the values. Browsers
+// post multipart or urlencoded forms and get HTML back; clients that post
+// JSON get JSON. Protected forms need the session cookie or the password.
+func (h *FormHandler) submit(c *gin.Context) {
+	flow, trigger, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+	wantsJSON := isJSONRequest(c.GetHeader("Content-Type")) || c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON
+
+	sub, err := readFormSubmission(c)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.JSONError(c, http.StatusRequestEntityTooLarge, apierrors.ErrPayloadTooLarge, "submission too large", nil)
+			return
+		}
+		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+		return
+	}
+
+	if !h.unlocked(c, *flow, *trigger) && !h.checkPassword(c, *flow, *trigger, sub, wantsJSON, lockedFormPage(url.PathEscape(flow.ID)+"/unlock", "")) {
+		return
+	}
+
+	values, problems := services.ValidateSubmission(*trigger, sub)
+	if len(problems) > 0 {
+		if wantsJSON {
+			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, "invalid form submission", problems)
+			return
+		}
+		h.render(c, http.StatusBadRequest, newFormPage(*trigger, sub, problems, "Please correct the highlighted fields."))
+		return
+	}
+
+	run, err := h.forms.CreateRun(c.Request.Context(), *flow, *trigger, values)
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	if _, err := startTriggeredRun(c.Request.Context(), h.temporalClient, h.runs, run); err != nil {
+		utils.JSONError(c, http.StatusBadGateway, apierrors.ErrTemporalUnavailable, err.Error(), nil)
+		return
+	}
+
+	if wantsJSON {
+		resp := dto.FormSubmittedResponse{RunID: run.ID, Status: run.Status, RedirectURL: trigger.RedirectURL}
+		if trigger.RedirectURL == "" {
+			resp.Message = trigger.CompletionMessage
+		}
+		utils.JSONResponse(c, http.StatusAccepted, resp)
+		return
+	}
+	if trigger.RedirectURL != "" {
+		c.Redirect(http.StatusSeeOther, trigger.RedirectURL)
+		return
+	}
+	h.render(c, http.StatusOK, formPage{Title: trigger.Title, Completed: true, Message: trigger.CompletionMessage})
+}
+
+// get returns the form definition and public URL of a flow for the builder.
+func (h *FormHandler) get(c *gin.Context) {
+	user, _ := currentAuthUser(c)
+	flow, err := h.flows.GetAccessible(c.Request.Context(), user, c.Param("id"))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "flow not found", nil)
+			return
+		}
+		if err == utils.ErrForbidden {
+			utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
+			return
+		}
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	triggers := services.FormTriggers(flow.DefinitionJSON)
+	if len(triggers) == 0 {
+		utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "flow has no form trigger", nil)
+		return
+	}
+	utils.JSONResponse(c, http.StatusOK, h.definition(*flow, triggers[0]))
+}
+
+// unlocked reports whether the request may see a form: it is public, or the
+// request carries a valid session cookie.
+func (h *FormHandler) unlocked(c *gin.Context, flow domain.Flow, trigger domain.FormTrigger) bool {
+	if trigger.CredentialID == "" {
+		return true
+	}
+	session, err := c.Cookie(formSessionCookie(flow.ID))
+	if err != nil {
+		return false
+	}
+	return h.forms.CheckSession(c.Request.Context(), flow, trigger, session) == nil
+}
+
+// checkPassword checks the submitted _password. When it is wrong or the
+// client made too many attempts it writes the error, as JSON or as page.
+func (h *FormHandler) checkPassword(c *gin.Context, flow domain.Flow, trigger domain.FormTrigger, sub domain.FormSubmission, wantsJSON bool, page formPage) bool {
+	password := ""
+	if vals := sub.Values[services.FormPasswordField]; len(vals) > 0 {
+		password = vals[0]
+	}
+	err := h.forms.CheckPassword(c.Request.Context(), flow, trigger, password, c.ClientIP())
+	if err == nil {
+		return true
+	}
+	logger := c.MustGet("logger").(zerolog.Logger)
+	status, message := http.StatusUnauthorized, "The password is incorrect."
+	if errors.Is(err, services.ErrFormLocked) {
+		status, message = http.StatusTooManyRequests, "Too many incorrect passwords. Try again later."
+		logger.Warn().Str("flowId", flow.ID).Str("ip", c.ClientIP()).Msg("form password attempts exceeded")
+	} else {
+		logger.Warn().Str("flowId", flow.ID).Str("ip", c.ClientIP()).Msg("form password rejected")
+	}
+	if wantsJSON {
+		code := apierrors.ErrUnauthorized
+		if status == http.StatusTooManyRequests {
+			code = apierrors.ErrTooManyRequests
+		}
+		utils.JSONError(c, status, code, strings.ToLower(strings.TrimSuffix(message, ".")), nil)
+		return false
+	}
+	page.Error = message
+	h.render(c, status, page)
+	return false
+}
+
+func (h *FormHandler) resolve(c *gin.Context) (*domain.Flow, *domain.FormTrigger, bool) {
+	flow, trigger, err := h.forms.Resolve(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "form not found", nil)
+			return nil, nil, false
+		}
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return nil, nil, false
+	}
+	return flow, trigger, true
+}
+
+func (h *FormHandler) definition(flow domain.Flow, trigger domain.FormTrigger) dto.FormDefinitionResponse {
+	fields := make([]dto.FormFieldResponse, 0, len(trigger.Fields))
+	for _, f := range trigger.Fields {
+		fields = append(fields, dto.FormFieldResponse{
+			Name:        f.Name,
+			Label:       f.Label,
+			Type:        f.Type,
+			Required:    f.Required,
+			Options:     f.Options,
+			Placeholder: f.Placeholder,
+		})
+	}
+	return dto.FormDefinitionResponse{
+		FlowID:            flow.ID,
+		NodeID:            trigger.NodeID,
+		Title:             trigger.Title,
+		Description:       trigger.Description,
+		ButtonLabel:       trigger.ButtonLabel,
+		PasswordProtected: trigger.CredentialID != "",
+		Fields:            fields,
+		URL:               h.publicURL + "/api/v1/form/" + url.PathEscape(flow.ID),
+	}
+}
+
+func (h *FormHandler) render(c *gin.Context, status int, page formPage) {
+	var buf bytes.Buffer
+	if err := formTemplate.Execute(&buf, page); err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	c.Header("X-Frame-Options", "DENY")
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// lockedFormPage is the password prompt of a protected form. It shows
+// nothing of the form itself. unlockURL is relative to the current page.
+func lockedFormPage(unlockURL string, message string) formPage {
+	return formPage{
+		Title:       "Password required",
+		ButtonLabel: "Continue",
+		Locked:      true,
+		UnlockURL:   unlockURL,
+		Error:       message,
+	}
+}
+
+func formSessionCookie(flowID string) string {
+	return "flowcraft_form_" + flowID
+}
+
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")
+}
+
+// newFormPage fills the template data; a re-rendered page keeps the
+// submitted values (never files or the password) and shows field problems.
+func newFormPage(trigger domain.FormTrigger, sub domain.FormSubmission, problems map[string]string, message string) formPage {
+	page := formPage{
+		Title:       trigger.Title,
+		Description: trigger.Description,
+		ButtonLabel: trigger.ButtonLabel,
+		Error:       message,
+	}
+	for _, f := range trigger.Fields {
+		field := formPageField{FormField: f, InputType: f.Type, Error: problems[f.Name]}
+		if f.Type != services.FormFieldEmail && f.Type != services.FormFieldNumber {
+			field.InputType = "text"
+		}
+		if vals := sub.Values[f.Name]; len(vals) > 0 && f.Type != services.FormFieldFile {
+			field.Value = vals[0]
+		}
+		page.Fields = append(page.Fields, field)
+	}
+	return page
+}
+
+func isJSONRequest(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// readFormSubmission reads multipart, urlencoded or JSON submissions. JSON
+// bodies are objects of field values; files can only be uploaded as multipart.
+func readFormSubmission(c *gin.Context) (domain.FormSubmission, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, formMaxBodyBytes)
+	sub := domain.FormSubmission{Values: map[string][]string{}, Files: map[string][]domain.FormFile{}}
+
+	if isJSONRequest(c.GetHeader("Content-Type")) {
+		var body map[string]any
+		if err := json.NewDecoder(c.Request.Body).Decode(&body); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return sub, err
+			}
+			return sub, errors.New("invalid JSON body")
+		}
+		for key, value := range body {
+			switch v := value.(type) {
+			case nil:
+			case string:
+				sub.Values[key] = []string{v}
+			case float64, bool:
+				sub.Values[key] = []string{fmt.Sprint(v)}
+			default:
+				return sub, fmt.Errorf("field %q must be a string, number or boolean", key)
+			}
+		}
+		return sub, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if err := c.Request.ParseForm(); err != nil {
+			return sub, unwrapFormError(err)
+		}
+		sub.Values = c.Request.PostForm
+		return sub, nil
+	}
+
+	if err := c.Request.ParseMultipartForm(formMaxBodyBytes); err != nil {
+		return sub, unwrapFormError(err)
+	}
+	defer func() { _ = c.Request.MultipartForm.RemoveAll() }()
+	sub.Values = c.Request.MultipartForm.Value
+	for field, headers := range c.Request.MultipartForm.File {
+		for _, fh := range headers {
+			f, err := fh.Open()
+			if err != nil {
+				return sub, err
+			}
+			data, err := io.ReadAll(f)
+			_ = f.Close()
+			if err != nil {
+				return sub, err
+			}
+			sub.Files[field] = append(sub.Files[field], domain.FormFile{
+				Filename:    fh.Filename,
+				ContentType: fh.Header.Get("Content-Type"),
+				Data:        data,
+			})
+		}
+	}
+	return sub, nil
+}
+
+func unwrapFormError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return err
+	}
+	return errors.New("invalid form body")
+}
@@ -2,6 +2,7 @@ package httpadapter
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -11,25 +12,36 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"flowcraft-api/internal/adapters/external/bannerbear"
+	"flowcraft-api/internal/adapters/external/discord"
+	"flowcraft-api/internal/adapters/external/email"
+	"flowcraft-api/internal/adapters/external/endpoints"
 	"flowcraft-api/internal/adapters/external/gemini"
 	"flowcraft-api/internal/adapters/external/github"
 	"flowcraft-api/internal/adapters/external/google"
 	"flowcraft-api/internal/adapters/external/grok"
+	"flowcraft-api/internal/adapters/external/notion"
 	"flowcraft-api/internal/adapters/external/openai"
+	"flowcraft-api/internal/adapters/external/slack"
+	"flowcraft-api/internal/adapters/external/sqldb"
+	"flowcraft-api/internal/adapters/external/teams"
+	"flowcraft-api/internal/adapters/external/telegram"
 	"flowcraft-api/internal/config"
 	"flowcraft-api/internal/core/domain"
 	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/egress"
 	"flowcraft-api/internal/utils"
 	"flowcraft-api/pkg/apierrors"
 )
 
 type NodeTestHandler struct {
-	creds *services.CredentialService
-	cfg   config.Config
+	creds    *services.CredentialService
+	flows    *services.FlowService
+	projects *services.ProjectService
+	cfg      config.Config
 }
 
-func NewNodeTestHandler(creds *services.CredentialService, cfg config.Config) *NodeTestHandler {
-	return &NodeTestHandler{creds: creds, cfg: cfg}
+func NewNodeTestHandler(creds *services.CredentialService, flows *services.FlowService, projects *services.ProjectService, cfg config.Config) *NodeTestHandler {
+	return &NodeTestHandler{creds: creds, flows: flows, projects: projects, cfg: cfg}
 }
 
 func (h *NodeTestHandler) Register(r *gin.RouterGroup) {
@@ -38,6 +50,7 @@ func (h *NodeTestHandler) Register(r *gin.RouterGroup) {
 
 type nodeTestRequest struct {
 	Kind         string         `json:"kind"`
+	FlowID       string         `json:"flowId,omitempty"`
 	Provider     string         `json:"provider"`
 	Action       string         `json:"action,omitempty"`
 	CredentialID string         `json:"credentialId,omitempty"`
@@ -63,6 +76,7 @@ func (h *NodeTestHandler) test(c *gin.Context) {
 	}
 	user, _ := currentAuthUser(c)
 	req.Kind = strings.TrimSpace(req.Kind)
+	req.FlowID = strings.TrimSpace(req.FlowID)
 	req.Provider = strings.TrimSpace(req.Provider)
 	req.Action = strings.TrimSpace(req.Action)
 	req.CredentialID = strings.TrimSpace(req.CredentialID)
@@ -73,6 +87,21 @@ func (h *NodeTestHandler) test(c *gin.Context) {
 		req.Config = map[string]any{}
 	}
 
+	ctx, err := h.withProjectEgressRules(c.Request.Context(), user, req)
+	if err == utils.ErrNotFound {
+		utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "flow not found", nil)
+		return
+	}
+	if err == utils.ErrForbidden {
+		utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
+		return
+	}
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	c.Request = c.Request.WithContext(ctx)
+
 	var result nodeTestResult
 	switch req.Kind {
 	case "app-action", "agent-tool":
@@ -104,11 +133,12 @@ func (h *NodeTestHandler) testAppAction(c *gin.Context, user domain.AuthUser, re
 		if !strings.EqualFold(credProvider, "google") {
 			return nodeTestResult{Success: false, Message: "expected google credential"}
 		}
-		accessToken, err := h.googleAccessToken(c.Request.Context(), payload)
+		ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
+		accessToken, err := h.googleAccessToken(ctx, payload)
 		if err != nil {
 			return nodeTestResult{Success: false, Message: err.Error()}
 		}
-		profile, err := google.GetProfile(c.Request.Context(), accessToken)
+		profile, err := google.GetProfile(ctx, accessToken)
 		if err != nil {
 			return nodeTestResult{Success: false, Message: err.Error()}
 		}
@@ -132,6 +162,7 @@ func (h *NodeTestHandler) testAppAction(c *gin.Context, user domain.AuthUser, re
 		if !strings.EqualFold(credProvider, "google") {
 			return nodeTestResult{Success: false, Message: "expected google credential"}
 		}
+		ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
 		spreadsheetID := strings.TrimSpace(readAnyString(req.Config["spreadsheetId"]))
 		if spreadsheetID == "" {
 			return nodeTestResult{Success: false, Message: "spreadsheetId is required for Sheets test"}
@@ -140,11 +171,11 @@ func (h *NodeTestHandler) testAppAction(c *gin.Context, user domain.AuthUser, re
 		if sheetName == "" {
 			sheetName = "Sheet1"
 		}
-		accessToken, err := h.googleAccessToken(c.Request.Context(), payload)
+		accessToken, err := h.googleAccessToken(ctx, payload)
 		if err != nil {
 			return nodeTestResult{Success: false, Message: err.Error()}
 		}
-		sheetID, err := google.ResolveSheetID(c.Request.Context(), accessToken, spreadsheetID, sheetName)
+		sheetID, err := google.ResolveSheetID(ctx, accessToken, spreadsheetID, sheetName)
 		if err != nil {
 			return nodeTestResult{Success: false, Message: err.Error()}
 		}
@@ -157,6 +188,52 @@ func (h *NodeTestHandler) testAppAction(c *gin.Context, user domain.AuthUser, re
 				"sheetId":       sheetID,
 			},
 		}
+	case "googleDrive":
+		if req.CredentialID == "" {
+			return nodeTestResult{Success: false, Message: "credentialId is required"}
+		}
+		credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		if !strings.EqualFold(credProvider, "google") {
+			return nodeTestResult{Success: false, Message: "expected google credential"}
+		}
+		ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
+		accessToken, err := h.googleAccessToken(ctx, payload)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		list, err := google.ListFiles(ctx, accessToken, google.DriveListOptions{PageSize: 1})
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		return nodeTestResult{Success: true, Message: "Connected to Google Drive", Preview: list}
+	case "googleCalendar":
+		if req.CredentialID == "" {
+			return nodeTestResult{Success: false, Message: "credentialId is required"}
+		}
+		credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		if !strings.EqualFold(credProvider, "google") {
+			return nodeTestResult{Success: false, Message: "expected google credential"}
+		}
+		ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
+		accessToken, err := h.googleAccessToken(ctx, payload)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		calendars, err := google.ListCalendars(ctx, accessToken)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		return nodeTestResult{
+			Success: true,
+			Message: fmt.Sprintf("Connected to Google Calendar (%v calendars)", calendars["count"]),
+			Preview: calendars,
+		}
 	case "github":
 		if req.CredentialID == "" {
 			return nodeTestResult{Success: false, Message: "credentialId is required"}
@@ -168,6 +245,7 @@ func (h *NodeTestHandler) testAppAction(c *gin.Context, user domain.AuthUser, re
 		if !strings.EqualFold(credProvider, "github") {
 			return nodeTestResult{Success: false, Message: "expected github credential"}
 		}
+		ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
 		accessToken := strings.TrimSpace(readAnyString(payload["access_token"]))
 		if accessToken == "" {
 			return nodeTestResult{Success: false, Message: "github credential missing access token"}
@@ -177,7 +255,7 @@ func (h *NodeTestHandler) testAppAction(c *gin.Context, user domain.AuthUser, re
 		if owner == "" || repo == "" {
 			return nodeTestResult{Success: false, Message: "owner and repo are required for GitHub test"}
 		}
-		repoInfo, err := github.GetRepo(c.Request.Context(), accessToken, owner, repo)
+		repoInfo, err := github.GetRepo(ctx, accessToken, owner, repo)
 		if err != nil {
 			return nodeTestResult{Success: false, Message: err.Error()}
 		}
@@ -217,11 +295,333 @@ func (h *NodeTestHandler) testAppAction(c *gin.Context, user domain.AuthUser, re
 			return nodeTestResult{Success: false, Message: err.Error()}
 		}
 		return nodeTestResult{Success: true, Message: "Connected to Bannerbear", Preview: out}
+	case "slack":
+		return h.testSlack(c, user, req)
+	case "notion":
+		return h.testNotion(c, user, req)
+	case "discord":
+		return h.testDiscord(c, user, req)
+	case "microsoftTeams":
+		return h.testTeams(c, user, req)
+	case "email":
+		return h.testEmail(c, user, req)
+	case "database":
+		return h.testDatabase(c, user, req)
+	case "telegram":
+		return h.testTelegram(c, user, req)
 	default:
 		return nodeTestResult{Success: false, Message: fmt.Sprintf("unsupported provider %q", req.Provider)}
 	}
 }
 
+// testSlack checks the token with auth.test. Read-only actions also return a
+// preview of their result; actions that post or change messages are never
+// performed by a test.
+func (h *NodeTestHandler) testSlack(c *gin.Context, user domain.AuthUser, req nodeTestRequest) nodeTestResult {
+	if req.CredentialID == "" {
+		return nodeTestResult{Success: false, Message: "credentialId is required"}
+	}
+	credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	if !strings.EqualFold(credProvider, "slack") {
+		return nodeTestResult{Success: false, Message: "expected slack credential"}
+	}
+	token := strings.TrimSpace(readAnyString(payload["access_token"]))
+	if token == "" {
+		token = strings.TrimSpace(readAnyString(payload["token"]))
+	}
+	if token == "" {
+		return nodeTestResult{Success: false, Message: "slack credential missing access token"}
+	}
+
+	ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
+	auth, err := slack.AuthTest(ctx, token)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	team := strings.TrimSpace(readAnyString(auth["team"]))
+	msg := "Connected to Slack"
+	if team != "" {
+		msg = fmt.Sprintf("Connected to Slack (%s)", team)
+	}
+
+	switch strings.ToLower(req.Action) {
+	case "slack.listchannels":
+		out, err := slack.ListChannels(ctx, token, slack.ListChannelsOptions{
+			Types:           strings.TrimSpace(readAnyString(req.Config["types"])),
+			ExcludeArchived: true,
+			Limit:           20,
+		})
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		return nodeTestResult{Success: true, Message: msg, Preview: out}
+	case "slack.getuserbyemail":
+		email := strings.TrimSpace(readAnyString(req.Config["email"]))
+		if email == "" {
+			return nodeTestResult{Success: false, Message: "email is required for Slack user lookup test"}
+		}
+		out, err := slack.GetUserByEmail(ctx, token, email)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		return nodeTestResult{Success: true, Message: msg, Preview: out}
+	default:
+		return nodeTestResult{Success: true, Message: msg, Preview: map[string]any{
+			"team":   auth["team"],
+			"teamId": auth["team_id"],
+			"user":   auth["user"],
+			"userId": auth["user_id"],
+		}}
+	}
+}
+
+// testNotion checks the token. With a databaseId it returns the database
+// schema (property name -> type) and validates any configured properties
+// against it, so type mistakes show up before the flow runs.
+func (h *NodeTestHandler) testNotion(c *gin.Context, user domain.AuthUser, req nodeTestRequest) nodeTestResult {
+	if req.CredentialID == "" {
+		return nodeTestResult{Success: false, Message: "credentialId is required"}
+	}
+	credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	if !strings.EqualFold(credProvider, "notion") {
+		return nodeTestResult{Success: false, Message: "expected notion credential"}
+	}
+	token := strings.TrimSpace(readAnyString(payload["access_token"]))
+	if token == "" {
+		token = strings.TrimSpace(readAnyString(payload["token"]))
+	}
+	if token == "" {
+		return nodeTestResult{Success: false, Message: "notion credential missing access token"}
+	}
+
+	ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
+	databaseID := strings.TrimSpace(readAnyString(req.Config["databaseId"]))
+	if databaseID == "" {
+		out, err := notion.Search(ctx, token, notion.SearchOptions{PageSize: 1})
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		return nodeTestResult{Success: true, Message: "Connected to Notion", Preview: out}
+	}
+
+	database, err := notion.GetDatabase(ctx, token, databaseID)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	schema := notion.DatabaseSchema(database)
+	types := map[string]any{}
+	for name, raw := range schema {
+		if prop, ok := raw.(map[string]any); ok {
+			types[name] = prop["type"]
+		}
+	}
+	preview := map[string]any{"databaseId": databaseID, "properties": types}
+
+	if raw, ok := req.Config["properties"]; ok && raw != nil && raw != "" {
+		values, ok := raw.(map[string]any)
+		if s, isString := raw.(string); isString {
+			ok = json.Unmarshal([]byte(s), &values) == nil
+		}
+		if !ok {
+			return nodeTestResult{Success: false, Message: "properties must be a JSON object", Preview: preview}
+		}
+		if _, err := notion.BuildProperties(schema, values); err != nil {
+			return nodeTestResult{Success: false, Message: err.Error(), Preview: preview}
+		}
+	}
+	return nodeTestResult{Success: true, Message: "Connected to Notion database", Preview: preview}
+}
+
+// testDiscord checks the credential's webhook (read without posting) and bot
+// token, whichever are set.
+func (h *NodeTestHandler) testDiscord(c *gin.Context, user domain.AuthUser, req nodeTestRequest) nodeTestResult {
+	if req.CredentialID == "" {
+		return nodeTestResult{Success: false, Message: "credentialId is required"}
+	}
+	credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	if !strings.EqualFold(credProvider, "discord") {
+		return nodeTestResult{Success: false, Message: "expected discord credential"}
+	}
+	webhookURL := strings.TrimSpace(readAnyString(payload["webhook_url"]))
+	botToken := strings.TrimSpace(readAnyString(payload["bot_token"]))
+	if botToken == "" {
+		botToken = strings.TrimSpace(readAnyString(payload["token"]))
+	}
+	if webhookURL == "" && botToken == "" {
+		return nodeTestResult{Success: false, Message: "discord credential needs a webhook_url or bot_token"}
+	}
+
+	ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
+	preview := map[string]any{}
+	var connected []string
+	if webhookURL != "" {
+		hook, err := discord.GetWebhook(ctx, webhookURL)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		preview["webhook"] = map[string]any{"name": hook["name"], "channelId": hook["channel_id"], "guildId": hook["guild_id"]}
+		connected = append(connected, fmt.Sprintf("webhook %s", readAnyString(hook["name"])))
+	}
+	if botToken != "" {
+		bot, err := discord.GetCurrentUser(ctx, botToken)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		preview["bot"] = map[string]any{"id": bot["id"], "username": bot["username"]}
+		connected = append(connected, fmt.Sprintf("bot %s", readAnyString(bot["username"])))
+	}
+	if strings.HasPrefix(strings.ToLower(req.Action), "discord.send") || strings.EqualFold(req.Action, "discord.createThread") {
+		// Posting actions are never performed by a test.
+		preview["note"] = "test does not post messages"
+	}
+	return nodeTestResult{Success: true, Message: fmt.Sprintf("Connected to Discord (%s)", strings.Join(connected, ", ")), Preview: preview}
+}
+
+// testTelegram checks the bot token with getMe and reports where the bot's
+// webhook points. Sending actions are never performed by a test.
+func (h *NodeTestHandler) testTelegram(c *gin.Context, user domain.AuthUser, req nodeTestRequest) nodeTestResult {
+	if req.CredentialID == "" {
+		return nodeTestResult{Success: false, Message: "credentialId is required"}
+	}
+	credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	if !strings.EqualFold(credProvider, "telegram") {
+		return nodeTestResult{Success: false, Message: "expected telegram credential"}
+	}
+	botToken := telegram.BotToken(payload)
+	if botToken == "" {
+		return nodeTestResult{Success: false, Message: "telegram credential has no bot_token"}
+	}
+
+	ctx := endpoints.Apply(c.Request.Context(), h.cfg, credProvider, payload)
+	bot, err := telegram.GetMe(ctx, botToken)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	preview := map[string]any{
+		"bot": map[string]any{"id": bot["id"], "username": bot["username"], "name": bot["first_name"]},
+	}
+	if info, err := telegram.GetWebhookInfo(ctx, botToken); err == nil {
+		preview["webhook"] = map[string]any{
+			"url":                info["url"],
+			"pendingUpdateCount": info["pending_update_count"],
+			"lastErrorMessage":   info["last_error_message"],
+		}
+	}
+	if req.Action != "" {
+		preview["note"] = "test does not send messages"
+	}
+	return nodeTestResult{Success: true, Message: fmt.Sprintf("Connected to Telegram as @%s", readAnyString(bot["username"])), Preview: preview}
+}
+
+// testTeams validates the webhook URL. Teams webhooks cannot be checked
+// without posting, so a test card is only sent when sendTestMessage is set.
+func (h *NodeTestHandler) testTeams(c *gin.Context, user domain.AuthUser, req nodeTestRequest) nodeTestResult {
+	if req.CredentialID == "" {
+		return nodeTestResult{Success: false, Message: "credentialId is required"}
+	}
+	credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	switch strings.ToLower(credProvider) {
+	case "microsoftteams", "microsoft_teams", "teams":
+	default:
+		return nodeTestResult{Success: false, Message: "expected microsoftTeams credential"}
+	}
+	webhookURL, err := teams.ValidateWebhookURL(readAnyString(payload["webhook_url"]))
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	if send, _ := req.Config["sendTestMessage"].(bool); !send {
+		return nodeTestResult{Success: true, Message: "Teams webhook URL is valid (enable sendTestMessage to post a test card)"}
+	}
+	message, _ := teams.CardMessage(teams.TextCard("FlowCraft", "Connection test from FlowCraft."))
+	out, err := teams.Post(c.Request.Context(), webhookURL, message)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	return nodeTestResult{Success: true, Message: "Posted a test card to Microsoft Teams", Preview: out}
+}
+
+// testEmail logs into the smtp or imap server without sending or changing
+// any mail. imap tests also open the configured folder.
+func (h *NodeTestHandler) testEmail(c *gin.Context, user domain.AuthUser, req nodeTestRequest) nodeTestResult {
+	if req.CredentialID == "" {
+		return nodeTestResult{Success: false, Message: "credentialId is required"}
+	}
+	credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	acct := email.AccountFromPayload(payload)
+	switch strings.ToLower(credProvider) {
+	case "smtp":
+		transport, err := acct.SMTPTransport()
+		if err == nil {
+			err = transport.Check(c.Request.Context())
+		}
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		return nodeTestResult{Success: true, Message: fmt.Sprintf("Connected to SMTP server %s", acct.Host)}
+	case "imap":
+		folder, _ := req.Config["folder"].(string)
+		box, err := email.Status(c.Request.Context(), acct, folder)
+		if err != nil {
+			return nodeTestResult{Success: false, Message: err.Error()}
+		}
+		return nodeTestResult{
+			Success: true,
+			Message: fmt.Sprintf("Connected to IMAP server %s (%s: %d messages)", acct.Host, box.Name, box.Exists),
+			Preview: map[string]any{"folder": box.Name, "exists": box.Exists},
+		}
+	default:
+		return nodeTestResult{Success: false, Message: "expected smtp or imap credential"}
+	}
+}
+
+// testDatabase connects and reads the server version; the node's query is
+// never run by a test.
+func (h *NodeTestHandler) testDatabase(c *gin.Context, user domain.AuthUser, req nodeTestRequest) nodeTestResult {
+	if req.CredentialID == "" {
+		return nodeTestResult{Success: false, Message: "credentialId is required"}
+	}
+	credProvider, payload, err := h.loadCredentialPayload(c.Request.Context(), user, req.CredentialID)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	dbConfig, err := sqldb.ConfigFromPayload(credProvider, payload)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	db, err := sqldb.Open(dbConfig)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	defer db.Close()
+	version, err := sqldb.Ping(c.Request.Context(), db)
+	if err != nil {
+		return nodeTestResult{Success: false, Message: err.Error()}
+	}
+	return nodeTestResult{
+		Success: true,
+		Message: fmt.Sprintf("Connected to %s at %s", dbConfig.Dialect, dbConfig.Host),
+		Preview: map[string]any{"version": version, "database": dbConfig.Database, "readOnly": dbConfig.ReadOnly},
+	}
+}
+
 func (h *NodeTestHandler) testAgentModel(c *gin.Context, user domain.AuthUser, req nodeTestRequest) nodeTestResult {
 	provider := strings.ToLower(strings.TrimSpace(req.Provider))
 	model := strings.TrimSpace(req.Model)
@@ -323,6 +723,41 @@ func (h *NodeTestHandler) testAgentModel(c *gin.Context, user domain.AuthUser, r
 	}
 }
 
+// withProjectEgressRules holds the test's requests to the egress rules of the
+// flow's project and of the credential's project, as runs are held to them.
+// A credential the user cannot read is left for the test itself to report.
+func (h *NodeTestHandler) withProjectEgressRules(ctx context.Context, user domain.AuthUser, req nodeTestRequest) (context.Context, error) {
+	if h.projects == nil {
+		return ctx, nil
+	}
+	projectIDs := make([]string, 0, 2)
+	if req.FlowID != "" && h.flows != nil {
+		flow, err := h.flows.GetAccessible(ctx, user, req.FlowID)
+		if err != nil {
+			return ctx, err
+		}
+		projectIDs = append(projectIDs, flow.ProjectID)
+	}
+	if req.CredentialID != "" && h.creds != nil {
+		if cred, err := h.creds.Get(ctx, user, req.CredentialID); err == nil {
+			projectIDs = append(projectIDs, cred.ProjectID)
+		}
+	}
+	seen := make(map[string]bool, len(projectIDs))
+	for _, projectID := range projectIDs {
+		if projectID == "" || seen[projectID] {
+			continue
+		}
+		seen[projectID] = true
+		rules, err := h.projects.EgressRules(ctx, projectID)
+		if err != nil {
+			return ctx, err
+		}
+		ctx = egress.WithRules(ctx, rules)
+	}
+	return ctx, nil
+}
+
 func (h *NodeTestHandler) loadCredentialPayload(ctx context.Context, user domain.AuthUser, credentialID string) (string, map[string]any, error) {
 	if h.creds == nil {
 		return "", nil, errors.New("credential service not configured")
@@ -364,10 +799,28 @@ func normalizeAppProvider(raw string) string {
 		return "gmail"
 	case "google-sheets", "google_sheets", "googlesheets", "googlesheet", "gsheets", "sheets":
 		return "googleSheets"
+	case "google-drive", "google_drive", "googledrive", "drive":
+		return "googleDrive"
+	case "google-calendar", "google_calendar", "googlecalendar", "calendar":
+		return "googleCalendar"
 	case "github":
 		return "github"
 	case "bannerbear", "bananabear":
 		return "bannerbear"
+	case "slack":
+		return "slack"
+	case "notion":
+		return "notion"
+	case "discord":
+		return "discord"
+	case "microsoftteams", "microsoft-teams", "microsoft_teams", "teams":
+		return "microsoftTeams"
+	case "email", "smtp", "imap":
+		return "email"
+	case "database", "postgres", "postgresql", "mysql":
+		return "database"
+	case "telegram":
+		return "telegram"
 	default:
 		return v
 	}
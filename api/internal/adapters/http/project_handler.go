@@ -1,6 +1,7 @@
 package httpadapter
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -28,6 +29,8 @@ func (h *ProjectHandler) Register(r *gin.RouterGroup) {
 	r.GET("/projects/:id", h.get)
 	r.PUT("/projects/:id", h.update)
 	r.DELETE("/projects/:id", h.delete)
+	r.GET("/projects/:id/egress-policy", h.getEgressPolicy)
+	r.PUT("/projects/:id/egress-policy", h.setEgressPolicy)
 	r.GET("/projects/:id/members", h.listMembers)
 	r.POST("/projects/:id/members", h.addMember)
 	r.DELETE("/projects/:id/members/:userId", h.removeMember)
@@ -147,6 +150,65 @@ func (h *ProjectHandler) delete(c *gin.Context) {
 	utils.JSONResponse(c, http.StatusOK, gin.H{"id": id})
 }
 
+func egressPolicyToResponse(policy domain.EgressPolicy) dto.EgressPolicyResponse {
+	out := dto.EgressPolicyResponse{AllowHosts: policy.AllowHosts, DenyHosts: policy.DenyHosts}
+	if out.AllowHosts == nil {
+		out.AllowHosts = []string{}
+	}
+	if out.DenyHosts == nil {
+		out.DenyHosts = []string{}
+	}
+	return out
+}
+
+func (h *ProjectHandler) getEgressPolicy(c *gin.Context) {
+	user, _ := currentAuthUser(c)
+	policy, err := h.projects.GetEgressPolicy(c.Request.Context(), user, c.Param("id"))
+	if err == utils.ErrForbidden {
+		utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
+		return
+	}
+	if err == utils.ErrNotFound {
+		utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "project not found", nil)
+		return
+	}
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	utils.JSONResponse(c, http.StatusOK, egressPolicyToResponse(policy))
+}
+
+func (h *ProjectHandler) setEgressPolicy(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.EgressPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+		return
+	}
+	user, _ := currentAuthUser(c)
+	policy := domain.EgressPolicy{AllowHosts: req.AllowHosts, DenyHosts: req.DenyHosts}
+	if err := h.projects.SetEgressPolicy(c.Request.Context(), user, id, policy); err != nil {
+		switch {
+		case err == utils.ErrForbidden:
+			utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
+		case err == utils.ErrNotFound:
+			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "project not found", nil)
+		case errors.Is(err, services.ErrInvalidEgressPolicy):
+			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+		default:
+			utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		}
+		return
+	}
+	updated, err := h.projects.GetEgressPolicy(c.Request.Context(), user, id)
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	utils.JSONResponse(c, http.StatusOK, egressPolicyToResponse(updated))
+}
+
 func (h *ProjectHandler) listMembers(c *gin.Context) {
 	projectID := c.Param("id")
 	user, _ := currentAuthUser(c)
@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -12,17 +11,33 @@ import (
 	"go.temporal.io/sdk/client"
 
 	"flowcraft-api/internal/adapters/database/postgres"
+	"flowcraft-api/internal/adapters/realtime"
 	"flowcraft-api/internal/adapters/websocket"
 	"flowcraft-api/internal/config"
+	"flowcraft-api/internal/core/domain"
 	"flowcraft-api/internal/core/services"
 	"flowcraft-api/internal/mailer"
 	"flowcraft-api/internal/utils"
 	"flowcraft-api/pkg/apierrors"
 )
 
-func NewRouter(cfg config.Config, db *sql.DB, logger zerolog.Logger, temporalClient client.Client, hub *websocket.Hub) *gin.Engine {
+// NewRouter wires the API. pgListener, when not nil, makes trigger
+// registrations follow flow status changes made by the workers.
+func NewRouter(cfg config.Config, db *sql.DB, logger zerolog.Logger, temporalClient client.Client, hub *websocket.Hub, pgListener *realtime.PostgresListener) *gin.Engine {
 	r := gin.Default()
 
+	// Client IPs feed webhook IP allowlists, so X-Forwarded-For is only
+	// honoured when the request comes through a configured proxy.
+	var trustedProxies []string
+	for _, p := range strings.Split(cfg.TrustedProxies, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			trustedProxies = append(trustedProxies, p)
+		}
+	}
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		logger.Error().Err(err).Msg("invalid TRUSTED_PROXIES")
+	}
+
 	// Simple CORS middleware
 	r.Use(func(c *gin.Context) {
 		origin := c.GetHeader("Origin")
@@ -70,6 +85,7 @@ func NewRouter(cfg config.Config, db *sql.DB, logger zerolog.Logger, temporalCli
 	oauthAccountRepo := postgres.NewOAuthAccountRepository(db)
 	credentialRepo := postgres.NewCredentialRepository(db)
 	variableRepo := postgres.NewVariableRepository(db)
+	scheduleRepo := postgres.NewScheduleRepository(db)
 	projectRepo := postgres.NewProjectRepository(db)
 	projectMemberRepo := postgres.NewProjectMemberRepository(db)
 
@@ -79,6 +95,7 @@ func NewRouter(cfg config.Config, db *sql.DB, logger zerolog.Logger, temporalCli
 	systemSvc := services.NewSystemService(systemRepo)
 	projectSvc := services.NewProjectService(projectRepo, projectMemberRepo, userRepo, flowRepo)
 	variableSvc := services.NewVariableService(variableRepo, projectMemberRepo)
+	scheduleSvc := services.NewScheduleService(scheduleRepo)
 
 	credSvc, err := services.NewCredentialService(credentialRepo, projectMemberRepo, cfg.CredentialsEncKey)
 	if err != nil {
@@ -86,38 +103,31 @@ func NewRouter(cfg config.Config, db *sql.DB, logger zerolog.Logger, temporalCli
 		credSvc = nil
 	}
 
-	parseBool := func(value string) bool {
-		parsed, err := strconv.ParseBool(strings.TrimSpace(value))
-		return err == nil && parsed
+	flowSvc.AddTriggerRegistrar(scheduleSvc)
+	flowSvc.AddTriggerRegistrar(NewTelegramTriggerRegistrar(credSvc, projectSvc, cfg))
+	if pgListener != nil {
+		// Workers deactivate failing flows directly in the database; remove
+		// their registrations (e.g. a Telegram webhook) here.
+		pgListener.OnFlowUpdate(func(ctx context.Context, update domain.FlowUpdateEvent) {
+			if update.Status != services.FlowStatusInactive {
+				return
+			}
+			if err := flowSvc.SyncDeactivated(ctx, update.FlowID); err != nil {
+				logger.Error().Err(err).Str("flowId", update.FlowID).Msg("sync triggers of deactivated flow failed")
+			}
+		})
 	}
-	mail := (*mailer.Mailer)(nil)
-	if strings.TrimSpace(cfg.SMTPHost) != "" && strings.TrimSpace(cfg.SMTPFrom) != "" {
-		port, err := strconv.Atoi(strings.TrimSpace(cfg.SMTPPort))
-		if err != nil || port == 0 {
-			port = 587
-		}
-		cfgMailer := mailer.Config{
-			Host:        cfg.SMTPHost,
-			Port:        port,
-			Username:    cfg.SMTPUser,
-			Password:    cfg.SMTPPass,
-			From:        cfg.SMTPFrom,
-			UseTLS:      parseBool(cfg.SMTPUseTLS),
-			UseStartTLS: parseBool(cfg.SMTPUseStartTLS),
-			AppBaseURL:  cfg.AppBaseURL,
-			SupportURL:  cfg.SMTPSupportURL,
-		}
-		instance, err := mailer.New(cfgMailer)
-		if err != nil {
-			logger.Error().Err(err).Msg("failed to initialize mailer")
-		} else {
-			mail = instance
-		}
+
+	mail, err := mailer.FromConfig(cfg)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to initialize mailer")
+		mail = nil
 	}
 
 	authSvc := services.NewAuthService(userRepo, sessionRepo, passwordResetRepo, mail, cfg.AppBaseURL)
 
 	flowHandler := NewFlowHandler(flowSvc)
+	scheduleHandler := NewScheduleHandler(flowSvc, scheduleSvc)
 	runHandler := NewRunHandler(runSvc, flowSvc, runStepSvc, temporalClient)
 	systemHandler := NewSystemHandler(systemSvc)
 	authHandler := NewAuthHandler(authSvc, userRepo, oauthAccountRepo, credSvc, cfg)
@@ -127,9 +137,15 @@ func NewRouter(cfg config.Config, db *sql.DB, logger zerolog.Logger, temporalCli
 	if credSvc != nil {
 		credentialHandler = NewCredentialHandler(credSvc, cfg)
 	}
-	nodeTestHandler := NewNodeTestHandler(credSvc, cfg)
+	nodeTestHandler := NewNodeTestHandler(credSvc, flowSvc, projectSvc, cfg)
+	webhookSvc := services.NewWebhookService(flowRepo, runRepo, runStepRepo, credSvc)
+	webhookHandler := NewWebhookHandler(webhookSvc, flowSvc, runSvc, temporalClient, hub, cfg.PublicAPIURL)
+	formSvc := services.NewFormService(flowRepo, runRepo, credSvc)
+	formHandler := NewFormHandler(formSvc, flowSvc, runSvc, temporalClient, cfg.PublicAPIURL)
 
 	authHandler.Register(apiPublic)
+	webhookHandler.RegisterPublic(apiPublic)
+	formHandler.RegisterPublic(apiPublic)
 
 	apiProtected := r.Group("/api/v1")
 	apiProtected.Use(func(c *gin.Context) {
@@ -165,6 +181,7 @@ func NewRouter(cfg config.Config, db *sql.DB, logger zerolog.Logger, temporalCli
 	})
 
 	flowHandler.Register(apiProtected)
+	scheduleHandler.Register(apiProtected)
 	runHandler.Register(apiProtected)
 	systemHandler.Register(apiProtected)
 	projectHandler.Register(apiProtected)
@@ -173,6 +190,8 @@ func NewRouter(cfg config.Config, db *sql.DB, logger zerolog.Logger, temporalCli
 	}
 	variableHandler.Register(apiProtected)
 	nodeTestHandler.Register(apiProtected)
+	webhookHandler.Register(apiProtected)
+	formHandler.Register(apiProtected)
 	apiProtected.POST("/flows/:id/run", runHandler.CreateForFlow)
 	apiProtected.POST("/workflows/:id/run", runHandler.CreateForFlow)
 
@@ -0,0 +1,141 @@
+package httpadapter
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/dto"
+	"flowcraft-api/internal/utils"
+	"flowcraft-api/pkg/apierrors"
+)
+
+type ScheduleHandler struct {
+	flows     *services.FlowService
+	schedules *services.ScheduleService
+}
+
+func NewScheduleHandler(flows *services.FlowService, schedules *services.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{flows: flows, schedules: schedules}
+}
+
+func (h *ScheduleHandler) Register(r *gin.RouterGroup) {
+	r.GET("/flows/:id/schedule", h.get)
+	r.POST("/flows/:id/schedule/pause", h.pause)
+	r.POST("/flows/:id/schedule/resume", h.resume)
+}
+
+// get previews the next fire times of every cron trigger in the flow.
+// ?count sets how many times per trigger (default 5, max 50).
+func (h *ScheduleHandler) get(c *gin.Context) {
+	count := 5
+	if raw := c.Query("count"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 50 {
+			utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, "count must be between 1 and 50", nil)
+			return
+		}
+		count = n
+	}
+
+	flow, ok := h.accessibleFlow(c)
+	if !ok {
+		return
+	}
+	state, err := h.schedules.State(c.Request.Context(), flow.ID)
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	utils.JSONResponse(c, http.StatusOK, scheduleResponse(*flow, state, count))
+}
+
+func (h *ScheduleHandler) pause(c *gin.Context) {
+	flow, ok := h.accessibleFlow(c)
+	if !ok {
+		return
+	}
+	user, _ := currentAuthUser(c)
+	state, err := h.schedules.Pause(c.Request.Context(), flow.ID, user.ID)
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	utils.JSONResponse(c, http.StatusOK, scheduleResponse(*flow, state, 5))
+}
+
+func (h *ScheduleHandler) resume(c *gin.Context) {
+	flow, ok := h.accessibleFlow(c)
+	if !ok {
+		return
+	}
+	state, err := h.schedules.Resume(c.Request.Context(), flow.ID)
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	utils.JSONResponse(c, http.StatusOK, scheduleResponse(*flow, state, 5))
+}
+
+func (h *ScheduleHandler) accessibleFlow(c *gin.Context) (*domain.Flow, bool) {
+	user, _ := currentAuthUser(c)
+	flow, err := h.flows.GetAccessible(c.Request.Context(), user, c.Param("id"))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "flow not found", nil)
+			return nil, false
+		}
+		if err == utils.ErrForbidden {
+			utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
+			return nil, false
+		}
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return nil, false
+	}
+	return flow, true
+}
+
+func scheduleResponse(flow domain.Flow, state domain.ScheduleState, count int) dto.FlowScheduleResponse {
+	resp := dto.FlowScheduleResponse{
+		FlowID: flow.ID,
+		Active: services.FlowActive(flow),
+		Paused: state.PausedAt != nil,
+	}
+	if state.PausedAt != nil {
+		resp.PausedAt = state.PausedAt.UTC().Format(time.RFC3339)
+	}
+
+	now := time.Now()
+	triggers := services.CronTriggers(flow.DefinitionJSON)
+	resp.Triggers = make([]dto.CronTriggerScheduleItem, 0, len(triggers))
+	for _, trigger := range triggers {
+		item := dto.CronTriggerScheduleItem{
+			NodeID:        trigger.NodeID,
+			Expression:    trigger.Expression,
+			Timezone:      trigger.Timezone,
+			MisfirePolicy: trigger.MisfirePolicy,
+			NextRuns:      []string{},
+		}
+		if item.Timezone == "" {
+			item.Timezone = "UTC"
+		}
+		if last, ok := state.LastFiredAt[trigger.NodeID]; ok {
+			item.LastFiredAt = last.UTC().Format(time.RFC3339)
+		}
+		schedule, err := services.ParseCronSchedule(trigger)
+		if err != nil {
+			item.Error = err.Error()
+			resp.Triggers = append(resp.Triggers, item)
+			continue
+		}
+		for _, next := range services.NextFireTimes(schedule, now, count) {
+			item.NextRuns = append(item.NextRuns, next.Format(time.RFC3339))
+		}
+		resp.Triggers = append(resp.Triggers, item)
+	}
+	return resp
+}
@@ -0,0 +1,147 @@
+package httpadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
+	"flowcraft-api/internal/adapters/external/telegram"
+	"flowcraft-api/internal/config"
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/egress"
+)
+
+// TelegramTriggerRegistrar points a bot's webhook at the production URL of
+// each telegramTrigger while its flow is active, and removes it when the
+// trigger stops. A bot has one webhook, so the last activated flow wins.
+type TelegramTriggerRegistrar struct {
+	creds     *services.CredentialService
+	projects  *services.ProjectService
+	cfg       config.Config
+	publicURL string
+}
+
+// NewTelegramTriggerRegistrar wires the registrar. creds may be nil when
+// credential encryption is not configured; activating a Telegram trigger then fails.
+// Calls for a project flow are held to the project's egress rules.
+func NewTelegramTriggerRegistrar(creds *services.CredentialService, projects *services.ProjectService, cfg config.Config) *TelegramTriggerRegistrar {
+	return &TelegramTriggerRegistrar{
+		creds:     creds,
+		projects:  projects,
+		cfg:       cfg,
+		publicURL: strings.TrimRight(strings.TrimSpace(cfg.PublicAPIURL), "/"),
+	}
+}
+
+// SyncTriggers implements ports.TriggerRegistrar.
+func (r *TelegramTriggerRegistrar) SyncTriggers(ctx context.Context, before *domain.Flow, after *domain.Flow) error {
+	live := map[string]domain.WebhookTrigger{}
+	if after != nil && services.FlowActive(*after) {
+		for _, trigger := range services.TelegramTriggers(after.DefinitionJSON) {
+			live[trigger.NodeID] = trigger
+		}
+	}
+	if before != nil {
+		for _, trigger := range services.TelegramTriggers(before.DefinitionJSON) {
+			next, ok := live[trigger.NodeID]
+			if ok && credentialIDOf(next) == credentialIDOf(trigger) {
+				continue
+			}
+			if err := r.unregister(ctx, *before, trigger); err != nil {
+				return err
+			}
+		}
+	}
+	for _, trigger := range live {
+		if err := r.register(ctx, *after, trigger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *TelegramTriggerRegistrar) register(ctx context.Context, flow domain.Flow, trigger domain.WebhookTrigger) error {
+	credentialID := credentialIDOf(trigger)
+	if credentialID == "" {
+		return fmt.Errorf("telegram trigger %s: credentialId is required", trigger.NodeID)
+	}
+	ctx, botToken, err := r.botToken(ctx, flow, credentialID)
+	if err != nil {
+		return fmt.Errorf("telegram trigger %s: %w", trigger.NodeID, err)
+	}
+	err = telegram.SetWebhook(ctx, botToken, telegram.Webhook{
+		URL:            r.webhookURL(flow, trigger),
+		SecretToken:    services.TelegramSecretToken(botToken, flow.ID, trigger.NodeID),
+		AllowedUpdates: services.TelegramAllowedUpdates(trigger),
+	})
+	if err != nil {
+		return fmt.Errorf("telegram trigger %s: %w", trigger.NodeID, err)
+	}
+	return nil
+}
+
+// unregister removes the bot's webhook when it still points at trigger. A
+// credential that is gone or a revoked token leaves nothing to remove.
+func (r *TelegramTriggerRegistrar) unregister(ctx context.Context, flow domain.Flow, trigger domain.WebhookTrigger) error {
+	credentialID := credentialIDOf(trigger)
+	if credentialID == "" {
+		return nil
+	}
+	ctx, botToken, err := r.botToken(ctx, flow, credentialID)
+	if err != nil {
+		return nil
+	}
+	info, err := telegram.GetWebhookInfo(ctx, botToken)
+	if err == nil && readAnyString(info["url"]) != r.webhookURL(flow, trigger) {
+		return nil
+	}
+	if err == nil {
+		err = telegram.DeleteWebhook(ctx, botToken)
+	}
+	if err != nil && !telegram.IsUnauthorized(err) {
+		return fmt.Errorf("telegram trigger %s: %w", trigger.NodeID, err)
+	}
+	return nil
+}
+
+func (r *TelegramTriggerRegistrar) botToken(ctx context.Context, flow domain.Flow, credentialID string) (context.Context, string, error) {
+	if r.creds == nil {
+		return ctx, "", errors.New("credential encryption is not configured")
+	}
+	cred, err := r.creds.GetForFlow(ctx, flow, credentialID)
+	if err != nil {
+		return ctx, "", fmt.Errorf("credential %s unavailable: %w", credentialID, err)
+	}
+	if !strings.EqualFold(cred.Provider, "telegram") {
+		return ctx, "", fmt.Errorf("expected telegram credential, got %s", cred.Provider)
+	}
+	var payload map[string]any
+	if err := r.creds.DecryptPayload(cred.DataEncrypted, &payload); err != nil {
+		return ctx, "", fmt.Errorf("credential %s could not be decrypted: %w", credentialID, err)
+	}
+	botToken := telegram.BotToken(payload)
+	if botToken == "" {
+		return ctx, "", errors.New("credential has no bot_token")
+	}
+	if r.projects != nil && flow.ProjectID != "" {
+		rules, err := r.projects.EgressRules(ctx, flow.ProjectID)
+		if err != nil {
+			return ctx, "", err
+		}
+		ctx = egress.WithRules(ctx, rules)
+	}
+	return endpoints.Apply(ctx, r.cfg, cred.Provider, payload), botToken, nil
+}
+
+func (r *TelegramTriggerRegistrar) webhookURL(flow domain.Flow, trigger domain.WebhookTrigger) string {
+	return r.publicURL + "/api/v1/webhook/" + url.PathEscape(flow.ID) + "/" + trigger.Path
+}
+
+func credentialIDOf(trigger domain.WebhookTrigger) string {
+	id, _ := trigger.Config["credentialId"].(string)
+	return strings.TrimSpace(id)
+}
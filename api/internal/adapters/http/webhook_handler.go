@@ -0,0 +1,449 @@
+package httpadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"go.temporal.io/sdk/client"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/dto"
+	flowtemporal "flowcraft-api/internal/temporal"
+	"flowcraft-api/internal/utils"
+	"flowcraft-api/pkg/apierrors"
+)
+
+const (
+	webhookMaxBodyBytes = 1 << 20
+	webhookListenTTL    = 2 * time.Minute
+)
+
+// webhookListeners tracks flows whose builder is waiting for a test event.
+// State is per API instance; the test URL must hit the instance that was asked to listen.
+type webhookListeners struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+}
+
+func (l *webhookListeners) listen(flowID string, ttl time.Duration) time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	expiresAt := time.Now().Add(ttl)
+	l.expires[flowID] = expiresAt
+	return expiresAt
+}
+
+func (l *webhookListeners) stop(flowID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.expires, flowID)
+}
+
+// consume reports whether flowID is listening and stops listening, so one
+// "listen" click captures exactly one test event.
+func (l *webhookListeners) consume(flowID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	expiresAt, ok := l.expires[flowID]
+	if !ok {
+		return false
+	}
+	delete(l.expires, flowID)
+	return time.Now().Before(expiresAt)
+}
+
+type WebhookHandler struct {
+	webhooks       *services.WebhookService
+	flows          *services.FlowService
+	runs           *services.RunService
+	temporalClient client.Client
+	realtime       ports.RealtimeService
+	publicURL      string
+	listeners      *webhookListeners
+}
+
+func NewWebhookHandler(
+	webhooks *services.WebhookService,
+	flows *services.FlowService,
+	runs *services.RunService,
+	temporalClient client.Client,
+	realtime ports.RealtimeService,
+	publicURL string,
+) *WebhookHandler {
+	return &WebhookHandler{
+		webhooks:       webhooks,
+		flows:          flows,
+		runs:           runs,
+		temporalClient: temporalClient,
+		realtime:       realtime,
+		publicURL:      strings.TrimRight(strings.TrimSpace(publicURL), "/"),
+		listeners:      &webhookListeners{expires: map[string]time.Time{}},
+	}
+}
+
+// RegisterPublic mounts the unauthenticated inbound webhook endpoints.
+func (h *WebhookHandler) RegisterPublic(r *gin.RouterGroup) {
+	r.Any("/webhook/:flowId/*path", h.production)
+	r.Any("/webhook-test/:flowId/*path", h.test)
+}
+
+func (h *WebhookHandler) Register(r *gin.RouterGroup) {
+	r.GET("/flows/:id/webhooks", h.list)
+	r.POST("/flows/:id/webhooks/listen", h.listen)
+	r.DELETE("/flows/:id/webhooks/listen", h.stopListening)
+}
+
+func (h *WebhookHandler) production(c *gin.Context) {
+	h.handle(c, services.WebhookModeProduction)
+}
+
+func (h *WebhookHandler) test(c *gin.Context) {
+	h.handle(c, services.WebhookModeTest)
+}
+
+func (h *WebhookHandler) handle(c *gin.Context, mode string) {
+	flowID := c.Param("flowId")
+	path := c.Param("path")
+
+	flow, trigger, err := h.webhooks.Resolve(c.Request.Context(), flowID, path, c.Request.Method, mode)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "webhook not found", nil)
+			return
+		}
+		if err == services.ErrWebhookMethodNotAllowed {
+			utils.JSONError(c, http.StatusMethodNotAllowed, apierrors.ErrMethodNotAllowed, "method not allowed for this webhook", nil)
+			return
+		}
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+
+	req, rawBody, err := captureWebhookRequest(c, mode, services.NormalizeWebhookPath(path))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.JSONError(c, http.StatusRequestEntityTooLarge, apierrors.ErrPayloadTooLarge, "request body too large", nil)
+			return
+		}
+		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, err.Error(), nil)
+		return
+	}
+
+	if err := h.webhooks.Authenticate(c.Request.Context(), *flow, *trigger, req, rawBody); err != nil {
+		var rejection *services.WebhookRejection
+		if !errors.As(err, &rejection) {
+			utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+			return
+		}
+		logger := c.MustGet("logger").(zerolog.Logger)
+		logger.Warn().
+			Str("flowId", flow.ID).
+			Str("nodeId", trigger.NodeID).
+			Str("mode", mode).
+			Str("ip", req.ClientIP).
+			Str("reason", rejection.Reason).
+			Msg("webhook call rejected")
+		if errors.Is(err, services.ErrWebhookIPNotAllowed) {
+			utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
+			return
+		}
+		utils.JSONError(c, http.StatusUnauthorized, apierrors.ErrUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	if mode == services.WebhookModeTest && !h.listeners.consume(flow.ID) {
+		utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "test webhook is not listening; click \"Listen for test event\" in the builder first", nil)
+		return
+	}
+
+	run, err := h.webhooks.CreateRun(c.Request.Context(), *flow, *trigger, req)
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	we, err := h.startRun(c.Request.Context(), run)
+	if err != nil {
+		utils.JSONError(c, http.StatusBadGateway, apierrors.ErrTemporalUnavailable, err.Error(), nil)
+		return
+	}
+
+	if mode == services.WebhookModeTest && h.realtime != nil {
+		h.realtime.Broadcast("webhook_test_event", map[string]any{
+			"flowId": flow.ID,
+			"nodeId": trigger.NodeID,
+			"runId":  run.ID,
+		})
+	}
+
+	responseMode := services.ResponseMode(*trigger)
+	if responseMode == services.WebhookResponseImmediately {
+		utils.JSONResponse(c, http.StatusAccepted, dto.WebhookAcceptedResponse{RunID: run.ID, Status: run.Status, Mode: mode})
+		return
+	}
+	h.respondWhenFinished(c, we, run, mode, responseMode, services.ResponseTimeout(*trigger))
+}
+
+// respondWhenFinished holds the caller until the run completes and answers
+// with the response built from its steps. A run that outlives timeout keeps
+// going and the caller gets the usual 202.
+func (h *WebhookHandler) respondWhenFinished(c *gin.Context, we client.WorkflowRun, run domain.Run, mode string, responseMode string, timeout time.Duration) {
+	waitCtx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+
+	runErr := we.Get(waitCtx, nil)
+	if runErr != nil && waitCtx.Err() != nil {
+		utils.JSONResponse(c, http.StatusAccepted, dto.WebhookAcceptedResponse{RunID: run.ID, Status: "running", Mode: mode})
+		return
+	}
+
+	resp, ok, err := h.webhooks.BuildResponse(c.Request.Context(), run.ID, responseMode)
+	if err != nil {
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return
+	}
+	if !ok {
+		if runErr != nil {
+			utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, "flow execution failed", nil)
+			return
+		}
+		utils.JSONResponse(c, http.StatusOK, dto.WebhookAcceptedResponse{RunID: run.ID, Status: "success", Mode: mode})
+		return
+	}
+	writeWebhookResponse(c, resp)
+}
+
+// blockedWebhookResponseHeaders are managed by the HTTP server and cannot be
+// set from a flow.
+var blockedWebhookResponseHeaders = map[string]struct{}{
+	"content-length":    {},
+	"transfer-encoding": {},
+	"connection":        {},
+}
+
+func writeWebhookResponse(c *gin.Context, resp domain.WebhookResponse) {
+	for key, value := range resp.Headers {
+		if _, blocked := blockedWebhookResponseHeaders[strings.ToLower(key)]; blocked {
+			continue
+		}
+		c.Header(key, value)
+	}
+	switch body := resp.Body.(type) {
+	case nil:
+		c.Status(resp.StatusCode)
+	case string:
+		contentType := c.Writer.Header().Get("Content-Type")
+		if contentType == "" {
+			contentType = "text/plain; charset=utf-8"
+		}
+		c.Data(resp.StatusCode, contentType, []byte(body))
+	default:
+		c.JSON(resp.StatusCode, body)
+	}
+}
+
+func (h *WebhookHandler) startRun(ctx context.Context, run domain.Run) (client.WorkflowRun, error) {
+	return startTriggeredRun(ctx, h.temporalClient, h.runs, run)
+}
+
+// startTriggeredRun starts the workflow of a run recorded by a public
+// trigger (webhook or form) and marks the run failed if Temporal refuses it.
+func startTriggeredRun(ctx context.Context, temporalClient client.Client, runs *services.RunService, run domain.Run) (client.WorkflowRun, error) {
+	startCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	we, err := temporalClient.ExecuteWorkflow(startCtx, client.StartWorkflowOptions{
+		ID:        run.TemporalWorkflow,
+		TaskQueue: flowtemporal.TaskQueue,
+	}, flowtemporal.RunFlowWorkflow, flowtemporal.RunFlowInput{FlowID: run.FlowID, RunID: run.ID})
+	if err != nil {
+		_ = runs.UpdateStatus(ctx, run.ID, "failed", "failed to start workflow: "+err.Error())
+		return nil, err
+	}
+	return we, nil
+}
+
+func (h *WebhookHandler) list(c *gin.Context) {
+	flow, ok := h.accessibleFlow(c)
+	if !ok {
+		return
+	}
+	utils.JSONResponse(c, http.StatusOK, h.endpoints(*flow))
+}
+
+func (h *WebhookHandler) listen(c *gin.Context) {
+	flow, ok := h.accessibleFlow(c)
+	if !ok {
+		return
+	}
+	endpoints := h.endpoints(*flow)
+	if len(endpoints) == 0 {
+		utils.JSONError(c, http.StatusBadRequest, apierrors.ErrBadRequest, "flow has no webhook trigger", nil)
+		return
+	}
+	expiresAt := h.listeners.listen(flow.ID, webhookListenTTL)
+	utils.JSONResponse(c, http.StatusOK, dto.WebhookListenResponse{
+		FlowID:    flow.ID,
+		ExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+		Webhooks:  endpoints,
+	})
+}
+
+func (h *WebhookHandler) stopListening(c *gin.Context) {
+	flow, ok := h.accessibleFlow(c)
+	if !ok {
+		return
+	}
+	h.listeners.stop(flow.ID)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) accessibleFlow(c *gin.Context) (*domain.Flow, bool) {
+	user, _ := currentAuthUser(c)
+	flow, err := h.flows.GetAccessible(c.Request.Context(), user, c.Param("id"))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.JSONError(c, http.StatusNotFound, apierrors.ErrNotFound, "flow not found", nil)
+			return nil, false
+		}
+		if err == utils.ErrForbidden {
+			utils.JSONError(c, http.StatusForbidden, apierrors.ErrForbidden, "forbidden", nil)
+			return nil, false
+		}
+		utils.JSONError(c, http.StatusInternalServerError, apierrors.ErrInternalServer, err.Error(), nil)
+		return nil, false
+	}
+	return flow, true
+}
+
+func (h *WebhookHandler) endpoints(flow domain.Flow) []dto.WebhookEndpointResponse {
+	triggers := services.WebhookTriggers(flow.DefinitionJSON)
+	out := make([]dto.WebhookEndpointResponse, 0, len(triggers))
+	for _, t := range triggers {
+		method := t.Method
+		if method == "" {
+			method = "ANY"
+		}
+		suffix := url.PathEscape(flow.ID) + "/" + t.Path
+		out = append(out, dto.WebhookEndpointResponse{
+			NodeID:        t.NodeID,
+			NodeType:      t.NodeType,
+			Method:        method,
+			Path:          t.Path,
+			TestURL:       h.publicURL + "/api/v1/webhook-test/" + suffix,
+			ProductionURL: h.publicURL + "/api/v1/webhook/" + suffix,
+		})
+	}
+	return out
+}
+
+// captureWebhookRequest reads the inbound request into the trigger payload.
+// JSON bodies are decoded, form bodies become field maps and anything else is
+// kept as a string. The raw body is returned for signature checks.
+func captureWebhookRequest(c *gin.Context, mode string, path string) (domain.WebhookRequest, []byte, error) {
+	raw, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, webhookMaxBodyBytes))
+	if err != nil {
+		return domain.WebhookRequest{}, nil, err
+	}
+
+	headers := make(map[string]string, len(c.Request.Header))
+	for key, values := range c.Request.Header {
+		headers[strings.ToLower(key)] = strings.Join(values, ", ")
+	}
+
+	req := domain.WebhookRequest{
+		Mode:       mode,
+		Method:     c.Request.Method,
+		Path:       path,
+		Headers:    headers,
+		Query:      flattenValues(c.Request.URL.Query()),
+		ClientIP:   c.ClientIP(),
+		ReceivedAt: time.Now().UTC(),
+	}
+
+	body, err := decodeWebhookBody(c.GetHeader("Content-Type"), raw)
+	if err != nil {
+		return domain.WebhookRequest{}, nil, err
+	}
+	req.Body = body
+	return req, raw, nil
+}
+
+func decodeWebhookBody(contentType string, raw []byte) (any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var body any
+		if err := json.Unmarshal(raw, &body); err != nil {
+			return nil, errors.New("invalid JSON body")
+		}
+		return body, nil
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(raw))
+		if err != nil {
+			return nil, errors.New("invalid form body")
+		}
+		return flattenValues(values), nil
+	case mediaType == "multipart/form-data":
+		return decodeMultipartBody(params["boundary"], raw)
+	default:
+		return string(raw), nil
+	}
+}
+
+func decodeMultipartBody(boundary string, raw []byte) (any, error) {
+	if boundary == "" {
+		return nil, errors.New("multipart body is missing a boundary")
+	}
+	form, err := multipart.NewReader(bytes.NewReader(raw), boundary).ReadForm(webhookMaxBodyBytes)
+	if err != nil {
+		return nil, errors.New("invalid multipart body")
+	}
+	defer func() { _ = form.RemoveAll() }()
+
+	out := flattenValues(form.Value)
+	for field, headers := range form.File {
+		files := make([]map[string]any, 0, len(headers))
+		for _, fh := range headers {
+			files = append(files, map[string]any{
+				"filename":    fh.Filename,
+				"size":        fh.Size,
+				"contentType": fh.Header.Get("Content-Type"),
+			})
+		}
+		out[field] = files
+	}
+	return out, nil
+}
+
+// flattenValues turns single-valued entries into strings and keeps repeated keys as lists.
+func flattenValues(values map[string][]string) map[string]any {
+	out := make(map[string]any, len(values))
+	for key, vals := range values {
+		if len(vals) == 1 {
+			out[key] = vals[0]
+			continue
+		}
+		list := make([]string, len(vals))
+		copy(list, vals)
+		out[key] = list
+	}
+	return out
+}
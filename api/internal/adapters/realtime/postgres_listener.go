@@ -13,12 +13,16 @@ import (
 	"github.com/rs/zerolog"
 )
 
+const flowUpdateHandlerTimeout = 30 * time.Second
+
 // PostgresListener subscribes to Postgres NOTIFY channels and forwards
 // events to WebSocket clients via the RealtimeService abstraction.
 type PostgresListener struct {
 	connConfig *pgx.ConnConfig
 	realtime   ports.RealtimeService // I2: depend on interface, not concrete *Hub
 	logger     zerolog.Logger
+	// flowUpdateHandlers follow status changes the API did not make itself.
+	flowUpdateHandlers []func(ctx context.Context, update domain.FlowUpdateEvent)
 }
 
 // NewPostgresListener creates a listener that broadcasts to realtime.
@@ -35,6 +39,13 @@ func NewPostgresListener(databaseURL string, realtime ports.RealtimeService, log
 	}, nil
 }
 
+// OnFlowUpdate calls fn for every flow_updates notification, in its own
+// goroutine so slow handlers do not hold up realtime events. Register
+// handlers before listening.
+func (l *PostgresListener) OnFlowUpdate(fn func(ctx context.Context, update domain.FlowUpdateEvent)) {
+	l.flowUpdateHandlers = append(l.flowUpdateHandlers, fn)
+}
+
 func (l *PostgresListener) Listen(ctx context.Context) error {
 	conn, err := pgx.ConnectConfig(ctx, l.connConfig)
 	if err != nil {
@@ -46,8 +57,12 @@ func (l *PostgresListener) Listen(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	_, err = conn.Exec(ctx, "LISTEN flow_updates")
+	if err != nil {
+		return err
+	}
 
-	l.logger.Info().Msg("Started listening for postgres notifications on channel: run_updates")
+	l.logger.Info().Msg("Started listening for postgres notifications on channels: run_updates, flow_updates")
 
 	for {
 		if ctx.Err() != nil {
@@ -108,4 +123,20 @@ func (l *PostgresListener) handleNotification(n *pgconn.Notification) {
 		// Broadcast to all connected WS clients
 		l.realtime.Broadcast("run_update", update)
 	}
+
+	if n.Channel == "flow_updates" {
+		var update domain.FlowUpdateEvent
+		if err := json.Unmarshal([]byte(n.Payload), &update); err != nil {
+			l.logger.Error().Err(err).Msg("failed to unmarshal flow update payload")
+			return
+		}
+		l.realtime.Broadcast("flow_update", update)
+		for _, fn := range l.flowUpdateHandlers {
+			go func(fn func(context.Context, domain.FlowUpdateEvent)) {
+				ctx, cancel := context.WithTimeout(context.Background(), flowUpdateHandlerTimeout)
+				defer cancel()
+				fn(ctx, update)
+			}(fn)
+		}
+	}
 }
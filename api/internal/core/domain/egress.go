@@ -0,0 +1,9 @@
+package domain
+
+// EgressPolicy narrows the hosts a project's flows may call over HTTP. Deny
+// blocks hosts; a non-empty Allow permits only the hosts it lists. Neither
+// can permit what the deployment policy blocks.
+type EgressPolicy struct {
+	AllowHosts []string
+	DenyHosts  []string
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Flow struct {
 	ID             string
@@ -8,6 +11,7 @@ type Flow struct {
 	Description    string
 	Scope          string
 	Status         string
+	StatusReason   string
 	Version        int
 	DefinitionJSON string
 	CreatedAt      time.Time
@@ -28,8 +32,12 @@ type Run struct {
 	FinishedAt       *time.Time
 	Log              string
 	TemporalWorkflow string
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	// TriggerNodeID is the trigger node that started the run; empty for manual runs.
+	TriggerNodeID string
+	// TriggerPayload is the trigger output (e.g. the captured webhook request).
+	TriggerPayload json.RawMessage
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 type RunStats struct {
@@ -0,0 +1,11 @@
+package domain
+
+// FlowEventTrigger is a flowEvent node resolved from a flow definition: it
+// starts the flow when a run of another flow finishes.
+type FlowEventTrigger struct {
+	NodeID string
+	// FlowID is the upstream flow whose runs are watched.
+	FlowID string
+	// Status is the run outcome to react to: success, failed or any.
+	Status string
+}
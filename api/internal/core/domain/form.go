@@ -0,0 +1,40 @@
+package domain
+
+// FormTrigger is a formTrigger node resolved from a flow definition.
+type FormTrigger struct {
+	NodeID      string
+	Title       string
+	Description string
+	ButtonLabel string
+	Fields      []FormField
+	// CompletionMessage is shown after a submission unless RedirectURL is set.
+	CompletionMessage string
+	RedirectURL       string
+	// CredentialID names a credential whose "password" protects the form;
+	// empty means the form is public.
+	CredentialID string
+	Config       map[string]any
+}
+
+// FormField is one input of a hosted form.
+type FormField struct {
+	Name        string
+	Label       string
+	Type        string
+	Required    bool
+	Options     []string
+	Placeholder string
+}
+
+// FormFile is an uploaded file of a form submission.
+type FormFile struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// FormSubmission is what a browser or API client posted to a form.
+type FormSubmission struct {
+	Values map[string][]string
+	Files  map[string][]FormFile
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// PollingTrigger is a pollingTrigger node resolved from a flow definition.
+type PollingTrigger struct {
+	NodeID string
+	// Event names the source and change to watch, e.g. "github.issueOpened".
+	Event    string
+	Interval time.Duration
+	// Batch starts one run per poll with all new items instead of one run per item.
+	Batch  bool
+	Config map[string]any
+}
+
+// PollState is what a polling trigger remembers between polls: a source
+// specific cursor and/or the IDs of recently seen items.
+type PollState struct {
+	FlowID       string
+	NodeID       string
+	Cursor       string
+	SeenIDs      []string
+	LastPolledAt *time.Time
+	LastError    string
+}
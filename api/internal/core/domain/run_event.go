@@ -5,3 +5,11 @@ type RunUpdateEvent struct {
 	Status string `json:"status"`
 	Log    string `json:"log,omitempty"`
 }
+
+// FlowUpdateEvent is sent when the system changes a flow's status, e.g. an
+// automatic deactivation after repeated failures.
+type FlowUpdateEvent struct {
+	FlowID string `json:"flowId"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
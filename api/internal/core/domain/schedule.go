@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// CronTrigger is a cron node resolved from a flow definition.
+type CronTrigger struct {
+	NodeID     string
+	Expression string
+	// Timezone is an IANA zone name; empty means UTC.
+	Timezone string
+	// MisfirePolicy decides what happens to fire times missed while no
+	// scheduler was running: skip, runOnce or runAll.
+	MisfirePolicy string
+	// MisfireMaxRuns caps the catch-up runs of the runAll policy.
+	MisfireMaxRuns int
+}
+
+// ScheduleState is the pause flag and fire history of a flow's cron triggers.
+type ScheduleState struct {
+	FlowID   string
+	PausedAt *time.Time
+	PausedBy string
+	// LastFiredAt is keyed by cron node ID.
+	LastFiredAt map[string]time.Time
+}
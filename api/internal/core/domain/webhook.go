@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// WebhookTrigger is a webhook, httpTrigger or telegramTrigger node resolved
+// from a flow definition.
+type WebhookTrigger struct {
+	NodeID   string
+	NodeType string
+	Path     string
+	// Method is the accepted HTTP method; empty accepts any method.
+	Method string
+	Config map[string]any
+}
+
+// WebhookRequest is an inbound HTTP call captured as the trigger output of a run.
+type WebhookRequest struct {
+	Mode       string            `json:"mode"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	Headers    map[string]string `json:"headers"`
+	Query      map[string]any    `json:"query"`
+	Body       any               `json:"body"`
+	ClientIP   string            `json:"ip"`
+	ReceivedAt time.Time         `json:"receivedAt"`
+}
+
+// WebhookResponse is the HTTP response returned to a webhook caller that
+// waits for the run to finish.
+type WebhookResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       any
+}
@@ -206,6 +206,7 @@ type MockFlowRepository struct {
 	ListByProjectFunc   func(ctx context.Context, projectID string) ([]domain.Flow, error)
 	GetFunc             func(ctx context.Context, id string) (*domain.Flow, error)
 	UpdateFunc          func(ctx context.Context, flow domain.Flow) error
+	SetStatusFunc       func(ctx context.Context, id string, status string, updatedBy string) error
 	DeleteFunc          func(ctx context.Context, id string) error
 	DeleteByProjectFunc func(ctx context.Context, projectID string) error
 }
@@ -252,6 +253,13 @@ func (m *MockFlowRepository) Update(ctx context.Context, flow domain.Flow) error
 	return nil
 }
 
+func (m *MockFlowRepository) SetStatus(ctx context.Context, id string, status string, updatedBy string) error {
+	if m.SetStatusFunc != nil {
+		return m.SetStatusFunc(ctx, id, status, updatedBy)
+	}
+	return nil
+}
+
 func (m *MockFlowRepository) Delete(ctx context.Context, id string) error {
 	if m.DeleteFunc != nil {
 		return m.DeleteFunc(ctx, id)
@@ -301,3 +309,219 @@ func (m *MockProjectMemberRepository) ListMembers(ctx context.Context, projectID
 	}
 	return nil, nil
 }
+
+// MockProjectRepository implements ports.ProjectRepository
+type MockProjectRepository struct {
+	CreateFunc          func(ctx context.Context, project domain.Project) error
+	ListByUserFunc      func(ctx context.Context, userID string) ([]domain.Project, error)
+	GetForUserFunc      func(ctx context.Context, projectID string, userID string) (*domain.Project, error)
+	UpdateFunc          func(ctx context.Context, project domain.Project) error
+	DeleteFunc          func(ctx context.Context, projectID string) error
+	GetEgressPolicyFunc func(ctx context.Context, projectID string) (domain.EgressPolicy, error)
+	SetEgressPolicyFunc func(ctx context.Context, projectID string, policy domain.EgressPolicy) error
+}
+
+func (m *MockProjectRepository) Create(ctx context.Context, project domain.Project) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, project)
+	}
+	return nil
+}
+
+func (m *MockProjectRepository) ListByUser(ctx context.Context, userID string) ([]domain.Project, error) {
+	if m.ListByUserFunc != nil {
+		return m.ListByUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) GetForUser(ctx context.Context, projectID string, userID string) (*domain.Project, error) {
+	if m.GetForUserFunc != nil {
+		return m.GetForUserFunc(ctx, projectID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) Update(ctx context.Context, project domain.Project) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, project)
+	}
+	return nil
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, projectID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, projectID)
+	}
+	return nil
+}
+
+func (m *MockProjectRepository) GetEgressPolicy(ctx context.Context, projectID string) (domain.EgressPolicy, error) {
+	if m.GetEgressPolicyFunc != nil {
+		return m.GetEgressPolicyFunc(ctx, projectID)
+	}
+	return domain.EgressPolicy{}, nil
+}
+
+func (m *MockProjectRepository) SetEgressPolicy(ctx context.Context, projectID string, policy domain.EgressPolicy) error {
+	if m.SetEgressPolicyFunc != nil {
+		return m.SetEgressPolicyFunc(ctx, projectID, policy)
+	}
+	return nil
+}
+
+// MockCredentialRepository implements ports.CredentialRepository
+type MockCredentialRepository struct {
+	CreateFunc         func(ctx context.Context, cred domain.Credential) error
+	ListForUserFunc    func(ctx context.Context, userID string) ([]domain.Credential, error)
+	ListForProjectFunc func(ctx context.Context, projectID string) ([]domain.Credential, error)
+	GetFunc            func(ctx context.Context, id string) (*domain.Credential, error)
+	UpdateFunc         func(ctx context.Context, cred domain.Credential) error
+	DeleteFunc         func(ctx context.Context, id string) error
+}
+
+func (m *MockCredentialRepository) Create(ctx context.Context, cred domain.Credential) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, cred)
+	}
+	return nil
+}
+
+func (m *MockCredentialRepository) ListForUser(ctx context.Context, userID string) ([]domain.Credential, error) {
+	if m.ListForUserFunc != nil {
+		return m.ListForUserFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockCredentialRepository) ListForProject(ctx context.Context, projectID string) ([]domain.Credential, error) {
+	if m.ListForProjectFunc != nil {
+		return m.ListForProjectFunc(ctx, projectID)
+	}
+	return nil, nil
+}
+
+func (m *MockCredentialRepository) Get(ctx context.Context, id string) (*domain.Credential, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockCredentialRepository) Update(ctx context.Context, cred domain.Credential) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, cred)
+	}
+	return nil
+}
+
+func (m *MockCredentialRepository) Delete(ctx context.Context, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+// MockRunStepRepository implements ports.RunStepRepository
+type MockRunStepRepository struct {
+	CreateManyFunc      func(ctx context.Context, steps []domain.RunStep) error
+	ListByRunIDFunc     func(ctx context.Context, runID string) ([]domain.RunStep, error)
+	GetFunc             func(ctx context.Context, runID string, stepIDOrKey string) (*domain.RunStep, error)
+	UpdateStateFunc     func(ctx context.Context, id string, status string, inputsJSON []byte, outputsJSON []byte, logText string, errText string) error
+	CancelOpenStepsFunc func(ctx context.Context, runID string, message string) error
+}
+
+func (m *MockRunStepRepository) CreateMany(ctx context.Context, steps []domain.RunStep) error {
+	if m.CreateManyFunc != nil {
+		return m.CreateManyFunc(ctx, steps)
+	}
+	return nil
+}
+
+func (m *MockRunStepRepository) ListByRunID(ctx context.Context, runID string) ([]domain.RunStep, error) {
+	if m.ListByRunIDFunc != nil {
+		return m.ListByRunIDFunc(ctx, runID)
+	}
+	return nil, nil
+}
+
+func (m *MockRunStepRepository) Get(ctx context.Context, runID string, stepIDOrKey string) (*domain.RunStep, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, runID, stepIDOrKey)
+	}
+	return nil, nil
+}
+
+func (m *MockRunStepRepository) UpdateState(ctx context.Context, id string, status string, inputsJSON []byte, outputsJSON []byte, logText string, errText string) error {
+	if m.UpdateStateFunc != nil {
+		return m.UpdateStateFunc(ctx, id, status, inputsJSON, outputsJSON, logText, errText)
+	}
+	return nil
+}
+
+func (m *MockRunStepRepository) CancelOpenSteps(ctx context.Context, runID string, message string) error {
+	if m.CancelOpenStepsFunc != nil {
+		return m.CancelOpenStepsFunc(ctx, runID, message)
+	}
+	return nil
+}
+
+// MockScheduleRepository implements ports.ScheduleRepository
+type MockScheduleRepository struct {
+	GetFunc               func(ctx context.Context, flowID string) (domain.ScheduleState, error)
+	ListLastFiredFunc     func(ctx context.Context) (map[string]time.Time, error)
+	ListPausedFlowIDsFunc func(ctx context.Context) ([]string, error)
+	RecordFireFunc        func(ctx context.Context, flowID string, nodeID string, firedAt time.Time) error
+	PauseFunc             func(ctx context.Context, flowID string, userID string) error
+	ResumeFunc            func(ctx context.Context, flowID string) error
+	ClearFiresFunc        func(ctx context.Context, flowID string) error
+}
+
+func (m *MockScheduleRepository) Get(ctx context.Context, flowID string) (domain.ScheduleState, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, flowID)
+	}
+	return domain.ScheduleState{FlowID: flowID}, nil
+}
+
+func (m *MockScheduleRepository) ListLastFired(ctx context.Context) (map[string]time.Time, error) {
+	if m.ListLastFiredFunc != nil {
+		return m.ListLastFiredFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockScheduleRepository) ListPausedFlowIDs(ctx context.Context) ([]string, error) {
+	if m.ListPausedFlowIDsFunc != nil {
+		return m.ListPausedFlowIDsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockScheduleRepository) RecordFire(ctx context.Context, flowID string, nodeID string, firedAt time.Time) error {
+	if m.RecordFireFunc != nil {
+		return m.RecordFireFunc(ctx, flowID, nodeID, firedAt)
+	}
+	return nil
+}
+
+func (m *MockScheduleRepository) Pause(ctx context.Context, flowID string, userID string) error {
+	if m.PauseFunc != nil {
+		return m.PauseFunc(ctx, flowID, userID)
+	}
+	return nil
+}
+
+func (m *MockScheduleRepository) Resume(ctx context.Context, flowID string) error {
+	if m.ResumeFunc != nil {
+		return m.ResumeFunc(ctx, flowID)
+	}
+	return nil
+}
+
+func (m *MockScheduleRepository) ClearFires(ctx context.Context, flowID string) error {
+	if m.ClearFiresFunc != nil {
+		return m.ClearFiresFunc(ctx, flowID)
+	}
+	return nil
+}
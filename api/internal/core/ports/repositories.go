@@ -29,6 +29,7 @@ type FlowRepository interface {
 	ListByProject(ctx context.Context, projectID string) ([]domain.Flow, error)
 	Get(ctx context.Context, id string) (*domain.Flow, error)
 	Update(ctx context.Context, flow domain.Flow) error
+	SetStatus(ctx context.Context, id string, status string, updatedBy string) error
 	Delete(ctx context.Context, id string) error
 	DeleteByProject(ctx context.Context, projectID string) error
 }
@@ -60,6 +61,8 @@ type ProjectRepository interface {
 	GetForUser(ctx context.Context, projectID string, userID string) (*domain.Project, error)
 	Update(ctx context.Context, project domain.Project) error
 	Delete(ctx context.Context, projectID string) error
+	GetEgressPolicy(ctx context.Context, projectID string) (domain.EgressPolicy, error)
+	SetEgressPolicy(ctx context.Context, projectID string, policy domain.EgressPolicy) error
 }
 
 type RunRepository interface {
@@ -83,6 +86,24 @@ type RunStepRepository interface {
 	CancelOpenSteps(ctx context.Context, runID string, message string) error
 }
 
+type ScheduleRepository interface {
+	Get(ctx context.Context, flowID string) (domain.ScheduleState, error)
+	// ListLastFired returns the last fire time of every cron trigger keyed by "flowID/nodeID".
+	ListLastFired(ctx context.Context) (map[string]time.Time, error)
+	ListPausedFlowIDs(ctx context.Context) ([]string, error)
+	RecordFire(ctx context.Context, flowID string, nodeID string, firedAt time.Time) error
+	Pause(ctx context.Context, flowID string, userID string) error
+	// Resume clears the pause and the fire history so the paused window is not caught up.
+	Resume(ctx context.Context, flowID string) error
+	// ClearFires drops the fire history of the flow's cron triggers.
+	ClearFires(ctx context.Context, flowID string) error
+}
+
+type PollStateRepository interface {
+	List(ctx context.Context) ([]domain.PollState, error)
+	Save(ctx context.Context, state domain.PollState) error
+}
+
 type SystemRepository interface {
 	ResetWorkspace(ctx context.Context) error
 }
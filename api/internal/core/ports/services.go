@@ -35,6 +35,15 @@ type FlowService interface {
 	DeleteAccessible(ctx context.Context, user domain.AuthUser, id string) error
 }
 
+// TriggerRegistrar keeps registrations with external services, such as a
+// Telegram bot's webhook, in line with a flow's triggers. before and after
+// are the flow around a change, nil when it is created or deleted. Triggers
+// of an active after are registered; registrations of before's triggers that
+// are no longer live are removed.
+type TriggerRegistrar interface {
+	SyncTriggers(ctx context.Context, before *domain.Flow, after *domain.Flow) error
+}
+
 type ProjectService interface {
 	List(ctx context.Context, user domain.AuthUser) ([]domain.Project, error)
 	Get(ctx context.Context, user domain.AuthUser, projectID string) (*domain.Project, error)
@@ -140,6 +140,33 @@ func (s *CredentialService) Get(ctx context.Context, user domain.AuthUser, id st
 	return cred, nil
 }
 
+// GetForFlow returns a credential that flow may use without a signed-in user
+// (e.g. for public webhook calls): a project credential of the flow's project,
+// or a personal credential of the flow's owner.
+func (s *CredentialService) GetForFlow(ctx context.Context, flow domain.Flow, id string) (*domain.Credential, error) {
+	cred, err := s.creds.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !CredentialUsableByFlow(*cred, flow) {
+		return nil, utils.ErrForbidden
+	}
+	return cred, nil
+}
+
+// CredentialUsableByFlow is the rule of GetForFlow, for callers that load
+// credentials themselves, such as the worker running a flow's steps.
+func CredentialUsableByFlow(cred domain.Credential, flow domain.Flow) bool {
+	if cred.ProjectID != "" {
+		return cred.ProjectID == flow.ProjectID
+	}
+	owner := flow.OwnerUserID
+	if owner == "" {
+		owner = flow.CreatedBy
+	}
+	return owner != "" && cred.UserID == owner
+}
+
 func (s *CredentialService) Update(ctx context.Context, user domain.AuthUser, cred domain.Credential) error {
 	existing, err := s.creds.Get(ctx, cred.ID)
 	if err != nil {
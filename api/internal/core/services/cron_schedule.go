@@ -0,0 +1,233 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// The runtime image has no zoneinfo; embed it so cron timezones resolve.
+	_ "time/tzdata"
+
+	"github.com/robfig/cron"
+
+	"flowcraft-api/internal/core/domain"
+)
+
+// Misfire policies for fire times missed while no scheduler was running.
+const (
+	MisfireSkip    = "skip"
+	MisfireRunOnce = "runOnce"
+	MisfireRunAll  = "runAll"
+)
+
+const (
+	defaultMisfireMaxRuns = 10
+	maxMisfireMaxRuns     = 100
+	// maxMissedScan bounds the fire times walked for one trigger, so a
+	// per-second schedule after a long outage cannot stall the scheduler.
+	maxMissedScan = 100000
+)
+
+// ErrInvalidFlowDefinition is returned when a flow cannot be saved because its
+// definition is unusable, e.g. a cron trigger with a bad expression.
+var ErrInvalidFlowDefinition = errors.New("invalid flow definition")
+
+// CronTriggers lists the cron nodes of a flow definition that have an expression.
+func CronTriggers(definitionJSON string) []domain.CronTrigger {
+	type flowDef struct {
+		Reactflow struct {
+			Nodes []struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+				Data struct {
+					NodeType string         `json:"nodeType"`
+					Config   map[string]any `json:"config"`
+				} `json:"data"`
+			} `json:"nodes"`
+		} `json:"reactflow"`
+	}
+
+	var def flowDef
+	if err := json.Unmarshal([]byte(definitionJSON), &def); err != nil {
+		return nil
+	}
+
+	out := make([]domain.CronTrigger, 0, 1)
+	for _, node := range def.Reactflow.Nodes {
+		nodeType := strings.TrimSpace(node.Data.NodeType)
+		if nodeType == "" {
+			nodeType = strings.TrimSpace(node.Type)
+		}
+		if nodeType != "cron" {
+			continue
+		}
+		expr := configString(node.Data.Config, "expression")
+		if expr == "" {
+			continue
+		}
+		policy := configString(node.Data.Config, "misfirePolicy")
+		if policy == "" {
+			policy = MisfireRunOnce
+		}
+		maxRuns, err := strconv.Atoi(configString(node.Data.Config, "misfireMaxRuns"))
+		if err != nil || maxRuns <= 0 {
+			maxRuns = defaultMisfireMaxRuns
+		}
+		if maxRuns > maxMisfireMaxRuns {
+			maxRuns = maxMisfireMaxRuns
+		}
+		out = append(out, domain.CronTrigger{
+			NodeID:         node.ID,
+			Expression:     expr,
+			Timezone:       configString(node.Data.Config, "timezone"),
+			MisfirePolicy:  policy,
+			MisfireMaxRuns: maxRuns,
+		})
+	}
+	return out
+}
+
+// ValidateCronTriggers rejects definitions whose cron nodes have an expression
+// or timezone the scheduler cannot use.
+func ValidateCronTriggers(definitionJSON string) error {
+	for _, trigger := range CronTriggers(definitionJSON) {
+		if _, err := ParseCronSchedule(trigger); err != nil {
+			return fmt.Errorf("%w: cron node %s: %v", ErrInvalidFlowDefinition, trigger.NodeID, err)
+		}
+		switch trigger.MisfirePolicy {
+		case MisfireSkip, MisfireRunOnce, MisfireRunAll:
+		default:
+			return fmt.Errorf("%w: cron node %s: unknown misfirePolicy %q", ErrInvalidFlowDefinition, trigger.NodeID, trigger.MisfirePolicy)
+		}
+	}
+	return nil
+}
+
+// ParseCronSchedule parses the trigger expression and evaluates it in the
+// trigger timezone. Five fields are standard cron, six add seconds, and
+// descriptors such as "@hourly" or "@every 5m" are accepted.
+func ParseCronSchedule(trigger domain.CronTrigger) (cron.Schedule, error) {
+	loc := time.UTC
+	if tz := strings.TrimSpace(trigger.Timezone); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", tz)
+		}
+	}
+
+	raw := strings.TrimSpace(trigger.Expression)
+	if raw == "" {
+		return nil, errors.New("empty schedule")
+	}
+	var (
+		schedule cron.Schedule
+		err      error
+	)
+	if !strings.HasPrefix(raw, "@") && len(strings.Fields(raw)) == 5 {
+		schedule, err = cron.ParseStandard(raw)
+	} else {
+		schedule, err = cron.Parse(raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %v", raw, err)
+	}
+	if every, ok := schedule.(cron.ConstantDelaySchedule); ok {
+		return epochSchedule{delay: every.Delay}, nil
+	}
+	return zonedSchedule{schedule: schedule, loc: loc}, nil
+}
+
+// NextFireTimes returns the next n fire times of schedule after from.
+func NextFireTimes(schedule cron.Schedule, from time.Time, n int) []time.Time {
+	out := make([]time.Time, 0, n)
+	next := from
+	for len(out) < n {
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		out = append(out, next)
+	}
+	return out
+}
+
+// FireTimeAt returns the latest fire time of schedule in (now-grace, now]:
+// the tick a cron job started at now belongs to, even when the job started
+// late. Schedules without a fire time in that window, such as "@every" with a
+// longer interval, fall back to now truncated to the second.
+func FireTimeAt(schedule cron.Schedule, now time.Time, grace time.Duration) time.Time {
+	var fire time.Time
+	next := now.Add(-grace)
+	for i := 0; i < maxMissedScan; i++ {
+		next = schedule.Next(next)
+		if next.IsZero() || next.After(now) {
+			break
+		}
+		fire = next
+	}
+	if fire.IsZero() {
+		return now.Truncate(time.Second)
+	}
+	return fire
+}
+
+// MissedFireTimes walks the fire times after lastFired up to until and
+// applies the trigger's misfire policy. It returns the fire times to run now,
+// oldest first, and the latest fire time seen (zero when nothing was missed),
+// which becomes the new last fire time even when runs are skipped.
+func MissedFireTimes(schedule cron.Schedule, trigger domain.CronTrigger, lastFired time.Time, until time.Time) ([]time.Time, time.Time) {
+	keep := 1
+	if trigger.MisfirePolicy == MisfireRunAll {
+		keep = trigger.MisfireMaxRuns
+		if keep <= 0 {
+			keep = defaultMisfireMaxRuns
+		}
+	}
+
+	// recent keeps the last `keep` fire times in order.
+	recent := make([]time.Time, 0, keep)
+	next := lastFired
+	for i := 0; i < maxMissedScan; i++ {
+		next = schedule.Next(next)
+		if next.IsZero() || next.After(until) {
+			break
+		}
+		if len(recent) == keep {
+			recent = append(recent[:0], recent[1:]...)
+		}
+		recent = append(recent, next)
+	}
+	if len(recent) == 0 {
+		return nil, time.Time{}
+	}
+	latest := recent[len(recent)-1]
+	if trigger.MisfirePolicy == MisfireSkip {
+		return nil, latest
+	}
+	return recent, latest
+}
+
+// zonedSchedule evaluates a schedule in a fixed location regardless of the
+// location of the time passed in, so "0 9 * * *" means 09:00 in that zone.
+type zonedSchedule struct {
+	schedule cron.Schedule
+	loc      *time.Location
+}
+
+func (z zonedSchedule) Next(t time.Time) time.Time {
+	return z.schedule.Next(t.In(z.loc))
+}
+
+// epochSchedule fires every delay counted from the Unix epoch. cron's own
+// "@every" counts from the time passed in, which would give every scheduler
+// its own ticks and so its own run IDs.
+type epochSchedule struct {
+	delay time.Duration
+}
+
+func (e epochSchedule) Next(t time.Time) time.Time {
+	secs := int64(e.delay / time.Second)
+	return time.Unix((t.Unix()/secs+1)*secs, 0).In(t.Location())
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"flowcraft-api/internal/core/domain"
+)
+
+// Flow statuses. Only active flows are started by their triggers (cron,
+// webhooks, polling, forms and flow events); manual runs work in any status.
+const (
+	FlowStatusDraft    = "draft"
+	FlowStatusActive   = "active"
+	FlowStatusInactive = "inactive"
+	FlowStatusArchived = "archived"
+)
+
+var (
+	ErrInvalidFlowStatus = errors.New("invalid flow status")
+	ErrFlowArchived      = errors.New("flow is archived")
+)
+
+// FlowActive reports whether flow's triggers may start runs.
+func FlowActive(flow domain.Flow) bool {
+	return strings.TrimSpace(flow.Status) == FlowStatusActive
+}
+
+// ValidateForActivation checks that a definition can run unattended: it must
+// parse, have at least one node and pass the trigger checks done on save.
+func ValidateForActivation(definitionJSON string) error {
+	var def struct {
+		Reactflow struct {
+			Nodes []json.RawMessage `json:"nodes"`
+		} `json:"reactflow"`
+	}
+	if err := json.Unmarshal([]byte(definitionJSON), &def); err != nil {
+		return fmt.Errorf("%w: definition is not valid JSON", ErrInvalidFlowDefinition)
+	}
+	if len(def.Reactflow.Nodes) == 0 {
+		return fmt.Errorf("%w: flow has no nodes", ErrInvalidFlowDefinition)
+	}
+	return validateTriggers(definitionJSON)
+}
+
+// validateStatus rejects unknown statuses and validates definitions that are
+// saved as active. An empty status leaves the stored one unchanged.
+func validateStatus(status string, definitionJSON string) error {
+	switch status {
+	case "", FlowStatusDraft, FlowStatusInactive, FlowStatusArchived:
+		return nil
+	case FlowStatusActive:
+		return ValidateForActivation(definitionJSON)
+	default:
+		return fmt.Errorf("%w %q: use draft, active, inactive or archived", ErrInvalidFlowStatus, status)
+	}
+}
+
+// Activate validates the flow and marks it active, which resets its failure count.
+func (s *FlowService) Activate(ctx context.Context, user domain.AuthUser, id string) (*domain.Flow, error) {
+	return s.setStatus(ctx, user, id, FlowStatusActive)
+}
+
+// Deactivate marks the flow inactive so its triggers stop starting runs.
+func (s *FlowService) Deactivate(ctx context.Context, user domain.AuthUser, id string) (*domain.Flow, error) {
+	return s.setStatus(ctx, user, id, FlowStatusInactive)
+}
+
+func (s *FlowService) setStatus(ctx context.Context, user domain.AuthUser, id string, status string) (*domain.Flow, error) {
+	flow, err := s.GetAccessible(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+	if flow.Status == FlowStatusArchived {
+		return nil, ErrFlowArchived
+	}
+	if status == FlowStatusActive {
+		if err := ValidateForActivation(flow.DefinitionJSON); err != nil {
+			return nil, err
+		}
+	}
+	after := *flow
+	after.Status = status
+	if err := s.syncTriggers(ctx, flow, &after); err != nil {
+		return nil, err
+	}
+	if err := s.flows.SetStatus(ctx, flow.ID, status, user.ID); err != nil {
+		return nil, err
+	}
+	return s.flows.Get(ctx, flow.ID)
+}
+
+// SyncDeactivated lets the registrars follow a deactivation stored outside the
+// service, such as a worker turning a flow off after repeated failures. A flow
+// that is active again by now is left alone.
+func (s *FlowService) SyncDeactivated(ctx context.Context, id string) error {
+	flow, err := s.flows.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if FlowActive(*flow) {
+		return nil
+	}
+	before := *flow
+	before.Status = FlowStatusActive
+	return s.syncTriggers(ctx, &before, flow)
+}
+
+// syncTriggers lets the registrars follow a change from before to after
+// (nil when the flow is deleted). It runs before the change is stored, so a
+// flow whose triggers cannot be registered is not activated.
+func (s *FlowService) syncTriggers(ctx context.Context, before *domain.Flow, after *domain.Flow) error {
+	for _, r := range s.registrars {
+		if err := r.SyncTriggers(ctx, before, after); err != nil {
+			return fmt.Errorf("%w: %v", ErrTriggerRegistration, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"flowcraft-api/internal/core/domain"
+)
+
+// Run outcomes a flowEvent trigger can react to. Any also matches canceled runs.
+const (
+	FlowEventSuccess = "success"
+	FlowEventFailed  = "failed"
+	FlowEventAny     = "any"
+)
+
+// MaxFlowEventDepth caps how many flows a chain of flowEvent triggers can
+// pass through, on top of the cycle check.
+const MaxFlowEventDepth = 10
+
+// FlowEventTriggers lists the flowEvent nodes of a flow definition that name
+// an upstream flow. status defaults to success.
+func FlowEventTriggers(definitionJSON string) []domain.FlowEventTrigger {
+	type flowDef struct {
+		Reactflow struct {
+			Nodes []struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+				Data struct {
+					NodeType string         `json:"nodeType"`
+					Config   map[string]any `json:"config"`
+				} `json:"data"`
+			} `json:"nodes"`
+		} `json:"reactflow"`
+	}
+
+	var def flowDef
+	if err := json.Unmarshal([]byte(definitionJSON), &def); err != nil {
+		return nil
+	}
+
+	out := make([]domain.FlowEventTrigger, 0, 1)
+	for _, node := range def.Reactflow.Nodes {
+		nodeType := strings.TrimSpace(node.Data.NodeType)
+		if nodeType == "" {
+			nodeType = strings.TrimSpace(node.Type)
+		}
+		if nodeType != "flowEvent" {
+			continue
+		}
+		cfg := node.Data.Config
+		if cfg == nil {
+			cfg = map[string]any{}
+		}
+		flowID := configString(cfg, "flowId")
+		if flowID == "" {
+			continue
+		}
+		status := configString(cfg, "status")
+		if status == "" {
+			status = FlowEventSuccess
+		}
+		out = append(out, domain.FlowEventTrigger{NodeID: node.ID, FlowID: flowID, Status: status})
+	}
+	return out
+}
+
+// ValidateFlowEventTriggers rejects flowEvent triggers with an unknown status.
+func ValidateFlowEventTriggers(definitionJSON string) error {
+	for _, trigger := range FlowEventTriggers(definitionJSON) {
+		switch trigger.Status {
+		case FlowEventSuccess, FlowEventFailed, FlowEventAny:
+		default:
+			return fmt.Errorf("%w: flow event node %s: unknown status %q", ErrInvalidFlowDefinition, trigger.NodeID, trigger.Status)
+		}
+	}
+	return nil
+}
+
+// FlowEventMatches reports whether trigger reacts to a run of upstreamFlowID
+// that finished with runStatus.
+func FlowEventMatches(trigger domain.FlowEventTrigger, upstreamFlowID string, runStatus string) bool {
+	if trigger.FlowID != upstreamFlowID {
+		return false
+	}
+	switch trigger.Status {
+	case FlowEventAny:
+		return runStatus == "success" || runStatus == "failed" || runStatus == "canceled"
+	default:
+		return trigger.Status == runStatus
+	}
+}
+
+// FlowEventAllowed reports whether downstream may react to upstream's runs.
+// The trigger receives the upstream run's output, so both flows must belong
+// to the same owner or the same project.
+func FlowEventAllowed(upstream domain.Flow, downstream domain.Flow) bool {
+	upScope, downScope := flowScope(upstream), flowScope(downstream)
+	if upScope != downScope {
+		return false
+	}
+	switch upScope {
+	case "personal":
+		owner := flowOwner(upstream)
+		return owner != "" && owner == flowOwner(downstream)
+	case "project":
+		project := strings.TrimSpace(upstream.ProjectID)
+		return project != "" && project == strings.TrimSpace(downstream.ProjectID)
+	default:
+		return false
+	}
+}
+
+// FlowEventChain returns the flows a run's flowEvent chain passed through,
+// oldest first, ending with flowID. Downstream flows already in the chain
+// are not started again, which stops A→B→A loops.
+func FlowEventChain(triggerPayload json.RawMessage, flowID string) []string {
+	var payload struct {
+		Mode  string   `json:"mode"`
+		Chain []string `json:"chain"`
+	}
+	chain := make([]string, 0, 1)
+	if len(triggerPayload) > 0 && json.Unmarshal(triggerPayload, &payload) == nil && payload.Mode == "flowEvent" {
+		chain = append(chain, payload.Chain...)
+	}
+	return append(chain, flowID)
+}
+
+func flowScope(flow domain.Flow) string {
+	scope := strings.TrimSpace(flow.Scope)
+	if scope == "" {
+		return "personal"
+	}
+	return scope
+}
+
+func flowOwner(flow domain.Flow) string {
+	if owner := strings.TrimSpace(flow.OwnerUserID); owner != "" {
+		return owner
+	}
+	return strings.TrimSpace(flow.CreatedBy)
+}
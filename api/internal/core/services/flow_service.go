@@ -13,12 +13,18 @@ import (
 type FlowService struct {
 	flows          ports.FlowRepository
 	projectMembers ports.ProjectMemberRepository
+	registrars     []ports.TriggerRegistrar
 }
 
 func NewFlowService(flows ports.FlowRepository, projectMembers ports.ProjectMemberRepository) *FlowService {
 	return &FlowService{flows: flows, projectMembers: projectMembers}
 }
 
+// AddTriggerRegistrar makes r follow the flows' status and definition changes.
+func (s *FlowService) AddTriggerRegistrar(r ports.TriggerRegistrar) {
+	s.registrars = append(s.registrars, r)
+}
+
 func (s *FlowService) Create(ctx context.Context, flow domain.Flow) (domain.Flow, error) {
 	if flow.ID == "" {
 		flow.ID = utils.NewUUID()
@@ -30,7 +36,7 @@ func (s *FlowService) Create(ctx context.Context, flow domain.Flow) (domain.Flow
 		flow.OwnerUserID = flow.CreatedBy
 	}
 	if flow.Status == "" {
-		flow.Status = "draft"
+		flow.Status = FlowStatusDraft
 	}
 	if flow.Version == 0 {
 		flow.Version = 1
@@ -38,6 +44,12 @@ func (s *FlowService) Create(ctx context.Context, flow domain.Flow) (domain.Flow
 	if flow.DefinitionJSON == "" {
 		flow.DefinitionJSON = "{}"
 	}
+	if err := validateTriggers(flow.DefinitionJSON); err != nil {
+		return domain.Flow{}, err
+	}
+	if err := validateStatus(flow.Status, flow.DefinitionJSON); err != nil {
+		return domain.Flow{}, err
+	}
 	return flow, s.flows.Create(ctx, flow)
 }
 
@@ -140,15 +152,53 @@ func (s *FlowService) GetAccessible(ctx context.Context, user domain.AuthUser, i
 }
 
 func (s *FlowService) UpdateAccessible(ctx context.Context, user domain.AuthUser, flow domain.Flow) error {
-	if _, err := s.GetAccessible(ctx, user, flow.ID); err != nil {
+	existing, err := s.GetAccessible(ctx, user, flow.ID)
+	if err != nil {
 		return err
 	}
+	if flow.Status == "" {
+		flow.Status = existing.Status
+	}
+	if err := validateTriggers(flow.DefinitionJSON); err != nil {
+		return err
+	}
+	if err := validateStatus(flow.Status, flow.DefinitionJSON); err != nil {
+		return err
+	}
+	// Saving a draft leaves registrations alone; they follow activation.
+	if FlowActive(*existing) || FlowActive(flow) {
+		if err := s.syncTriggers(ctx, existing, &flow); err != nil {
+			return err
+		}
+	}
 	return s.flows.Update(ctx, flow)
 }
 
 func (s *FlowService) DeleteAccessible(ctx context.Context, user domain.AuthUser, id string) error {
-	if _, err := s.GetAccessible(ctx, user, id); err != nil {
+	existing, err := s.GetAccessible(ctx, user, id)
+	if err != nil {
+		return err
+	}
+	if err := s.syncTriggers(ctx, existing, nil); err != nil {
 		return err
 	}
 	return s.flows.Delete(ctx, id)
 }
+
+// validateTriggers checks the trigger nodes the workers schedule on their own,
+// so a broken trigger fails the save instead of only showing up in worker logs.
+func validateTriggers(definitionJSON string) error {
+	if err := ValidateCronTriggers(definitionJSON); err != nil {
+		return err
+	}
+	if err := ValidatePollingTriggers(definitionJSON); err != nil {
+		return err
+	}
+	if err := ValidateFlowEventTriggers(definitionJSON); err != nil {
+		return err
+	}
+	if err := ValidateTelegramTriggers(definitionJSON); err != nil {
+		return err
+	}
+	return ValidateFormTriggers(definitionJSON)
+}
@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports"
+	"flowcraft-api/internal/utils"
+)
+
+// Form field types.
+const (
+	FormFieldText   = "text"
+	FormFieldEmail  = "email"
+	FormFieldNumber = "number"
+	FormFieldSelect = "select"
+	FormFieldFile   = "file"
+)
+
+// FormPasswordField is the submitted value that carries the form password.
+// Field names starting with "_" are reserved for it.
+const FormPasswordField = "_password"
+
+const (
+	defaultFormButtonLabel       = "Submit"
+	defaultFormCompletionMessage = "Thanks! Your response has been submitted."
+	maxFormTextLength            = 10000
+
+	// FormSessionTTL is how long an unlocked password protected form stays
+	// unlocked.
+	FormSessionTTL = 12 * time.Hour
+	// maxFormPasswordAttempts wrong passwords per form and client lock the
+	// form for that client until formPasswordWindow has passed.
+	maxFormPasswordAttempts = 5
+	formPasswordWindow      = 15 * time.Minute
+)
+
+var (
+	ErrFormUnauthorized = errors.New("form password is incorrect")
+	ErrFormLocked       = errors.New("too many incorrect form passwords")
+)
+
+type FormService struct {
+	flows    ports.FlowRepository
+	runs     ports.RunRepository
+	creds    *CredentialService
+	attempts *formAttempts
+}
+
+// NewFormService wires the hosted form trigger service. creds may be nil when
+// credential encryption is not configured; password protected forms then
+// reject every submission.
+func NewFormService(flows ports.FlowRepository, runs ports.RunRepository, creds *CredentialService) *FormService {
+	return &FormService{flows: flows, runs: runs, creds: creds, attempts: newFormAttempts()}
+}
+
+// Resolve returns the form trigger of flowID. Flows that are not active or
+// have no form return utils.ErrNotFound.
+func (s *FormService) Resolve(ctx context.Context, flowID string) (*domain.Flow, *domain.FormTrigger, error) {
+	flow, err := s.flows.Get(ctx, flowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !FlowActive(*flow) {
+		return nil, nil, utils.ErrNotFound
+	}
+	triggers := FormTriggers(flow.DefinitionJSON)
+	if len(triggers) == 0 {
+		return nil, nil, utils.ErrNotFound
+	}
+	return flow, &triggers[0], nil
+}
+
+// CheckPassword compares password with the "password" of the form's
+// credential. Forms without a credential accept any password. Wrong
+// passwords are counted per form and client (usually the IP address); after
+// maxFormPasswordAttempts the client gets ErrFormLocked until the window ends.
+func (s *FormService) CheckPassword(ctx context.Context, flow domain.Flow, trigger domain.FormTrigger, password string, client string) error {
+	if trigger.CredentialID == "" {
+		return nil
+	}
+	key := flow.ID + "|" + client
+	if s.attempts.locked(key, time.Now()) {
+		return ErrFormLocked
+	}
+	expected, err := s.formPassword(ctx, flow, trigger)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(password), []byte(expected)) != 1 {
+		s.attempts.fail(key, time.Now())
+		return ErrFormUnauthorized
+	}
+	s.attempts.reset(key)
+	return nil
+}
+
+// NewSession returns a session token that unlocks the form until
+// FormSessionTTL has passed. Call it only after CheckPassword succeeded.
+func (s *FormService) NewSession(ctx context.Context, flow domain.Flow, trigger domain.FormTrigger) (string, error) {
+	expected, err := s.formPassword(ctx, flow, trigger)
+	if err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(FormSessionTTL).Unix(), 10)
+	return expires + "." + formSessionMAC(expected, flow.ID, trigger.NodeID, expires), nil
+}
+
+// CheckSession reports whether token unlocks the form. Tokens are signed
+// with the form password, so changing the password ends every session.
+func (s *FormService) CheckSession(ctx context.Context, flow domain.Flow, trigger domain.FormTrigger, token string) error {
+	if trigger.CredentialID == "" {
+		return nil
+	}
+	expires, mac, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrFormUnauthorized
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().After(time.Unix(unix, 0)) {
+		return ErrFormUnauthorized
+	}
+	expected, err := s.formPassword(ctx, flow, trigger)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(mac), []byte(formSessionMAC(expected, flow.ID, trigger.NodeID, expires))) {
+		return ErrFormUnauthorized
+	}
+	return nil
+}
+
+// formPassword loads the password of a protected form.
+func (s *FormService) formPassword(ctx context.Context, flow domain.Flow, trigger domain.FormTrigger) (string, error) {
+	if s.creds == nil {
+		return "", ErrFormUnauthorized
+	}
+	cred, err := s.creds.GetForFlow(ctx, flow, trigger.CredentialID)
+	if err != nil {
+		return "", ErrFormUnauthorized
+	}
+	var payload map[string]any
+	if err := s.creds.DecryptPayload(cred.DataEncrypted, &payload); err != nil {
+		return "", ErrFormUnauthorized
+	}
+	expected := configString(payload, "password")
+	if expected == "" {
+		return "", ErrFormUnauthorized
+	}
+	return expected, nil
+}
+
+func formSessionMAC(password string, flowID string, nodeID string, expires string) string {
+	mac := hmac.New(sha256.New, []byte(password))
+	mac.Write([]byte(flowID + "|" + nodeID + "|" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// formAttempts counts wrong form passwords per key within formPasswordWindow.
+type formAttempts struct {
+	mu      sync.Mutex
+	entries map[string]formAttempt
+}
+
+type formAttempt struct {
+	count int
+	since time.Time
+}
+
+func newFormAttempts() *formAttempts {
+	return &formAttempts{entries: map[string]formAttempt{}}
+}
+
+func (a *formAttempts) locked(key string, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, ok := a.entries[key]
+	return ok && now.Sub(entry.since) < formPasswordWindow && entry.count >= maxFormPasswordAttempts
+}
+
+func (a *formAttempts) fail(key string, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.entries) > 10000 {
+		for k, entry := range a.entries {
+			if now.Sub(entry.since) >= formPasswordWindow {
+				delete(a.entries, k)
+			}
+		}
+	}
+	entry := a.entries[key]
+	if now.Sub(entry.since) >= formPasswordWindow {
+		entry = formAttempt{since: now}
+	}
+	entry.count++
+	a.entries[key] = entry
+}
+
+func (a *formAttempts) reset(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.entries, key)
+}
+
+// CreateRun records a queued run started by the form with the validated
+// values as payload.
+func (s *FormService) CreateRun(ctx context.Context, flow domain.Flow, trigger domain.FormTrigger, values map[string]any) (domain.Run, error) {
+	payload, err := json.Marshal(map[string]any{
+		"mode":        "form",
+		"submittedAt": time.Now().UTC().Format(time.RFC3339),
+		"fields":      values,
+	})
+	if err != nil {
+		return domain.Run{}, err
+	}
+	run := domain.Run{
+		ID:             utils.NewUUID(),
+		FlowID:         flow.ID,
+		Status:         "queued",
+		Log:            "queued (form)",
+		TriggerNodeID:  trigger.NodeID,
+		TriggerPayload: payload,
+	}
+	run.TemporalWorkflow = "run-" + run.ID
+	return run, s.runs.Create(ctx, run)
+}
+
+// ValidateSubmission checks a submission against the form fields and
+// returns the typed values by field name. Numbers become float64 and files a
+// list of {filename, contentType, size, data} with base64 data. Problems are
+// returned per field name; unknown fields are ignored.
+func ValidateSubmission(trigger domain.FormTrigger, sub domain.FormSubmission) (map[string]any, map[string]string) {
+	values := make(map[string]any, len(trigger.Fields))
+	problems := map[string]string{}
+	for _, field := range trigger.Fields {
+		if field.Type == FormFieldFile {
+			files := make([]map[string]any, 0)
+			for _, f := range sub.Files[field.Name] {
+				if f.Filename == "" && len(f.Data) == 0 {
+					continue
+				}
+				files = append(files, map[string]any{
+					"filename":    f.Filename,
+					"contentType": f.ContentType,
+					"size":        len(f.Data),
+					"data":        base64.StdEncoding.EncodeToString(f.Data),
+				})
+			}
+			if field.Required && len(files) == 0 {
+				problems[field.Name] = "is required"
+			}
+			values[field.Name] = files
+			continue
+		}
+
+		raw := ""
+		if vals := sub.Values[field.Name]; len(vals) > 0 {
+			raw = strings.TrimSpace(vals[0])
+		}
+		if raw == "" {
+			if field.Required {
+				problems[field.Name] = "is required"
+			}
+			if field.Type == FormFieldNumber {
+				values[field.Name] = nil
+			} else {
+				values[field.Name] = ""
+			}
+			continue
+		}
+
+		switch field.Type {
+		case FormFieldEmail:
+			addr, err := mail.ParseAddress(raw)
+			if err != nil || addr.Address != raw {
+				problems[field.Name] = "must be a valid email address"
+				continue
+			}
+			values[field.Name] = raw
+		case FormFieldNumber:
+			n, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				problems[field.Name] = "must be a number"
+				continue
+			}
+			values[field.Name] = n
+		case FormFieldSelect:
+			valid := false
+			for _, option := range field.Options {
+				if option == raw {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				problems[field.Name] = "must be one of the listed options"
+				continue
+			}
+			values[field.Name] = raw
+		default:
+			if len(raw) > maxFormTextLength {
+				problems[field.Name] = fmt.Sprintf("must be at most %d characters", maxFormTextLength)
+				continue
+			}
+			values[field.Name] = raw
+		}
+	}
+	return values, problems
+}
+
+// FormTriggers lists the formTrigger nodes of a flow definition.
+func FormTriggers(definitionJSON string) []domain.FormTrigger {
+	type flowDef struct {
+		Reactflow struct {
+			Nodes []struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+				Data struct {
+					NodeType string         `json:"nodeType"`
+					Config   map[string]any `json:"config"`
+				} `json:"data"`
+			} `json:"nodes"`
+		} `json:"reactflow"`
+	}
+
+	var def flowDef
+	if err := json.Unmarshal([]byte(definitionJSON), &def); err != nil {
+		return nil
+	}
+
+	out := make([]domain.FormTrigger, 0, 1)
+	for _, node := range def.Reactflow.Nodes {
+		nodeType := strings.TrimSpace(node.Data.NodeType)
+		if nodeType == "" {
+			nodeType = strings.TrimSpace(node.Type)
+		}
+		if nodeType != "formTrigger" {
+			continue
+		}
+		cfg := node.Data.Config
+		if cfg == nil {
+			cfg = map[string]any{}
+		}
+		trigger := domain.FormTrigger{
+			NodeID:            node.ID,
+			Title:             configString(cfg, "title"),
+			Description:       configString(cfg, "description"),
+			ButtonLabel:       configString(cfg, "buttonLabel"),
+			Fields:            formFields(cfg["fields"]),
+			CompletionMessage: configString(cfg, "completionMessage"),
+			RedirectURL:       configString(cfg, "redirectUrl"),
+			CredentialID:      configString(cfg, "credentialId"),
+			Config:            cfg,
+		}
+		if trigger.ButtonLabel == "" {
+			trigger.ButtonLabel = defaultFormButtonLabel
+		}
+		if trigger.CompletionMessage == "" {
+			trigger.CompletionMessage = defaultFormCompletionMessage
+		}
+		out = append(out, trigger)
+	}
+	return out
+}
+
+func formFields(raw any) []domain.FormField {
+	list, _ := raw.([]any)
+	out := make([]domain.FormField, 0, len(list))
+	for _, item := range list {
+		cfg, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		field := domain.FormField{
+			Name:        configString(cfg, "name"),
+			Label:       configString(cfg, "label"),
+			Type:        configString(cfg, "type"),
+			Required:    configString(cfg, "required") == "true",
+			Placeholder: configString(cfg, "placeholder"),
+		}
+		if required, ok := cfg["required"].(bool); ok {
+			field.Required = required
+		}
+		if field.Type == "" {
+			field.Type = FormFieldText
+		}
+		if field.Label == "" {
+			field.Label = field.Name
+		}
+		options, _ := cfg["options"].([]any)
+		for _, option := range options {
+			if s := strings.TrimSpace(fmt.Sprint(option)); s != "" {
+				field.Options = append(field.Options, s)
+			}
+		}
+		out = append(out, field)
+	}
+	return out
+}
+
+// ValidateFormTriggers allows one form per flow and checks its fields and
+// redirect URL.
+func ValidateFormTriggers(definitionJSON string) error {
+	triggers := FormTriggers(definitionJSON)
+	if len(triggers) > 1 {
+		return fmt.Errorf("%w: a flow can have only one form trigger", ErrInvalidFlowDefinition)
+	}
+	for _, trigger := range triggers {
+		if len(trigger.Fields) == 0 {
+			return fmt.Errorf("%w: form node %s has no fields", ErrInvalidFlowDefinition, trigger.NodeID)
+		}
+		seen := map[string]struct{}{}
+		for _, field := range trigger.Fields {
+			if field.Name == "" || strings.HasPrefix(field.Name, "_") {
+				return fmt.Errorf("%w: form node %s: field name %q is empty or reserved", ErrInvalidFlowDefinition, trigger.NodeID, field.Name)
+			}
+			if _, ok := seen[field.Name]; ok {
+				return fmt.Errorf("%w: form node %s: duplicate field %q", ErrInvalidFlowDefinition, trigger.NodeID, field.Name)
+			}
+			seen[field.Name] = struct{}{}
+			switch field.Type {
+			case FormFieldText, FormFieldEmail, FormFieldNumber, FormFieldFile:
+			case FormFieldSelect:
+				if len(field.Options) == 0 {
+					return fmt.Errorf("%w: form node %s: select field %q has no options", ErrInvalidFlowDefinition, trigger.NodeID, field.Name)
+				}
+			default:
+				return fmt.Errorf("%w: form node %s: field %q has unknown type %q", ErrInvalidFlowDefinition, trigger.NodeID, field.Name, field.Type)
+			}
+		}
+		if trigger.RedirectURL != "" {
+			u, err := url.Parse(trigger.RedirectURL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%w: form node %s: redirectUrl must be an http(s) URL", ErrInvalidFlowDefinition, trigger.NodeID)
+			}
+		}
+	}
+	return nil
+}
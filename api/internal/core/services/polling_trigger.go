@@ -0,0 +1,97 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+)
+
+// Polling trigger events. Each one maps to a source in the worker's poller.
+const (
+	PollEventSheetsRowAdded       = "gsheets.rowAdded"
+	PollEventGitHubIssueOpened    = "github.issueOpened"
+	PollEventGmailMessageReceived = "gmail.messageReceived"
+	PollEventEmailMessageReceived = "email.messageReceived"
+)
+
+const (
+	defaultPollInterval = 5 * time.Minute
+	minPollInterval     = time.Minute
+)
+
+var pollEvents = map[string]struct{}{
+	PollEventSheetsRowAdded:       {},
+	PollEventGitHubIssueOpened:    {},
+	PollEventGmailMessageReceived: {},
+	PollEventEmailMessageReceived: {},
+}
+
+// PollingTriggers lists the pollingTrigger nodes of a flow definition that
+// have an event. intervalSeconds defaults to 300 and cannot go below 60.
+func PollingTriggers(definitionJSON string) []domain.PollingTrigger {
+	type flowDef struct {
+		Reactflow struct {
+			Nodes []struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+				Data struct {
+					NodeType string         `json:"nodeType"`
+					Config   map[string]any `json:"config"`
+				} `json:"data"`
+			} `json:"nodes"`
+		} `json:"reactflow"`
+	}
+
+	var def flowDef
+	if err := json.Unmarshal([]byte(definitionJSON), &def); err != nil {
+		return nil
+	}
+
+	out := make([]domain.PollingTrigger, 0, 1)
+	for _, node := range def.Reactflow.Nodes {
+		nodeType := strings.TrimSpace(node.Data.NodeType)
+		if nodeType == "" {
+			nodeType = strings.TrimSpace(node.Type)
+		}
+		if nodeType != "pollingTrigger" {
+			continue
+		}
+		cfg := node.Data.Config
+		if cfg == nil {
+			cfg = map[string]any{}
+		}
+		event := configString(cfg, "event")
+		if event == "" {
+			continue
+		}
+		interval := defaultPollInterval
+		if seconds, err := strconv.Atoi(configString(cfg, "intervalSeconds")); err == nil && seconds > 0 {
+			interval = time.Duration(seconds) * time.Second
+		}
+		if interval < minPollInterval {
+			interval = minPollInterval
+		}
+		out = append(out, domain.PollingTrigger{
+			NodeID:   node.ID,
+			Event:    event,
+			Interval: interval,
+			Batch:    configString(cfg, "emit") == "batch",
+			Config:   cfg,
+		})
+	}
+	return out
+}
+
+// ValidatePollingTriggers rejects polling triggers with an unknown event.
+func ValidatePollingTriggers(definitionJSON string) error {
+	for _, trigger := range PollingTriggers(definitionJSON) {
+		if _, ok := pollEvents[trigger.Event]; !ok {
+			return fmt.Errorf("%w: polling node %s: unknown event %q", ErrInvalidFlowDefinition, trigger.NodeID, trigger.Event)
+		}
+	}
+	return nil
+}
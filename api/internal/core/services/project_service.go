@@ -3,11 +3,13 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"flowcraft-api/internal/core/domain"
 	"flowcraft-api/internal/core/ports"
+	"flowcraft-api/internal/egress"
 	"flowcraft-api/internal/utils"
 )
 
@@ -110,6 +112,72 @@ func (s *ProjectService) Delete(ctx context.Context, user domain.AuthUser, proje
 	return s.projects.Delete(ctx, projectID)
 }
 
+// GetEgressPolicy returns the project's egress policy to any member.
+func (s *ProjectService) GetEgressPolicy(ctx context.Context, user domain.AuthUser, projectID string) (domain.EgressPolicy, error) {
+	if _, err := s.members.GetRole(ctx, projectID, user.ID); err != nil {
+		if err == utils.ErrNotFound {
+			return domain.EgressPolicy{}, utils.ErrForbidden
+		}
+		return domain.EgressPolicy{}, err
+	}
+	return s.projects.GetEgressPolicy(ctx, projectID)
+}
+
+// SetEgressPolicy replaces the project's egress policy; admins only.
+func (s *ProjectService) SetEgressPolicy(ctx context.Context, user domain.AuthUser, projectID string, policy domain.EgressPolicy) error {
+	if err := s.requireAdmin(ctx, projectID, user); err != nil {
+		return err
+	}
+	var err error
+	if policy.AllowHosts, err = normalizeHostList(policy.AllowHosts); err != nil {
+		return fmt.Errorf("%w: allowHosts: %v", ErrInvalidEgressPolicy, err)
+	}
+	if policy.DenyHosts, err = normalizeHostList(policy.DenyHosts); err != nil {
+		return fmt.Errorf("%w: denyHosts: %v", ErrInvalidEgressPolicy, err)
+	}
+	return s.projects.SetEgressPolicy(ctx, projectID, policy)
+}
+
+// ErrInvalidEgressPolicy is returned for host lists that do not parse.
+var ErrInvalidEgressPolicy = errors.New("invalid egress policy")
+
+func normalizeHostList(entries []string) ([]string, error) {
+	out := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry = strings.ToLower(strings.TrimSpace(entry)); entry != "" {
+			out = append(out, entry)
+		}
+	}
+	if _, err := egress.ParseHostList(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EgressRules returns the egress rules of a project, for requests made on its
+// behalf outside a run, such as node tests.
+func (s *ProjectService) EgressRules(ctx context.Context, projectID string) (egress.Rules, error) {
+	return ProjectEgressRules(ctx, s.projects, projectID)
+}
+
+// ProjectEgressRules reads a project's egress policy as rules for
+// egress.WithRules.
+func ProjectEgressRules(ctx context.Context, projects ports.ProjectRepository, projectID string) (egress.Rules, error) {
+	policy, err := projects.GetEgressPolicy(ctx, projectID)
+	if err != nil {
+		return egress.Rules{}, err
+	}
+	allow, err := egress.ParseHostList(policy.AllowHosts)
+	if err != nil {
+		return egress.Rules{}, fmt.Errorf("project egress policy: %w", err)
+	}
+	deny, err := egress.ParseHostList(policy.DenyHosts)
+	if err != nil {
+		return egress.Rules{}, fmt.Errorf("project egress policy: %w", err)
+	}
+	return egress.Rules{Allow: allow, Deny: deny}, nil
+}
+
 func (s *ProjectService) ListMembers(ctx context.Context, user domain.AuthUser, projectID string) ([]domain.ProjectMember, error) {
 	if _, err := s.members.GetRole(ctx, projectID, user.ID); err != nil {
 		if err == utils.ErrNotFound {
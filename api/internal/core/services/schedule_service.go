@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports"
+)
+
+// ScheduleService manages the runtime state of cron triggers: pausing and the
+// fire history used for misfire catch-up. Callers check flow access first.
+type ScheduleService struct {
+	schedules ports.ScheduleRepository
+}
+
+func NewScheduleService(schedules ports.ScheduleRepository) *ScheduleService {
+	return &ScheduleService{schedules: schedules}
+}
+
+func (s *ScheduleService) State(ctx context.Context, flowID string) (domain.ScheduleState, error) {
+	return s.schedules.Get(ctx, flowID)
+}
+
+// Pause stops all cron triggers of the flow until Resume. Pausing a paused
+// flow keeps the original pause time.
+func (s *ScheduleService) Pause(ctx context.Context, flowID string, userID string) (domain.ScheduleState, error) {
+	if err := s.schedules.Pause(ctx, flowID, userID); err != nil {
+		return domain.ScheduleState{}, err
+	}
+	return s.schedules.Get(ctx, flowID)
+}
+
+// SyncTriggers drops the fire history when a flow becomes active, so the
+// scheduler records a fresh baseline instead of catching up the ticks missed
+// while the flow was off. ScheduleService is registered as a TriggerRegistrar.
+func (s *ScheduleService) SyncTriggers(ctx context.Context, before *domain.Flow, after *domain.Flow) error {
+	if after == nil || !FlowActive(*after) || (before != nil && FlowActive(*before)) {
+		return nil
+	}
+	return s.schedules.ClearFires(ctx, after.ID)
+}
+
+// Resume restarts the flow's cron triggers. Fire times that passed while
+// paused are not caught up.
+func (s *ScheduleService) Resume(ctx context.Context, flowID string) (domain.ScheduleState, error) {
+	if err := s.schedules.Resume(ctx, flowID); err != nil {
+		return domain.ScheduleState{}, err
+	}
+	return s.schedules.Get(ctx, flowID)
+}
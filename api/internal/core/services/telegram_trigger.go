@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"flowcraft-api/internal/core/domain"
+)
+
+// TelegramTriggerNodeType is the node type of a Telegram bot trigger. It is
+// served by the webhook endpoint at TelegramTriggerPath and registered as the
+// bot's webhook while its flow is active.
+const TelegramTriggerNodeType = "telegramTrigger"
+
+// TelegramSecretHeader carries the secret token Telegram sends with every update.
+const TelegramSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// ErrTriggerRegistration is returned when a trigger could not be registered
+// with, or removed from, the external service that calls it.
+var ErrTriggerRegistration = errors.New("trigger registration failed")
+
+// telegramUpdateTypes are the update types a telegramTrigger can subscribe to.
+var telegramUpdateTypes = map[string]struct{}{
+	"message":              {},
+	"edited_message":       {},
+	"channel_post":         {},
+	"edited_channel_post":  {},
+	"callback_query":       {},
+	"inline_query":         {},
+	"chosen_inline_result": {},
+	"my_chat_member":       {},
+	"chat_member":          {},
+	"chat_join_request":    {},
+	"message_reaction":     {},
+	"poll":                 {},
+	"poll_answer":          {},
+}
+
+// TelegramTriggerPath is the webhook path of a telegramTrigger node.
+func TelegramTriggerPath(nodeID string) string {
+	return "telegram/" + NormalizeWebhookPath(nodeID)
+}
+
+func telegramWebhookTrigger(nodeID string, cfg map[string]any) domain.WebhookTrigger {
+	if cfg == nil {
+		cfg = map[string]any{}
+	}
+	return domain.WebhookTrigger{
+		NodeID:   nodeID,
+		NodeType: TelegramTriggerNodeType,
+		Path:     TelegramTriggerPath(nodeID),
+		Method:   "POST",
+		Config:   cfg,
+	}
+}
+
+// TelegramTriggers lists the telegramTrigger nodes of a flow definition.
+func TelegramTriggers(definitionJSON string) []domain.WebhookTrigger {
+	var out []domain.WebhookTrigger
+	for _, trigger := range WebhookTriggers(definitionJSON) {
+		if trigger.NodeType == TelegramTriggerNodeType {
+			out = append(out, trigger)
+		}
+	}
+	return out
+}
+
+// TelegramAllowedUpdates returns the update types a trigger subscribes to,
+// from its "updates" setting (an array or a comma-separated string). Empty
+// means Telegram's default: every type except chat_member,
+// message_reaction and message_reaction_count.
+func TelegramAllowedUpdates(trigger domain.WebhookTrigger) []string {
+	var raw []string
+	switch v := trigger.Config["updates"].(type) {
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	case string:
+		raw = strings.Split(v, ",")
+	}
+	var out []string
+	for _, s := range raw {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// ValidateTelegramTriggers checks update types and that no two triggers of a
+// flow use the same bot, which can have only one webhook.
+func ValidateTelegramTriggers(definitionJSON string) error {
+	bots := map[string]string{}
+	for _, trigger := range TelegramTriggers(definitionJSON) {
+		for _, update := range TelegramAllowedUpdates(trigger) {
+			if _, ok := telegramUpdateTypes[update]; !ok {
+				return fmt.Errorf("%w: telegram trigger %s: unknown update type %q", ErrInvalidFlowDefinition, trigger.NodeID, update)
+			}
+		}
+		credentialID := configString(trigger.Config, "credentialId")
+		if credentialID == "" {
+			continue
+		}
+		if other, ok := bots[credentialID]; ok {
+			return fmt.Errorf("%w: telegram triggers %s and %s use the same bot; a bot has one webhook", ErrInvalidFlowDefinition, other, trigger.NodeID)
+		}
+		bots[credentialID] = trigger.NodeID
+	}
+	return nil
+}
+
+// TelegramSecretToken derives the secret token registered for a trigger's
+// webhook from the bot token, so it never has to be stored: a different
+// token per flow and node, made of the characters Telegram allows.
+func TelegramSecretToken(botToken string, flowID string, nodeID string) string {
+	mac := hmac.New(sha256.New, []byte(botToken))
+	mac.Write([]byte("telegram-webhook:" + flowID + ":" + nodeID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) checkTelegramSecret(ctx context.Context, flow domain.Flow, trigger domain.WebhookTrigger, req domain.WebhookRequest) error {
+	got := strings.TrimSpace(req.Headers[strings.ToLower(TelegramSecretHeader)])
+	if got == "" {
+		return rejectWebhook("missing %s header", TelegramSecretHeader)
+	}
+	secrets, err := s.webhookSecrets(ctx, flow, configString(trigger.Config, "credentialId"))
+	if err != nil {
+		return err
+	}
+	botToken := configString(secrets, "bot_token")
+	if botToken == "" {
+		botToken = configString(secrets, "token")
+	}
+	if botToken == "" {
+		return rejectWebhook("credential has no bot_token")
+	}
+	expected := TelegramSecretToken(botToken, flow.ID, trigger.NodeID)
+	if subtle.ConstantTimeCompare([]byte(got), []byte(expected)) != 1 {
+		return rejectWebhook("invalid %s header", TelegramSecretHeader)
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+)
+
+// Webhook trigger auth types (node config "authType").
+const (
+	WebhookAuthNone        = "none"
+	WebhookAuthHeaderToken = "headerToken"
+	WebhookAuthBasic       = "basicAuth"
+	WebhookAuthHMAC        = "hmac"
+)
+
+const (
+	defaultWebhookTokenHeader     = "X-Webhook-Token"
+	defaultWebhookSignatureHeader = "X-Signature"
+	defaultWebhookTolerance       = 5 * time.Minute
+	// untimedReplayWindow is how long signatures without a timestamp are
+	// remembered; nothing else bounds their replay.
+	untimedReplayWindow    = 24 * time.Hour
+	webhookNonceCacheLimit = 10000
+)
+
+var (
+	ErrWebhookUnauthorized = errors.New("webhook unauthorized")
+	ErrWebhookIPNotAllowed = errors.New("webhook client ip not allowed")
+)
+
+// WebhookRejection wraps ErrWebhookUnauthorized or ErrWebhookIPNotAllowed with
+// the detailed reason. The reason is for server logs only and must not be
+// returned to the caller.
+type WebhookRejection struct {
+	Err    error
+	Reason string
+}
+
+func (r *WebhookRejection) Error() string { return r.Err.Error() + ": " + r.Reason }
+
+func (r *WebhookRejection) Unwrap() error { return r.Err }
+
+func rejectWebhook(reason string, args ...any) error {
+	return &WebhookRejection{Err: ErrWebhookUnauthorized, Reason: fmt.Sprintf(reason, args...)}
+}
+
+// webhookNonces remembers recently accepted signatures so a captured request
+// cannot be replayed while they are kept.
+// State is per API instance.
+type webhookNonces struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// claim records nonce until expiresAt and reports false if it was already seen.
+func (n *webhookNonces) claim(nonce string, now time.Time, expiresAt time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if exp, ok := n.seen[nonce]; ok && now.Before(exp) {
+		return false
+	}
+	if len(n.seen) >= webhookNonceCacheLimit {
+		for key, exp := range n.seen {
+			if !now.Before(exp) {
+				delete(n.seen, key)
+			}
+		}
+	}
+	n.seen[nonce] = expiresAt
+	return true
+}
+
+// Authenticate checks an inbound call against the trigger's auth settings:
+// IP allowlist, then header token, basic auth or HMAC signature with replay
+// protection. Telegram triggers check the secret token registered with the
+// bot's webhook instead. Failures return a *WebhookRejection.
+func (s *WebhookService) Authenticate(ctx context.Context, flow domain.Flow, trigger domain.WebhookTrigger, req domain.WebhookRequest, rawBody []byte) error {
+	cfg := trigger.Config
+
+	if allowlist := configString(cfg, "ipAllowlist"); allowlist != "" {
+		if !ipAllowed(req.ClientIP, allowlist) {
+			return &WebhookRejection{Err: ErrWebhookIPNotAllowed, Reason: "client ip " + req.ClientIP + " is not in the allowlist"}
+		}
+	}
+
+	if trigger.NodeType == TelegramTriggerNodeType {
+		return s.checkTelegramSecret(ctx, flow, trigger, req)
+	}
+
+	authType := configString(cfg, "authType")
+	if authType == "" || authType == WebhookAuthNone {
+		return nil
+	}
+
+	secrets, err := s.webhookSecrets(ctx, flow, configString(cfg, "credentialId"))
+	if err != nil {
+		return err
+	}
+
+	switch authType {
+	case WebhookAuthHeaderToken:
+		return checkHeaderToken(cfg, req, secrets)
+	case WebhookAuthBasic:
+		return checkBasicAuth(req, secrets)
+	case WebhookAuthHMAC:
+		return s.checkSignature(flow, trigger, req, rawBody, secrets, time.Now())
+	default:
+		return rejectWebhook("unsupported authType %q", authType)
+	}
+}
+
+func (s *WebhookService) webhookSecrets(ctx context.Context, flow domain.Flow, credentialID string) (map[string]any, error) {
+	if credentialID == "" {
+		return nil, rejectWebhook("credentialId is required for webhook auth")
+	}
+	if s.creds == nil {
+		return nil, rejectWebhook("credential encryption is not configured")
+	}
+	cred, err := s.creds.GetForFlow(ctx, flow, credentialID)
+	if err != nil {
+		return nil, rejectWebhook("credential %s unavailable: %v", credentialID, err)
+	}
+	var payload map[string]any
+	if err := s.creds.DecryptPayload(cred.DataEncrypted, &payload); err != nil {
+		return nil, rejectWebhook("credential %s could not be decrypted: %v", credentialID, err)
+	}
+	return payload, nil
+}
+
+func checkHeaderToken(cfg map[string]any, req domain.WebhookRequest, secrets map[string]any) error {
+	expected := configString(secrets, "token")
+	if expected == "" {
+		return rejectWebhook("credential has no token")
+	}
+	header := configString(cfg, "authHeader")
+	if header == "" {
+		header = defaultWebhookTokenHeader
+	}
+	got := strings.TrimSpace(req.Headers[strings.ToLower(header)])
+	if len(got) > 7 && strings.EqualFold(got[:7], "bearer ") {
+		got = strings.TrimSpace(got[7:])
+	}
+	if got == "" {
+		return rejectWebhook("missing %s header", header)
+	}
+	if subtle.ConstantTimeCompare([]byte(got), []byte(expected)) != 1 {
+		return rejectWebhook("invalid token in %s header", header)
+	}
+	return nil
+}
+
+func checkBasicAuth(req domain.WebhookRequest, secrets map[string]any) error {
+	username := configString(secrets, "username")
+	password := configString(secrets, "password")
+	if username == "" {
+		return rejectWebhook("credential has no username")
+	}
+	raw := strings.TrimSpace(req.Headers["authorization"])
+	if len(raw) < 6 || !strings.EqualFold(raw[:6], "basic ") {
+		return rejectWebhook("missing basic authorization header")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(raw[6:]))
+	if err != nil {
+		return rejectWebhook("malformed basic authorization header")
+	}
+	gotUser, gotPass, _ := strings.Cut(string(decoded), ":")
+	userOK := subtle.ConstantTimeCompare([]byte(gotUser), []byte(username)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(gotPass), []byte(password)) == 1
+	if !userOK || !passOK {
+		return rejectWebhook("invalid basic auth credentials for user %q", gotUser)
+	}
+	return nil
+}
+
+// checkSignature verifies an HMAC signature. signatureStyle selects a preset:
+//   - github: X-Hub-Signature-256 = "sha256=" + hex(HMAC(body))
+//   - stripe: Stripe-Signature = "t=<unix>,v1=<hex>" over "<t>.<body>"
+//   - slack:  X-Slack-Signature = "v0=" + hex(HMAC("v0:<ts>:<body>")), X-Slack-Request-Timestamp
+//   - generic (default): configurable signatureHeader, signatureAlgorithm,
+//     signatureEncoding and optional timestampHeader (signs "<ts>.<body>")
+func (s *WebhookService) checkSignature(flow domain.Flow, trigger domain.WebhookTrigger, req domain.WebhookRequest, rawBody []byte, secrets map[string]any, now time.Time) error {
+	cfg := trigger.Config
+	secret := configString(secrets, "secret")
+	if secret == "" {
+		return rejectWebhook("credential has no secret")
+	}
+	tolerance := defaultWebhookTolerance
+	if v := configString(cfg, "toleranceSeconds"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			tolerance = time.Duration(secs) * time.Second
+		}
+	}
+	header := func(name string) string { return strings.TrimSpace(req.Headers[strings.ToLower(name)]) }
+
+	var (
+		newHash    = sha256.New
+		encoding   = "hex"
+		signed     []byte
+		candidates []string
+		timestamp  string
+	)
+
+	switch strings.ToLower(configString(cfg, "signatureStyle")) {
+	case "github":
+		value := header("X-Hub-Signature-256")
+		if value == "" {
+			return rejectWebhook("missing X-Hub-Signature-256 header")
+		}
+		candidates = []string{strings.TrimPrefix(value, "sha256=")}
+		signed = rawBody
+	case "stripe":
+		value := header("Stripe-Signature")
+		if value == "" {
+			return rejectWebhook("missing Stripe-Signature header")
+		}
+		for _, part := range strings.Split(value, ",") {
+			key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch key {
+			case "t":
+				timestamp = val
+			case "v1":
+				candidates = append(candidates, val)
+			}
+		}
+		if timestamp == "" || len(candidates) == 0 {
+			return rejectWebhook("malformed Stripe-Signature header")
+		}
+		signed = append([]byte(timestamp+"."), rawBody...)
+	case "slack":
+		value := header("X-Slack-Signature")
+		timestamp = header("X-Slack-Request-Timestamp")
+		if value == "" || timestamp == "" {
+			return rejectWebhook("missing X-Slack-Signature or X-Slack-Request-Timestamp header")
+		}
+		candidates = []string{strings.TrimPrefix(value, "v0=")}
+		signed = append([]byte("v0:"+timestamp+":"), rawBody...)
+	default:
+		name := configString(cfg, "signatureHeader")
+		if name == "" {
+			name = defaultWebhookSignatureHeader
+		}
+		value := header(name)
+		if value == "" {
+			return rejectWebhook("missing %s header", name)
+		}
+		algorithm := strings.ToLower(configString(cfg, "signatureAlgorithm"))
+		switch algorithm {
+		case "", "sha256":
+			algorithm = "sha256"
+		case "sha1":
+			newHash = sha1.New
+		case "sha512":
+			newHash = sha512.New
+		default:
+			return rejectWebhook("unsupported signatureAlgorithm %q", algorithm)
+		}
+		if strings.EqualFold(configString(cfg, "signatureEncoding"), "base64") {
+			encoding = "base64"
+		}
+		candidates = []string{strings.TrimPrefix(value, algorithm+"=")}
+		signed = rawBody
+		if tsHeader := configString(cfg, "timestampHeader"); tsHeader != "" {
+			timestamp = header(tsHeader)
+			if timestamp == "" {
+				return rejectWebhook("missing %s header", tsHeader)
+			}
+			signed = append([]byte(timestamp+"."), rawBody...)
+		}
+	}
+
+	if timestamp != "" {
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return rejectWebhook("invalid signature timestamp %q", timestamp)
+		}
+		age := now.Sub(time.Unix(unix, 0))
+		if age > tolerance || age < -tolerance {
+			return rejectWebhook("signature timestamp outside tolerance (%s)", age.Round(time.Second))
+		}
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(signed)
+	expected := encodeSignature(mac, encoding)
+	matched := false
+	for _, candidate := range candidates {
+		if hmac.Equal([]byte(strings.TrimSpace(candidate)), []byte(expected)) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return rejectWebhook("signature mismatch")
+	}
+
+	// Replay protection: the nonce is the signature, since unsigned headers
+	// such as a delivery ID can be changed by whoever captured the request.
+	// A timestamped signature is refused once it leaves the tolerance window,
+	// so it is kept that long; one without a timestamp is kept for a day.
+	window := tolerance
+	if timestamp == "" {
+		window = untimedReplayWindow
+	}
+	key := flow.ID + ":" + trigger.NodeID + ":" + expected
+	if !s.nonces.claim(key, now, now.Add(window)) {
+		return rejectWebhook("replayed request (signature already seen)")
+	}
+	return nil
+}
+
+func encodeSignature(mac hash.Hash, encoding string) string {
+	sum := mac.Sum(nil)
+	if encoding == "base64" {
+		return base64.StdEncoding.EncodeToString(sum)
+	}
+	return hex.EncodeToString(sum)
+}
+
+// ipAllowed reports whether ip matches one of the comma/newline separated
+// addresses or CIDR ranges in allowlist.
+func ipAllowed(ip string, allowlist string) bool {
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	if addr == nil {
+		return false
+	}
+	entries := strings.FieldsFunc(allowlist, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' ' || r == '\t' || r == '\r'
+	})
+	for _, entry := range entries {
+		if strings.Contains(entry, "/") {
+			if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func configString(cfg map[string]any, key string) string {
+	if cfg == nil {
+		return ""
+	}
+	switch v := cfg[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return ""
+	}
+}
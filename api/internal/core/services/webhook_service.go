@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports"
+	"flowcraft-api/internal/utils"
+)
+
+// Webhook modes: test calls only work while the builder is listening,
+// production calls start a run only for active flows.
+const (
+	WebhookModeTest       = "test"
+	WebhookModeProduction = "production"
+)
+
+// Response modes decide when a webhook caller gets its answer: immediately
+// with 202, or after the run finishes with the output of the last node or of
+// a respondToWebhook node.
+const (
+	WebhookResponseImmediately = "immediately"
+	WebhookResponseLastNode    = "lastNode"
+	WebhookResponseRespondNode = "respondNode"
+)
+
+const (
+	defaultWebhookResponseTimeout = 30 * time.Second
+	maxWebhookResponseTimeout     = 120 * time.Second
+)
+
+var ErrWebhookMethodNotAllowed = errors.New("webhook method not allowed")
+
+type WebhookService struct {
+	flows  ports.FlowRepository
+	runs   ports.RunRepository
+	steps  ports.RunStepRepository
+	creds  *CredentialService
+	nonces *webhookNonces
+}
+
+// NewWebhookService wires the webhook trigger service. creds may be nil when
+// credential encryption is not configured; triggers with auth then reject calls.
+func NewWebhookService(flows ports.FlowRepository, runs ports.RunRepository, steps ports.RunStepRepository, creds *CredentialService) *WebhookService {
+	return &WebhookService{
+		flows:  flows,
+		runs:   runs,
+		steps:  steps,
+		creds:  creds,
+		nonces: &webhookNonces{seen: map[string]time.Time{}},
+	}
+}
+
+// Resolve finds the webhook trigger of flowID that serves path and method.
+// It returns utils.ErrNotFound when no trigger matches the path and
+// ErrWebhookMethodNotAllowed when the path matches with a different method.
+func (s *WebhookService) Resolve(ctx context.Context, flowID string, path string, method string, mode string) (*domain.Flow, *domain.WebhookTrigger, error) {
+	flow, err := s.flows.Get(ctx, flowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mode == WebhookModeProduction && !FlowActive(*flow) {
+		return nil, nil, utils.ErrNotFound
+	}
+
+	path = NormalizeWebhookPath(path)
+	method = strings.ToUpper(strings.TrimSpace(method))
+	pathMatched := false
+	for _, trigger := range WebhookTriggers(flow.DefinitionJSON) {
+		if trigger.Path != path {
+			continue
+		}
+		pathMatched = true
+		if trigger.Method == "" || trigger.Method == method {
+			t := trigger
+			return flow, &t, nil
+		}
+	}
+	if pathMatched {
+		return nil, nil, ErrWebhookMethodNotAllowed
+	}
+	return nil, nil, utils.ErrNotFound
+}
+
+// CreateRun records a queued run started by trigger with the captured request as payload.
+// Auth headers are masked before the payload is stored.
+func (s *WebhookService) CreateRun(ctx context.Context, flow domain.Flow, trigger domain.WebhookTrigger, req domain.WebhookRequest) (domain.Run, error) {
+	req.Headers = redactWebhookHeaders(req.Headers, trigger)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return domain.Run{}, err
+	}
+	run := domain.Run{
+		ID:             utils.NewUUID(),
+		FlowID:         flow.ID,
+		Status:         "queued",
+		Log:            "queued (webhook " + req.Mode + ")",
+		TriggerNodeID:  trigger.NodeID,
+		TriggerPayload: payload,
+	}
+	run.TemporalWorkflow = "run-" + run.ID
+	return run, s.runs.Create(ctx, run)
+}
+
+// ResponseMode returns the trigger's responseMode, defaulting to "immediately".
+func ResponseMode(trigger domain.WebhookTrigger) string {
+	switch configString(trigger.Config, "responseMode") {
+	case WebhookResponseLastNode:
+		return WebhookResponseLastNode
+	case WebhookResponseRespondNode:
+		return WebhookResponseRespondNode
+	default:
+		return WebhookResponseImmediately
+	}
+}
+
+// ResponseTimeout is how long a waiting caller is held before falling back to
+// 202. responseTimeoutSeconds defaults to 30 and is capped at 120.
+func ResponseTimeout(trigger domain.WebhookTrigger) time.Duration {
+	seconds, err := strconv.Atoi(configString(trigger.Config, "responseTimeoutSeconds"))
+	if err != nil || seconds <= 0 {
+		return defaultWebhookResponseTimeout
+	}
+	timeout := time.Duration(seconds) * time.Second
+	if timeout > maxWebhookResponseTimeout {
+		return maxWebhookResponseTimeout
+	}
+	return timeout
+}
+
+// BuildResponse derives the caller's response from the finished steps of runID.
+// In respondNode mode the first successful respondToWebhook step wins; in
+// lastNode mode the data of the last successful step is returned with 200.
+// It reports false when no step produced a response.
+func (s *WebhookService) BuildResponse(ctx context.Context, runID string, mode string) (domain.WebhookResponse, bool, error) {
+	steps, err := s.steps.ListByRunID(ctx, runID)
+	if err != nil {
+		return domain.WebhookResponse{}, false, err
+	}
+
+	switch mode {
+	case WebhookResponseRespondNode:
+		for _, step := range steps {
+			if step.NodeType != "respondToWebhook" || step.Status != "success" {
+				continue
+			}
+			var out struct {
+				Data struct {
+					StatusCode int               `json:"statusCode"`
+					Headers    map[string]string `json:"headers"`
+					Body       any               `json:"body"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(step.OutputsJSON, &out); err != nil {
+				return domain.WebhookResponse{}, false, err
+			}
+			statusCode := out.Data.StatusCode
+			if statusCode == 0 {
+				statusCode = http.StatusOK
+			}
+			return domain.WebhookResponse{StatusCode: statusCode, Headers: out.Data.Headers, Body: out.Data.Body}, true, nil
+		}
+	case WebhookResponseLastNode:
+		body, ok, err := LastStepOutput(steps)
+		if err != nil {
+			return domain.WebhookResponse{}, false, err
+		}
+		if ok {
+			return domain.WebhookResponse{StatusCode: http.StatusOK, Body: body}, true, nil
+		}
+	}
+	return domain.WebhookResponse{}, false, nil
+}
+
+// LastStepOutput returns the data of the step that finished last among the
+// successful steps of a run, i.e. the run's final output.
+func LastStepOutput(steps []domain.RunStep) (any, bool, error) {
+	var last *domain.RunStep
+	for i := range steps {
+		step := &steps[i]
+		if step.Status != "success" || step.FinishedAt == nil {
+			continue
+		}
+		if last == nil || step.FinishedAt.After(*last.FinishedAt) {
+			last = step
+		}
+	}
+	if last == nil {
+		return nil, false, nil
+	}
+	var out map[string]any
+	if err := json.Unmarshal(last.OutputsJSON, &out); err != nil {
+		return nil, false, err
+	}
+	if data, ok := out["data"]; ok {
+		return data, true, nil
+	}
+	return out, true, nil
+}
+
+// WebhookTriggers lists the webhook, httpTrigger and telegramTrigger nodes of
+// a flow definition.
+func WebhookTriggers(definitionJSON string) []domain.WebhookTrigger {
+	type flowDef struct {
+		Reactflow struct {
+			Nodes []struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+				Data struct {
+					NodeType string         `json:"nodeType"`
+					Config   map[string]any `json:"config"`
+				} `json:"data"`
+			} `json:"nodes"`
+		} `json:"reactflow"`
+	}
+
+	var def flowDef
+	if err := json.Unmarshal([]byte(definitionJSON), &def); err != nil {
+		return nil
+	}
+
+	out := make([]domain.WebhookTrigger, 0, 1)
+	for _, node := range def.Reactflow.Nodes {
+		nodeType := strings.TrimSpace(node.Data.NodeType)
+		if nodeType == "" {
+			nodeType = strings.TrimSpace(node.Type)
+		}
+		if nodeType == TelegramTriggerNodeType {
+			out = append(out, telegramWebhookTrigger(node.ID, node.Data.Config))
+			continue
+		}
+		if nodeType != "webhook" && nodeType != "httpTrigger" {
+			continue
+		}
+		cfg := node.Data.Config
+		if cfg == nil {
+			cfg = map[string]any{}
+		}
+		path, _ := cfg["path"].(string)
+		method, _ := cfg["method"].(string)
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "ANY" {
+			method = ""
+		}
+		out = append(out, domain.WebhookTrigger{
+			NodeID:   node.ID,
+			NodeType: nodeType,
+			Path:     NormalizeWebhookPath(path),
+			Method:   method,
+			Config:   cfg,
+		})
+	}
+	return out
+}
+
+// NormalizeWebhookPath trims whitespace and surrounding slashes so "/incoming/"
+// and "incoming" address the same trigger.
+func NormalizeWebhookPath(path string) string {
+	return strings.Trim(strings.TrimSpace(path), "/")
+}
+
+func redactWebhookHeaders(headers map[string]string, trigger domain.WebhookTrigger) map[string]string {
+	secret := map[string]struct{}{"authorization": {}, "cookie": {}}
+	if name := configString(trigger.Config, "authHeader"); name != "" {
+		secret[strings.ToLower(name)] = struct{}{}
+	} else if configString(trigger.Config, "authType") == WebhookAuthHeaderToken {
+		secret[strings.ToLower(defaultWebhookTokenHeader)] = struct{}{}
+	}
+	if trigger.NodeType == TelegramTriggerNodeType {
+		secret[strings.ToLower(TelegramSecretHeader)] = struct{}{}
+	}
+	out := make(map[string]string, len(headers))
+	for key, value := range headers {
+		if _, ok := secret[key]; ok {
+			value = "***"
+		}
+		out[key] = value
+	}
+	return out
+}
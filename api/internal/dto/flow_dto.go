@@ -18,8 +18,27 @@ type FlowResponse struct {
 	ProjectID      string        `json:"projectId,omitempty"`
 	Project        *ProjectRef   `json:"project,omitempty"`
 	Status         string        `json:"status"`
+	StatusReason   string        `json:"statusReason,omitempty"`
 	Version        int           `json:"version"`
 	DefinitionJSON string        `json:"definitionJson"`
 	UpdatedAt      string        `json:"updatedAt,omitempty"`
 	Owner          *UserResponse `json:"owner,omitempty"`
 }
+
+type FlowScheduleResponse struct {
+	FlowID   string                    `json:"flowId"`
+	Active   bool                      `json:"active"`
+	Paused   bool                      `json:"paused"`
+	PausedAt string                    `json:"pausedAt,omitempty"`
+	Triggers []CronTriggerScheduleItem `json:"triggers"`
+}
+
+type CronTriggerScheduleItem struct {
+	NodeID        string   `json:"nodeId"`
+	Expression    string   `json:"expression"`
+	Timezone      string   `json:"timezone"`
+	MisfirePolicy string   `json:"misfirePolicy"`
+	LastFiredAt   string   `json:"lastFiredAt,omitempty"`
+	NextRuns      []string `json:"nextRuns"`
+	Error         string   `json:"error,omitempty"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+type FormFieldResponse struct {
+	Name        string   `json:"name"`
+	Label       string   `json:"label"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required"`
+	Options     []string `json:"options,omitempty"`
+	Placeholder string   `json:"placeholder,omitempty"`
+}
+
+type FormDefinitionResponse struct {
+	FlowID            string              `json:"flowId"`
+	NodeID            string              `json:"nodeId"`
+	Title             string              `json:"title"`
+	Description       string              `json:"description"`
+	ButtonLabel       string              `json:"buttonLabel"`
+	PasswordProtected bool                `json:"passwordProtected"`
+	Fields            []FormFieldResponse `json:"fields"`
+	URL               string              `json:"url"`
+}
+
+type FormSubmittedResponse struct {
+	RunID       string `json:"runId"`
+	Status      string `json:"status"`
+	Message     string `json:"message,omitempty"`
+	RedirectURL string `json:"redirectUrl,omitempty"`
+}
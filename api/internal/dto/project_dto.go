@@ -29,3 +29,13 @@ type ProjectMemberResponse struct {
 	Role string       `json:"role"`
 }
 
+
+type EgressPolicyRequest struct {
+	AllowHosts []string `json:"allowHosts"`
+	DenyHosts  []string `json:"denyHosts"`
+}
+
+type EgressPolicyResponse struct {
+	AllowHosts []string `json:"allowHosts"`
+	DenyHosts  []string `json:"denyHosts"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+type WebhookAcceptedResponse struct {
+	RunID  string `json:"runId"`
+	Status string `json:"status"`
+	Mode   string `json:"mode"`
+}
+
+type WebhookEndpointResponse struct {
+	NodeID        string `json:"nodeId"`
+	NodeType      string `json:"nodeType"`
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	TestURL       string `json:"testUrl"`
+	ProductionURL string `json:"productionUrl"`
+}
+
+type WebhookListenResponse struct {
+	FlowID    string                    `json:"flowId"`
+	ExpiresAt string                    `json:"expiresAt"`
+	Webhooks  []WebhookEndpointResponse `json:"webhooks"`
+}
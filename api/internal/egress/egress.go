@@ -0,0 +1,252 @@
+// Package egress enforces the outbound HTTP policy of the worker: which hosts
+// flows may call. Addresses are checked after DNS resolution, on every
+// connection, so a name that resolves to a private address (or rebinds to
+// one) and a redirect into the internal network are both caught.
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/config"
+)
+
+// ErrBlocked is matched by every error for a request the policy refused.
+var ErrBlocked = errors.New("egress blocked")
+
+// Category is the error category of steps whose request was blocked.
+const Category = "egress_blocked"
+
+const defaultMaxRedirects = 5
+
+// BlockedError reports a request the policy refused.
+type BlockedError struct {
+	Host   string
+	Reason string
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("egress blocked: %s: %s", e.Host, e.Reason)
+}
+
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrBlocked
+}
+
+// Policy is the deployment-wide egress policy.
+type Policy struct {
+	// Allow exempts hosts from the private address check, e.g. an internal
+	// GitHub Enterprise Server or a local model server.
+	Allow HostList
+	// Deny blocks hosts whatever they resolve to.
+	Deny HostList
+	// AllowPrivate turns the private address check off.
+	AllowPrivate bool
+	// MaxRedirects caps the redirects one request may follow.
+	MaxRedirects int
+}
+
+// FromConfig reads the policy from EGRESS_ALLOW_HOSTS, EGRESS_DENY_HOSTS,
+// EGRESS_ALLOW_PRIVATE and EGRESS_MAX_REDIRECTS.
+func FromConfig(cfg config.Config) (Policy, error) {
+	allow, err := ParseHostList(splitList(cfg.EgressAllowHosts))
+	if err != nil {
+		return Policy{}, fmt.Errorf("EGRESS_ALLOW_HOSTS: %w", err)
+	}
+	deny, err := ParseHostList(splitList(cfg.EgressDenyHosts))
+	if err != nil {
+		return Policy{}, fmt.Errorf("EGRESS_DENY_HOSTS: %w", err)
+	}
+	maxRedirects := defaultMaxRedirects
+	if raw := strings.TrimSpace(cfg.EgressMaxRedirects); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return Policy{}, fmt.Errorf("EGRESS_MAX_REDIRECTS: invalid value %q", raw)
+		}
+		maxRedirects = n
+	}
+	return Policy{
+		Allow:        allow,
+		Deny:         deny,
+		AllowPrivate: strings.EqualFold(strings.TrimSpace(cfg.EgressAllowPrivate), "true"),
+		MaxRedirects: maxRedirects,
+	}, nil
+}
+
+// Rules narrow the policy for one project: Deny blocks more hosts, and a
+// non-empty Allow permits only the hosts it lists. Rules never allow what
+// the deployment policy blocks.
+type Rules struct {
+	Allow HostList
+	Deny  HostList
+}
+
+type rulesKey struct{}
+
+// WithRules returns ctx whose requests are also held to rules, in addition
+// to any rules ctx already holds, e.g. of a flow's and a credential's project.
+func WithRules(ctx context.Context, rules Rules) context.Context {
+	return context.WithValue(ctx, rulesKey{}, append(slices.Clip(rulesFrom(ctx)), rules))
+}
+
+func rulesFrom(ctx context.Context) []Rules {
+	rules, _ := ctx.Value(rulesKey{}).([]Rules)
+	return rules
+}
+
+// Check decides whether host, resolved to ips, may be called with ctx.
+func (p Policy) Check(ctx context.Context, host string, ips []net.IP) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if p.Deny.Match(host, ips...) {
+		return &BlockedError{Host: host, Reason: "host is on the deny list"}
+	}
+	for _, rules := range rulesFrom(ctx) {
+		if rules.Deny.Match(host, ips...) {
+			return &BlockedError{Host: host, Reason: "host is on the deny list"}
+		}
+		if len(rules.Allow) > 0 && !rules.Allow.Match(host, ips...) {
+			return &BlockedError{Host: host, Reason: "host is not on the project allow list"}
+		}
+	}
+	if p.AllowPrivate || p.Allow.Match(host, ips...) {
+		return nil
+	}
+	for _, ip := range ips {
+		if IsPrivate(ip) {
+			return &BlockedError{Host: host, Reason: fmt.Sprintf("resolves to private address %s", ip)}
+		}
+	}
+	return nil
+}
+
+var reservedNets = mustCIDRs(
+	"0.0.0.0/8",     // "this" network
+	"100.64.0.0/10", // carrier-grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"240.0.0.0/4",   // reserved, broadcast
+	"64:ff9b::/96",  // NAT64, embeds IPv4 addresses
+)
+
+// IsPrivate reports whether ip is loopback, private, link-local (including
+// cloud metadata at 169.254.169.254), multicast, unspecified or reserved.
+func IsPrivate(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, n := range reservedNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTransport returns an http.RoundTripper that enforces p on every
+// connection and redirect. Proxies are not used: a proxy would make the
+// policy check the proxy's address instead of the target's.
+func NewTransport(p Policy) http.RoundTripper {
+	base := stdTransport.Clone()
+	base.Proxy = nil
+	t := &transport{
+		policy: p,
+		base:   base,
+		lookup: net.DefaultResolver.LookupIPAddr,
+		dialer: net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+	}
+	base.DialContext = t.dial
+	return t
+}
+
+// stdTransport is net/http's default transport, captured before Install
+// replaces it.
+var stdTransport = http.DefaultTransport.(*http.Transport)
+
+// Install makes NewTransport(p) the transport of every client without one
+// of its own, which covers the HTTP Request node, chat models and connectors.
+func Install(p Policy) {
+	http.DefaultTransport = NewTransport(p)
+}
+
+type transport struct {
+	policy Policy
+	base   *http.Transport
+	lookup func(ctx context.Context, host string) ([]net.IPAddr, error)
+	dialer net.Dialer
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests the client creates to follow a redirect link the response
+	// that caused them; the chain length is the redirect count.
+	redirects := 0
+	for res := req.Response; res != nil && res.Request != nil; res = res.Request.Response {
+		redirects++
+	}
+	if redirects > t.policy.MaxRedirects {
+		return nil, &BlockedError{Host: req.URL.Hostname(), Reason: fmt.Sprintf("more than %d redirects", t.policy.MaxRedirects)}
+	}
+	// Pooled connections skip dial, where the check runs. They passed the
+	// deployment policy, but project rules differ per request.
+	if len(rulesFrom(req.Context())) > 0 {
+		if _, err := t.resolve(req.Context(), req.URL.Hostname()); err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// dial resolves addr, checks every address and connects to a checked one,
+// so the name cannot resolve differently between the check and the dial.
+func (t *transport) dial(ctx context.Context, network string, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := t.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var lastErr error
+	for _, ip := range ips {
+		conn, err := t.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// resolve looks host up and checks it with every address it resolves to.
+func (t *transport) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := t.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range addrs {
+			ips = append(ips, a.IP)
+		}
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("egress: no addresses for %s", host)
+	}
+	if err := t.policy.Check(ctx, host, ips); err != nil {
+		return nil, err
+	}
+	return ips, nil
+}
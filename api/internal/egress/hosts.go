@@ -0,0 +1,93 @@
+package egress
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// HostList matches hosts by name ("api.example.com"), subdomain wildcard
+// ("*.example.com", which also matches example.com), IP address or CIDR
+// range ("10.0.0.0/8").
+type HostList []hostPattern
+
+type hostPattern struct {
+	name     string
+	wildcard bool
+	network  *net.IPNet
+}
+
+// ParseHostList parses host list entries, ignoring blank ones.
+func ParseHostList(entries []string) (HostList, error) {
+	var out HostList
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			_, network, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q", entry)
+			}
+			out = append(out, hostPattern{network: network})
+			continue
+		}
+		if ip := net.ParseIP(strings.Trim(entry, "[]")); ip != nil {
+			bits := 8 * len(ip.To16())
+			if v4 := ip.To4(); v4 != nil {
+				ip, bits = v4, 32
+			}
+			out = append(out, hostPattern{network: &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}})
+			continue
+		}
+		wildcard := strings.HasPrefix(entry, "*.")
+		name := strings.TrimSuffix(strings.TrimPrefix(entry, "*."), ".")
+		if name == "" || strings.ContainsAny(name, "*:@ ") {
+			return nil, fmt.Errorf("invalid host %q", entry)
+		}
+		out = append(out, hostPattern{name: name, wildcard: wildcard})
+	}
+	return out, nil
+}
+
+// Match reports whether host or any of ips is on the list.
+func (l HostList) Match(host string, ips ...net.IP) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, p := range l {
+		if p.network != nil {
+			for _, ip := range ips {
+				if p.network.Contains(ip) {
+					return true
+				}
+			}
+			if ip := net.ParseIP(host); ip != nil && p.network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if host == p.name || (p.wildcard && strings.HasSuffix(host, "."+p.name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits a comma or whitespace separated list.
+func splitList(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+}
+
+func mustCIDRs(cidrs ...string) []*net.IPNet {
+	out := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, network, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		out = append(out, network)
+	}
+	return out
+}
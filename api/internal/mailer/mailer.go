@@ -3,13 +3,14 @@ package mailer
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"embed"
 	"fmt"
 	"html/template"
-	"net/smtp"
+	"strconv"
 	"strings"
 	"time"
+
+	"flowcraft-api/internal/config"
 )
 
 //go:embed templates/*.html
@@ -40,9 +41,44 @@ type ResetTemplateData struct {
 	SupportURL    string
 }
 
+type FlowDeactivatedTemplateData struct {
+	DisplayName string
+	FlowName    string
+	Failures    int
+	FlowURL     string
+	SupportURL  string
+}
+
+// FromConfig builds a mailer from the SMTP settings. It returns nil when
+// SMTP_HOST or SMTP_FROM is not set.
+func FromConfig(cfg config.Config) (*Mailer, error) {
+	if strings.TrimSpace(cfg.SMTPHost) == "" || strings.TrimSpace(cfg.SMTPFrom) == "" {
+		return nil, nil
+	}
+	parseBool := func(value string) bool {
+		parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+		return err == nil && parsed
+	}
+	port, err := strconv.Atoi(strings.TrimSpace(cfg.SMTPPort))
+	if err != nil || port == 0 {
+		port = 587
+	}
+	return New(Config{
+		Host:        cfg.SMTPHost,
+		Port:        port,
+		Username:    cfg.SMTPUser,
+		Password:    cfg.SMTPPass,
+		From:        cfg.SMTPFrom,
+		UseTLS:      parseBool(cfg.SMTPUseTLS),
+		UseStartTLS: parseBool(cfg.SMTPUseStartTLS),
+		AppBaseURL:  cfg.AppBaseURL,
+		SupportURL:  cfg.SMTPSupportURL,
+	})
+}
+
 func New(cfg Config) (*Mailer, error) {
 	tmpls := make(map[string]*template.Template)
-	for _, name := range []string{"reset_password_en.html", "reset_password_th.html"} {
+	for _, name := range []string{"reset_password_en.html", "reset_password_th.html", "flow_deactivated_en.html"} {
 		raw, err := templatesFS.ReadFile("templates/" + name)
 		if err != nil {
 			return nil, err
@@ -86,6 +122,44 @@ func (m *Mailer) SendPasswordReset(ctx context.Context, to string, displayName s
 	return m.send(ctx, []string{to}, msg)
 }
 
+// SendFlowDeactivated tells a flow owner that the flow was turned off after
+// failing the given number of times in a row.
+func (m *Mailer) SendFlowDeactivated(ctx context.Context, to string, displayName string, flowID string, flowName string, failures int) error {
+	if strings.TrimSpace(to) == "" {
+		return fmt.Errorf("missing recipient")
+	}
+	if displayName == "" {
+		displayName = to
+	}
+	baseURL := strings.TrimRight(m.cfg.AppBaseURL, "/")
+	supportURL := m.cfg.SupportURL
+	if supportURL == "" {
+		supportURL = baseURL + "/docs"
+	}
+	data := FlowDeactivatedTemplateData{
+		DisplayName: displayName,
+		FlowName:    flowName,
+		Failures:    failures,
+		FlowURL:     baseURL + "/flows/" + flowID,
+		SupportURL:  supportURL,
+	}
+
+	var buf bytes.Buffer
+	if err := m.templates["flow_deactivated_en.html"].Execute(&buf, data); err != nil {
+		return err
+	}
+	textBody := fmt.Sprintf(
+		"Hello %s,\n\nYour flow \"%s\" failed %d times in a row, so its triggers were turned off. Schedules, webhooks, polling and forms will not start it until you activate it again.\n\nReview the flow: %s\n\nBest regards,\nThe FlowCraft Team",
+		data.DisplayName,
+		data.FlowName,
+		data.Failures,
+		data.FlowURL,
+	)
+	subject := fmt.Sprintf("[FlowCraft] Flow \"%s\" was deactivated", flowName)
+	msg := buildMIMEMessage(m.cfg.From, to, subject, textBody, buf.String())
+	return m.send(ctx, []string{to}, msg)
+}
+
 func (m *Mailer) renderResetHTML(lang string, data ResetTemplateData) (string, error) {
 	key := "reset_password_en.html"
 	if strings.EqualFold(lang, "th") {
@@ -140,76 +214,13 @@ func buildMIMEMessage(from string, to string, subject string, textBody string, h
 	return buf.Bytes()
 }
 
-func (m *Mailer) send(_ context.Context, to []string, msg []byte) error {
-	addr := fmt.Sprintf("%s:%d", m.cfg.Host, m.cfg.Port)
-	auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
-	if m.cfg.UseTLS {
-		tlsCfg := &tls.Config{ServerName: m.cfg.Host}
-		conn, err := tls.Dial("tcp", addr, tlsCfg)
-		if err != nil {
-			return err
-		}
-		c, err := smtp.NewClient(conn, m.cfg.Host)
-		if err != nil {
-			return err
-		}
-		defer c.Quit()
-		if ok, _ := c.Extension("AUTH"); ok {
-			if err := c.Auth(auth); err != nil {
-				return err
-			}
-		}
-		if err := c.Mail(m.cfg.From); err != nil {
-			return err
-		}
-		for _, rcpt := range to {
-			if err := c.Rcpt(rcpt); err != nil {
-				return err
-			}
-		}
-		w, err := c.Data()
-		if err != nil {
-			return err
-		}
-		if _, err := w.Write(msg); err != nil {
-			_ = w.Close()
-			return err
-		}
-		return w.Close()
-	}
-	if m.cfg.UseStartTLS {
-		c, err := smtp.Dial(addr)
-		if err != nil {
-			return err
-		}
-		defer c.Quit()
-		if ok, _ := c.Extension("STARTTLS"); ok {
-			if err := c.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
-				return err
-			}
-		}
-		if ok, _ := c.Extension("AUTH"); ok {
-			if err := c.Auth(auth); err != nil {
-				return err
-			}
-		}
-		if err := c.Mail(m.cfg.From); err != nil {
-			return err
-		}
-		for _, rcpt := range to {
-			if err := c.Rcpt(rcpt); err != nil {
-				return err
-			}
-		}
-		w, err := c.Data()
-		if err != nil {
-			return err
-		}
-		if _, err := w.Write(msg); err != nil {
-			_ = w.Close()
-			return err
-		}
-		return w.Close()
-	}
-	return smtp.SendMail(addr, auth, m.cfg.From, to, msg)
+func (m *Mailer) send(ctx context.Context, to []string, msg []byte) error {
+	return Transport{
+		Host:        m.cfg.Host,
+		Port:        m.cfg.Port,
+		Username:    m.cfg.Username,
+		Password:    m.cfg.Password,
+		UseTLS:      m.cfg.UseTLS,
+		UseStartTLS: m.cfg.UseStartTLS,
+	}.Send(ctx, m.cfg.From, to, msg)
 }
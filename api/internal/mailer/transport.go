@@ -0,0 +1,120 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// defaultTimeout bounds an SMTP session when ctx has no deadline.
+const defaultTimeout = 60 * time.Second
+
+// Transport delivers messages to an SMTP server. UseTLS connects with
+// implicit TLS (usually port 465). Otherwise the connection is upgraded with
+// STARTTLS when the server offers it, and must be when UseStartTLS is set.
+// Credentials are only sent over TLS or to localhost.
+type Transport struct {
+	Host        string
+	Port        int
+	Username    string
+	Password    string
+	UseTLS      bool
+	UseStartTLS bool
+}
+
+// Send delivers msg, a complete RFC 5322 message, from the envelope sender
+// from to the envelope recipients to.
+func (t Transport) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	if len(to) == 0 {
+		return errors.New("smtp: no recipients")
+	}
+	c, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("smtp: recipient %s: %w", rcpt, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		_ = w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// Check connects, negotiates TLS and authenticates without sending anything.
+func (t Transport) Check(ctx context.Context) error {
+	c, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	return c.Quit()
+}
+
+// dial opens an SMTP session that is ready for MAIL FROM.
+func (t Transport) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	var conn net.Conn
+	var err error
+	if t.UseTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: t.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, t.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if !t.UseTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: t.Host}); err != nil {
+				_ = c.Close()
+				return nil, err
+			}
+		} else if t.UseStartTLS {
+			_ = c.Close()
+			return nil, errors.New("smtp: server does not support STARTTLS")
+		}
+	}
+	if t.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			_ = c.Close()
+			return nil, errors.New("smtp: server does not support AUTH")
+		}
+		if err := c.Auth(smtp.PlainAuth("", t.Username, t.Password, t.Host)); err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
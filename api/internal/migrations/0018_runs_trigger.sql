@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS trigger_node_id TEXT;
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS trigger_payload JSONB;
+
+-- +goose Down
+ALTER TABLE runs DROP COLUMN IF EXISTS trigger_payload;
+ALTER TABLE runs DROP COLUMN IF EXISTS trigger_node_id;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS flow_schedules (
+    flow_id UUID PRIMARY KEY REFERENCES flows(id) ON DELETE CASCADE,
+    paused_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    paused_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS schedule_fires (
+    flow_id UUID NOT NULL REFERENCES flows(id) ON DELETE CASCADE,
+    node_id TEXT NOT NULL,
+    last_fired_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (flow_id, node_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS schedule_fires;
+DROP TABLE IF EXISTS flow_schedules;
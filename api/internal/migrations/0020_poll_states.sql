@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS poll_states (
+    flow_id UUID NOT NULL REFERENCES flows(id) ON DELETE CASCADE,
+    node_id TEXT NOT NULL,
+    cursor TEXT NOT NULL DEFAULT '',
+    seen_ids JSONB NOT NULL DEFAULT '[]'::jsonb,
+    last_polled_at TIMESTAMPTZ,
+    last_error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (flow_id, node_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS poll_states;
@@ -0,0 +1,15 @@
+-- +goose Up
+UPDATE flows SET status = 'draft' WHERE status NOT IN ('draft', 'active', 'inactive', 'archived');
+
+ALTER TABLE flows
+    ADD CONSTRAINT flows_status_check CHECK (status IN ('draft', 'active', 'inactive', 'archived')),
+    ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS consecutive_failures INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_counted_run_id UUID;
+
+-- +goose Down
+ALTER TABLE flows
+    DROP COLUMN IF EXISTS last_counted_run_id,
+    DROP COLUMN IF EXISTS consecutive_failures,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP CONSTRAINT IF EXISTS flows_status_check;
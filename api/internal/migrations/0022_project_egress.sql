@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE projects
+    ADD COLUMN IF NOT EXISTS egress_allow_hosts JSONB NOT NULL DEFAULT '[]'::jsonb,
+    ADD COLUMN IF NOT EXISTS egress_deny_hosts JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- +goose Down
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS egress_deny_hosts,
+    DROP COLUMN IF EXISTS egress_allow_hosts;
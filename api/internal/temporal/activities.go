@@ -7,15 +7,21 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
 
 	"flowcraft-api/internal/adapters/database/postgres"
+	"flowcraft-api/internal/adapters/external/sqldb"
 	"flowcraft-api/internal/config"
 	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/egress"
+	"flowcraft-api/internal/mailer"
 	"flowcraft-api/internal/utils"
 )
 
@@ -24,8 +30,15 @@ type Activities struct {
 	runs     *postgres.RunRepository
 	steps    *postgres.RunStepRepository
 	creds    *postgres.CredentialRepository
+	projects *postgres.ProjectRepository
 	cfg      config.Config
 	credsKey []byte
+	// temporal starts the runs of flowEvent triggers; nil disables them.
+	temporal client.Client
+	// mail notifies owners of deactivated flows; nil when SMTP is not set up.
+	mail             *mailer.Mailer
+	failureThreshold int
+	sqlPools         *sqldb.Pools
 }
 
 func NewActivities(
@@ -34,6 +47,8 @@ func NewActivities(
 	runs *postgres.RunRepository,
 	steps *postgres.RunStepRepository,
 	creds *postgres.CredentialRepository,
+	projects *postgres.ProjectRepository,
+	temporalClient client.Client,
 ) (*Activities, error) {
 	var key []byte
 	if strings.TrimSpace(cfg.CredentialsEncKey) != "" {
@@ -43,16 +58,58 @@ func NewActivities(
 		}
 		key = parsed
 	}
+	mail, err := mailer.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	threshold, err := strconv.Atoi(strings.TrimSpace(cfg.FlowAutoDeactivateFailures))
+	if err != nil || threshold < 0 {
+		threshold = defaultFailureThreshold
+	}
 	return &Activities{
-		flows:    flows,
-		runs:     runs,
-		steps:    steps,
-		creds:    creds,
-		cfg:      cfg,
-		credsKey: key,
+		flows:            flows,
+		runs:             runs,
+		steps:            steps,
+		creds:            creds,
+		projects:         projects,
+		cfg:              cfg,
+		credsKey:         key,
+		temporal:         temporalClient,
+		mail:             mail,
+		failureThreshold: threshold,
+		sqlPools:         sqldb.NewPools(),
 	}, nil
 }
 
+func (a *Activities) stepDeps() stepDependencies {
+	return stepDependencies{cfg: a.cfg, creds: a.creds, credsKey: a.credsKey, sqlPools: a.sqlPools}
+}
+
+// runFlow loads the flow a run belongs to.
+func (a *Activities) runFlow(ctx context.Context, runID string) (*domain.Flow, error) {
+	run, err := a.runs.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return a.flows.Get(ctx, run.FlowID)
+}
+
+// withProjectEgressRules returns ctx holding the egress rules of the project
+// flow belongs to, so its steps' requests are held to them.
+func (a *Activities) withProjectEgressRules(ctx context.Context, flow *domain.Flow) (context.Context, error) {
+	if a.projects == nil {
+		return ctx, nil
+	}
+	if strings.TrimSpace(flow.ProjectID) == "" {
+		return ctx, nil
+	}
+	rules, err := services.ProjectEgressRules(ctx, a.projects, flow.ProjectID)
+	if err != nil {
+		return ctx, err
+	}
+	return egress.WithRules(ctx, rules), nil
+}
+
 func (a *Activities) LoadFlowDefinitionActivity(ctx context.Context, flowID string) (string, error) {
 	flow, err := a.flows.Get(ctx, flowID)
 	if err != nil {
@@ -97,6 +154,15 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 		return "", fmt.Errorf("invalid flow definition: %w", err)
 	}
 
+	flow, err := a.runFlow(ctx, runID)
+	if err != nil {
+		return "", err
+	}
+	ctx, err = a.withProjectEgressRules(ctx, flow)
+	if err != nil {
+		return "", err
+	}
+
 	nodeCount := len(def.Reactflow.Nodes)
 	log.Info("executing flow", "runID", runID, "nodes", nodeCount)
 
@@ -167,15 +233,6 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 	orderedIDs := make([]string, 0, nodeCount)
 	rootIDs := make([]string, 0, nodeCount)
 	if nodeCount > 0 {
-		isTrigger := func(nodeType string) bool {
-			switch nodeType {
-			case "cron", "webhook", "httpTrigger", "trigger":
-				return true
-			default:
-				return false
-			}
-		}
-
 		indegree := make(map[string]int, nodeCount)
 		adj := make(map[string][]string, nodeCount)
 		for id := range runnableByID {
@@ -221,10 +278,10 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 
 				aRank := 1
 				bRank := 1
-				if isTrigger(aType) {
+				if isTriggerNodeType(aType) {
 					aRank = 0
 				}
-				if isTrigger(bType) {
+				if isTriggerNodeType(bType) {
 					bRank = 0
 				}
 				if aRank != bRank {
@@ -406,10 +463,10 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 		if nodeType == "" {
 			nodeType = strings.TrimSpace(node.Type)
 		}
-		switch nodeType {
-		case "cron", "webhook", "httpTrigger", "trigger":
+		switch {
+		case isTriggerNodeType(nodeType):
 			triggers = append(triggers, node.ID)
-		case "errorTrigger":
+		case nodeType == "errorTrigger":
 			errorTriggers = append(errorTriggers, node.ID)
 		}
 	}
@@ -428,6 +485,22 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 		startIDs = []string{orderedIDs[0]}
 	}
 
+	// Runs started by a trigger (e.g. a webhook call) start only from that
+	// trigger, with its captured payload as the trigger input.
+	triggerNodeID, triggerPayload, err := a.runs.GetTrigger(ctx, runID)
+	if err != nil {
+		return "", err
+	}
+	var triggerInput map[string]any
+	if _, ok := plannedByNodeID[triggerNodeID]; ok {
+		startIDs = []string{triggerNodeID}
+		if len(triggerPayload) > 0 {
+			if err := json.Unmarshal(triggerPayload, &triggerInput); err != nil {
+				return "", fmt.Errorf("invalid trigger payload: %w", err)
+			}
+		}
+	}
+
 	visited := make(map[string]struct{}, nodeCount)
 	executed := 0
 	failedButContinued := 0
@@ -484,7 +557,8 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 			return err
 		}
 
-		deps := stepDependencies{cfg: a.cfg, creds: a.creds, credsKey: a.credsKey}
+		deps := a.stepDeps()
+		deps.flow = flow
 
 		// Retry Logic — clamp to minimum 1 so the loop always executes at least once
 		maxAttempts := readIntWithDefault(p.config, "maxAttempts", 1)
@@ -508,7 +582,14 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 				if errors.Is(execErr, context.Canceled) || errors.Is(execErr, context.DeadlineExceeded) {
 					break // Do not retry if context is canceled
 				}
-				sleepTime := time.Duration(float64(initialInterval)*math.Pow(retryBackoff, float64(attempt-1))) * time.Millisecond
+				if errors.Is(execErr, egress.ErrBlocked) {
+					break // The policy blocks every attempt alike
+				}
+				backoff := time.Duration(float64(initialInterval)*math.Pow(retryBackoff, float64(attempt-1))) * time.Millisecond
+				sleepTime, ok := retryDelay(ctx, backoff, execErr)
+				if !ok {
+					break // Waiting would outlast the run; fail with the error
+				}
 				// Log retry attempt
 				retryMsg := fmt.Sprintf("attempt %d failed: %v. retrying in %v...", attempt, execErr, sleepTime)
 				_ = a.steps.UpdateState(ctx, p.step.ID, "running", inputsJSON, nil, retryMsg, "")
@@ -523,6 +604,15 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 			}
 		}
 	StopRetry:
+		// Requests refused by the egress policy form their own error
+		// category, apart from failures of the remote service.
+		if errors.Is(execErr, egress.ErrBlocked) {
+			if outputs == nil {
+				outputs = map[string]any{"status": 0}
+			}
+			outputs["error"] = execErr.Error()
+			outputs["error_category"] = egress.Category
+		}
 		outputsJSON, _ := json.Marshal(outputs)
 
 		if p.step.NodeID != "" {
@@ -580,6 +670,9 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 						"inputs":  inputs,
 						"outputs": outputs,
 					}
+					if category, ok := outputs["error_category"]; ok {
+						errorPayload["error_category"] = category
+					}
 					if err := executeNode(errorTriggerID, errorPayload); err != nil {
 						return err
 					}
@@ -652,7 +745,11 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 	}
 
 	for _, id := range startIDs {
-		if err := executeNode(id, nil); err != nil {
+		var input map[string]any
+		if id == triggerNodeID {
+			input = triggerInput
+		}
+		if err := executeNode(id, input); err != nil {
 			return "", err
 		}
 	}
@@ -680,7 +777,19 @@ func (a *Activities) ExecuteNodeActivity(ctx context.Context, runID string, defi
 
 func (a *Activities) UpdateRunStatusActivity(ctx context.Context, runID string, status string, logText string) error {
 	activity.GetLogger(ctx).Info("updating run", "runID", runID, "status", status)
-	return a.runs.UpdateStatus(ctx, runID, status, logText)
+	if err := a.runs.UpdateStatus(ctx, runID, status, logText); err != nil {
+		return err
+	}
+	switch status {
+	case "success", "failed":
+		if err := a.recordRunOutcome(ctx, runID, status); err != nil {
+			return err
+		}
+		return a.dispatchFlowEvents(ctx, runID)
+	case "canceled":
+		return a.dispatchFlowEvents(ctx, runID)
+	}
+	return nil
 }
 
 func deterministicStepID(runID string, stepKey string) string {
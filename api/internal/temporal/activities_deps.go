@@ -3,10 +3,16 @@ package temporal
 import (
 	"flowcraft-api/internal/config"
 	"flowcraft-api/internal/adapters/database/postgres"
+	"flowcraft-api/internal/adapters/external/sqldb"
+	"flowcraft-api/internal/core/domain"
 )
 
 type stepDependencies struct {
 	cfg      config.Config
 	creds    *postgres.CredentialRepository
 	credsKey []byte
+	// sqlPools caches database node connections; nil opens one per step.
+	sqlPools *sqldb.Pools
+	// flow is the flow the step belongs to; it may only use its own credentials.
+	flow *domain.Flow
 }
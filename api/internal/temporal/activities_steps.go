@@ -9,10 +9,6 @@ import (
 	"time"
 )
 
-func sampleWebhookPayload() map[string]any {
-	return map[string]any{"order_id": 10234, "total": 520.0, "currency": "USD"}
-}
-
 func buildStepInputs(nodeType string, config map[string]any, runID string, stepKey string, input map[string]any) map[string]any {
 	now := time.Now().UTC().Format(time.RFC3339)
 	inputs := map[string]any{
@@ -52,13 +48,19 @@ func buildStepInputs(nodeType string, config map[string]any, runID string, stepK
 			inputs["body"] = parseJSONOrString(body)
 		}
 
-		if authType := readString(config, "authType"); authType != "" && authType != "None" {
+		// A stored credential is resolved at execution; only its ID is recorded.
+		if credentialID := strings.TrimSpace(readString(config, "credentialId")); credentialID != "" {
+			inputs["credential_id"] = credentialID
+		} else if authType := readString(config, "authType"); authType != "" && authType != "None" {
 			inputs["auth_type"] = authType
 			inputs["auth_value"] = "***" // Masked
 		}
 		if timeout := config["timeout"]; timeout != nil {
 			inputs["timeout"] = timeout
 		}
+		if mode := paginationMode(config); mode != "" {
+			inputs["pagination"] = mode
+		}
 	case "aiAgent":
 		if prompt := readString(config, "prompt"); strings.TrimSpace(prompt) != "" {
 			inputs["prompt"] = prompt
@@ -98,22 +100,53 @@ func buildStepInputs(nodeType string, config map[string]any, runID string, stepK
 		if expr := readString(config, "expression"); expr != "" {
 			inputs["expression"] = expr
 		}
-	case "webhook":
-		if path := readString(config, "path"); path != "" {
-			inputs["path"] = path
+		if tz := readString(config, "timezone"); tz != "" {
+			inputs["timezone"] = tz
+		}
+	case "formTrigger":
+		if fields, ok := config["fields"].([]any); ok {
+			inputs["field_count"] = len(fields)
+		}
+	case "flowEvent":
+		if flowID := readString(config, "flowId"); flowID != "" {
+			inputs["flow_id"] = flowID
 		}
-		inputs["payload"] = sampleWebhookPayload()
-	case "httpTrigger":
+		if status := readString(config, "status"); status != "" {
+			inputs["status"] = status
+		}
+	case "pollingTrigger":
+		if event := readString(config, "event"); event != "" {
+			inputs["event"] = event
+		}
+		if credentialID := readString(config, "credentialId"); credentialID != "" {
+			inputs["credential_id"] = credentialID
+		}
+	case "webhook", "httpTrigger":
 		if path := readString(config, "path"); path != "" {
 			inputs["path"] = path
 		}
 		if method := readString(config, "method"); method != "" {
 			inputs["method"] = strings.ToUpper(strings.TrimSpace(method))
 		}
+		if input != nil {
+			inputs["payload"] = input
+		}
+	case "telegramTrigger":
+		inputs["credential_id"] = readString(config, "credentialId")
+		if input != nil {
+			inputs["payload"] = input
+		}
 	case "errorTrigger":
 		if input != nil {
 			inputs["payload"] = input
 		}
+	case "respondToWebhook":
+		if statusCode := readInt(config, "statusCode"); statusCode != 0 {
+			inputs["status_code"] = statusCode
+		}
+		if respondWith := readString(config, "respondWith"); respondWith != "" {
+			inputs["respond_with"] = respondWith
+		}
 	case "gmail":
 		inputs["credential_id"] = readString(config, "credentialId")
 		if to := readString(config, "to"); to != "" {
@@ -158,11 +191,33 @@ func executeStep(
 
 	switch nodeType {
 	case "httpRequest":
-		return executeHTTPRequest(ctx, config)
+		return executeHTTPRequest(ctx, config, deps)
 	case "cron":
-		return simulateStep(ctx, map[string]any{"status": 200, "data": map[string]any{"scheduled_at": now}})
-	case "webhook":
-		return simulateStep(ctx, map[string]any{"status": 200, "data": sampleWebhookPayload()})
+		if _, ok := input["scheduled_at"]; ok {
+			return simulateStep(ctx, map[string]any{"status": 200, "data": input})
+		}
+		return simulateStep(ctx, map[string]any{"status": 200, "data": map[string]any{"scheduled_at": now, "mode": "manual"}})
+	case "formTrigger":
+		if mode, _ := input["mode"].(string); mode == "form" {
+			return simulateStep(ctx, map[string]any{"status": 200, "data": input})
+		}
+		return simulateStep(ctx, map[string]any{"status": 200, "data": map[string]any{"mode": "manual", "submittedAt": now, "fields": map[string]any{}}})
+	case "flowEvent":
+		if mode, _ := input["mode"].(string); mode == "flowEvent" {
+			return simulateStep(ctx, map[string]any{"status": 200, "data": input})
+		}
+		return simulateStep(ctx, map[string]any{"status": 200, "data": map[string]any{"mode": "manual", "triggered_at": now}})
+	case "pollingTrigger":
+		if mode, _ := input["mode"].(string); mode == "poll" {
+			return simulateStep(ctx, map[string]any{"status": 200, "data": input})
+		}
+		return simulateStep(ctx, map[string]any{"status": 200, "data": map[string]any{"mode": "manual", "polled_at": now}})
+	case "webhook", "httpTrigger":
+		return executeWebhookTrigger(input)
+	case "telegramTrigger":
+		return executeTelegramTrigger(input)
+	case "respondToWebhook":
+		return executeRespondToWebhook(ctx, config, input, steps)
 	case "aiAgent":
 		return executeAIAgent(ctx, config, input, steps, deps)
 	case "if":
@@ -184,6 +239,20 @@ func executeStep(
 	}
 }
 
+// executeWebhookTrigger outputs the inbound request captured by the webhook
+// endpoint. Manual runs have no request, so they get an empty request shape.
+func executeWebhookTrigger(input map[string]any) (map[string]any, string, error) {
+	if input == nil {
+		return map[string]any{"status": 200, "data": map[string]any{
+			"mode":    "manual",
+			"headers": map[string]any{},
+			"query":   map[string]any{},
+			"body":    nil,
+		}}, "manual run (no webhook request)", nil
+	}
+	return map[string]any{"status": 200, "data": input}, fmt.Sprintf("received %s request", readString(input, "method")), nil
+}
+
 func executeIf(_ context.Context, config map[string]any, input map[string]any, steps map[string]any) (map[string]any, string, error) {
 	conds, combine, ignoreCase, convertTypes := parseIfConfig(config)
 	if len(conds) == 0 {
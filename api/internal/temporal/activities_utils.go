@@ -1,10 +1,24 @@
 package temporal
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
+// isTriggerNodeType reports whether nodeType starts a flow. Runs begin at
+// trigger nodes; errorTrigger is handled separately.
+func isTriggerNodeType(nodeType string) bool {
+	switch nodeType {
+	case "cron", "webhook", "httpTrigger", "trigger", "pollingTrigger", "flowEvent", "formTrigger", "telegramTrigger":
+		return true
+	default:
+		return false
+	}
+}
+
 func readBool(cfg map[string]any, key string) bool {
 	if cfg == nil {
 		return false
@@ -134,3 +148,41 @@ func readIntWithDefault(cfg map[string]any, key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// retryAfter returns the delay requested by a connector error (for example a
+// rate-limit response with Retry-After), or 0.
+func retryAfter(err error) time.Duration {
+	var delayed interface{ RetryDelay() time.Duration }
+	if errors.As(err, &delayed) {
+		return delayed.RetryDelay()
+	}
+	return 0
+}
+
+// maxRetryAfter caps the delay a rate-limited service may ask for. All steps
+// of a run share one activity timeout, so a longer wait would time the run
+// out instead of failing the step.
+const maxRetryAfter = 60 * time.Second
+
+// retryDelay returns how long to wait before retrying a step that failed with
+// err, given the backoff delay. It reports false when the step should not be
+// retried: the requested delay is over maxRetryAfter or ends after ctx's
+// deadline.
+func retryDelay(ctx context.Context, backoff time.Duration, err error) (time.Duration, bool) {
+	delay := backoff
+	// Rate-limited connectors report how long to back off; never retry sooner.
+	if d := retryAfter(err); d > delay {
+		if d > maxRetryAfter {
+			return 0, false
+		}
+		delay = d
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+		return 0, false
+	}
+	return delay, true
+}
+
+func RetryDelayForTest(ctx context.Context, backoff time.Duration, err error) (time.Duration, bool) {
+	return retryDelay(ctx, backoff, err)
+}
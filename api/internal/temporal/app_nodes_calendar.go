@@ -0,0 +1,176 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/google"
+)
+
+func executeAppCalendar(ctx context.Context, config map[string]any, deps stepDependencies, action string) (map[string]any, string, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return map[string]any{"status": 0}, "missing credential", errors.New("googleCalendar: credentialId is required")
+	}
+
+	key := strings.ToLower(strings.TrimSpace(action))
+	calendarID := strings.TrimSpace(readString(config, "calendarId"))
+	eventID := strings.TrimSpace(readString(config, "eventId"))
+	sendUpdates := strings.TrimSpace(readString(config, "sendUpdates"))
+	switch key {
+	case "googlecalendar.updateevent", "googlecalendar.deleteevent":
+		if eventID == "" {
+			return map[string]any{"status": 0}, "missing eventId", fmt.Errorf("%s: eventId is required", action)
+		}
+	case "googlecalendar.createevent":
+		if strings.TrimSpace(readString(config, "start")) == "" || strings.TrimSpace(readString(config, "end")) == "" {
+			return map[string]any{"status": 0}, "missing start or end", fmt.Errorf("%s: start and end are required", action)
+		}
+	case "googlecalendar.freebusy":
+		if strings.TrimSpace(readString(config, "timeMin")) == "" || strings.TrimSpace(readString(config, "timeMax")) == "" {
+			return map[string]any{"status": 0}, "missing time range", fmt.Errorf("%s: timeMin and timeMax are required", action)
+		}
+	case "googlecalendar.listevents", "googlecalendar.listcalendars":
+	default:
+		return map[string]any{"status": 0}, "unsupported googleCalendar action", fmt.Errorf("app(googleCalendar): unsupported action %q", action)
+	}
+	var event google.EventInput
+	if key == "googlecalendar.createevent" || key == "googlecalendar.updateevent" {
+		var err error
+		event, err = readCalendarEvent(config)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid event", fmt.Errorf("%s: %w", action, err)
+		}
+	}
+
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return map[string]any{"status": 0}, "credential load failed", err
+	}
+	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "google" {
+		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("googleCalendar: expected google credential")
+	}
+	if !hasGoogleScope(payload, google.ScopeCalendar) {
+		return map[string]any{"status": 0}, "missing calendar scope", google.ErrCalendarScope
+	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
+	accessToken, err := googleAccessToken(ctx, deps, payload)
+	if err != nil {
+		return map[string]any{"status": 0}, "token refresh failed", err
+	}
+
+	started := time.Now()
+	var out map[string]any
+	logText := ""
+	switch key {
+	case "googlecalendar.listevents":
+		singleEvents := true
+		if v := readOptionalBool(config, "singleEvents"); v != nil {
+			singleEvents = *v
+		}
+		out, err = google.ListEvents(ctx, accessToken, google.EventListOptions{
+			CalendarID:   calendarID,
+			TimeMin:      readString(config, "timeMin"),
+			TimeMax:      readString(config, "timeMax"),
+			Query:        readString(config, "query"),
+			TimeZone:     readString(config, "timeZone"),
+			SingleEvents: singleEvents,
+			ShowDeleted:  readBool(config, "showDeleted"),
+			MaxResults:   readInt(config, "maxResults"),
+			PageToken:    strings.TrimSpace(readString(config, "pageToken")),
+			All:          readBool(config, "returnAll"),
+		})
+	case "googlecalendar.createevent":
+		out, err = google.CreateEvent(ctx, accessToken, calendarID, event, sendUpdates)
+		logText = fmt.Sprintf("googleCalendar create %q", event.Summary)
+	case "googlecalendar.updateevent":
+		out, err = google.UpdateEvent(ctx, accessToken, calendarID, eventID, event, sendUpdates)
+	case "googlecalendar.deleteevent":
+		out, err = google.DeleteEvent(ctx, accessToken, calendarID, eventID, sendUpdates)
+	case "googlecalendar.freebusy":
+		out, err = google.FreeBusy(ctx, accessToken, google.FreeBusyQuery{
+			TimeMin:   readString(config, "timeMin"),
+			TimeMax:   readString(config, "timeMax"),
+			TimeZone:  readString(config, "timeZone"),
+			Calendars: readList(config, "calendars"),
+		})
+	case "googlecalendar.listcalendars":
+		out, err = google.ListCalendars(ctx, accessToken)
+	}
+
+	duration := time.Since(started)
+	outputs := map[string]any{
+		"status": 200,
+		"data":   out,
+		"meta": map[string]any{
+			"duration_ms": duration.Milliseconds(),
+		},
+	}
+	if err != nil {
+		outputs["status"] = 0
+		outputs["error"] = err.Error()
+		return outputs, "googleCalendar action failed", err
+	}
+	if logText == "" {
+		logText = action
+	}
+	return outputs, fmt.Sprintf("%s (%dms)", logText, duration.Milliseconds()), nil
+}
+
+// readCalendarEvent reads the event fields shared by create and update.
+// "reminders" is a JSON array of {method, minutes}; "recurrence" is a JSON
+// array or one RRULE/EXDATE line per line (RRULEs contain commas).
+func readCalendarEvent(config map[string]any) (google.EventInput, error) {
+	event := google.EventInput{
+		Summary:       readString(config, "summary"),
+		Description:   readString(config, "description"),
+		Location:      readString(config, "location"),
+		Start:         strings.TrimSpace(readString(config, "start")),
+		End:           strings.TrimSpace(readString(config, "end")),
+		TimeZone:      strings.TrimSpace(readString(config, "timeZone")),
+		Attendees:     readList(config, "attendees"),
+		AddConference: readBool(config, "addConference"),
+		Visibility:    strings.TrimSpace(readString(config, "visibility")),
+		ColorID:       strings.TrimSpace(readString(config, "colorId")),
+	}
+
+	recurrence, err := readJSONConfig(config, "recurrence")
+	if err != nil {
+		for _, line := range strings.Split(readString(config, "recurrence"), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				event.Recurrence = append(event.Recurrence, line)
+			}
+		}
+	} else if items, ok := recurrence.([]any); ok {
+		for _, item := range items {
+			if line := strings.TrimSpace(readAnyString(item)); line != "" {
+				event.Recurrence = append(event.Recurrence, line)
+			}
+		}
+	}
+
+	raw, err := readJSONConfig(config, "reminders")
+	if err != nil {
+		return google.EventInput{}, err
+	}
+	if raw != nil {
+		items, ok := raw.([]any)
+		if !ok {
+			return google.EventInput{}, errors.New("reminders must be an array")
+		}
+		for i, item := range items {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return google.EventInput{}, fmt.Errorf("reminder %d must be an object", i+1)
+			}
+			event.Reminders = append(event.Reminders, google.Reminder{
+				Method:  readString(m, "method"),
+				Minutes: readInt(m, "minutes"),
+			})
+		}
+	}
+	return event, nil
+}
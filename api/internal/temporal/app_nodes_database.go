@@ -0,0 +1,180 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/sqldb"
+)
+
+const (
+	// defaultDatabaseRows and maxDatabaseRows bound the rows a step returns.
+	defaultDatabaseRows = 1000
+	maxDatabaseRows     = 10000
+	// maxDatabaseTimeout bounds timeoutSeconds.
+	maxDatabaseTimeout = 5 * time.Minute
+)
+
+func executeAppDatabase(ctx context.Context, config map[string]any, deps stepDependencies, action string) (map[string]any, string, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return map[string]any{"status": 0}, "missing credential", errors.New("database: credentialId is required")
+	}
+
+	key := strings.ToLower(strings.TrimSpace(action))
+	table := strings.TrimSpace(readString(config, "table"))
+	var rows []map[string]any
+	var params []any
+	switch key {
+	case "database.executequery":
+		if strings.TrimSpace(readString(config, "query")) == "" {
+			return map[string]any{"status": 0}, "missing query", fmt.Errorf("%s: query is required", action)
+		}
+		raw, err := readJSONConfig(config, "parameters")
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid parameters", fmt.Errorf("%s: %w", action, err)
+		}
+		switch v := raw.(type) {
+		case nil:
+		case []any:
+			params = v
+		default:
+			return map[string]any{"status": 0}, "invalid parameters", fmt.Errorf("%s: parameters must be an array", action)
+		}
+	case "database.select":
+		if table == "" {
+			return map[string]any{"status": 0}, "missing table", fmt.Errorf("%s: table is required", action)
+		}
+	case "database.insertrows", "database.upsertrows":
+		if table == "" {
+			return map[string]any{"status": 0}, "missing table", fmt.Errorf("%s: table is required", action)
+		}
+		var err error
+		rows, err = readDatabaseRows(config)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid rows", fmt.Errorf("%s: %w", action, err)
+		}
+		if key == "database.upsertrows" && len(readList(config, "conflictColumns")) == 0 {
+			return map[string]any{"status": 0}, "missing conflictColumns", fmt.Errorf("%s: conflictColumns is required", action)
+		}
+	default:
+		return map[string]any{"status": 0}, "unsupported database action", fmt.Errorf("app(database): unsupported action %q", action)
+	}
+
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return map[string]any{"status": 0}, "credential load failed", err
+	}
+	dbConfig, err := sqldb.ConfigFromPayload(cred.Provider, payload)
+	if err != nil {
+		return map[string]any{"status": 0}, "invalid database credential", err
+	}
+	opts := sqldb.Options{
+		Timeout:  time.Duration(readIntWithDefault(config, "timeoutSeconds", 30)) * time.Second,
+		ReadOnly: dbConfig.ReadOnly || readBool(config, "readOnly"),
+		MaxRows:  readIntWithDefault(config, "maxRows", defaultDatabaseRows),
+	}
+	if opts.Timeout <= 0 || opts.Timeout > maxDatabaseTimeout {
+		opts.Timeout = maxDatabaseTimeout
+	}
+	if opts.MaxRows <= 0 || opts.MaxRows > maxDatabaseRows {
+		opts.MaxRows = maxDatabaseRows
+	}
+	if opts.ReadOnly && (key == "database.insertrows" || key == "database.upsertrows") {
+		return map[string]any{"status": 0}, "read-only", fmt.Errorf("%s: not allowed in read-only mode", action)
+	}
+
+	db, release, err := deps.sqlPools.Acquire(credentialID, dbConfig)
+	if err != nil {
+		return map[string]any{"status": 0}, "database connection failed", err
+	}
+	defer release()
+
+	started := time.Now()
+	var out map[string]any
+	switch key {
+	case "database.executequery":
+		out, err = sqldb.Execute(ctx, db, dbConfig.Dialect, opts, readString(config, "query"), sqldb.ArgValues(params))
+	case "database.select":
+		var where map[string]any
+		raw, jsonErr := readJSONConfig(config, "where")
+		if jsonErr != nil {
+			err = fmt.Errorf("where: %w", jsonErr)
+			break
+		}
+		if raw != nil {
+			var ok bool
+			if where, ok = raw.(map[string]any); !ok {
+				err = errors.New("where must be an object of column values")
+				break
+			}
+		}
+		limit := readIntWithDefault(config, "limit", 100)
+		if limit <= 0 || limit > maxDatabaseRows {
+			limit = maxDatabaseRows
+		}
+		out, err = sqldb.Select(ctx, db, dbConfig.Dialect, opts, sqldb.SelectInput{
+			Table:   table,
+			Columns: readList(config, "columns"),
+			Where:   where,
+			OrderBy: readString(config, "orderBy"),
+			Limit:   limit,
+		})
+	case "database.insertrows":
+		out, err = sqldb.WriteRows(ctx, db, dbConfig.Dialect, opts, table, readList(config, "columns"), rows, nil)
+	case "database.upsertrows":
+		out, err = sqldb.WriteRows(ctx, db, dbConfig.Dialect, opts, table, readList(config, "columns"), rows, readList(config, "conflictColumns"))
+	}
+
+	duration := time.Since(started)
+	outputs := map[string]any{
+		"status": 200,
+		"data":   out,
+		"meta": map[string]any{
+			"duration_ms": duration.Milliseconds(),
+			"dialect":     string(dbConfig.Dialect),
+			"read_only":   opts.ReadOnly,
+		},
+	}
+	if err != nil {
+		outputs["status"] = 0
+		outputs["error"] = err.Error()
+		return outputs, "database action failed", err
+	}
+	return outputs, fmt.Sprintf("%s (%dms)", action, duration.Milliseconds()), nil
+}
+
+// readDatabaseRows reads "rows": an array of objects (or one object), given
+// as JSON or passed from an upstream node's items.
+func readDatabaseRows(config map[string]any) ([]map[string]any, error) {
+	raw, err := readJSONConfig(config, "rows")
+	if err != nil {
+		return nil, err
+	}
+	var items []any
+	switch v := raw.(type) {
+	case []any:
+		items = v
+	case map[string]any:
+		items = []any{v}
+	case nil:
+		return nil, errors.New("rows is required")
+	default:
+		return nil, errors.New("rows must be an array of objects")
+	}
+	if len(items) == 0 {
+		return nil, errors.New("rows is empty")
+	}
+	rows := make([]map[string]any, 0, len(items))
+	for i, item := range items {
+		row, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("row %d must be an object", i+1)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
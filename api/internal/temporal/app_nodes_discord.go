@@ -0,0 +1,141 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/discord"
+)
+
+func executeAppDiscord(ctx context.Context, config map[string]any, deps stepDependencies, action string) (map[string]any, string, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return map[string]any{"status": 0}, "missing credential", errors.New("discord: credentialId is required")
+	}
+
+	key := strings.ToLower(strings.TrimSpace(action))
+	channelID := strings.TrimSpace(readString(config, "channelId"))
+	switch key {
+	case "discord.sendwebhookmessage":
+	case "discord.sendmessage", "discord.createthread":
+		if channelID == "" {
+			return map[string]any{"status": 0}, "missing channelId", fmt.Errorf("%s: channelId is required", action)
+		}
+	default:
+		return map[string]any{"status": 0}, "unsupported discord action", fmt.Errorf("app(discord): unsupported action %q", action)
+	}
+	embeds, err := readDiscordEmbeds(config)
+	if err != nil {
+		return map[string]any{"status": 0}, "invalid embeds", fmt.Errorf("%s: %w", action, err)
+	}
+	msg := discord.Message{
+		Content:   readString(config, "content"),
+		Embeds:    embeds,
+		TTS:       readBool(config, "tts"),
+		Username:  readString(config, "username"),
+		AvatarURL: readString(config, "avatarUrl"),
+		ReplyTo:   readString(config, "replyToMessageId"),
+	}
+
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return map[string]any{"status": 0}, "credential load failed", err
+	}
+	if !strings.EqualFold(cred.Provider, "discord") {
+		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("discord: expected discord credential, got %s", cred.Provider)
+	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
+	webhookURL, botToken := discordSecrets(payload)
+	if key == "discord.sendwebhookmessage" && webhookURL == "" {
+		return map[string]any{"status": 0}, "missing webhook URL", errors.New("discord: credential has no webhook_url")
+	}
+	if key != "discord.sendwebhookmessage" && botToken == "" {
+		return map[string]any{"status": 0}, "missing bot token", errors.New("discord: credential has no bot_token")
+	}
+
+	started := time.Now()
+	var out map[string]any
+	switch key {
+	case "discord.sendwebhookmessage":
+		out, err = discord.ExecuteWebhook(ctx, webhookURL, msg, readString(config, "threadId"))
+	case "discord.sendmessage":
+		out, err = discord.SendMessage(ctx, botToken, channelID, msg)
+	case "discord.createthread":
+		out, err = discord.CreateThread(ctx, botToken, channelID, discord.ThreadInput{
+			Name:               readString(config, "name"),
+			MessageID:          readString(config, "messageId"),
+			AutoArchiveMinutes: readInt(config, "autoArchiveMinutes"),
+			Private:            readBool(config, "private"),
+		})
+		// An opening message is posted into the new thread, which is a channel of its own.
+		if err == nil && (strings.TrimSpace(msg.Content) != "" || len(msg.Embeds) > 0) {
+			threadID := strings.TrimSpace(readAnyString(out["id"]))
+			var first map[string]any
+			first, err = discord.SendMessage(ctx, botToken, threadID, msg)
+			out["message"] = first
+		}
+	}
+
+	duration := time.Since(started)
+	outputs := map[string]any{
+		"status": 200,
+		"data":   out,
+		"meta": map[string]any{
+			"duration_ms": duration.Milliseconds(),
+		},
+	}
+	if err != nil {
+		outputs["status"] = 0
+		outputs["error"] = err.Error()
+		var rateLimited *discord.RateLimitError
+		if errors.As(err, &rateLimited) {
+			outputs["status"] = 429
+			outputs["meta"].(map[string]any)["retry_after_ms"] = rateLimited.RetryAfter.Milliseconds()
+			return outputs, "discord rate limited", err
+		}
+		return outputs, "discord action failed", err
+	}
+	return outputs, fmt.Sprintf("%s (%dms)", action, duration.Milliseconds()), nil
+}
+
+// discordSecrets returns the webhook URL and bot token stored in a discord credential.
+func discordSecrets(payload map[string]any) (string, string) {
+	botToken := strings.TrimSpace(readAnyString(payload["bot_token"]))
+	if botToken == "" {
+		botToken = strings.TrimSpace(readAnyString(payload["token"]))
+	}
+	return strings.TrimSpace(readAnyString(payload["webhook_url"])), botToken
+}
+
+// readDiscordEmbeds accepts embeds as an array, a JSON string, a single embed
+// object, or a {"embeds": [...]} message as copied from an embed builder.
+func readDiscordEmbeds(config map[string]any) ([]any, error) {
+	raw, ok := config["embeds"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	if s, ok := raw.(string); ok {
+		if strings.TrimSpace(s) == "" {
+			return nil, nil
+		}
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return nil, fmt.Errorf("embeds must be valid JSON: %w", err)
+		}
+		raw = decoded
+	}
+	switch v := raw.(type) {
+	case []any:
+		return v, nil
+	case map[string]any:
+		if embeds, ok := v["embeds"].([]any); ok {
+			return embeds, nil
+		}
+		return []any{v}, nil
+	}
+	return nil, errors.New("embeds must be an array of embed objects")
+}
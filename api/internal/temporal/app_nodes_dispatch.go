@@ -15,6 +15,10 @@ func executeApp(ctx context.Context, config map[string]any, deps stepDependencie
 			app = "gmail"
 		case strings.HasPrefix(strings.ToLower(action), "gsheets."):
 			app = "googleSheets"
+		case strings.HasPrefix(strings.ToLower(action), "googledrive."):
+			app = "googleDrive"
+		case strings.HasPrefix(strings.ToLower(action), "googlecalendar."):
+			app = "googleCalendar"
 		case strings.HasPrefix(strings.ToLower(action), "github."):
 			app = "github"
 		case strings.HasPrefix(strings.ToLower(action), "bannerbear."):
@@ -23,6 +27,16 @@ func executeApp(ctx context.Context, config map[string]any, deps stepDependencie
 			app = "slack"
 		case strings.HasPrefix(strings.ToLower(action), "notion."):
 			app = "notion"
+		case strings.HasPrefix(strings.ToLower(action), "discord."):
+			app = "discord"
+		case strings.HasPrefix(strings.ToLower(action), "microsoftteams."):
+			app = "microsoftTeams"
+		case strings.HasPrefix(strings.ToLower(action), "email."):
+			app = "email"
+		case strings.HasPrefix(strings.ToLower(action), "database."):
+			app = "database"
+		case strings.HasPrefix(strings.ToLower(action), "telegram."):
+			app = "telegram"
 		}
 	}
 
@@ -31,15 +45,22 @@ func executeApp(ctx context.Context, config map[string]any, deps stepDependencie
 		if action == "" {
 			action = "gmail.sendEmail"
 		}
-		if strings.EqualFold(action, "gmail.sendEmail") {
-			return executeGmail(ctx, config, deps)
-		}
-		return map[string]any{"status": 0}, "unsupported gmail action", fmt.Errorf("app(gmail): unsupported action %q", action)
+		return executeAppGmail(ctx, config, deps, action)
 	case "googlesheets", "google_sheets", "sheets", "gsheets":
 		if action == "" {
 			action = "gsheets.appendRow"
 		}
 		return executeAppSheets(ctx, config, deps, action)
+	case "googledrive", "google_drive", "drive":
+		if action == "" {
+			action = "googleDrive.listFiles"
+		}
+		return executeAppDrive(ctx, config, deps, action)
+	case "googlecalendar", "google_calendar", "calendar":
+		if action == "" {
+			action = "googleCalendar.listEvents"
+		}
+		return executeAppCalendar(ctx, config, deps, action)
 	case "github":
 		if action == "" {
 			action = "github.createIssue"
@@ -60,6 +81,31 @@ func executeApp(ctx context.Context, config map[string]any, deps stepDependencie
 			action = "notion.createPage"
 		}
 		return executeAppNotion(ctx, config, deps, action)
+	case "discord":
+		if action == "" {
+			action = "discord.sendWebhookMessage"
+		}
+		return executeAppDiscord(ctx, config, deps, action)
+	case "microsoftteams", "microsoft_teams", "teams":
+		if action == "" {
+			action = "microsoftTeams.sendMessage"
+		}
+		return executeAppTeams(ctx, config, deps, action)
+	case "email":
+		if action == "" {
+			action = "email.send"
+		}
+		return executeAppEmail(ctx, config, deps, action)
+	case "database", "postgres", "mysql":
+		if action == "" {
+			action = "database.executeQuery"
+		}
+		return executeAppDatabase(ctx, config, deps, action)
+	case "telegram":
+		if action == "" {
+			action = "telegram.sendMessage"
+		}
+		return executeAppTelegram(ctx, config, deps, action)
 	default:
 		return map[string]any{"status": 0}, "unsupported app", fmt.Errorf("app: unsupported app %q", app)
 	}
@@ -0,0 +1,172 @@
+package temporal
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"flowcraft-api/internal/adapters/external/google"
+)
+
+func executeAppDrive(ctx context.Context, config map[string]any, deps stepDependencies, action string) (map[string]any, string, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return map[string]any{"status": 0}, "missing credential", errors.New("googleDrive: credentialId is required")
+	}
+
+	key := strings.ToLower(strings.TrimSpace(action))
+	fileID := strings.TrimSpace(readString(config, "fileId"))
+	folderID := strings.TrimSpace(readString(config, "folderId"))
+	switch key {
+	case "googledrive.getfile", "googledrive.downloadfile", "googledrive.sharefile", "googledrive.deletefile":
+		if fileID == "" {
+			return map[string]any{"status": 0}, "missing fileId", fmt.Errorf("%s: fileId is required", action)
+		}
+	case "googledrive.movefile":
+		if fileID == "" || folderID == "" {
+			return map[string]any{"status": 0}, "missing fileId or folderId", fmt.Errorf("%s: fileId and folderId are required", action)
+		}
+	case "googledrive.uploadfile", "googledrive.createfolder":
+		if strings.TrimSpace(readString(config, "name")) == "" {
+			return map[string]any{"status": 0}, "missing name", fmt.Errorf("%s: name is required", action)
+		}
+	case "googledrive.listfiles":
+	default:
+		return map[string]any{"status": 0}, "unsupported googleDrive action", fmt.Errorf("app(googleDrive): unsupported action %q", action)
+	}
+	exportMimeType := ""
+	if format := strings.TrimSpace(readString(config, "exportFormat")); format != "" && key == "googledrive.downloadfile" {
+		exportMimeType = google.ExportMimeType(format)
+		if exportMimeType == "" {
+			return map[string]any{"status": 0}, "invalid exportFormat", fmt.Errorf("%s: unsupported exportFormat %q", action, format)
+		}
+	}
+	var content []byte
+	if key == "googledrive.uploadfile" {
+		var err error
+		content, err = readSlackFileContent(config)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid content", fmt.Errorf("%s: %w", action, err)
+		}
+	}
+
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return map[string]any{"status": 0}, "credential load failed", err
+	}
+	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "google" {
+		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("googleDrive: expected google credential")
+	}
+	if !hasGoogleScope(payload, google.ScopeDrive) {
+		return map[string]any{"status": 0}, "missing drive scope", google.ErrDriveScope
+	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
+	accessToken, err := googleAccessToken(ctx, deps, payload)
+	if err != nil {
+		return map[string]any{"status": 0}, "token refresh failed", err
+	}
+
+	started := time.Now()
+	var out map[string]any
+	logText := ""
+	switch key {
+	case "googledrive.listfiles":
+		out, err = google.ListFiles(ctx, accessToken, google.DriveListOptions{
+			Query:          readString(config, "query"),
+			FolderID:       folderID,
+			OrderBy:        readString(config, "orderBy"),
+			PageSize:       readInt(config, "pageSize"),
+			PageToken:      strings.TrimSpace(readString(config, "pageToken")),
+			IncludeTrashed: readBool(config, "includeTrashed"),
+			All:            readBool(config, "returnAll"),
+		})
+	case "googledrive.getfile":
+		out, err = google.GetFile(ctx, accessToken, fileID)
+	case "googledrive.downloadfile":
+		var download google.DriveDownload
+		download, err = google.DownloadFile(ctx, accessToken, fileID, exportMimeType)
+		if err == nil {
+			out = driveDownloadOutput(download)
+			logText = fmt.Sprintf("googleDrive download %s (%d bytes)", download.Name, len(download.Content))
+		}
+	case "googledrive.uploadfile":
+		out, err = google.UploadFile(ctx, accessToken, google.DriveUpload{
+			Name:      readString(config, "name"),
+			MimeType:  readString(config, "mimeType"),
+			FolderID:  folderID,
+			ConvertTo: readString(config, "convertTo"),
+			Content:   content,
+		})
+		logText = fmt.Sprintf("googleDrive upload %s (%d bytes)", strings.TrimSpace(readString(config, "name")), len(content))
+	case "googledrive.createfolder":
+		out, err = google.CreateFolder(ctx, accessToken, readString(config, "name"), folderID)
+	case "googledrive.movefile":
+		out, err = google.MoveFile(ctx, accessToken, fileID, folderID)
+	case "googledrive.sharefile":
+		out, err = google.ShareFile(ctx, accessToken, fileID, google.DrivePermission{
+			Type:             readString(config, "shareType"),
+			Role:             readString(config, "role"),
+			EmailAddress:     readString(config, "emailAddress"),
+			Domain:           readString(config, "domain"),
+			SendNotification: readBool(config, "sendNotification"),
+			Message:          readString(config, "message"),
+		})
+	case "googledrive.deletefile":
+		out, err = google.DeleteFile(ctx, accessToken, fileID, readBool(config, "permanent"))
+	}
+
+	duration := time.Since(started)
+	outputs := map[string]any{
+		"status": 200,
+		"data":   out,
+		"meta": map[string]any{
+			"duration_ms": duration.Milliseconds(),
+		},
+	}
+	if err != nil {
+		outputs["status"] = 0
+		outputs["error"] = err.Error()
+		return outputs, "googleDrive action failed", err
+	}
+	if logText == "" {
+		logText = action
+	}
+	return outputs, fmt.Sprintf("%s (%dms)", logText, duration.Milliseconds()), nil
+}
+
+// driveDownloadOutput returns the file as contentBase64, plus content when it is text.
+func driveDownloadOutput(download google.DriveDownload) map[string]any {
+	out := map[string]any{
+		"id":            download.ID,
+		"name":          download.Name,
+		"mimeType":      download.MimeType,
+		"size":          len(download.Content),
+		"contentBase64": base64.StdEncoding.EncodeToString(download.Content),
+	}
+	mimeType := strings.ToLower(download.MimeType)
+	textual := strings.HasPrefix(mimeType, "text/") || strings.Contains(mimeType, "json") || strings.Contains(mimeType, "xml")
+	if textual && utf8.Valid(download.Content) {
+		out["content"] = string(download.Content)
+	}
+	return out
+}
+
+// hasGoogleScope reports whether a Google credential was granted scope.
+// Credentials saved before scopes were recorded are given the benefit of the
+// doubt; Google rejects the call if the scope is actually missing.
+func hasGoogleScope(payload map[string]any, scope string) bool {
+	granted := strings.Fields(readAnyString(payload["scopes"]))
+	if len(granted) == 0 {
+		return true
+	}
+	for _, s := range granted {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
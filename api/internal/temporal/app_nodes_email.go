@@ -0,0 +1,121 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/email"
+)
+
+// maxEmailFetch bounds email.fetch's limit.
+const maxEmailFetch = 50
+
+func executeAppEmail(ctx context.Context, config map[string]any, deps stepDependencies, action string) (map[string]any, string, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return map[string]any{"status": 0}, "missing credential", errors.New("email: credentialId is required")
+	}
+
+	key := strings.ToLower(strings.TrimSpace(action))
+	var provider string
+	var msg email.Message
+	switch key {
+	case "email.send":
+		provider = "smtp"
+		attachments, err := readGmailAttachments(config)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid attachments", fmt.Errorf("%s: %w", action, err)
+		}
+		msg = email.Message{
+			From:    strings.TrimSpace(readString(config, "from")),
+			To:      readEmailAddresses(config, "to"),
+			Cc:      readEmailAddresses(config, "cc"),
+			Bcc:     readEmailAddresses(config, "bcc"),
+			ReplyTo: strings.TrimSpace(readString(config, "replyTo")),
+			Subject: readString(config, "subject"),
+			Text:    readString(config, "bodyText"),
+			HTML:    readString(config, "bodyHtml"),
+		}
+		for _, a := range attachments {
+			msg.Attachments = append(msg.Attachments, email.Attachment{Filename: a.Filename, MimeType: a.MimeType, Content: a.Content})
+		}
+		if len(msg.To)+len(msg.Cc)+len(msg.Bcc) == 0 {
+			return map[string]any{"status": 0}, "missing recipient", errors.New("email.send: to is required")
+		}
+	case "email.fetch":
+		provider = "imap"
+	default:
+		return map[string]any{"status": 0}, "unsupported email action", fmt.Errorf("app(email): unsupported action %q", action)
+	}
+
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return map[string]any{"status": 0}, "credential load failed", err
+	}
+	if !strings.EqualFold(strings.TrimSpace(cred.Provider), provider) {
+		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("%s: expected %s credential, got %s", action, provider, cred.Provider)
+	}
+	acct := email.AccountFromPayload(payload)
+
+	started := time.Now()
+	var out map[string]any
+	switch key {
+	case "email.send":
+		out, err = email.Send(ctx, acct, msg)
+	case "email.fetch":
+		limit := readIntWithDefault(config, "limit", 10)
+		if limit < 1 || limit > maxEmailFetch {
+			limit = maxEmailFetch
+		}
+		var result email.FetchResult
+		result, err = email.Fetch(ctx, acct, email.FetchOptions{
+			Folder:             readString(config, "folder"),
+			UnseenOnly:         readBool(config, "unseenOnly"),
+			MarkAsRead:         readBool(config, "markAsRead"),
+			Limit:              limit,
+			IncludeAttachments: readBool(config, "includeAttachments"),
+		})
+		// Newest first, like a mail client's list.
+		messages := result.Messages
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+		out = map[string]any{
+			"folder":   result.Mailbox.Name,
+			"exists":   result.Mailbox.Exists,
+			"messages": messages,
+			"count":    len(messages),
+		}
+	}
+
+	duration := time.Since(started)
+	outputs := map[string]any{
+		"status": 200,
+		"data":   out,
+		"meta": map[string]any{
+			"duration_ms": duration.Milliseconds(),
+		},
+	}
+	if err != nil {
+		outputs["status"] = 0
+		outputs["error"] = err.Error()
+		return outputs, "email action failed", err
+	}
+	return outputs, fmt.Sprintf("%s (%dms)", action, duration.Milliseconds()), nil
+}
+
+// readEmailAddresses reads an address list given as an array or as one
+// string; a string is split by the address parser, so quoted display names
+// may contain commas.
+func readEmailAddresses(config map[string]any, key string) []string {
+	if _, ok := config[key].(string); ok {
+		if v := strings.TrimSpace(readString(config, key)); v != "" {
+			return []string{v}
+		}
+		return nil
+	}
+	return readList(config, key)
+}
@@ -30,6 +30,7 @@ func executeGitHub(ctx context.Context, config map[string]any, deps stepDependen
 	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "github" {
 		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("github: expected github credential")
 	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
 	accessToken := strings.TrimSpace(readAnyString(payload["access_token"]))
 	if accessToken == "" {
 		return map[string]any{"status": 0}, "missing token", errors.New("github: access token missing")
@@ -66,6 +67,7 @@ func executeAppGitHub(ctx context.Context, config map[string]any, deps stepDepen
 	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "github" {
 		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("github: expected github credential")
 	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
 	accessToken := strings.TrimSpace(readAnyString(payload["access_token"]))
 	if accessToken == "" {
 		return map[string]any{"status": 0}, "missing token", errors.New("github: access token missing")
@@ -178,6 +180,122 @@ func executeAppGitHub(ctx context.Context, config map[string]any, deps stepDepen
 			return map[string]any{"status": 0}, "missing fields", errors.New("github.deleteFile: owner, repo, path, and message are required")
 		}
 		out, err = github.DeleteFile(ctx, accessToken, owner, repo, path, message, sha, branch)
+	case "github.createpullrequest":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		input := github.PullRequestInput{
+			Title:               strings.TrimSpace(readString(config, "title")),
+			Head:                strings.TrimSpace(readString(config, "head")),
+			Base:                strings.TrimSpace(readString(config, "base")),
+			Body:                readString(config, "body"),
+			Draft:               readBool(config, "draft"),
+			MaintainerCanModify: readOptionalBool(config, "maintainerCanModify"),
+		}
+		if owner == "" || repo == "" || input.Title == "" || input.Head == "" || input.Base == "" {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.createPullRequest: owner, repo, title, head, and base are required")
+		}
+		out, err = github.CreatePullRequest(ctx, accessToken, owner, repo, input)
+	case "github.listpullrequests":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		if owner == "" || repo == "" {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.listPullRequests: owner and repo are required")
+		}
+		out, err = github.ListPullRequests(ctx, accessToken, owner, repo, github.ListPullRequestsOptions{
+			State:     strings.TrimSpace(readString(config, "state")),
+			Head:      strings.TrimSpace(readString(config, "head")),
+			Base:      strings.TrimSpace(readString(config, "base")),
+			Sort:      strings.TrimSpace(readString(config, "sort")),
+			Direction: strings.TrimSpace(readString(config, "direction")),
+			Pages:     readInt(config, "maxPages"),
+		})
+	case "github.mergepullrequest":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		pullNumber := readInt(config, "pullNumber")
+		if owner == "" || repo == "" || pullNumber <= 0 {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.mergePullRequest: owner, repo, and pullNumber are required")
+		}
+		out, err = github.MergePullRequest(ctx, accessToken, owner, repo, pullNumber, github.MergeOptions{
+			Method:        strings.TrimSpace(readString(config, "mergeMethod")),
+			CommitTitle:   strings.TrimSpace(readString(config, "commitTitle")),
+			CommitMessage: readString(config, "commitMessage"),
+			SHA:           strings.TrimSpace(readString(config, "sha")),
+		})
+	case "github.requestreviewers":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		pullNumber := readInt(config, "pullNumber")
+		reviewers := readList(config, "reviewers")
+		teamReviewers := readList(config, "teamReviewers")
+		if owner == "" || repo == "" || pullNumber <= 0 || (len(reviewers) == 0 && len(teamReviewers) == 0) {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.requestReviewers: owner, repo, pullNumber, and reviewers or teamReviewers are required")
+		}
+		out, err = github.RequestReviewers(ctx, accessToken, owner, repo, pullNumber, reviewers, teamReviewers)
+	case "github.createrelease":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		input := github.ReleaseInput{
+			TagName:         strings.TrimSpace(readString(config, "tag")),
+			TargetCommitish: strings.TrimSpace(readString(config, "target")),
+			Name:            strings.TrimSpace(readString(config, "name")),
+			Body:            readString(config, "body"),
+			Draft:           readBool(config, "draft"),
+			Prerelease:      readBool(config, "prerelease"),
+			GenerateNotes:   readBool(config, "generateNotes"),
+		}
+		if owner == "" || repo == "" || input.TagName == "" {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.createRelease: owner, repo, and tag are required")
+		}
+		out, err = github.CreateRelease(ctx, accessToken, owner, repo, input)
+	case "github.listcommits":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		if owner == "" || repo == "" {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.listCommits: owner and repo are required")
+		}
+		out, err = github.ListCommits(ctx, accessToken, owner, repo, github.ListCommitsOptions{
+			SHA:    strings.TrimSpace(readString(config, "ref")),
+			Path:   strings.TrimSpace(readString(config, "path")),
+			Author: strings.TrimSpace(readString(config, "author")),
+			Since:  strings.TrimSpace(readString(config, "since")),
+			Until:  strings.TrimSpace(readString(config, "until")),
+			Pages:  readInt(config, "maxPages"),
+		})
+	case "github.createbranch":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		branch := strings.TrimSpace(readString(config, "branch"))
+		fromRef := strings.TrimSpace(readString(config, "fromRef"))
+		if owner == "" || repo == "" || branch == "" {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.createBranch: owner, repo, and branch are required")
+		}
+		out, err = github.CreateBranch(ctx, accessToken, owner, repo, branch, fromRef)
+	case "github.dispatchworkflow":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		workflow := strings.TrimSpace(readString(config, "workflow"))
+		ref := strings.TrimSpace(readString(config, "ref"))
+		if owner == "" || repo == "" || workflow == "" {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.dispatchWorkflow: owner, repo, and workflow are required")
+		}
+		inputs, inputsErr := readJSONConfig(config, "inputs")
+		if inputsErr != nil {
+			return map[string]any{"status": 0}, "invalid inputs", fmt.Errorf("github.dispatchWorkflow: %w", inputsErr)
+		}
+		inputMap, ok := inputs.(map[string]any)
+		if inputs != nil && !ok {
+			return map[string]any{"status": 0}, "invalid inputs", errors.New("github.dispatchWorkflow: inputs must be an object")
+		}
+		out, err = github.DispatchWorkflow(ctx, accessToken, owner, repo, workflow, ref, inputMap)
+	case "github.getworkflowrun":
+		owner := strings.TrimSpace(readString(config, "owner"))
+		repo := strings.TrimSpace(readString(config, "repo"))
+		runID := readInt(config, "runId")
+		if owner == "" || repo == "" || runID <= 0 {
+			return map[string]any{"status": 0}, "missing fields", errors.New("github.getWorkflowRun: owner, repo, and runId are required")
+		}
+		out, err = github.GetWorkflowRun(ctx, accessToken, owner, repo, int64(runID))
 	default:
 		return map[string]any{"status": 0}, "unsupported github action", fmt.Errorf("app(github): unsupported action %q", action)
 	}
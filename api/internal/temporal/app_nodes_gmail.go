@@ -2,6 +2,7 @@ package temporal
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,19 +11,51 @@ import (
 	"flowcraft-api/internal/adapters/external/google"
 )
 
+// maxGmailDetails bounds gmail.searchMessages with includeDetails set.
+const maxGmailDetails = 50
+
 func executeGmail(ctx context.Context, config map[string]any, deps stepDependencies) (map[string]any, string, error) {
+	return executeAppGmail(ctx, config, deps, "gmail.sendEmail")
+}
+
+func executeAppGmail(ctx context.Context, config map[string]any, deps stepDependencies, action string) (map[string]any, string, error) {
 	credentialID := strings.TrimSpace(readString(config, "credentialId"))
 	if credentialID == "" {
 		return map[string]any{"status": 0}, "missing credential", errors.New("gmail: credentialId is required")
 	}
-	to := strings.TrimSpace(readString(config, "to"))
-	if to == "" {
-		return map[string]any{"status": 0}, "missing recipient", errors.New("gmail: to is required")
+
+	key := strings.ToLower(strings.TrimSpace(action))
+	var email google.Email
+	switch key {
+	case "gmail.sendemail", "gmail.createdraft", "gmail.reply":
+		var err error
+		email, err = readGmailEmail(config)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid attachments", fmt.Errorf("%s: %w", action, err)
+		}
+	}
+	messageID := strings.TrimSpace(readString(config, "messageId"))
+	switch key {
+	case "gmail.sendemail":
+		if strings.TrimSpace(email.To) == "" {
+			return map[string]any{"status": 0}, "missing recipient", errors.New("gmail: to is required")
+		}
+	case "gmail.createdraft":
+		if strings.TrimSpace(email.To) == "" && strings.TrimSpace(readString(config, "replyToMessageId")) == "" {
+			return map[string]any{"status": 0}, "missing recipient", errors.New("gmail.createDraft: to is required")
+		}
+	case "gmail.reply", "gmail.getmessage", "gmail.addlabels", "gmail.removelabels", "gmail.markread", "gmail.markunread":
+		if messageID == "" {
+			return map[string]any{"status": 0}, "missing messageId", fmt.Errorf("%s: messageId is required", action)
+		}
+	case "gmail.searchmessages", "gmail.listlabels":
+	default:
+		return map[string]any{"status": 0}, "unsupported gmail action", fmt.Errorf("app(gmail): unsupported action %q", action)
+	}
+	labels := readList(config, "labels")
+	if (key == "gmail.addlabels" || key == "gmail.removelabels") && len(labels) == 0 {
+		return map[string]any{"status": 0}, "missing labels", fmt.Errorf("%s: labels is required", action)
 	}
-	subject := strings.TrimSpace(readString(config, "subject"))
-	bodyText := readString(config, "bodyText")
-	bodyHTML := readString(config, "bodyHtml")
-	from := strings.TrimSpace(readString(config, "from"))
 
 	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
 	if err != nil {
@@ -31,16 +64,66 @@ func executeGmail(ctx context.Context, config map[string]any, deps stepDependenc
 	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "google" {
 		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("gmail: expected google credential")
 	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
 	accessToken, err := googleAccessToken(ctx, deps, payload)
 	if err != nil {
 		return map[string]any{"status": 0}, "token refresh failed", err
 	}
-	if from == "" {
-		from = strings.TrimSpace(readAnyString(payload["account_email"]))
+	if email.From == "" {
+		email.From = strings.TrimSpace(readAnyString(payload["account_email"]))
 	}
 
 	started := time.Now()
-	out, err := google.SendEmail(ctx, accessToken, from, to, subject, bodyText, bodyHTML)
+	var out map[string]any
+	logText := ""
+	switch key {
+	case "gmail.sendemail":
+		out, err = google.SendEmail(ctx, accessToken, email)
+		logText = fmt.Sprintf("gmail send -> %s", email.To)
+	case "gmail.reply":
+		var original map[string]any
+		original, err = google.GetMessage(ctx, accessToken, messageID)
+		if err == nil {
+			email = google.ReplyTo(original, email)
+			out, err = google.SendEmail(ctx, accessToken, email)
+			logText = fmt.Sprintf("gmail reply -> %s", email.To)
+		}
+	case "gmail.createdraft":
+		if replyTo := strings.TrimSpace(readString(config, "replyToMessageId")); replyTo != "" {
+			var original map[string]any
+			original, err = google.GetMessage(ctx, accessToken, replyTo)
+			if err != nil {
+				break
+			}
+			email = google.ReplyTo(original, email)
+		}
+		out, err = google.CreateDraft(ctx, accessToken, email)
+	case "gmail.searchmessages":
+		out, err = google.SearchMessages(ctx, accessToken, google.SearchOptions{
+			Query:            readString(config, "query"),
+			LabelIDs:         readList(config, "labelIds"),
+			MaxResults:       readInt(config, "maxResults"),
+			PageToken:        strings.TrimSpace(readString(config, "pageToken")),
+			IncludeSpamTrash: readBool(config, "includeSpamTrash"),
+			All:              readBool(config, "returnAll"),
+		})
+		if err == nil && readBool(config, "includeDetails") {
+			err = addGmailDetails(ctx, accessToken, out)
+		}
+	case "gmail.getmessage":
+		out, err = google.GetMessage(ctx, accessToken, messageID)
+	case "gmail.addlabels":
+		out, err = google.ModifyLabels(ctx, accessToken, messageID, labels, nil)
+	case "gmail.removelabels":
+		out, err = google.ModifyLabels(ctx, accessToken, messageID, nil, labels)
+	case "gmail.markread":
+		out, err = google.ModifyLabels(ctx, accessToken, messageID, nil, []string{"UNREAD"})
+	case "gmail.markunread":
+		out, err = google.ModifyLabels(ctx, accessToken, messageID, []string{"UNREAD"}, nil)
+	case "gmail.listlabels":
+		out, err = google.ListLabels(ctx, accessToken)
+	}
+
 	duration := time.Since(started)
 	outputs := map[string]any{
 		"status": 200,
@@ -52,8 +135,116 @@ func executeGmail(ctx context.Context, config map[string]any, deps stepDependenc
 	if err != nil {
 		outputs["status"] = 0
 		outputs["error"] = err.Error()
-		return outputs, "gmail send failed", err
+		if key == "gmail.sendemail" {
+			return outputs, "gmail send failed", err
+		}
+		return outputs, "gmail action failed", err
+	}
+	if logText == "" {
+		logText = action
+	}
+	return outputs, fmt.Sprintf("%s (%dms)", logText, duration.Milliseconds()), nil
+}
+
+// addGmailDetails replaces the search result's message references with
+// parsed messages, for at most maxGmailDetails messages.
+func addGmailDetails(ctx context.Context, accessToken string, out map[string]any) error {
+	refs, _ := out["messages"].([]any)
+	if len(refs) > maxGmailDetails {
+		refs = refs[:maxGmailDetails]
+	}
+	detailed := make([]any, 0, len(refs))
+	for _, raw := range refs {
+		ref, _ := raw.(map[string]any)
+		id := strings.TrimSpace(readAnyString(ref["id"]))
+		if id == "" {
+			continue
+		}
+		msg, err := google.GetMessage(ctx, accessToken, id)
+		if err != nil {
+			return err
+		}
+		detailed = append(detailed, msg)
+	}
+	out["messages"] = detailed
+	out["count"] = len(detailed)
+	return nil
+}
+
+// readGmailEmail reads the outgoing message fields shared by send, reply and draft.
+func readGmailEmail(config map[string]any) (google.Email, error) {
+	attachments, err := readGmailAttachments(config)
+	if err != nil {
+		return google.Email{}, err
+	}
+	return google.Email{
+		From:        strings.TrimSpace(readString(config, "from")),
+		To:          strings.TrimSpace(readString(config, "to")),
+		Cc:          strings.TrimSpace(readString(config, "cc")),
+		Bcc:         strings.TrimSpace(readString(config, "bcc")),
+		ReplyTo:     strings.TrimSpace(readString(config, "replyTo")),
+		Subject:     strings.TrimSpace(readString(config, "subject")),
+		BodyText:    readString(config, "bodyText"),
+		BodyHTML:    readString(config, "bodyHtml"),
+		Attachments: attachments,
+	}, nil
+}
+
+// readGmailAttachments reads "attachments": a JSON array of
+// {filename, mimeType?, contentBase64 | content}.
+func readGmailAttachments(config map[string]any) ([]google.Attachment, error) {
+	raw, err := readJSONConfig(config, "attachments")
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, errors.New("attachments must be an array")
+	}
+	out := make([]google.Attachment, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("attachment %d must be an object", i+1)
+		}
+		a := google.Attachment{
+			Filename: strings.TrimSpace(readString(m, "filename")),
+			MimeType: strings.TrimSpace(readString(m, "mimeType")),
+		}
+		if a.Filename == "" {
+			return nil, fmt.Errorf("attachment %d: filename is required", i+1)
+		}
+		if encoded := strings.TrimSpace(readString(m, "contentBase64")); encoded != "" {
+			a.Content, err = base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %d: contentBase64 is not valid base64", i+1)
+			}
+		} else {
+			a.Content = []byte(readString(m, "content"))
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+// readList reads a list config value given as an array or a comma-separated string, dropping blanks.
+func readList(config map[string]any, key string) []string {
+	var parts []string
+	switch v := config[key].(type) {
+	case []any:
+		for _, item := range v {
+			parts = append(parts, readAnyString(item))
+		}
+	case []string:
+		parts = v
+	default:
+		parts = strings.Split(readString(config, key), ",")
+	}
+	var out []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
 	}
-	logText := fmt.Sprintf("gmail send -> %s (%dms)", to, duration.Milliseconds())
-	return outputs, logText, nil
+	return out
 }
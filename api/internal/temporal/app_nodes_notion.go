@@ -24,6 +24,7 @@ func executeAppNotion(ctx context.Context, config map[string]any, deps stepDepen
 	if !strings.EqualFold(cred.Provider, "notion") {
 		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("notion: expected notion credential, got %s", cred.Provider)
 	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
 
 	token := strings.TrimSpace(readAnyString(payload["access_token"]))
 	if token == "" {
@@ -45,11 +46,121 @@ func executeAppNotion(ctx context.Context, config map[string]any, deps stepDepen
 		}
 		title := strings.TrimSpace(readString(config, "title"))
 		content := readString(config, "content")
+		values, valuesErr := readNotionProperties(config)
+		if valuesErr != nil {
+			return map[string]any{"status": 0}, "invalid properties", fmt.Errorf("notion.createPage: %w", valuesErr)
+		}
 
 		if parentID == "" || title == "" {
 			return map[string]any{"status": 0}, "missing fields", errors.New("notion.createPage: databaseId and title are required")
 		}
-		out, err = notion.CreatePage(ctx, token, parentID, title, content)
+		// The schema gives the title property's real name and the property types.
+		var database map[string]any
+		database, err = notion.GetDatabase(ctx, token, parentID)
+		if err != nil {
+			break
+		}
+		schema := notion.DatabaseSchema(database)
+		var properties map[string]any
+		properties, err = notion.BuildProperties(schema, values)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid properties", fmt.Errorf("notion.createPage: %w", err)
+		}
+		var children []any
+		if strings.TrimSpace(content) != "" {
+			children = notion.MarkdownToBlocks(content)
+		}
+		out, err = notion.CreatePage(ctx, token, parentID, notion.TitleProperty(schema), title, properties, children)
+	case "notion.getpage":
+		pageID := strings.TrimSpace(readString(config, "pageId"))
+		if pageID == "" {
+			return map[string]any{"status": 0}, "missing pageId", errors.New("notion.getPage: pageId is required")
+		}
+		out, err = notion.GetPage(ctx, token, pageID)
+	case "notion.getdatabase":
+		databaseID := strings.TrimSpace(readString(config, "databaseId"))
+		if databaseID == "" {
+			return map[string]any{"status": 0}, "missing databaseId", errors.New("notion.getDatabase: databaseId is required")
+		}
+		out, err = notion.GetDatabase(ctx, token, databaseID)
+	case "notion.querydatabase":
+		databaseID := strings.TrimSpace(readString(config, "databaseId"))
+		if databaseID == "" {
+			return map[string]any{"status": 0}, "missing databaseId", errors.New("notion.queryDatabase: databaseId is required")
+		}
+		filter, filterErr := readJSONConfig(config, "filter")
+		if filterErr != nil {
+			return map[string]any{"status": 0}, "invalid filter", fmt.Errorf("notion.queryDatabase: %w", filterErr)
+		}
+		if _, ok := filter.(map[string]any); filter != nil && !ok {
+			return map[string]any{"status": 0}, "invalid filter", errors.New("notion.queryDatabase: filter must be an object")
+		}
+		sorts, sortsErr := readJSONConfig(config, "sorts")
+		if sortsErr != nil {
+			return map[string]any{"status": 0}, "invalid sorts", fmt.Errorf("notion.queryDatabase: %w", sortsErr)
+		}
+		sortList, ok := sorts.([]any)
+		if sorts != nil && !ok {
+			return map[string]any{"status": 0}, "invalid sorts", errors.New("notion.queryDatabase: sorts must be an array")
+		}
+		out, err = notion.QueryDatabase(ctx, token, databaseID, notion.QueryOptions{
+			Filter:      filter,
+			Sorts:       sortList,
+			PageSize:    readInt(config, "pageSize"),
+			StartCursor: strings.TrimSpace(readString(config, "startCursor")),
+			All:         readBool(config, "returnAll"),
+		})
+	case "notion.updatepageproperties":
+		pageID := strings.TrimSpace(readString(config, "pageId"))
+		values, valuesErr := readNotionProperties(config)
+		if valuesErr != nil {
+			return map[string]any{"status": 0}, "invalid properties", fmt.Errorf("notion.updatePageProperties: %w", valuesErr)
+		}
+		if pageID == "" || len(values) == 0 {
+			return map[string]any{"status": 0}, "missing fields", errors.New("notion.updatePageProperties: pageId and properties are required")
+		}
+		var schema map[string]any
+		schema, err = notionPageSchema(ctx, token, pageID)
+		if err != nil {
+			break
+		}
+		var properties map[string]any
+		properties, err = notion.BuildProperties(schema, values)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid properties", fmt.Errorf("notion.updatePageProperties: %w", err)
+		}
+		out, err = notion.UpdatePageProperties(ctx, token, pageID, properties)
+	case "notion.appendblocks":
+		blockID := strings.TrimSpace(readString(config, "blockId"))
+		if blockID == "" {
+			blockID = strings.TrimSpace(readString(config, "pageId"))
+		}
+		children, blocksErr := readNotionBlocks(config)
+		if blocksErr != nil {
+			return map[string]any{"status": 0}, "invalid blocks", fmt.Errorf("notion.appendBlocks: %w", blocksErr)
+		}
+		if blockID == "" || len(children) == 0 {
+			return map[string]any{"status": 0}, "missing fields", errors.New("notion.appendBlocks: pageId and markdown (or blocks) are required")
+		}
+		out, err = notion.AppendBlocks(ctx, token, blockID, children)
+	case "notion.archivepage":
+		pageID := strings.TrimSpace(readString(config, "pageId"))
+		if pageID == "" {
+			return map[string]any{"status": 0}, "missing pageId", errors.New("notion.archivePage: pageId is required")
+		}
+		out, err = notion.ArchivePage(ctx, token, pageID, !readBool(config, "restore"))
+	case "notion.search":
+		filter := strings.ToLower(strings.TrimSpace(readString(config, "filter")))
+		if filter != "" && filter != "page" && filter != "database" {
+			return map[string]any{"status": 0}, "invalid filter", errors.New("notion.search: filter must be page or database")
+		}
+		out, err = notion.Search(ctx, token, notion.SearchOptions{
+			Query:       strings.TrimSpace(readString(config, "query")),
+			Filter:      filter,
+			PageSize:    readInt(config, "pageSize"),
+			StartCursor: strings.TrimSpace(readString(config, "startCursor")),
+			All:         readBool(config, "returnAll"),
+		})
 
 	default:
 		return map[string]any{"status": 0}, "unsupported notion action", fmt.Errorf("app(notion): unsupported action %q", action)
@@ -66,8 +177,68 @@ func executeAppNotion(ctx context.Context, config map[string]any, deps stepDepen
 	if err != nil {
 		outputs["status"] = 0
 		outputs["error"] = err.Error()
+		var rateLimited *notion.RateLimitError
+		if errors.As(err, &rateLimited) {
+			outputs["status"] = 429
+			outputs["meta"].(map[string]any)["retry_after_ms"] = rateLimited.RetryAfter.Milliseconds()
+			return outputs, "notion rate limited", err
+		}
 		return outputs, "notion action failed", err
 	}
 
 	return outputs, fmt.Sprintf("%s (%dms)", action, duration.Milliseconds()), nil
 }
+
+// notionPageSchema returns the property schema for a page: its database's
+// schema, or the page's own properties when it is not in a database.
+func notionPageSchema(ctx context.Context, token string, pageID string) (map[string]any, error) {
+	page, err := notion.GetPage(ctx, token, pageID)
+	if err != nil {
+		return nil, err
+	}
+	if databaseID := notion.PageDatabaseID(page); databaseID != "" {
+		database, err := notion.GetDatabase(ctx, token, databaseID)
+		if err != nil {
+			return nil, err
+		}
+		return notion.DatabaseSchema(database), nil
+	}
+	schema, _ := page["properties"].(map[string]any)
+	return schema, nil
+}
+
+func readNotionProperties(config map[string]any) (map[string]any, error) {
+	raw, err := readJSONConfig(config, "properties")
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return map[string]any{}, nil
+	}
+	props, ok := raw.(map[string]any)
+	if !ok {
+		return nil, errors.New("properties must be an object of property name to value")
+	}
+	return props, nil
+}
+
+// readNotionBlocks returns raw Notion blocks from "blocks", or converts the
+// "markdown" field.
+func readNotionBlocks(config map[string]any) ([]any, error) {
+	raw, err := readJSONConfig(config, "blocks")
+	if err != nil {
+		return nil, err
+	}
+	if raw != nil {
+		blocks, ok := raw.([]any)
+		if !ok {
+			return nil, errors.New("blocks must be an array of Notion blocks")
+		}
+		return blocks, nil
+	}
+	markdown := readString(config, "markdown")
+	if strings.TrimSpace(markdown) == "" {
+		return nil, nil
+	}
+	return notion.MarkdownToBlocks(markdown), nil
+}
@@ -28,6 +28,7 @@ func executeSheets(ctx context.Context, config map[string]any, deps stepDependen
 	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "google" {
 		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("gsheets: expected google credential")
 	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
 	accessToken, err := googleAccessToken(ctx, deps, payload)
 	if err != nil {
 		return map[string]any{"status": 0}, "token refresh failed", err
@@ -68,6 +69,7 @@ func executeAppSheets(ctx context.Context, config map[string]any, deps stepDepen
 	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "google" {
 		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("gsheets: expected google credential")
 	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
 	accessToken, err := googleAccessToken(ctx, deps, payload)
 	if err != nil {
 		return map[string]any{"status": 0}, "token refresh failed", err
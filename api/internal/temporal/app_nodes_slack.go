@@ -2,6 +2,8 @@ package temporal
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -25,6 +27,7 @@ func executeAppSlack(ctx context.Context, config map[string]any, deps stepDepend
 	if !strings.EqualFold(cred.Provider, "slack") {
 		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("slack: expected slack credential, got %s", cred.Provider)
 	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
 
 	token := strings.TrimSpace(readAnyString(payload["access_token"]))
 	// Sometimes it might be called 'bot_token' or just 'token' depending on how we stored it.
@@ -46,11 +49,88 @@ func executeAppSlack(ctx context.Context, config map[string]any, deps stepDepend
 		if text == "" {
 			text = readString(config, "text")
 		}
+		blocks, blocksErr := readSlackBlocks(config)
+		if blocksErr != nil {
+			return map[string]any{"status": 0}, "invalid blocks", fmt.Errorf("slack.sendMessage: %w", blocksErr)
+		}
 
-		if channel == "" || text == "" {
-			return map[string]any{"status": 0}, "missing fields", errors.New("slack.sendMessage: channel and message are required")
+		if channel == "" || (text == "" && len(blocks) == 0) {
+			return map[string]any{"status": 0}, "missing fields", errors.New("slack.sendMessage: channel and message (or blocks) are required")
+		}
+		out, err = slack.SendMessage(ctx, token, channel, text, slack.MessageOptions{
+			Blocks:         blocks,
+			ThreadTS:       strings.TrimSpace(readString(config, "threadTs")),
+			ReplyBroadcast: readBool(config, "replyBroadcast"),
+			UnfurlLinks:    readOptionalBool(config, "unfurlLinks"),
+			UnfurlMedia:    readOptionalBool(config, "unfurlMedia"),
+		})
+	case "slack.updatemessage":
+		channel := strings.TrimSpace(readString(config, "channel"))
+		ts := strings.TrimSpace(readString(config, "ts"))
+		text := readString(config, "message")
+		if text == "" {
+			text = readString(config, "text")
 		}
-		out, err = slack.SendMessage(ctx, token, channel, text)
+		blocks, blocksErr := readSlackBlocks(config)
+		if blocksErr != nil {
+			return map[string]any{"status": 0}, "invalid blocks", fmt.Errorf("slack.updateMessage: %w", blocksErr)
+		}
+		if channel == "" || ts == "" || (text == "" && len(blocks) == 0) {
+			return map[string]any{"status": 0}, "missing fields", errors.New("slack.updateMessage: channel, ts and message (or blocks) are required")
+		}
+		out, err = slack.UpdateMessage(ctx, token, channel, ts, text, blocks)
+	case "slack.deletemessage":
+		channel := strings.TrimSpace(readString(config, "channel"))
+		ts := strings.TrimSpace(readString(config, "ts"))
+		if channel == "" || ts == "" {
+			return map[string]any{"status": 0}, "missing fields", errors.New("slack.deleteMessage: channel and ts are required")
+		}
+		out, err = slack.DeleteMessage(ctx, token, channel, ts)
+	case "slack.addreaction":
+		channel := strings.TrimSpace(readString(config, "channel"))
+		ts := strings.TrimSpace(readString(config, "ts"))
+		name := strings.TrimSpace(readString(config, "emoji"))
+		if channel == "" || ts == "" || name == "" {
+			return map[string]any{"status": 0}, "missing fields", errors.New("slack.addReaction: channel, ts and emoji are required")
+		}
+		out, err = slack.AddReaction(ctx, token, channel, ts, name)
+	case "slack.uploadfile":
+		filename := strings.TrimSpace(readString(config, "filename"))
+		content, contentErr := readSlackFileContent(config)
+		if contentErr != nil {
+			return map[string]any{"status": 0}, "invalid content", fmt.Errorf("slack.uploadFile: %w", contentErr)
+		}
+		if filename == "" || len(content) == 0 {
+			return map[string]any{"status": 0}, "missing fields", errors.New("slack.uploadFile: filename and content are required")
+		}
+		out, err = slack.UploadFile(ctx, token, slack.FileUpload{
+			Filename:       filename,
+			Title:          strings.TrimSpace(readString(config, "title")),
+			Content:        content,
+			ChannelID:      strings.TrimSpace(readString(config, "channel")),
+			InitialComment: readString(config, "initialComment"),
+			ThreadTS:       strings.TrimSpace(readString(config, "threadTs")),
+		})
+	case "slack.listchannels":
+		out, err = slack.ListChannels(ctx, token, slack.ListChannelsOptions{
+			Types:           strings.TrimSpace(readString(config, "types")),
+			ExcludeArchived: readBool(config, "excludeArchived"),
+			Limit:           readInt(config, "limit"),
+			Cursor:          strings.TrimSpace(readString(config, "cursor")),
+			All:             readBool(config, "returnAll"),
+		})
+	case "slack.getuserbyemail":
+		email := strings.TrimSpace(readString(config, "email"))
+		if email == "" {
+			return map[string]any{"status": 0}, "missing email", errors.New("slack.getUserByEmail: email is required")
+		}
+		out, err = slack.GetUserByEmail(ctx, token, email)
+	case "slack.opendirectmessage":
+		users := splitSlackUsers(readString(config, "users"))
+		if len(users) == 0 {
+			return map[string]any{"status": 0}, "missing users", errors.New("slack.openDirectMessage: users is required")
+		}
+		out, err = slack.OpenDirectMessage(ctx, token, users)
 
 	default:
 		return map[string]any{"status": 0}, "unsupported slack action", fmt.Errorf("app(slack): unsupported action %q", action)
@@ -67,8 +147,78 @@ func executeAppSlack(ctx context.Context, config map[string]any, deps stepDepend
 	if err != nil {
 		outputs["status"] = 0
 		outputs["error"] = err.Error()
+		var rateLimited *slack.RateLimitError
+		if errors.As(err, &rateLimited) {
+			outputs["status"] = 429
+			outputs["meta"].(map[string]any)["retry_after_ms"] = rateLimited.RetryAfter.Milliseconds()
+			return outputs, "slack rate limited", err
+		}
 		return outputs, "slack action failed", err
 	}
 
 	return outputs, fmt.Sprintf("%s (%dms)", action, duration.Milliseconds()), nil
 }
+
+// readSlackBlocks accepts Block Kit blocks as an array, a JSON string, or a
+// {"blocks": [...]} object as copied from Block Kit Builder.
+func readSlackBlocks(config map[string]any) ([]any, error) {
+	raw, ok := config["blocks"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	if s, ok := raw.(string); ok {
+		if strings.TrimSpace(s) == "" {
+			return nil, nil
+		}
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return nil, fmt.Errorf("blocks must be valid JSON: %w", err)
+		}
+		raw = decoded
+	}
+	switch v := raw.(type) {
+	case []any:
+		return v, nil
+	case map[string]any:
+		if blocks, ok := v["blocks"].([]any); ok {
+			return blocks, nil
+		}
+	}
+	return nil, errors.New("blocks must be an array of Block Kit blocks")
+}
+
+// readSlackFileContent returns the file bytes from contentBase64 or, failing
+// that, the plain-text content field.
+func readSlackFileContent(config map[string]any) ([]byte, error) {
+	if encoded := strings.TrimSpace(readString(config, "contentBase64")); encoded != "" {
+		content, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("contentBase64 is not valid base64: %w", err)
+		}
+		return content, nil
+	}
+	return []byte(readString(config, "content")), nil
+}
+
+func splitSlackUsers(raw string) []string {
+	var users []string
+	for _, part := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			users = append(users, part)
+		}
+	}
+	return users
+}
+
+// readOptionalBool distinguishes an unset option from false.
+func readOptionalBool(cfg map[string]any, key string) *bool {
+	if raw, ok := cfg[key]; !ok || raw == nil || raw == "" {
+		return nil
+	}
+	v := readBool(cfg, key)
+	return &v
+}
+
+func ReadSlackBlocksForTest(config map[string]any) ([]any, error) {
+	return readSlackBlocks(config)
+}
@@ -0,0 +1,87 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/teams"
+)
+
+func executeAppTeams(ctx context.Context, config map[string]any, deps stepDependencies, action string) (map[string]any, string, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return map[string]any{"status": 0}, "missing credential", errors.New("microsoftTeams: credentialId is required")
+	}
+
+	var message map[string]any
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "microsoftteams.sendmessage":
+		title := readString(config, "title")
+		text := readString(config, "text")
+		if strings.TrimSpace(title) == "" && strings.TrimSpace(text) == "" {
+			return map[string]any{"status": 0}, "missing text", fmt.Errorf("%s: text is required", action)
+		}
+		message, _ = teams.CardMessage(teams.TextCard(title, text))
+	case "microsoftteams.sendadaptivecard":
+		raw, err := readJSONConfig(config, "card")
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid card", fmt.Errorf("%s: %w", action, err)
+		}
+		card, ok := raw.(map[string]any)
+		if !ok {
+			return map[string]any{"status": 0}, "missing card", fmt.Errorf("%s: card is required", action)
+		}
+		message, err = teams.CardMessage(card)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid card", fmt.Errorf("%s: %w", action, err)
+		}
+	default:
+		return map[string]any{"status": 0}, "unsupported microsoftTeams action", fmt.Errorf("app(microsoftTeams): unsupported action %q", action)
+	}
+
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return map[string]any{"status": 0}, "credential load failed", err
+	}
+	if !isTeamsProvider(cred.Provider) {
+		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("microsoftTeams: expected microsoftTeams credential, got %s", cred.Provider)
+	}
+	webhookURL := strings.TrimSpace(readAnyString(payload["webhook_url"]))
+	if webhookURL == "" {
+		return map[string]any{"status": 0}, "missing webhook URL", errors.New("microsoftTeams: credential has no webhook_url")
+	}
+
+	started := time.Now()
+	out, err := teams.Post(ctx, webhookURL, message)
+	duration := time.Since(started)
+	outputs := map[string]any{
+		"status": 200,
+		"data":   out,
+		"meta": map[string]any{
+			"duration_ms": duration.Milliseconds(),
+		},
+	}
+	if err != nil {
+		outputs["status"] = 0
+		outputs["error"] = err.Error()
+		var rateLimited *teams.RateLimitError
+		if errors.As(err, &rateLimited) {
+			outputs["status"] = 429
+			outputs["meta"].(map[string]any)["retry_after_ms"] = rateLimited.RetryAfter.Milliseconds()
+			return outputs, "microsoftTeams rate limited", err
+		}
+		return outputs, "microsoftTeams action failed", err
+	}
+	return outputs, fmt.Sprintf("%s (%dms)", action, duration.Milliseconds()), nil
+}
+
+func isTeamsProvider(provider string) bool {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "microsoftteams", "microsoft_teams", "teams":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/telegram"
+)
+
+func executeAppTelegram(ctx context.Context, config map[string]any, deps stepDependencies, action string) (map[string]any, string, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return map[string]any{"status": 0}, "missing credential", errors.New("telegram: credentialId is required")
+	}
+
+	key := strings.ToLower(strings.TrimSpace(action))
+	chatID := strings.TrimSpace(readString(config, "chatId"))
+	switch key {
+	case "telegram.sendmessage", "telegram.sendphoto", "telegram.senddocument":
+		if chatID == "" {
+			return map[string]any{"status": 0}, "missing chatId", fmt.Errorf("%s: chatId is required", action)
+		}
+	case "telegram.editmessage":
+		if strings.TrimSpace(readString(config, "inlineMessageId")) == "" && (chatID == "" || readInt(config, "messageId") <= 0) {
+			return map[string]any{"status": 0}, "missing message", fmt.Errorf("%s: chatId and messageId (or inlineMessageId) are required", action)
+		}
+	case "telegram.answercallbackquery":
+		if strings.TrimSpace(readString(config, "callbackQueryId")) == "" {
+			return map[string]any{"status": 0}, "missing callbackQueryId", fmt.Errorf("%s: callbackQueryId is required", action)
+		}
+	default:
+		return map[string]any{"status": 0}, "unsupported telegram action", fmt.Errorf("app(telegram): unsupported action %q", action)
+	}
+	replyMarkup, err := readTelegramReplyMarkup(config)
+	if err != nil {
+		return map[string]any{"status": 0}, "invalid replyMarkup", fmt.Errorf("%s: %w", action, err)
+	}
+	var content []byte
+	if key == "telegram.sendphoto" || key == "telegram.senddocument" {
+		content, err = readSlackFileContent(config)
+		if err != nil {
+			return map[string]any{"status": 0}, "invalid content", fmt.Errorf("%s: %w", action, err)
+		}
+		if len(content) == 0 && strings.TrimSpace(readString(config, "fileId")) == "" {
+			return map[string]any{"status": 0}, "missing content", fmt.Errorf("%s: contentBase64, content or fileId is required", action)
+		}
+	}
+
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return map[string]any{"status": 0}, "credential load failed", err
+	}
+	if !strings.EqualFold(cred.Provider, "telegram") {
+		return map[string]any{"status": 0}, "credential provider mismatch", fmt.Errorf("telegram: expected telegram credential, got %s", cred.Provider)
+	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
+	botToken := telegram.BotToken(payload)
+	if botToken == "" {
+		return map[string]any{"status": 0}, "missing bot token", errors.New("telegram: credential has no bot_token")
+	}
+
+	started := time.Now()
+	var out map[string]any
+	switch key {
+	case "telegram.sendmessage":
+		out, err = telegram.SendMessage(ctx, botToken, telegram.Message{
+			ChatID:                chatID,
+			Text:                  readString(config, "text"),
+			ParseMode:             readString(config, "parseMode"),
+			ReplyMarkup:           replyMarkup,
+			DisableNotification:   readBool(config, "disableNotification"),
+			DisableWebPagePreview: readBool(config, "disableWebPagePreview"),
+			ReplyToMessageID:      readInt(config, "replyToMessageId"),
+			MessageThreadID:       readInt(config, "messageThreadId"),
+		})
+	case "telegram.sendphoto", "telegram.senddocument":
+		file := telegram.File{
+			ChatID:              chatID,
+			Filename:            readString(config, "filename"),
+			MimeType:            readString(config, "mimeType"),
+			Content:             content,
+			FileRef:             readString(config, "fileId"),
+			Caption:             readString(config, "caption"),
+			ParseMode:           readString(config, "parseMode"),
+			ReplyMarkup:         replyMarkup,
+			DisableNotification: readBool(config, "disableNotification"),
+			ReplyToMessageID:    readInt(config, "replyToMessageId"),
+			MessageThreadID:     readInt(config, "messageThreadId"),
+		}
+		if key == "telegram.sendphoto" {
+			out, err = telegram.SendPhoto(ctx, botToken, file)
+		} else {
+			out, err = telegram.SendDocument(ctx, botToken, file)
+		}
+	case "telegram.editmessage":
+		out, err = telegram.EditMessageText(ctx, botToken, telegram.Edit{
+			ChatID:          chatID,
+			MessageID:       readInt(config, "messageId"),
+			InlineMessageID: readString(config, "inlineMessageId"),
+			Text:            readString(config, "text"),
+			ParseMode:       readString(config, "parseMode"),
+			ReplyMarkup:     replyMarkup,
+		})
+	case "telegram.answercallbackquery":
+		out, err = telegram.AnswerCallbackQuery(ctx, botToken, telegram.CallbackAnswer{
+			CallbackQueryID: readString(config, "callbackQueryId"),
+			Text:            readString(config, "text"),
+			ShowAlert:       readBool(config, "showAlert"),
+			URL:             readString(config, "url"),
+			CacheSeconds:    readInt(config, "cacheSeconds"),
+		})
+	}
+
+	duration := time.Since(started)
+	outputs := map[string]any{
+		"status": 200,
+		"data":   out,
+		"meta": map[string]any{
+			"duration_ms": duration.Milliseconds(),
+		},
+	}
+	if err != nil {
+		outputs["status"] = 0
+		outputs["error"] = err.Error()
+		var rateLimited *telegram.RateLimitError
+		if errors.As(err, &rateLimited) {
+			outputs["status"] = 429
+			outputs["meta"].(map[string]any)["retry_after_ms"] = rateLimited.RetryAfter.Milliseconds()
+			return outputs, "telegram rate limited", err
+		}
+		return outputs, "telegram action failed", err
+	}
+	return outputs, fmt.Sprintf("%s (%dms)", action, duration.Milliseconds()), nil
+}
+
+// readTelegramReplyMarkup reads replyMarkup: an inline_keyboard, keyboard,
+// remove_keyboard or force_reply object, or a bare array of button rows,
+// which becomes an inline keyboard.
+func readTelegramReplyMarkup(config map[string]any) (map[string]any, error) {
+	raw, err := readJSONConfig(config, "replyMarkup")
+	if err != nil {
+		return nil, err
+	}
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return v, nil
+	case []any:
+		return map[string]any{"inline_keyboard": v}, nil
+	}
+	return nil, errors.New("replyMarkup must be a reply markup object or an array of button rows")
+}
@@ -1,6 +1,7 @@
 package temporal
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -42,3 +43,24 @@ func readInt(cfg map[string]any, key string) int {
 func ReadIntForTest(cfg map[string]any, key string) int {
 	return readInt(cfg, key)
 }
+
+// readJSONConfig returns a config value that may be set as JSON text (from a
+// json field) or as an already decoded value. Missing and blank values are nil.
+func readJSONConfig(cfg map[string]any, key string) (any, error) {
+	raw, ok := cfg[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return raw, nil
+	}
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var out any
+	if err := json.Unmarshal([]byte(s), &out); err != nil {
+		return nil, fmt.Errorf("%s must be valid JSON: %w", key, err)
+	}
+	return out, nil
+}
@@ -6,11 +6,17 @@ import (
 	"fmt"
 	"strings"
 
+	"flowcraft-api/internal/adapters/external/endpoints"
 	"flowcraft-api/internal/adapters/external/google"
 	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
 	"flowcraft-api/internal/utils"
 )
 
+// loadCredentialPayload loads and decrypts a credential of the step's flow:
+// a project credential of its project or a personal one of its owner, as
+// CredentialService.GetForFlow allows. Any other credential is refused, so a
+// flow cannot send someone else's secret to a URL it picks.
 func loadCredentialPayload(ctx context.Context, deps stepDependencies, credentialID string) (domain.Credential, map[string]any, error) {
 	if deps.creds == nil {
 		return domain.Credential{}, nil, errors.New("credentials repository not configured")
@@ -18,10 +24,16 @@ func loadCredentialPayload(ctx context.Context, deps stepDependencies, credentia
 	if len(deps.credsKey) == 0 {
 		return domain.Credential{}, nil, errors.New("credentials encryption key not configured")
 	}
+	if deps.flow == nil {
+		return domain.Credential{}, nil, errors.New("credentials can only be used by a flow")
+	}
 	cred, err := deps.creds.Get(ctx, credentialID)
 	if err != nil {
 		return domain.Credential{}, nil, err
 	}
+	if !services.CredentialUsableByFlow(*cred, *deps.flow) {
+		return domain.Credential{}, nil, fmt.Errorf("credential %s is not available to this flow: %w", credentialID, utils.ErrForbidden)
+	}
 	var payload map[string]any
 	if err := utils.DecryptJSON(deps.credsKey, cred.DataEncrypted, &payload); err != nil {
 		return domain.Credential{}, nil, err
@@ -29,6 +41,12 @@ func loadCredentialPayload(ctx context.Context, deps stepDependencies, credentia
 	return *cred, payload, nil
 }
 
+// withEndpoints returns ctx carrying the connector endpoints for a credential:
+// its api_url override, else the server config in deps.
+func withEndpoints(ctx context.Context, deps stepDependencies, provider string, payload map[string]any) context.Context {
+	return endpoints.Apply(ctx, deps.cfg, provider, payload)
+}
+
 func googleAccessToken(ctx context.Context, deps stepDependencies, payload map[string]any) (string, error) {
 	refreshToken := strings.TrimSpace(readAnyString(payload["refresh_token"]))
 	if refreshToken == "" {
@@ -0,0 +1,43 @@
+package temporal
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var expressionPattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// resolveExpression evaluates a config value against scope (the node input
+// plus "steps"). A value that is exactly one "{{ path }}" returns the raw value
+// at path; any other string has each "{{ path }}" replaced by its text form.
+func resolveExpression(raw string, scope map[string]any) any {
+	trim := strings.TrimSpace(raw)
+	if m := expressionPattern.FindStringSubmatch(trim); m != nil && m[0] == trim {
+		v, _ := getByPath(scope, m[1])
+		return v
+	}
+	return renderTemplate(raw, scope)
+}
+
+// renderTemplate replaces every "{{ path }}" in tmpl with the value at path.
+// Maps and lists are rendered as JSON; missing paths render as "".
+func renderTemplate(tmpl string, scope map[string]any) string {
+	return expressionPattern.ReplaceAllStringFunc(tmpl, func(match string) string {
+		path := expressionPattern.FindStringSubmatch(match)[1]
+		v, ok := getByPath(scope, path)
+		if !ok || v == nil {
+			return ""
+		}
+		switch v.(type) {
+		case map[string]any, []any:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return ""
+			}
+			return string(b)
+		default:
+			return readAnyString(v)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/sdk/activity"
+
+	"flowcraft-api/internal/utils"
+)
+
+// defaultFailureThreshold applies when FLOW_AUTO_DEACTIVATE_FAILURES is not a number.
+const defaultFailureThreshold = 5
+
+// recordRunOutcome counts a finished triggered run towards its flow's
+// consecutive failures and deactivates the flow once the count reaches the
+// threshold. Manual runs do not count, so testing a broken flow in the
+// builder never turns it off.
+func (a *Activities) recordRunOutcome(ctx context.Context, runID string, status string) error {
+	if a.failureThreshold <= 0 {
+		return nil
+	}
+	triggerNodeID, _, err := a.runs.GetTrigger(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if triggerNodeID == "" {
+		return nil
+	}
+	run, err := a.runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	failures, err := a.flows.RecordRunOutcome(ctx, run.FlowID, runID, status == "failed")
+	if err == utils.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if failures < a.failureThreshold {
+		return nil
+	}
+
+	reason := fmt.Sprintf("deactivated after %d consecutive failed runs", failures)
+	deactivated, err := a.flows.DeactivateAfterFailures(ctx, run.FlowID, reason)
+	if err != nil || !deactivated {
+		return err
+	}
+	log := activity.GetLogger(ctx)
+	log.Warn("flow deactivated after consecutive failures", "flowID", run.FlowID, "failures", failures)
+	a.notifyDeactivated(ctx, run.FlowID, failures)
+	return nil
+}
+
+// notifyDeactivated emails the flow owner. The realtime event is sent by the
+// database together with the status change. Mail errors are only logged.
+func (a *Activities) notifyDeactivated(ctx context.Context, flowID string, failures int) {
+	if a.mail == nil {
+		return
+	}
+	log := activity.GetLogger(ctx)
+	flow, err := a.flows.Get(ctx, flowID)
+	if err != nil {
+		log.Error("load deactivated flow failed", "flowID", flowID, "error", err)
+		return
+	}
+	if flow.Owner == nil || flow.Owner.Email == "" {
+		return
+	}
+	if err := a.mail.SendFlowDeactivated(ctx, flow.Owner.Email, flow.Owner.Name, flow.ID, flow.Name, failures); err != nil {
+		log.Error("send flow deactivated email failed", "flowID", flowID, "error", err)
+	}
+}
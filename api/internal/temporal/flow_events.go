@@ -0,0 +1,137 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/utils"
+)
+
+// dispatchFlowEvents starts the flows whose flowEvent trigger watches the
+// flow of the finished run runID. It runs inside UpdateRunStatusActivity, so
+// a failed dispatch is retried with the activity; run IDs derived from the
+// upstream run keep retries from starting a downstream flow twice.
+func (a *Activities) dispatchFlowEvents(ctx context.Context, runID string) error {
+	if a.temporal == nil {
+		return nil
+	}
+	log := activity.GetLogger(ctx)
+
+	run, err := a.runs.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+	upstream, err := a.flows.Get(ctx, run.FlowID)
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	flows, err := a.flows.ListFlowEventCandidates(ctx, upstream.ID)
+	if err != nil {
+		return err
+	}
+
+	type target struct {
+		flow    domain.Flow
+		trigger domain.FlowEventTrigger
+	}
+	targets := make([]target, 0)
+	for _, flow := range flows {
+		if !services.FlowActive(flow) {
+			continue
+		}
+		for _, trigger := range services.FlowEventTriggers(flow.DefinitionJSON) {
+			if services.FlowEventMatches(trigger, upstream.ID, run.Status) {
+				targets = append(targets, target{flow: flow, trigger: trigger})
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	_, triggerPayload, err := a.runs.GetTrigger(ctx, runID)
+	if err != nil {
+		return err
+	}
+	chain := services.FlowEventChain(triggerPayload, upstream.ID)
+	if len(chain) > services.MaxFlowEventDepth {
+		log.Warn("flow event chain too deep, not starting downstream flows", "runID", runID, "depth", len(chain))
+		return nil
+	}
+
+	steps, err := a.steps.ListByRunID(ctx, runID)
+	if err != nil {
+		return err
+	}
+	output, _, err := services.LastStepOutput(steps)
+	if err != nil {
+		return err
+	}
+
+	runs := services.NewRunService(a.runs, nil)
+	for _, t := range targets {
+		if slices.Contains(chain, t.flow.ID) {
+			log.Warn("flow event would loop, skipping", "runID", runID, "flowID", t.flow.ID, "chain", strings.Join(chain, ">"))
+			continue
+		}
+		if !services.FlowEventAllowed(*upstream, t.flow) {
+			log.Warn("flow event target is outside the upstream flow's owner or project, skipping", "runID", runID, "flowID", t.flow.ID)
+			continue
+		}
+		payload, err := json.Marshal(flowEventPayload(*upstream, *run, output, chain))
+		if err != nil {
+			return err
+		}
+		created, err := startTriggerRun(ctx, runs, a.temporal, domain.Run{
+			ID:             triggerRunID("flowEvent", t.flow.ID, t.trigger.NodeID, run.ID),
+			FlowID:         t.flow.ID,
+			Status:         "queued",
+			Log:            "queued (flow " + upstream.Name + " " + run.Status + ")",
+			TriggerNodeID:  t.trigger.NodeID,
+			TriggerPayload: payload,
+		})
+		if err != nil {
+			// The run is recorded as failed; retrying the activity would not restart it.
+			log.Error("start flow event run failed", "runID", runID, "flowID", t.flow.ID, "error", err)
+			continue
+		}
+		if created {
+			log.Info("started flow event run", "runID", runID, "flowID", t.flow.ID, "nodeID", t.trigger.NodeID)
+		}
+	}
+	return nil
+}
+
+// flowEventPayload is the output of a flowEvent trigger node: the upstream
+// flow and run, the run's final output and the chain for cycle checks.
+func flowEventPayload(flow domain.Flow, run domain.Run, output any, chain []string) map[string]any {
+	summary := map[string]any{
+		"id":     run.ID,
+		"status": run.Status,
+		"log":    run.Log,
+	}
+	if run.StartedAt != nil {
+		summary["startedAt"] = run.StartedAt.UTC().Format(time.RFC3339)
+	}
+	if run.FinishedAt != nil {
+		summary["finishedAt"] = run.FinishedAt.UTC().Format(time.RFC3339)
+	}
+	return map[string]any{
+		"mode":   "flowEvent",
+		"flow":   map[string]any{"id": flow.ID, "name": flow.Name},
+		"run":    summary,
+		"output": output,
+		"chain":  chain,
+	}
+}
@@ -0,0 +1,107 @@
+package temporal
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// httpAuth is what a stored credential adds to an httpRequest. It is resolved
+// when the step runs and never written to the step inputs.
+type httpAuth struct {
+	headers map[string]string
+	query   map[string]string
+}
+
+// resolveHTTPAuth loads the credential referenced by an httpRequest node.
+func resolveHTTPAuth(ctx context.Context, deps stepDependencies, credentialID string) (httpAuth, error) {
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return httpAuth{}, err
+	}
+	if strings.EqualFold(cred.Provider, "google") {
+		ctx = withEndpoints(ctx, deps, cred.Provider, payload)
+		accessToken, err := googleAccessToken(ctx, deps, payload)
+		if err != nil {
+			return httpAuth{}, err
+		}
+		return bearerAuth(accessToken), nil
+	}
+	return httpCredentialAuth(cred.Provider, payload)
+}
+
+// httpCredentialAuth maps a credential payload to request authentication:
+// an httpAuth credential by its type, a provider credential as a bearer token.
+func httpCredentialAuth(provider string, payload map[string]any) (httpAuth, error) {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "httpauth":
+		return genericHTTPAuth(payload)
+	case "github", "slack":
+		return providerBearerAuth(provider, payload, "access_token")
+	case "notion":
+		return providerBearerAuth(provider, payload, "token")
+	default:
+		return httpAuth{}, fmt.Errorf("credential provider %s cannot authenticate HTTP requests", provider)
+	}
+}
+
+func genericHTTPAuth(payload map[string]any) (httpAuth, error) {
+	authType := strings.ToLower(strings.TrimSpace(readAnyString(payload["type"])))
+	switch authType {
+	case "apikey", "api_key":
+		name := strings.TrimSpace(readAnyString(payload["name"]))
+		value := readAnyString(payload["value"])
+		if value == "" {
+			return httpAuth{}, errors.New("httpAuth credential has no value")
+		}
+		switch strings.ToLower(strings.TrimSpace(readAnyString(payload["in"]))) {
+		case "", "header":
+			if name == "" {
+				name = "X-API-Key"
+			}
+			return httpAuth{headers: map[string]string{name: value}}, nil
+		case "query":
+			if name == "" {
+				return httpAuth{}, errors.New("httpAuth credential has no query parameter name")
+			}
+			return httpAuth{query: map[string]string{name: value}}, nil
+		default:
+			return httpAuth{}, fmt.Errorf("httpAuth credential: unsupported in %q (header or query)", readAnyString(payload["in"]))
+		}
+	case "bearer":
+		token := strings.TrimSpace(readAnyString(payload["token"]))
+		if token == "" {
+			return httpAuth{}, errors.New("httpAuth credential has no token")
+		}
+		return bearerAuth(token), nil
+	case "basic":
+		username := readAnyString(payload["username"])
+		if username == "" {
+			return httpAuth{}, errors.New("httpAuth credential has no username")
+		}
+		encoded := base64.StdEncoding.EncodeToString([]byte(username + ":" + readAnyString(payload["password"])))
+		return httpAuth{headers: map[string]string{"Authorization": "Basic " + encoded}}, nil
+	case "headers":
+		headers := parseStringMapFromConfig(payload, "headers")
+		if len(headers) == 0 {
+			return httpAuth{}, errors.New("httpAuth credential has no headers")
+		}
+		return httpAuth{headers: headers}, nil
+	default:
+		return httpAuth{}, fmt.Errorf("httpAuth credential: unsupported type %q (apiKey, bearer, basic or headers)", readAnyString(payload["type"]))
+	}
+}
+
+func providerBearerAuth(provider string, payload map[string]any, key string) (httpAuth, error) {
+	token := strings.TrimSpace(readAnyString(payload[key]))
+	if token == "" {
+		return httpAuth{}, fmt.Errorf("%s credential has no %s", provider, key)
+	}
+	return bearerAuth(token), nil
+}
+
+func bearerAuth(token string) httpAuth {
+	return httpAuth{headers: map[string]string{"Authorization": "Bearer " + token}}
+}
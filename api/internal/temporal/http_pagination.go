@@ -0,0 +1,323 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxPages = 10
+	maxPagesLimit   = 100
+	maxPageDelay    = 30 * time.Second
+	// maxPaginatedBytes caps the response bodies merged into one output.
+	maxPaginatedBytes = 5 * 1024 * 1024
+)
+
+// paginationMode returns the httpRequest pagination mode, "" when off.
+func paginationMode(config map[string]any) string {
+	switch strings.ToLower(strings.TrimSpace(readString(config, "paginationMode"))) {
+	case "nexturl", "next url", "link":
+		return "nextUrl"
+	case "page":
+		return "page"
+	case "offset":
+		return "offset"
+	case "cursor":
+		return "cursor"
+	default:
+		return ""
+	}
+}
+
+// paginateHTTPRequest sends req page after page and merges the items of
+// every page into one array. It stops when the API has no next page, the
+// stop conditions match, or maxPages pages were fetched.
+//
+// Paths (itemsPath, nextUrlPath, cursorPath) and stop conditions are read from
+// a page scope of {status, data, headers, page, items, itemCount}.
+func paginateHTTPRequest(ctx context.Context, config map[string]any, req httpRequestSpec, mode string) (map[string]any, string, error) {
+	maxPages := readIntWithDefault(config, "maxPages", defaultMaxPages)
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+	if maxPages > maxPagesLimit {
+		maxPages = maxPagesLimit
+	}
+	delay := time.Duration(readInt(config, "pageDelayMs")) * time.Millisecond
+	if delay > maxPageDelay {
+		delay = maxPageDelay
+	}
+	stopConds, stopCombine, err := parseStopConditions(config)
+	if err != nil {
+		return map[string]any{"status": 0}, "invalid stop conditions", fmt.Errorf("httpRequest: %w", err)
+	}
+
+	pageParam := strings.TrimSpace(readString(config, "pageParam"))
+	pageSize := readInt(config, "pageSize")
+	var position int
+	switch mode {
+	case "page":
+		if pageParam == "" {
+			pageParam = "page"
+		}
+		position = readIntWithDefault(config, "pageStart", 1)
+	case "offset":
+		if pageParam == "" {
+			pageParam = "offset"
+		}
+		position = readIntWithDefault(config, "pageStart", 0)
+	case "cursor":
+		if strings.TrimSpace(readString(config, "cursorPath")) == "" {
+			return map[string]any{"status": 0}, "missing cursor path", fmt.Errorf("httpRequest: cursorPath is required for cursor pagination")
+		}
+	}
+	cursorParam := strings.TrimSpace(readString(config, "cursorParam"))
+	if cursorParam == "" {
+		cursorParam = "cursor"
+	}
+
+	var (
+		items      = []any{}
+		pages      []any
+		last       httpPage
+		total      time.Duration
+		totalBytes int
+		stoppedBy  = "max_pages"
+	)
+	next := req.url
+	if mode == "page" || mode == "offset" {
+		next = withQueryParam(req.url, pageParam, fmt.Sprint(position))
+	}
+	outputs := func() map[string]any {
+		return map[string]any{
+			"status": last.status,
+			"data":   items,
+			"meta": map[string]any{
+				"duration_ms":  total.Milliseconds(),
+				"content_type": last.header.Get("Content-Type"),
+				"page_count":   len(pages),
+				"stopped_by":   stoppedBy,
+				"pages":        pages,
+			},
+		}
+	}
+
+	for n := 1; n <= maxPages; n++ {
+		current := next
+		page, err := req.do(ctx, current)
+		if err != nil {
+			stoppedBy = "error"
+			out := outputs()
+			out["status"] = 0
+			out["error"] = err.Error()
+			return out, fmt.Sprintf("request failed on page %d", n), err
+		}
+		last = page
+		total += page.duration
+		totalBytes += page.size
+
+		pageItems := paginationItems(page, readString(config, "itemsPath"))
+		items = append(items, pageItems...)
+		pages = append(pages, map[string]any{
+			"page":        n,
+			"url":         req.logURL(current),
+			"status":      page.status,
+			"item_count":  len(pageItems),
+			"duration_ms": page.duration.Milliseconds(),
+		})
+		if page.status >= 400 {
+			stoppedBy = "error"
+			return outputs(), fmt.Sprintf("%s %s -> %d on page %d", req.method, req.logURL(current), page.status, n), fmt.Errorf("httpRequest: received HTTP %d on page %d", page.status, n)
+		}
+
+		scope := map[string]any{
+			"status":    page.status,
+			"data":      page.data,
+			"headers":   flattenHeaders(page.header),
+			"page":      n,
+			"items":     pageItems,
+			"itemCount": len(pageItems),
+		}
+		if len(stopConds) > 0 {
+			stop, err := evaluateIfConditions(stopConds, stopCombine, false, true, scope)
+			if err != nil {
+				return outputs(), "stop condition failed", fmt.Errorf("httpRequest: stop condition on page %d: %w", n, err)
+			}
+			if stop {
+				stoppedBy = "condition"
+				break
+			}
+		}
+
+		var done bool
+		switch mode {
+		case "nextUrl":
+			next, done, err = nextPageURL(config, scope, page, current, req.url)
+			if err != nil {
+				return outputs(), "invalid next url", fmt.Errorf("httpRequest: page %d: %w", n, err)
+			}
+		case "page", "offset":
+			done = len(pageItems) == 0
+			step := 1
+			if mode == "offset" {
+				step = pageSize
+				if step <= 0 {
+					step = len(pageItems)
+				}
+			}
+			position += step
+			next = withQueryParam(req.url, pageParam, fmt.Sprint(position))
+		case "cursor":
+			cursor, _ := getByPath(scope, readString(config, "cursorPath"))
+			value := strings.TrimSpace(readAnyString(cursor))
+			done = value == ""
+			next = withQueryParam(req.url, cursorParam, value)
+		}
+		if done {
+			stoppedBy = "last_page"
+			break
+		}
+		if totalBytes >= maxPaginatedBytes {
+			stoppedBy = "max_bytes"
+			break
+		}
+		if n < maxPages && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return outputs(), "cancelled", ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+	}
+
+	logText := fmt.Sprintf("%s %s -> %d (%d pages, %d items, %dms)", req.method, req.logURL(req.url), last.status, len(pages), len(items), total.Milliseconds())
+	return outputs(), logText, nil
+}
+
+// parseStopConditions reads stopConditions in the format of the if node's
+// conditions, as an array or a JSON string, combined by stopCombine.
+func parseStopConditions(config map[string]any) ([]ifCondition, string, error) {
+	raw := config["stopConditions"]
+	if s, ok := raw.(string); ok {
+		trim := strings.TrimSpace(s)
+		if trim == "" || trim == "[]" {
+			return nil, "", nil
+		}
+		var parsed any
+		if err := json.Unmarshal([]byte(trim), &parsed); err != nil {
+			return nil, "", fmt.Errorf("stopConditions must be a JSON array: %w", err)
+		}
+		raw = parsed
+	}
+	if m, ok := raw.(map[string]any); ok {
+		raw = []any{m}
+	}
+	conds, combine, _, _ := parseIfConfig(map[string]any{
+		"conditions": raw,
+		"combine":    readString(config, "stopCombine"),
+	})
+	return conds, combine, nil
+}
+
+// paginationItems returns the items of a page: the array at itemsPath, else
+// the body when it is an array, else the body as a single item.
+func paginationItems(page httpPage, itemsPath string) []any {
+	var v any = page.data
+	if path := strings.TrimSpace(itemsPath); path != "" {
+		v, _ = getByPath(map[string]any{"data": page.data}, path)
+	}
+	switch t := v.(type) {
+	case []any:
+		return t
+	case nil:
+		return nil
+	case string:
+		if strings.TrimSpace(t) == "" {
+			return nil
+		}
+	}
+	if strings.TrimSpace(itemsPath) != "" {
+		return nil
+	}
+	return []any{v}
+}
+
+// nextPageURL reads the next page URL from nextUrlPath, or the Link header's
+// rel="next" when unset. Credentials are only sent to the host of the first
+// request, so a next URL on another host is an error.
+func nextPageURL(config map[string]any, scope map[string]any, page httpPage, current *url.URL, first *url.URL) (*url.URL, bool, error) {
+	var raw string
+	if path := strings.TrimSpace(readString(config, "nextUrlPath")); path != "" {
+		v, _ := getByPath(scope, path)
+		raw = strings.TrimSpace(readAnyString(v))
+	} else {
+		raw = linkHeaderNext(page.header)
+	}
+	if raw == "" {
+		return nil, true, nil
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid next url: %w", err)
+	}
+	next := current.ResolveReference(ref)
+	if next.Scheme != "http" && next.Scheme != "https" {
+		return nil, false, fmt.Errorf("next url must be http or https")
+	}
+	if !strings.EqualFold(next.Host, first.Host) {
+		return nil, false, fmt.Errorf("next url host %s differs from %s", next.Host, first.Host)
+	}
+	if next.String() == current.String() {
+		return nil, true, nil
+	}
+	return next, false, nil
+}
+
+// linkHeaderNext returns the rel="next" target of an RFC 8288 Link header.
+func linkHeaderNext(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func withQueryParam(u *url.URL, key string, value string) *url.URL {
+	out := *u
+	q := out.Query()
+	q.Set(key, value)
+	out.RawQuery = q.Encode()
+	return &out
+}
+
+// flattenHeaders maps lowercased header names to their first value.
+func flattenHeaders(header http.Header) map[string]any {
+	out := make(map[string]any, len(header))
+	for k, v := range header {
+		if len(v) > 0 {
+			out[strings.ToLower(k)] = v[0]
+		}
+	}
+	return out
+}
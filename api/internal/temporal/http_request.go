@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,7 +14,69 @@ import (
 	"time"
 )
 
-func executeHTTPRequest(ctx context.Context, config map[string]any) (map[string]any, string, error) {
+func executeHTTPRequest(ctx context.Context, config map[string]any, deps stepDependencies) (map[string]any, string, error) {
+	var auth *httpAuth
+	if credentialID := strings.TrimSpace(readString(config, "credentialId")); credentialID != "" {
+		resolved, err := resolveHTTPAuth(ctx, deps, credentialID)
+		if err != nil {
+			return map[string]any{"status": 0}, "credential load failed", fmt.Errorf("httpRequest: %w", err)
+		}
+		auth = &resolved
+	}
+	return sendHTTPRequest(ctx, config, auth)
+}
+
+// sendHTTPRequest performs the request, following pages when pagination is
+// configured. A stored credential's auth replaces the inline
+// authType/authValue settings.
+func sendHTTPRequest(ctx context.Context, config map[string]any, auth *httpAuth) (map[string]any, string, error) {
+	req, specErr := newHTTPRequestSpec(config, auth)
+	if specErr != nil {
+		return map[string]any{"status": 0}, specErr.label, specErr.err
+	}
+	if mode := paginationMode(config); mode != "" {
+		return paginateHTTPRequest(ctx, config, req, mode)
+	}
+
+	page, err := req.do(ctx, req.url)
+	if err != nil {
+		return map[string]any{"status": 0, "error": err.Error()}, "request failed", err
+	}
+	outputs := map[string]any{
+		"status": page.status,
+		"data":   page.data,
+		"meta": map[string]any{
+			"duration_ms":  page.duration.Milliseconds(),
+			"content_type": page.header.Get("Content-Type"),
+		},
+	}
+
+	logText := fmt.Sprintf("%s %s -> %d (%dms)", req.method, req.logURL(req.url), page.status, page.duration.Milliseconds())
+	if page.status >= 400 {
+		return outputs, logText, fmt.Errorf("httpRequest: received HTTP %d", page.status)
+	}
+	return outputs, logText, nil
+}
+
+// httpRequestSpec is an httpRequest node config resolved into everything
+// needed to send it, so pagination can repeat it with other URLs.
+type httpRequestSpec struct {
+	method  string
+	url     *url.URL
+	headers map[string]string
+	body    string
+	// authQuery holds credential query parameters, set on every page and
+	// redacted from logs.
+	authQuery map[string]string
+	client    *http.Client
+}
+
+type httpSpecError struct {
+	label string
+	err   error
+}
+
+func newHTTPRequestSpec(config map[string]any, auth *httpAuth) (httpRequestSpec, *httpSpecError) {
 	method := strings.ToUpper(strings.TrimSpace(readString(config, "method")))
 	if method == "" {
 		method = http.MethodGet
@@ -21,15 +84,15 @@ func executeHTTPRequest(ctx context.Context, config map[string]any) (map[string]
 
 	rawURL := strings.TrimSpace(readString(config, "url"))
 	if rawURL == "" {
-		return map[string]any{"status": 0}, "missing url", fmt.Errorf("httpRequest: url is required")
+		return httpRequestSpec{}, &httpSpecError{"missing url", fmt.Errorf("httpRequest: url is required")}
 	}
 
 	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
-		return map[string]any{"status": 0}, "invalid url", fmt.Errorf("httpRequest: invalid url: %w", err)
+		return httpRequestSpec{}, &httpSpecError{"invalid url", fmt.Errorf("httpRequest: invalid url: %w", err)}
 	}
 	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return map[string]any{"status": 0}, "unsupported scheme", fmt.Errorf("httpRequest: only http/https urls are allowed")
+		return httpRequestSpec{}, &httpSpecError{"unsupported scheme", fmt.Errorf("httpRequest: only http/https urls are allowed")}
 	}
 
 	queryParams := parseKeyValuePairsFromConfig(config, "queryParams")
@@ -44,13 +107,23 @@ func executeHTTPRequest(ctx context.Context, config map[string]any) (map[string]
 		}
 		parsedURL.RawQuery = q.Encode()
 	}
-	finalURL := parsedURL.String()
 
 	headers := parseStringMapFromConfig(config, "headers")
+	if headers == nil {
+		headers = map[string]string{}
+	}
 	body := readString(config, "body")
 
-	// Authentication handling
+	// Authentication handling; a stored credential replaces inline auth.
 	authType := strings.TrimSpace(readString(config, "authType"))
+	var authQuery map[string]string
+	if auth != nil {
+		authType = ""
+		for k, v := range auth.headers {
+			headers[k] = v
+		}
+		authQuery = auth.query
+	}
 	authValue := strings.TrimSpace(readString(config, "authValue"))
 	authHeader := strings.TrimSpace(readString(config, "authHeader"))
 	if authHeader == "" {
@@ -73,36 +146,27 @@ func executeHTTPRequest(ctx context.Context, config map[string]any) (map[string]
 		}
 	}
 
-	var bodyReader io.Reader
-	if strings.TrimSpace(body) != "" && method != http.MethodGet && method != http.MethodHead {
-		bodyReader = bytes.NewReader([]byte(body))
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, finalURL, bodyReader)
-	if err != nil {
-		return map[string]any{"status": 0}, "request build failed", fmt.Errorf("httpRequest: %w", err)
-	}
-
-	for k, v := range headers {
-		req.Header.Set(k, v)
+	hasBody := strings.TrimSpace(body) != "" && method != http.MethodGet && method != http.MethodHead
+	if !hasBody {
+		body = ""
 	}
 
 	// Content-Type handling
 	contentType := strings.TrimSpace(readString(config, "contentType"))
-	if bodyReader != nil && req.Header.Get("Content-Type") == "" {
+	if hasBody && !hasHeader(headers, "Content-Type") {
 		if contentType != "" {
-			req.Header.Set("Content-Type", contentType)
+			headers["Content-Type"] = contentType
 		} else {
 			trim := strings.TrimSpace(body)
 			if strings.HasPrefix(trim, "{") || strings.HasPrefix(trim, "[") {
-				req.Header.Set("Content-Type", "application/json")
+				headers["Content-Type"] = "application/json"
 			} else {
-				req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+				headers["Content-Type"] = "text/plain; charset=utf-8"
 			}
 		}
 	}
-	if req.Header.Get("User-Agent") == "" {
-		req.Header.Set("User-Agent", "FlowCraft/0.1")
+	if !hasHeader(headers, "User-Agent") {
+		headers["User-Agent"] = "FlowCraft/0.1"
 	}
 
 	// Timeout handling
@@ -119,33 +183,128 @@ func executeHTTPRequest(ctx context.Context, config map[string]any) (map[string]
 			}
 		}
 	}
+
 	client := &http.Client{Timeout: timeout}
+	if auth != nil {
+		client.CheckRedirect = sameHostRedirect
+	}
+
+	return httpRequestSpec{
+		method:    method,
+		url:       parsedURL,
+		headers:   headers,
+		body:      body,
+		authQuery: authQuery,
+		client:    client,
+	}, nil
+}
+
+// sameHostRedirect refuses redirects to another host. The client copies
+// every header of the first request to a redirect, so a credential header
+// would otherwise go wherever the response points.
+func sameHostRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if first := via[0].URL; !strings.EqualFold(req.URL.Host, first.Host) {
+		return fmt.Errorf("redirect host %s differs from %s", req.URL.Host, first.Host)
+	}
+	return nil
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// httpPage is one response of an httpRequest.
+type httpPage struct {
+	status   int
+	header   http.Header
+	data     any
+	size     int
+	duration time.Duration
+}
+
+// do sends the request to u, adding credential query parameters.
+func (s httpRequestSpec) do(ctx context.Context, u *url.URL) (httpPage, error) {
+	target := *u
+	if len(s.authQuery) > 0 {
+		q := target.Query()
+		for k, v := range s.authQuery {
+			q.Set(k, v)
+		}
+		target.RawQuery = q.Encode()
+	}
+
+	var bodyReader io.Reader
+	if s.body != "" {
+		bodyReader = bytes.NewReader([]byte(s.body))
+	}
+	req, err := http.NewRequestWithContext(ctx, s.method, target.String(), bodyReader)
+	if err != nil {
+		return httpPage{}, fmt.Errorf("httpRequest: %w", err)
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
 	started := time.Now()
-	res, err := client.Do(req)
+	res, err := s.client.Do(req)
 	duration := time.Since(started)
 	if err != nil {
-		return map[string]any{"status": 0, "error": err.Error()}, "request failed", err
+		// Transport errors carry the request URL, which may hold a secret.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = &url.Error{Op: urlErr.Op, URL: s.logURL(u), Err: urlErr.Err}
+		}
+		return httpPage{}, err
 	}
 	defer res.Body.Close()
 
 	const maxBodyBytes = 512 * 1024
 	bodyBytes, _ := io.ReadAll(io.LimitReader(res.Body, maxBodyBytes))
+	return httpPage{
+		status:   res.StatusCode,
+		header:   res.Header,
+		data:     parseJSONOrString(string(bodyBytes)),
+		size:     len(bodyBytes),
+		duration: duration,
+	}, nil
+}
 
-	parsedBody := parseJSONOrString(string(bodyBytes))
-	outputs := map[string]any{
-		"status": res.StatusCode,
-		"data":   parsedBody,
-		"meta": map[string]any{
-			"duration_ms":  duration.Milliseconds(),
-			"content_type": res.Header.Get("Content-Type"),
-		},
-	}
+// logURL renders u for logs and outputs, with credential query parameters
+// masked.
+func (s httpRequestSpec) logURL(u *url.URL) string {
+	if len(s.authQuery) == 0 {
+		return u.String()
+	}
+	redacted := *u
+	q := redacted.Query()
+	for k := range s.authQuery {
+		q.Set(k, "***")
+	}
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
 
-	logText := fmt.Sprintf("%s %s -> %d (%dms)", method, finalURL, res.StatusCode, duration.Milliseconds())
-	if res.StatusCode >= 400 {
-		return outputs, logText, fmt.Errorf("httpRequest: received HTTP %d", res.StatusCode)
+func ExecuteHTTPRequestForTest(ctx context.Context, config map[string]any, provider string, payload map[string]any) (map[string]any, string, error) {
+	if provider == "" {
+		return sendHTTPRequest(ctx, config, nil)
 	}
-	return outputs, logText, nil
+	auth, err := httpCredentialAuth(provider, payload)
+	if err != nil {
+		return map[string]any{"status": 0}, "credential load failed", err
+	}
+	return sendHTTPRequest(ctx, config, &auth)
+}
+
+func BuildStepInputsForTest(nodeType string, config map[string]any) map[string]any {
+	return buildStepInputs(nodeType, config, "run-1", "step-1", nil)
 }
 
 type keyValuePair struct {
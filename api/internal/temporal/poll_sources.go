@@ -0,0 +1,381 @@
+package temporal
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"flowcraft-api/internal/adapters/external/email"
+	"flowcraft-api/internal/adapters/external/github"
+	"flowcraft-api/internal/adapters/external/google"
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+)
+
+const (
+	// pollPageSize is how many recent items a source asks for per poll.
+	pollPageSize = 50
+	// maxSeenIDs bounds the seen-ID set kept per trigger. It only needs to
+	// cover what one page can return again.
+	maxSeenIDs = 500
+)
+
+// pollItem is one new item found by a source. ID must be stable for the item
+// so re-polling it maps to the same run.
+type pollItem struct {
+	ID   string
+	Data map[string]any
+}
+
+// pollSource lists what is new since state and returns the items (oldest
+// first) with the state to store for the next poll.
+type pollSource func(ctx context.Context, deps stepDependencies, config map[string]any, state domain.PollState) ([]pollItem, domain.PollState, error)
+
+var pollSources = map[string]pollSource{
+	services.PollEventSheetsRowAdded:       pollSheetsRows,
+	services.PollEventGitHubIssueOpened:    pollGitHubIssues,
+	services.PollEventGmailMessageReceived: pollGmailMessages,
+	services.PollEventEmailMessageReceived: pollEmailMessages,
+}
+
+// pollSheetsRows emits rows appended below the last seen row. The cursor is
+// the number of rows seen. When rows were deleted the cursor drops back to
+// the current row count without emitting anything.
+func pollSheetsRows(ctx context.Context, deps stepDependencies, config map[string]any, state domain.PollState) ([]pollItem, domain.PollState, error) {
+	spreadsheetID := strings.TrimSpace(readString(config, "spreadsheetId"))
+	if spreadsheetID == "" {
+		return nil, state, errors.New("gsheets: spreadsheetId is required")
+	}
+	ctx, accessToken, err := pollGoogleToken(ctx, deps, config, "gsheets")
+	if err != nil {
+		return nil, state, err
+	}
+	rangeRef := strings.TrimSpace(readString(config, "sheetName"))
+	if rangeRef == "" {
+		rangeRef = "A:ZZ"
+	}
+	out, err := google.GetRows(ctx, accessToken, spreadsheetID, rangeRef)
+	if err != nil {
+		return nil, state, err
+	}
+	rows, _ := out["values"].([]any)
+
+	header := true
+	if _, ok := config["firstRowIsHeader"]; ok {
+		header = readBool(config, "firstRowIsHeader")
+	}
+	lastRow, _ := strconv.Atoi(state.Cursor)
+	items := sheetRowsSince(rows, lastRow, header)
+	state.Cursor = strconv.Itoa(len(rows))
+	return items, state, nil
+}
+
+// sheetRowsSince turns rows after lastRow into items. With header, the first
+// row names the fields of every other row and is never emitted.
+func sheetRowsSince(rows []any, lastRow int, header bool) []pollItem {
+	if lastRow > len(rows) {
+		return nil
+	}
+	var names []string
+	if header && len(rows) > 0 {
+		first, _ := rows[0].([]any)
+		for _, cell := range first {
+			names = append(names, readAnyString(cell))
+		}
+	}
+
+	items := make([]pollItem, 0, len(rows)-lastRow)
+	for i := lastRow; i < len(rows); i++ {
+		if header && i == 0 {
+			continue
+		}
+		values, _ := rows[i].([]any)
+		data := map[string]any{
+			"rowNumber": i + 1,
+			"values":    values,
+		}
+		if header {
+			fields := make(map[string]any, len(names))
+			for col, name := range names {
+				if name == "" {
+					continue
+				}
+				if col < len(values) {
+					fields[name] = values[col]
+				} else {
+					fields[name] = ""
+				}
+			}
+			data["fields"] = fields
+		}
+		// Row numbers repeat after deletes, so the values are part of the ID.
+		raw, _ := json.Marshal(values)
+		sum := sha1.Sum(raw)
+		items = append(items, pollItem{ID: fmt.Sprintf("row:%d:%s", i+1, hex.EncodeToString(sum[:8])), Data: data})
+	}
+	return items
+}
+
+// pollGitHubIssues emits issues that were not in the last page of newest
+// issues. Pull requests are skipped.
+func pollGitHubIssues(ctx context.Context, deps stepDependencies, config map[string]any, state domain.PollState) ([]pollItem, domain.PollState, error) {
+	owner := strings.TrimSpace(readString(config, "owner"))
+	repo := strings.TrimSpace(readString(config, "repo"))
+	if owner == "" || repo == "" {
+		return nil, state, errors.New("github: owner and repo are required")
+	}
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return nil, state, errors.New("github: credentialId is required")
+	}
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return nil, state, err
+	}
+	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "github" {
+		return nil, state, errors.New("github: expected github credential")
+	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
+	accessToken := strings.TrimSpace(readAnyString(payload["access_token"]))
+	if accessToken == "" {
+		return nil, state, errors.New("github: access token missing")
+	}
+
+	issues, err := github.ListIssues(ctx, accessToken, owner, repo, github.ListIssuesOptions{
+		State:     "all",
+		Labels:    strings.TrimSpace(readString(config, "labels")),
+		Sort:      "created",
+		Direction: "desc",
+		PerPage:   pollPageSize,
+		Pages:     1,
+	})
+	if err != nil {
+		return nil, state, err
+	}
+
+	candidates := make([]pollItem, 0, len(issues))
+	for _, issue := range issues {
+		if _, isPR := issue["pull_request"]; isPR {
+			continue
+		}
+		candidates = append(candidates, pollItem{ID: readAnyString(issue["id"]), Data: issue})
+	}
+	items, seen := unseenItems(candidates, state.SeenIDs)
+	state.SeenIDs = seen
+	return items, state, nil
+}
+
+// pollGmailMessages emits messages matching query that were not seen before.
+// Only metadata (headers, labels, snippet) is fetched.
+func pollGmailMessages(ctx context.Context, deps stepDependencies, config map[string]any, state domain.PollState) ([]pollItem, domain.PollState, error) {
+	ctx, accessToken, err := pollGoogleToken(ctx, deps, config, "gmail")
+	if err != nil {
+		return nil, state, err
+	}
+	query := strings.TrimSpace(readString(config, "query"))
+	refs, err := google.ListMessages(ctx, accessToken, query, pollPageSize)
+	if err != nil {
+		return nil, state, err
+	}
+
+	candidates := make([]pollItem, 0, len(refs))
+	for _, ref := range refs {
+		candidates = append(candidates, pollItem{ID: readAnyString(ref["id"])})
+	}
+	items, seen := unseenItems(candidates, state.SeenIDs)
+	if state.LastPolledAt == nil {
+		// The first poll only records the baseline; skip fetching details.
+		state.SeenIDs = seen
+		return items, state, nil
+	}
+	for i := range items {
+		msg, err := google.GetMessageMetadata(ctx, accessToken, items[i].ID)
+		if err != nil {
+			return nil, state, err
+		}
+		items[i].Data = gmailMessageSummary(msg)
+	}
+	state.SeenIDs = seen
+	return items, state, nil
+}
+
+// pollEmailMessages emits messages that arrived in an IMAP folder since the
+// last poll. The cursor is "uidvalidity:lastUID"; the first poll and a
+// changed UIDVALIDITY (the folder was recreated) only record the baseline.
+func pollEmailMessages(ctx context.Context, deps stepDependencies, config map[string]any, state domain.PollState) ([]pollItem, domain.PollState, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return nil, state, errors.New("email: credentialId is required")
+	}
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return nil, state, err
+	}
+	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "imap" {
+		return nil, state, errors.New("email: expected imap credential")
+	}
+	acct := email.AccountFromPayload(payload)
+	folder := readString(config, "folder")
+
+	validity, lastUID, ok := parseEmailCursor(state.Cursor)
+	if state.LastPolledAt == nil || !ok {
+		box, err := email.Status(ctx, acct, folder)
+		if err != nil {
+			return nil, state, err
+		}
+		state.Cursor = emailCursor(box.UIDValidity, box.UIDNext-1)
+		return nil, state, nil
+	}
+
+	result, err := email.Fetch(ctx, acct, email.FetchOptions{
+		Folder:             folder,
+		UnseenOnly:         readBool(config, "unseenOnly"),
+		MarkAsRead:         readBool(config, "markAsRead"),
+		Limit:              pollPageSize,
+		AfterUID:           lastUID,
+		UIDValidity:        validity,
+		IncludeAttachments: readBool(config, "includeAttachments"),
+	})
+	if errors.Is(err, email.ErrUIDValidityChanged) {
+		box, err := email.Status(ctx, acct, folder)
+		if err != nil {
+			return nil, state, err
+		}
+		state.Cursor = emailCursor(box.UIDValidity, box.UIDNext-1)
+		return nil, state, nil
+	}
+	if err != nil {
+		return nil, state, err
+	}
+
+	items := make([]pollItem, 0, len(result.Messages))
+	for _, msg := range result.Messages {
+		uid, _ := msg["uid"].(uint32)
+		if uid > lastUID {
+			lastUID = uid
+		}
+		items = append(items, pollItem{ID: emailCursor(validity, uid), Data: msg})
+	}
+	state.Cursor = emailCursor(validity, lastUID)
+	return items, state, nil
+}
+
+func emailCursor(validity uint32, uid uint32) string {
+	return fmt.Sprintf("%d:%d", validity, uid)
+}
+
+func parseEmailCursor(cursor string) (uint32, uint32, bool) {
+	validityRaw, uidRaw, found := strings.Cut(cursor, ":")
+	if !found {
+		return 0, 0, false
+	}
+	validity, err1 := strconv.ParseUint(validityRaw, 10, 32)
+	uid, err2 := strconv.ParseUint(uidRaw, 10, 32)
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return uint32(validity), uint32(uid), true
+}
+
+func gmailMessageSummary(msg map[string]any) map[string]any {
+	out := map[string]any{
+		"id":       msg["id"],
+		"threadId": msg["threadId"],
+		"labelIds": msg["labelIds"],
+		"snippet":  msg["snippet"],
+	}
+	payload, _ := msg["payload"].(map[string]any)
+	headers, _ := payload["headers"].([]any)
+	for _, h := range headers {
+		header, _ := h.(map[string]any)
+		name := strings.ToLower(readAnyString(header["name"]))
+		switch name {
+		case "from", "to", "cc", "subject", "date":
+			out[name] = readAnyString(header["value"])
+		}
+	}
+	return out
+}
+
+// unseenItems returns candidates (newest first) missing from seen, reordered
+// oldest first, and the updated seen set with the newest IDs first.
+func unseenItems(candidates []pollItem, seen []string) ([]pollItem, []string) {
+	known := make(map[string]struct{}, len(seen))
+	for _, id := range seen {
+		known[id] = struct{}{}
+	}
+	fresh := make([]pollItem, 0)
+	nextSeen := make([]string, 0, len(seen)+len(candidates))
+	for _, item := range candidates {
+		if item.ID == "" {
+			continue
+		}
+		if _, ok := known[item.ID]; !ok {
+			fresh = append(fresh, item)
+			known[item.ID] = struct{}{}
+		}
+		nextSeen = append(nextSeen, item.ID)
+	}
+	inPage := make(map[string]struct{}, len(nextSeen))
+	for _, id := range nextSeen {
+		inPage[id] = struct{}{}
+	}
+	for _, id := range seen {
+		if _, ok := inPage[id]; !ok {
+			nextSeen = append(nextSeen, id)
+		}
+	}
+	if len(nextSeen) > maxSeenIDs {
+		nextSeen = nextSeen[:maxSeenIDs]
+	}
+	for i, j := 0, len(fresh)-1; i < j; i, j = i+1, j-1 {
+		fresh[i], fresh[j] = fresh[j], fresh[i]
+	}
+	return fresh, nextSeen
+}
+
+// pollGoogleToken returns an access token for the source's Google credential
+// and ctx carrying that credential's endpoints.
+func pollGoogleToken(ctx context.Context, deps stepDependencies, config map[string]any, app string) (context.Context, string, error) {
+	credentialID := strings.TrimSpace(readString(config, "credentialId"))
+	if credentialID == "" {
+		return ctx, "", fmt.Errorf("%s: credentialId is required", app)
+	}
+	cred, payload, err := loadCredentialPayload(ctx, deps, credentialID)
+	if err != nil {
+		return ctx, "", err
+	}
+	if strings.ToLower(strings.TrimSpace(cred.Provider)) != "google" {
+		return ctx, "", fmt.Errorf("%s: expected google credential", app)
+	}
+	ctx = withEndpoints(ctx, deps, cred.Provider, payload)
+	accessToken, err := googleAccessToken(ctx, deps, payload)
+	return ctx, accessToken, err
+}
+
+func SheetRowsSinceForTest(rows []any, lastRow int, header bool) []map[string]any {
+	items := sheetRowsSince(rows, lastRow, header)
+	out := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		out = append(out, map[string]any{"id": item.ID, "data": item.Data})
+	}
+	return out
+}
+
+func UnseenItemIDsForTest(candidateIDs []string, seen []string) ([]string, []string) {
+	candidates := make([]pollItem, 0, len(candidateIDs))
+	for _, id := range candidateIDs {
+		candidates = append(candidates, pollItem{ID: id})
+	}
+	fresh, nextSeen := unseenItems(candidates, seen)
+	ids := make([]string, 0, len(fresh))
+	for _, item := range fresh {
+		ids = append(ids, item.ID)
+	}
+	return ids, nextSeen
+}
@@ -0,0 +1,266 @@
+package temporal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.temporal.io/sdk/client"
+
+	"flowcraft-api/internal/adapters/database/postgres"
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+)
+
+// pollLeaderLockKey elects the poller leader independently of the cron
+// scheduler, so the two can run on different workers.
+const pollLeaderLockKey int64 = 0x666c6f77706f6c6c // "flowpoll"
+
+const (
+	pollTick    = 15 * time.Second
+	pollTimeout = time.Minute
+)
+
+// FlowPollScheduler runs polling triggers: on each trigger's interval it asks
+// the trigger's source for new items and starts runs for them. Like the cron
+// scheduler it runs in every worker but only the lock holder polls.
+type FlowPollScheduler struct {
+	flows     *postgres.FlowRepository
+	schedules *postgres.ScheduleRepository
+	states    *postgres.PollStateRepository
+	runs      *services.RunService
+	deps      stepDependencies
+	temporal  client.Client
+	logger    zerolog.Logger
+	leader    *advisoryLeader
+	leading   bool
+	mu        sync.Mutex
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+}
+
+func NewFlowPollScheduler(db *sql.DB, temporalClient client.Client, deps stepDependencies, logger zerolog.Logger) *FlowPollScheduler {
+	return &FlowPollScheduler{
+		flows:     postgres.NewFlowRepository(db),
+		schedules: postgres.NewScheduleRepository(db),
+		states:    postgres.NewPollStateRepository(db),
+		runs:      services.NewRunService(postgres.NewRunRepository(db), nil),
+		deps:      deps,
+		temporal:  temporalClient,
+		logger:    logger,
+		leader:    newAdvisoryLeader(db, pollLeaderLockKey),
+	}
+}
+
+func (p *FlowPollScheduler) Start() {
+	p.mu.Lock()
+	if p.stopCh != nil {
+		p.mu.Unlock()
+		return
+	}
+	p.stopCh = make(chan struct{})
+	p.doneCh = make(chan struct{})
+	stopCh := p.stopCh
+	doneCh := p.doneCh
+	p.mu.Unlock()
+
+	go func() {
+		defer close(doneCh)
+		p.loop(stopCh)
+	}()
+}
+
+func (p *FlowPollScheduler) Stop() {
+	p.mu.Lock()
+	stopCh := p.stopCh
+	doneCh := p.doneCh
+	p.stopCh = nil
+	p.doneCh = nil
+	p.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+	}
+	if doneCh != nil {
+		<-doneCh
+	}
+}
+
+func (p *FlowPollScheduler) loop(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(pollTick)
+	defer ticker.Stop()
+
+	p.tick()
+	for {
+		select {
+		case <-stopCh:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			p.leader.release(ctx)
+			cancel()
+			return
+		case <-ticker.C:
+			p.tick()
+		}
+	}
+}
+
+func (p *FlowPollScheduler) tick() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	leading, err := p.leader.ensure(ctx)
+	cancel()
+	if err != nil {
+		p.logger.Error().Err(err).Msg("poll: leader election failed")
+	}
+	if leading != p.leading {
+		if leading {
+			p.logger.Info().Msg("poll: acquired poller leadership")
+		} else {
+			p.logger.Warn().Msg("poll: lost poller leadership")
+		}
+		p.leading = leading
+	}
+	if leading {
+		p.pollDue()
+	}
+}
+
+// pollDue polls every trigger whose interval has passed since its last poll.
+// Flows that are not active or are paused are skipped.
+func (p *FlowPollScheduler) pollDue() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	flows, err := p.flows.List(ctx)
+	if err != nil {
+		p.logger.Error().Msgf("poll: list flows failed: %v", err)
+		return
+	}
+	pausedIDs, err := p.schedules.ListPausedFlowIDs(ctx)
+	if err != nil {
+		p.logger.Error().Msgf("poll: list paused flows failed: %v", err)
+		return
+	}
+	paused := make(map[string]struct{}, len(pausedIDs))
+	for _, id := range pausedIDs {
+		paused[id] = struct{}{}
+	}
+	states, err := p.states.List(ctx)
+	if err != nil {
+		p.logger.Error().Msgf("poll: list poll states failed: %v", err)
+		return
+	}
+	stateByKey := make(map[string]domain.PollState, len(states))
+	for _, state := range states {
+		stateByKey[state.FlowID+"/"+state.NodeID] = state
+	}
+
+	now := time.Now()
+	for _, flow := range flows {
+		if !services.FlowActive(flow) {
+			continue
+		}
+		if _, ok := paused[flow.ID]; ok {
+			continue
+		}
+		for _, trigger := range services.PollingTriggers(flow.DefinitionJSON) {
+			state, ok := stateByKey[flow.ID+"/"+trigger.NodeID]
+			if !ok {
+				state = domain.PollState{FlowID: flow.ID, NodeID: trigger.NodeID}
+			}
+			if state.LastPolledAt != nil && now.Before(state.LastPolledAt.Add(trigger.Interval)) {
+				continue
+			}
+			p.poll(flow, trigger, state)
+		}
+	}
+}
+
+// poll runs one trigger's source and starts runs for what is new. The first
+// poll of a trigger only records a baseline so existing items do not flood
+// the flow. The state is saved after the runs are started; if saving fails
+// the next poll finds the same items and their run IDs dedupe them.
+func (p *FlowPollScheduler) poll(flow domain.Flow, trigger domain.PollingTrigger, state domain.PollState) {
+	flowID := flow.ID
+	source, ok := pollSources[trigger.Event]
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+	defer cancel()
+
+	firstPoll := state.LastPolledAt == nil
+	deps := p.deps
+	deps.flow = &flow
+	items, next, err := source(ctx, deps, trigger.Config, state)
+	polledAt := time.Now()
+	if err != nil {
+		state.LastPolledAt = &polledAt
+		state.LastError = err.Error()
+		p.logger.Warn().Msgf("poll: %s failed (flow=%s node=%s): %v", trigger.Event, flowID, trigger.NodeID, err)
+		if saveErr := p.states.Save(ctx, state); saveErr != nil {
+			p.logger.Error().Msgf("poll: save state failed (flow=%s node=%s): %v", flowID, trigger.NodeID, saveErr)
+		}
+		return
+	}
+
+	if !firstPoll && len(items) > 0 {
+		p.startRuns(ctx, flowID, trigger, items)
+	}
+
+	next.FlowID = flowID
+	next.NodeID = trigger.NodeID
+	next.LastPolledAt = &polledAt
+	next.LastError = ""
+	if err := p.states.Save(ctx, next); err != nil {
+		p.logger.Error().Msgf("poll: save state failed (flow=%s node=%s): %v", flowID, trigger.NodeID, err)
+	}
+}
+
+func (p *FlowPollScheduler) startRuns(ctx context.Context, flowID string, trigger domain.PollingTrigger, items []pollItem) {
+	if trigger.Batch {
+		data := make([]map[string]any, 0, len(items))
+		for _, item := range items {
+			data = append(data, item.Data)
+		}
+		p.startRun(ctx, flowID, trigger, "batch:"+items[0].ID+":"+items[len(items)-1].ID, map[string]any{
+			"mode":  "poll",
+			"event": trigger.Event,
+			"items": data,
+			"count": len(data),
+		})
+		return
+	}
+	for _, item := range items {
+		p.startRun(ctx, flowID, trigger, item.ID, map[string]any{
+			"mode":  "poll",
+			"event": trigger.Event,
+			"item":  item.Data,
+		})
+	}
+}
+
+func (p *FlowPollScheduler) startRun(ctx context.Context, flowID string, trigger domain.PollingTrigger, itemKey string, payload map[string]any) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		p.logger.Error().Msgf("poll: encode trigger payload failed (flow=%s node=%s): %v", flowID, trigger.NodeID, err)
+		return
+	}
+	run := domain.Run{
+		ID:             triggerRunID("poll", flowID, trigger.NodeID, itemKey),
+		FlowID:         flowID,
+		Status:         "queued",
+		Log:            "queued (poll " + trigger.Event + ")",
+		TriggerNodeID:  trigger.NodeID,
+		TriggerPayload: raw,
+	}
+	created, err := startTriggerRun(ctx, p.runs, p.temporal, run)
+	if !created && err == nil {
+		return
+	}
+	if err != nil {
+		p.logger.Error().Msgf("poll: start run failed (flow=%s node=%s item=%s): %v", flowID, trigger.NodeID, itemKey, err)
+	}
+}
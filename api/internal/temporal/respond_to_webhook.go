@@ -0,0 +1,81 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// executeRespondToWebhook builds the HTTP response for a webhook trigger in
+// "respondNode" mode. The webhook handler reads it from the step outputs once
+// the run finishes.
+//
+// Config: statusCode (default 200), headers, respondWith
+// (json | text | input | noData) and body. Header values and body support
+// {{ path }} expressions over the node input and "steps".
+func executeRespondToWebhook(_ context.Context, config map[string]any, input map[string]any, steps map[string]any) (map[string]any, string, error) {
+	scope := buildIfContext(input, steps)
+
+	statusCode := readInt(config, "statusCode")
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	if statusCode < 100 || statusCode > 599 {
+		return map[string]any{"status": 0, "error": "invalid statusCode"}, "invalid status code", fmt.Errorf("respondToWebhook: invalid statusCode %d", statusCode)
+	}
+
+	headers := map[string]any{}
+	for key, value := range parseStringMapFromConfig(config, "headers") {
+		if strings.TrimSpace(key) == "" {
+			continue
+		}
+		headers[key] = renderTemplate(value, scope)
+	}
+
+	var body any
+	rawBody := readString(config, "body")
+	switch strings.ToLower(strings.TrimSpace(readString(config, "respondWith"))) {
+	case "", "json":
+		value := resolveExpression(rawBody, scope)
+		if text, ok := value.(string); ok {
+			if strings.TrimSpace(text) != "" {
+				if err := json.Unmarshal([]byte(text), &body); err != nil {
+					return map[string]any{"status": 0, "error": "body is not valid JSON"}, "invalid JSON body", errors.New("respondToWebhook: body is not valid JSON")
+				}
+			}
+		} else {
+			body = value
+		}
+	case "text":
+		body = renderTemplate(rawBody, scope)
+		if _, ok := headers["Content-Type"]; !ok {
+			headers["Content-Type"] = "text/plain; charset=utf-8"
+		}
+	case "input":
+		if data, ok := input["data"]; ok {
+			body = data
+		} else {
+			body = input
+		}
+	case "nodata":
+		body = nil
+	default:
+		return map[string]any{"status": 0, "error": "unsupported respondWith"}, "unsupported respondWith", fmt.Errorf("respondToWebhook: unsupported respondWith %q", readString(config, "respondWith"))
+	}
+
+	return map[string]any{
+		"status": 200,
+		"data": map[string]any{
+			"statusCode": statusCode,
+			"headers":    headers,
+			"body":       body,
+		},
+	}, fmt.Sprintf("respond %d", statusCode), nil
+}
+
+func ExecuteRespondToWebhookForTest(config map[string]any, input map[string]any, steps map[string]any) (map[string]any, string, error) {
+	return executeRespondToWebhook(context.Background(), config, input, steps)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
-	"fmt"
 	"log"
 	"strings"
 	"sync"
@@ -19,25 +18,33 @@ import (
 	"flowcraft-api/internal/core/services"
 )
 
+// FlowCronScheduler fires cron triggers. Every worker runs one, but only the
+// holder of the advisory lock schedules anything; run IDs derived from the fire
+// time catch the short overlap when leadership moves between workers.
 type FlowCronScheduler struct {
-	flows         *postgres.FlowRepository
-	runs          *services.RunService
-	temporal      client.Client
-	logger        zerolog.Logger
-	mu            sync.Mutex
-	cronRunner    *cron.Cron
-	currentByFlow map[string]string
-	stopCh        chan struct{}
-	doneCh        chan struct{}
+	flows      *postgres.FlowRepository
+	schedules  *postgres.ScheduleRepository
+	leader     *advisoryLeader
+	leading    bool
+	runs       *services.RunService
+	temporal   client.Client
+	logger     zerolog.Logger
+	mu         sync.Mutex
+	cronRunner *cron.Cron
+	current    map[string]domain.CronTrigger
+	stopCh     chan struct{}
+	doneCh     chan struct{}
 }
 
 func NewFlowCronScheduler(db *sql.DB, temporalClient client.Client, logger zerolog.Logger) *FlowCronScheduler {
 	return &FlowCronScheduler{
-		flows:         postgres.NewFlowRepository(db),
-		runs:          services.NewRunService(postgres.NewRunRepository(db), nil),
-		temporal:      temporalClient,
-		logger:        logger,
-		currentByFlow: map[string]string{},
+		flows:     postgres.NewFlowRepository(db),
+		schedules: postgres.NewScheduleRepository(db),
+		leader:    newAdvisoryLeader(db, cronLeaderLockKey),
+		runs:      services.NewRunService(postgres.NewRunRepository(db), nil),
+		temporal:  temporalClient,
+		logger:    logger,
+		current:   map[string]domain.CronTrigger{},
 	}
 }
 
@@ -79,18 +86,49 @@ func (s *FlowCronScheduler) loop(stopCh <-chan struct{}) {
 	ticker := time.NewTicker(15 * time.Second)
 	defer ticker.Stop()
 
-	s.reconcile()
+	s.tick()
 	for {
 		select {
 		case <-stopCh:
 			s.stopCron()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			s.leader.release(ctx)
+			cancel()
 			return
 		case <-ticker.C:
-			s.reconcile()
+			s.tick()
 		}
 	}
 }
 
+// tick keeps cron jobs running only while this worker is the leader.
+func (s *FlowCronScheduler) tick() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	leading, err := s.leader.ensure(ctx)
+	cancel()
+	if err != nil {
+		s.logger.Error().Err(err).Msg("schedule: leader election failed")
+	}
+
+	wasLeading := s.leading
+	s.leading = leading
+	if !leading {
+		if wasLeading {
+			s.logger.Warn().Msg("schedule: lost scheduler leadership, stopping cron jobs")
+			s.stopCron()
+			s.mu.Lock()
+			s.current = map[string]domain.CronTrigger{}
+			s.mu.Unlock()
+		}
+		return
+	}
+	if !wasLeading {
+		s.logger.Info().Msg("schedule: acquired scheduler leadership")
+	}
+	s.reconcile()
+	s.catchUp()
+}
+
 func (s *FlowCronScheduler) stopCron() {
 	s.mu.Lock()
 	runner := s.cronRunner
@@ -110,21 +148,34 @@ func (s *FlowCronScheduler) reconcile() {
 		s.logger.Error().Msgf("schedule: list flows failed: %v", err)
 		return
 	}
+	pausedIDs, err := s.schedules.ListPausedFlowIDs(ctx)
+	if err != nil {
+		s.logger.Error().Msgf("schedule: list paused flows failed: %v", err)
+		return
+	}
+	paused := make(map[string]struct{}, len(pausedIDs))
+	for _, id := range pausedIDs {
+		paused[id] = struct{}{}
+	}
 
-	desired := make(map[string]string)
+	desired := make(map[string]domain.CronTrigger)
+	flowByKey := make(map[string]string)
 	for _, flow := range flows {
-		if strings.EqualFold(strings.TrimSpace(flow.Status), "archived") {
+		if !services.FlowActive(flow) {
 			continue
 		}
-		spec := extractCronSpec(flow.DefinitionJSON)
-		if spec == "" {
+		if _, ok := paused[flow.ID]; ok {
 			continue
 		}
-		desired[flow.ID] = spec
+		for _, trigger := range services.CronTriggers(flow.DefinitionJSON) {
+			key := flow.ID + "/" + trigger.NodeID
+			desired[key] = trigger
+			flowByKey[key] = flow.ID
+		}
 	}
 
 	s.mu.Lock()
-	unchanged := mapsEqualStringString(s.currentByFlow, desired)
+	unchanged := cronTriggersEqual(s.current, desired)
 	s.mu.Unlock()
 
 	if unchanged {
@@ -133,19 +184,21 @@ func (s *FlowCronScheduler) reconcile() {
 
 	s.stopCron()
 
-	runner := cron.NewWithLocation(time.Local)
+	runner := cron.NewWithLocation(time.UTC)
 	runner.ErrorLog = log.New(log.Writer(), "schedule: ", log.LstdFlags)
 
-	for flowID, spec := range desired {
-		schedule, err := parseCronSchedule(spec)
+	for key, trigger := range desired {
+		flowID := flowByKey[key]
+		schedule, err := services.ParseCronSchedule(trigger)
 		if err != nil {
-			s.logger.Error().Msgf("schedule: invalid cron for flow %s: %q: %v", flowID, spec, err)
+			s.logger.Error().Msgf("schedule: invalid cron for flow %s node %s: %v", flowID, trigger.NodeID, err)
 			continue
 		}
-		id := flowID
-		specCopy := spec
+		t := trigger
 		runner.Schedule(schedule, cron.FuncJob(func() {
-			s.startScheduledRun(id, specCopy)
+			// The fire time comes from the schedule, not the clock, so a job
+			// that starts late still gets the run ID of its tick.
+			s.startScheduledRun(flowID, t, services.FireTimeAt(schedule, time.Now(), misfireGrace), false)
 		}))
 	}
 
@@ -153,95 +206,135 @@ func (s *FlowCronScheduler) reconcile() {
 
 	s.mu.Lock()
 	s.cronRunner = runner
-	s.currentByFlow = desired
+	s.current = desired
 	s.mu.Unlock()
 }
 
-func (s *FlowCronScheduler) startScheduledRun(flowID string, spec string) {
+// catchUp applies the misfire policy of every scheduled trigger to the fire
+// times missed since its last recorded fire, e.g. while all workers were down.
+// Fire times within misfireGrace are left to the cron runner. A trigger seen
+// for the first time only records a baseline.
+func (s *FlowCronScheduler) catchUp() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	created, err := s.runs.Create(ctx, domain.Run{
-		FlowID: flowID,
-		Status: "queued",
-		Log:    "queued (schedule)",
-	})
+	lastFired, err := s.schedules.ListLastFired(ctx)
 	if err != nil {
-		s.logger.Error().Msgf("schedule: create run failed (flow=%s spec=%q): %v", flowID, spec, err)
+		s.logger.Error().Msgf("schedule: list fire history failed: %v", err)
 		return
 	}
 
-	if _, err := s.temporal.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
-		ID:        created.TemporalWorkflow,
-		TaskQueue: TaskQueue,
-	}, RunFlowWorkflow, RunFlowInput{FlowID: flowID, RunID: created.ID}); err != nil {
-		_ = s.runs.UpdateStatus(context.Background(), created.ID, "failed", "failed to start workflow: "+err.Error())
-		s.logger.Error().Msgf("schedule: start workflow failed (flow=%s run=%s): %v", flowID, created.ID, err)
-		return
+	s.mu.Lock()
+	current := make(map[string]domain.CronTrigger, len(s.current))
+	for key, trigger := range s.current {
+		current[key] = trigger
+	}
+	s.mu.Unlock()
+
+	now := time.Now()
+	for key, trigger := range current {
+		flowID, _, _ := strings.Cut(key, "/")
+		last, ok := lastFired[key]
+		if !ok {
+			if err := s.schedules.RecordFire(ctx, flowID, trigger.NodeID, now.Truncate(time.Second)); err != nil {
+				s.logger.Error().Msgf("schedule: record fire failed (flow=%s node=%s): %v", flowID, trigger.NodeID, err)
+			}
+			continue
+		}
+		schedule, err := services.ParseCronSchedule(trigger)
+		if err != nil {
+			continue
+		}
+		runs, latest := services.MissedFireTimes(schedule, trigger, last, now.Add(-misfireGrace))
+		if latest.IsZero() {
+			continue
+		}
+		s.logger.Warn().Msgf("schedule: flow %s node %s missed fires through %s (policy=%s, catching up %d)",
+			flowID, trigger.NodeID, latest.UTC().Format(time.RFC3339), trigger.MisfirePolicy, len(runs))
+		for _, fireTime := range runs {
+			s.startScheduledRun(flowID, trigger, fireTime, true)
+		}
+		if err := s.schedules.RecordFire(ctx, flowID, trigger.NodeID, latest); err != nil {
+			s.logger.Error().Msgf("schedule: record fire failed (flow=%s node=%s): %v", flowID, trigger.NodeID, err)
+		}
 	}
 }
 
-func parseCronSchedule(spec string) (cron.Schedule, error) {
-	raw := strings.TrimSpace(spec)
-	if raw == "" {
-		return nil, fmt.Errorf("empty schedule")
+// startScheduledRun creates and starts the run for one tick of trigger. The
+// trigger node is recorded on the run so it starts from that node with the
+// fire details as its input.
+func (s *FlowCronScheduler) startScheduledRun(flowID string, trigger domain.CronTrigger, fireTime time.Time, catchUp bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	timezone := trigger.Timezone
+	if timezone == "" {
+		timezone = "UTC"
 	}
-	if strings.HasPrefix(raw, "@") {
-		return cron.Parse(raw)
+	payload, err := json.Marshal(map[string]any{
+		"mode":         "schedule",
+		"scheduled_at": fireTime.UTC().Format(time.RFC3339),
+		"expression":   trigger.Expression,
+		"timezone":     timezone,
+		"catch_up":     catchUp,
+	})
+	if err != nil {
+		s.logger.Error().Msgf("schedule: encode trigger payload failed (flow=%s node=%s): %v", flowID, trigger.NodeID, err)
+		return
 	}
-	parts := strings.Fields(raw)
-	if len(parts) == 5 {
-		return cron.ParseStandard(raw)
+
+	logText := "queued (schedule)"
+	if catchUp {
+		logText = "queued (schedule catch-up for " + fireTime.UTC().Format(time.RFC3339) + ")"
 	}
-	if len(parts) == 6 {
-		return cron.Parse(raw)
+	run := domain.Run{
+		ID:             scheduledRunID(flowID, trigger.NodeID, fireTime),
+		FlowID:         flowID,
+		Status:         "queued",
+		Log:            logText,
+		TriggerNodeID:  trigger.NodeID,
+		TriggerPayload: payload,
 	}
-	return cron.Parse(raw)
-}
-
-func extractCronSpec(definitionJSON string) string {
-	type flowDef struct {
-		Reactflow struct {
-			Nodes []struct {
-				Type string `json:"type"`
-				Data struct {
-					NodeType string         `json:"nodeType"`
-					Config   map[string]any `json:"config"`
-				} `json:"data"`
-			} `json:"nodes"`
-		} `json:"reactflow"`
+	created, err := startTriggerRun(ctx, s.runs, s.temporal, run)
+	if !created && err == nil {
+		s.logger.Info().Msgf("schedule: run for flow %s node %s at %s already started", flowID, trigger.NodeID, fireTime.UTC().Format(time.RFC3339))
+		return
 	}
-
-	var def flowDef
-	if err := json.Unmarshal([]byte(definitionJSON), &def); err != nil {
-		return ""
+	if !created {
+		s.logger.Error().Msgf("schedule: create run failed (flow=%s node=%s spec=%q): %v", flowID, trigger.NodeID, trigger.Expression, err)
+		return
 	}
-
-	for _, node := range def.Reactflow.Nodes {
-		nodeType := strings.TrimSpace(node.Data.NodeType)
-		if nodeType == "" {
-			nodeType = strings.TrimSpace(node.Type)
-		}
-		if nodeType != "cron" {
-			continue
-		}
-		expr := strings.TrimSpace(readString(node.Data.Config, "expression"))
-		if expr == "" {
-			continue
-		}
-		return expr
+	if recordErr := s.schedules.RecordFire(ctx, flowID, trigger.NodeID, fireTime); recordErr != nil {
+		s.logger.Error().Msgf("schedule: record fire failed (flow=%s node=%s): %v", flowID, trigger.NodeID, recordErr)
+	}
+	if err != nil {
+		s.logger.Error().Msgf("schedule: start workflow failed (flow=%s run=%s): %v", flowID, run.ID, err)
 	}
-	return ""
 }
 
-func mapsEqualStringString(a, b map[string]string) bool {
+// misfireGrace keeps catch-up away from fire times the cron runner is still
+// handling.
+const misfireGrace = time.Minute
+
+// scheduledRunID derives the run ID from the flow, trigger node and fire time,
+// so two schedulers firing the same tick collide on the runs primary key
+// instead of starting the flow twice.
+func scheduledRunID(flowID string, nodeID string, fireTime time.Time) string {
+	return triggerRunID("cron", flowID, nodeID, fireTime.UTC().Format(time.RFC3339))
+}
+
+func cronTriggersEqual(a, b map[string]domain.CronTrigger) bool {
 	if len(a) != len(b) {
 		return false
 	}
 	for k, v := range a {
-		if b[k] != v {
+		if other, ok := b[k]; !ok || other != v {
 			return false
 		}
 	}
 	return true
 }
+
+func ScheduledRunIDForTest(flowID string, nodeID string, fireTime time.Time) string {
+	return scheduledRunID(flowID, nodeID, fireTime)
+}
@@ -0,0 +1,64 @@
+package temporal
+
+import (
+	"context"
+	"database/sql"
+)
+
+// cronLeaderLockKey is the Postgres advisory lock that elects the cron
+// scheduler leader. Only the worker holding it fires scheduled runs.
+const cronLeaderLockKey int64 = 0x666c6f7763726f6e // "flowcron"
+
+// advisoryLeader holds a session-level advisory lock on a dedicated
+// connection. The lock is released when the connection closes, so a crashed
+// leader hands over as soon as Postgres drops its session.
+type advisoryLeader struct {
+	db   *sql.DB
+	key  int64
+	conn *sql.Conn
+}
+
+func newAdvisoryLeader(db *sql.DB, key int64) *advisoryLeader {
+	return &advisoryLeader{db: db, key: key}
+}
+
+// ensure reports whether this process holds the lock, trying to acquire it
+// when it does not. A leader whose connection broke loses the lock and has to
+// compete again.
+func (l *advisoryLeader) ensure(ctx context.Context) (bool, error) {
+	if l.db == nil {
+		return false, nil
+	}
+	if l.conn != nil {
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		_ = l.conn.Close()
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return false, err
+	}
+	if !acquired {
+		_ = conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+func (l *advisoryLeader) release(ctx context.Context) {
+	if l.conn == nil {
+		return
+	}
+	_, _ = l.conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, l.key)
+	_ = l.conn.Close()
+	l.conn = nil
+}
@@ -0,0 +1,72 @@
+package temporal
+
+import (
+	"fmt"
+)
+
+// telegramUpdateKinds are the update fields checked, in order, to name the
+// type of an incoming Telegram update.
+var telegramUpdateKinds = []string{
+	"message", "edited_message", "channel_post", "edited_channel_post",
+	"callback_query", "inline_query", "chosen_inline_result",
+	"my_chat_member", "chat_member", "chat_join_request",
+	"message_reaction", "poll", "poll_answer",
+}
+
+// executeTelegramTrigger outputs the Telegram update delivered to the bot's
+// webhook, with the update type, chat, text and callback query ID lifted to
+// the top so later nodes can reply without digging. Manual runs get an empty
+// update.
+func executeTelegramTrigger(input map[string]any) (map[string]any, string, error) {
+	if input == nil {
+		return map[string]any{"status": 200, "data": map[string]any{
+			"mode":       "manual",
+			"updateType": "",
+			"update":     map[string]any{},
+		}}, "manual run (no telegram update)", nil
+	}
+	update, _ := input["body"].(map[string]any)
+	if update == nil {
+		update = map[string]any{}
+	}
+	out := map[string]any{
+		"mode":       input["mode"],
+		"updateId":   update["update_id"],
+		"updateType": "",
+		"update":     update,
+	}
+	for _, kind := range telegramUpdateKinds {
+		item, ok := update[kind].(map[string]any)
+		if !ok {
+			continue
+		}
+		out["updateType"] = kind
+		message := item
+		if kind == "callback_query" {
+			out["callbackQueryId"] = item["id"]
+			out["text"] = item["data"]
+			message, _ = item["message"].(map[string]any)
+		} else if text, ok := item["text"]; ok {
+			out["text"] = text
+		} else if caption, ok := item["caption"]; ok {
+			out["text"] = caption
+		}
+		if from, ok := item["from"].(map[string]any); ok {
+			out["from"] = from
+		}
+		if chat, ok := item["chat"].(map[string]any); ok {
+			out["chatId"] = chat["id"]
+		} else if chat, ok := message["chat"].(map[string]any); ok {
+			out["chatId"] = chat["id"]
+		}
+		if id, ok := message["message_id"]; ok {
+			out["messageId"] = id
+		}
+		break
+	}
+	return map[string]any{"status": 200, "data": out}, fmt.Sprintf("received telegram %s", out["updateType"]), nil
+}
+
+func ExecuteTelegramTriggerForTest(input map[string]any) (map[string]any, string, error) {
+	return executeTelegramTrigger(input)
+}
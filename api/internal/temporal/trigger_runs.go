@@ -0,0 +1,45 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.temporal.io/sdk/client"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+)
+
+// startTriggerRun records run and starts its workflow. created is false when
+// the run could not be recorded; with a nil error that means a run with the
+// same ID already exists, i.e. another scheduler handled this fire or item.
+// A workflow start failure marks the run failed and returns created=true.
+func startTriggerRun(ctx context.Context, runs *services.RunService, temporalClient client.Client, run domain.Run) (bool, error) {
+	created, err := runs.Create(ctx, run)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if _, err := temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        created.TemporalWorkflow,
+		TaskQueue: TaskQueue,
+	}, RunFlowWorkflow, RunFlowInput{FlowID: created.FlowID, RunID: created.ID}); err != nil {
+		_ = runs.UpdateStatus(context.Background(), created.ID, "failed", "failed to start workflow: "+err.Error())
+		return true, err
+	}
+	return true, nil
+}
+
+// triggerRunID derives a run ID from what caused the run (trigger kind, flow,
+// node and fire time or item), so two schedulers handling the same event
+// collide on the runs primary key instead of starting the flow twice.
+func triggerRunID(kind string, parts ...string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("flowcraft:"+kind+":"+strings.Join(parts, ":"))).String()
+}
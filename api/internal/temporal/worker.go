@@ -3,6 +3,7 @@ package temporal
 import (
 	"database/sql"
 	"flowcraft-api/internal/adapters/database/postgres"
+	"flowcraft-api/internal/adapters/external/sqldb"
 	"flowcraft-api/internal/config"
 
 	"github.com/rs/zerolog"
@@ -14,6 +15,8 @@ const TaskQueue = "flowcraft-tasks"
 type Worker struct {
 	worker worker.Worker
 	sched  *FlowCronScheduler
+	poller *FlowPollScheduler
+	pools  *sqldb.Pools
 }
 
 func NewWorker(cfg config.Config, logger zerolog.Logger, db *sql.DB) (*Worker, error) {
@@ -29,18 +32,30 @@ func NewWorker(cfg config.Config, logger zerolog.Logger, db *sql.DB) (*Worker, e
 		postgres.NewRunRepository(db),
 		postgres.NewRunStepRepository(db),
 		postgres.NewCredentialRepository(db),
+		postgres.NewProjectRepository(db),
+		c,
 	)
 	if err != nil {
 		return nil, err
 	}
 	w.RegisterActivity(activities)
-	return &Worker{worker: w, sched: NewFlowCronScheduler(db, c, logger)}, nil
+	return &Worker{
+		worker: w,
+		sched:  NewFlowCronScheduler(db, c, logger),
+		poller: NewFlowPollScheduler(db, c, activities.stepDeps(), logger),
+		pools:  activities.sqlPools,
+	}, nil
 }
 
 func (w *Worker) Run() error {
+	defer w.pools.Close()
 	if w.sched != nil {
 		w.sched.Start()
 		defer w.sched.Stop()
 	}
+	if w.poller != nil {
+		w.poller.Start()
+		defer w.poller.Stop()
+	}
 	return w.worker.Run(worker.InterruptCh())
 }
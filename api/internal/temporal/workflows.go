@@ -7,6 +7,17 @@ import (
 	"go.temporal.io/sdk/workflow"
 )
 
+// Change IDs for workflow.GetVersion gates in RunFlowWorkflow. Every change to
+// the command sequence (activities, timers, markers) must be introduced behind
+// a new change ID so runs started by an older worker still replay cleanly.
+// See docs/temporal-versioning.md for the policy.
+const (
+	// changeCancelStatusDisconnected records the "canceled" run status on a
+	// disconnected context. Older runs scheduled the update on the already
+	// canceled workflow context, so it was canceled before it could run.
+	changeCancelStatusDisconnected = "run-flow-cancel-status-disconnected"
+)
+
 type RunFlowInput struct {
 	FlowID string
 	RunID  string
@@ -46,7 +57,11 @@ func RunFlowWorkflow(ctx workflow.Context, input RunFlowInput) error {
 	var summary string
 	if err := workflow.ExecuteActivity(execCtx, "ExecuteNodeActivity", input.RunID, def).Get(ctx, &summary); err != nil {
 		if temporal.IsCanceledError(err) {
-			_ = workflow.ExecuteActivity(ctx, "UpdateRunStatusActivity", input.RunID, "canceled", "canceled").Get(ctx, nil)
+			statusCtx := ctx
+			if workflow.GetVersion(ctx, changeCancelStatusDisconnected, workflow.DefaultVersion, 1) >= 1 {
+				statusCtx, _ = workflow.NewDisconnectedContext(ctx)
+			}
+			_ = workflow.ExecuteActivity(statusCtx, "UpdateRunStatusActivity", input.RunID, "canceled", "canceled").Get(statusCtx, nil)
 			return err
 		}
 		_ = workflow.ExecuteActivity(ctx, "UpdateRunStatusActivity", input.RunID, "failed", "execution failed").Get(ctx, nil)
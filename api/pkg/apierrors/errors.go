@@ -7,5 +7,8 @@ const (
 	ErrUnauthorized        = "ERR_UNAUTHORIZED"
 	ErrForbidden           = "ERR_FORBIDDEN"
 	ErrConflict            = "ERR_CONFLICT"
+	ErrMethodNotAllowed    = "ERR_METHOD_NOT_ALLOWED"
+	ErrPayloadTooLarge     = "ERR_PAYLOAD_TOO_LARGE"
+	ErrTooManyRequests     = "ERR_TOO_MANY_REQUESTS"
 	ErrTemporalUnavailable = "temporal_unavailable"
 )
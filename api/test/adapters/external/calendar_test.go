@@ -0,0 +1,81 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flowcraft-api/internal/adapters/external/google"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarCreateEventBody(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/calendar/v3/calendars/team@example.com/events", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("conferenceDataVersion"))
+		assert.Equal(t, "all", r.URL.Query().Get("sendUpdates"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"id":"ev1"}`))
+	}))
+	defer srv.Close()
+	ctx := google.WithEndpoints(context.Background(), google.EndpointsForBaseURL(srv.URL))
+
+	out, err := google.CreateEvent(ctx, "tok", "team@example.com", google.EventInput{
+		Summary:       "Planning",
+		Start:         "2024-05-01T10:00:00",
+		End:           "2024-05-01T11:00:00",
+		TimeZone:      "Europe/Berlin",
+		Attendees:     []string{"ada@example.com"},
+		Reminders:     []google.Reminder{{Minutes: 10}},
+		AddConference: true,
+	}, "all")
+	require.NoError(t, err)
+	assert.Equal(t, "ev1", out["id"])
+	assert.Equal(t, map[string]any{"dateTime": "2024-05-01T10:00:00", "timeZone": "Europe/Berlin"}, body["start"])
+	assert.Equal(t, []any{map[string]any{"email": "ada@example.com"}}, body["attendees"])
+	assert.Equal(t, map[string]any{
+		"useDefault": false,
+		"overrides":  []any{map[string]any{"method": "popup", "minutes": float64(10)}},
+	}, body["reminders"])
+	create := body["conferenceData"].(map[string]any)["createRequest"].(map[string]any)
+	assert.NotEmpty(t, create["requestId"])
+}
+
+func TestCalendarEventTimes(t *testing.T) {
+	_, err := google.CreateEvent(context.Background(), "tok", "", google.EventInput{Start: "2024-05-01T10:00:00", End: "2024-05-01T11:00:00"}, "")
+	assert.ErrorContains(t, err, "set a time zone")
+
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/calendar/v3/calendars/primary/events", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	ctx := google.WithEndpoints(context.Background(), google.EndpointsForBaseURL(srv.URL))
+	_, err = google.CreateEvent(ctx, "tok", "", google.EventInput{Start: "2024-05-01", End: "2024-05-02"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"date": "2024-05-01"}, body["start"])
+}
+
+func TestCalendarListEventsExpandsRecurring(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "true", q.Get("singleEvents"))
+		assert.Equal(t, "startTime", q.Get("orderBy"))
+		assert.Equal(t, "2024-05-01T00:00:00Z", q.Get("timeMin"))
+		_, _ = w.Write([]byte(`{"items":[{"id":"a"},{"id":"b"}]}`))
+	}))
+	defer srv.Close()
+	ctx := google.WithEndpoints(context.Background(), google.EndpointsForBaseURL(srv.URL))
+
+	out, err := google.ListEvents(ctx, "tok", google.EventListOptions{TimeMin: "2024-05-01", SingleEvents: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, out["count"])
+}
@@ -0,0 +1,89 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/discord"
+	"flowcraft-api/internal/adapters/external/endpoints"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscordExecuteWebhook(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/webhooks/123/secret", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("wait"))
+		assert.Equal(t, "t1", r.URL.Query().Get("thread_id"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"id":"m1"}`))
+	}))
+	defer srv.Close()
+
+	embeds := []any{map[string]any{"title": "Deploy"}}
+	out, err := discord.ExecuteWebhook(context.Background(), srv.URL+"/api/webhooks/123/secret", discord.Message{
+		Content:  "shipped",
+		Embeds:   embeds,
+		Username: "CI",
+		ReplyTo:  "ignored for webhooks",
+	}, "t1")
+	require.NoError(t, err)
+	assert.Equal(t, "m1", out["id"])
+	assert.Equal(t, map[string]any{"content": "shipped", "embeds": embeds, "username": "CI"}, body)
+
+	_, err = discord.ExecuteWebhook(context.Background(), srv.URL+"/not-a-webhook", discord.Message{Content: "x"}, "")
+	assert.ErrorContains(t, err, "must look like")
+	_, err = discord.ExecuteWebhook(context.Background(), srv.URL+"/api/webhooks/1/a", discord.Message{Content: strings.Repeat("x", 2001)}, "")
+	assert.ErrorContains(t, err, "Discord allows 2000")
+}
+
+func TestDiscordBotActions(t *testing.T) {
+	var paths []string
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bot tok", r.Header.Get("Authorization"))
+		paths = append(paths, r.URL.Path)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		_, _ = w.Write([]byte(`{"id":"x"}`))
+	}))
+	defer srv.Close()
+	ctx := endpoints.WithAPIURL(context.Background(), "discord", srv.URL)
+
+	_, err := discord.SendMessage(ctx, "tok", "c1", discord.Message{Content: "hi", ReplyTo: "m0"})
+	require.NoError(t, err)
+	_, err = discord.CreateThread(ctx, "tok", "c1", discord.ThreadInput{Name: "Incident", Private: true, AutoArchiveMinutes: 1440})
+	require.NoError(t, err)
+	_, err = discord.CreateThread(ctx, "tok", "c1", discord.ThreadInput{Name: "Follow-up", MessageID: "m0"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/channels/c1/messages", "/channels/c1/threads", "/channels/c1/messages/m0/threads"}, paths)
+	assert.Equal(t, map[string]any{"message_id": "m0", "fail_if_not_exists": false}, bodies[0]["message_reference"])
+	assert.Equal(t, float64(12), bodies[1]["type"])
+	assert.Equal(t, float64(1440), bodies[1]["auto_archive_duration"])
+	assert.NotContains(t, bodies[2], "type")
+}
+
+func TestDiscordRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":1.5,"global":false}`))
+	}))
+	defer srv.Close()
+	ctx := endpoints.WithAPIURL(context.Background(), "discord", srv.URL)
+
+	_, err := discord.SendMessage(ctx, "tok", "c1", discord.Message{Content: "hi"})
+	var rateLimited *discord.RateLimitError
+	require.True(t, errors.As(err, &rateLimited))
+	assert.Equal(t, 1500*time.Millisecond, rateLimited.RetryDelay())
+}
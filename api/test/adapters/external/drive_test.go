@@ -0,0 +1,96 @@
+package external_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flowcraft-api/internal/adapters/external/google"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriveListFilesBuildsQueryAndFollowsPages(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/drive/v3/files", r.URL.Path)
+		queries = append(queries, r.URL.Query().Get("q"))
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{"files":[{"id":"f1"}],"nextPageToken":"p2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"files":[{"id":"f2"}]}`))
+	}))
+	defer srv.Close()
+	ctx := google.WithEndpoints(context.Background(), google.EndpointsForBaseURL(srv.URL))
+
+	out, err := google.ListFiles(ctx, "tok", google.DriveListOptions{Query: "name contains 'invoice'", FolderID: "folder1", All: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, out["count"])
+	assert.Equal(t, "", out["nextPageToken"])
+	require.Len(t, queries, 2)
+	assert.Equal(t, "(name contains 'invoice') and 'folder1' in parents and trashed = false", queries[0])
+}
+
+func TestDriveDownloadExportsWorkspaceFiles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/drive/v3/files/doc1":
+			_, _ = w.Write([]byte(`{"id":"doc1","name":"Budget","mimeType":"application/vnd.google-apps.spreadsheet"}`))
+		case "/drive/v3/files/doc1/export":
+			assert.Equal(t, "text/csv", r.URL.Query().Get("mimeType"))
+			_, _ = w.Write([]byte("a,b\n1,2\n"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	ctx := google.WithEndpoints(context.Background(), google.EndpointsForBaseURL(srv.URL))
+
+	download, err := google.DownloadFile(ctx, "tok", "doc1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv", download.MimeType)
+	assert.Equal(t, "a,b\n1,2\n", string(download.Content))
+	assert.Equal(t, "application/pdf", google.ExportMimeType("PDF"))
+}
+
+func TestDriveUploadUsesResumableSession(t *testing.T) {
+	var uploaded string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			assert.Equal(t, "resumable", r.URL.Query().Get("uploadType"))
+			assert.Equal(t, "text/plain; charset=utf-8", r.Header.Get("X-Upload-Content-Type"))
+			w.Header().Set("Location", srv.URL+"/session/1")
+		case r.Method == http.MethodPut && r.URL.Path == "/session/1":
+			body, _ := io.ReadAll(r.Body)
+			uploaded = string(body)
+			_, _ = w.Write([]byte(`{"id":"new1","name":"notes.txt"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	ctx := google.WithEndpoints(context.Background(), google.EndpointsForBaseURL(srv.URL))
+
+	out, err := google.UploadFile(ctx, "tok", google.DriveUpload{Name: "notes.txt", Content: []byte("hello")})
+	require.NoError(t, err)
+	assert.Equal(t, "new1", out["id"])
+	assert.Equal(t, "hello", uploaded)
+}
+
+func TestDriveMissingScopeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":403,"message":"Request had insufficient authentication scopes."}}`))
+	}))
+	defer srv.Close()
+	ctx := google.WithEndpoints(context.Background(), google.EndpointsForBaseURL(srv.URL))
+
+	_, err := google.GetFile(ctx, "tok", "f1")
+	assert.ErrorIs(t, err, google.ErrDriveScope)
+}
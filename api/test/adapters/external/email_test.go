@@ -0,0 +1,495 @@
+package external_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"flowcraft-api/internal/adapters/external/email"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTP is a local SMTP stand-in that accepts AUTH PLAIN and records one
+// envelope per DATA.
+type fakeSMTP struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	auth string
+	from string
+	rcpt []string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTP{ln: ln}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) port() int { return s.ln.Addr().(*net.TCPAddr).Port }
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = fmt.Fprintf(conn, "%s\r\n", line) }
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		s.mu.Lock()
+		switch cmd {
+		case "EHLO":
+			reply("250-fake")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			raw, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, "AUTH PLAIN "))
+			s.auth = string(raw)
+			reply("235 ok")
+		case "MAIL":
+			s.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			reply("250 ok")
+		case "RCPT":
+			s.rcpt = append(s.rcpt, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			s.data = data.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("250 ok")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func TestEmailSend(t *testing.T) {
+	srv := newFakeSMTP(t)
+	acct := email.AccountFromPayload(map[string]any{
+		"host":     "127.0.0.1",
+		"port":     float64(srv.port()),
+		"username": "bot",
+		"password": "secret",
+		"security": "none",
+		"from":     "FlowCraft <bot@example.com>",
+	})
+	out, err := email.Send(context.Background(), acct, email.Message{
+		To:      []string{`"Doe, Jane" <jane@example.com>`},
+		Cc:      []string{"ops@example.com"},
+		Bcc:     []string{"audit@example.com"},
+		Subject: "Réport\r\nBcc: evil@example.com",
+		Text:    "Hello",
+		HTML:    "<p>Hello</p>",
+		Attachments: []email.Attachment{
+			{Filename: "report.csv", Content: []byte("a,b\n1,2\n")},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jane@example.com", "ops@example.com", "audit@example.com"}, out["recipients"])
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, "\x00bot\x00secret", srv.auth)
+	assert.Equal(t, "bot@example.com", srv.from)
+	assert.Equal(t, []string{"jane@example.com", "ops@example.com", "audit@example.com"}, srv.rcpt)
+	assert.NotContains(t, srv.data, "audit@example.com")
+	assert.NotContains(t, srv.data, "\r\nBcc:")
+
+	parsed, err := email.ParseMessage([]byte(srv.data), true)
+	require.NoError(t, err)
+	assert.Equal(t, "Réport Bcc: evil@example.com", parsed["subject"])
+	assert.Equal(t, "bot@example.com", parsed["fromAddress"])
+	assert.Equal(t, []string{"jane@example.com"}, parsed["to"])
+	assert.Equal(t, []string{"ops@example.com"}, parsed["cc"])
+	assert.Equal(t, "Hello", parsed["text"])
+	assert.Equal(t, "<p>Hello</p>", parsed["html"])
+	attachments := parsed["attachments"].([]map[string]any)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "report.csv", attachments[0]["filename"])
+	assert.Equal(t, "text/csv", attachments[0]["mimeType"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("a,b\n1,2\n")), attachments[0]["contentBase64"])
+}
+
+func TestEmailSendValidation(t *testing.T) {
+	acct := email.Account{Host: "127.0.0.1", Port: 1, Security: "none", From: "bot@example.com"}
+	_, err := email.Send(context.Background(), acct, email.Message{Text: "hi"})
+	assert.ErrorContains(t, err, "recipient")
+
+	_, err = email.Send(context.Background(), email.Account{Host: "127.0.0.1", Security: "ssl"}, email.Message{})
+	assert.ErrorContains(t, err, "security must be")
+}
+
+type fakeIMAPMessage struct {
+	uid  uint32
+	seen bool
+	raw  string
+}
+
+// fakeIMAP is a local IMAP stand-in for one folder that supports the
+// commands the client issues.
+type fakeIMAP struct {
+	ln          net.Listener
+	mu          sync.Mutex
+	uidValidity uint32
+	messages    []*fakeIMAPMessage
+	commands    []string
+	// password is the one LOGIN and AUTHENTICATE PLAIN accept for "bot".
+	password string
+	// loginDisabled advertises LOGINDISABLED and rejects LOGIN.
+	loginDisabled bool
+}
+
+func newFakeIMAP(t *testing.T, messages ...*fakeIMAPMessage) *fakeIMAP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeIMAP{ln: ln, uidValidity: 7, messages: messages, password: "secret"}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeIMAP) port() int { return s.ln.Addr().(*net.TCPAddr).Port }
+
+func (s *fakeIMAP) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	send := func(format string, args ...any) { _, _ = fmt.Fprintf(conn, format+"\r\n", args...) }
+	send("* OK fake IMAP4rev1 ready")
+	for {
+		line, err := readIMAPCommand(r, send)
+		if err != nil {
+			return
+		}
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			return
+		}
+		tag, cmd, args := parts[0], strings.ToUpper(parts[1]), parts[2:]
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.Join(parts[1:], " "))
+		switch cmd {
+		case "CAPABILITY":
+			if s.loginDisabled {
+				send("* CAPABILITY IMAP4rev1 LOGINDISABLED AUTH=PLAIN")
+			} else {
+				send("* CAPABILITY IMAP4rev1 AUTH=PLAIN")
+			}
+			send("%s OK capability done", tag)
+		case "LOGIN":
+			if !s.loginDisabled && len(args) == 2 && args[0] == `"bot"` && args[1] == strconv.Quote(s.password) {
+				send("%s OK logged in", tag)
+			} else {
+				send("%s NO invalid credentials", tag)
+			}
+		case "AUTHENTICATE":
+			send("+ ")
+			resp, err := r.ReadString('\n')
+			if err != nil {
+				s.mu.Unlock()
+				return
+			}
+			raw, _ := base64.StdEncoding.DecodeString(strings.TrimRight(resp, "\r\n"))
+			if len(args) == 1 && strings.EqualFold(args[0], "PLAIN") && string(raw) == "\x00bot\x00"+s.password {
+				send("%s OK authenticated", tag)
+			} else {
+				send("%s NO invalid credentials", tag)
+			}
+		case "SELECT", "EXAMINE":
+			send("* %d EXISTS", len(s.messages))
+			send("* OK [UIDVALIDITY %d] ok", s.uidValidity)
+			send("* OK [UIDNEXT %d] ok", s.uidNext())
+			send("%s OK [READ-WRITE] done", tag)
+		case "UID":
+			s.uidCommand(send, tag, strings.ToUpper(args[0]), args[1:])
+		case "LOGOUT":
+			send("* BYE")
+			send("%s OK bye", tag)
+			s.mu.Unlock()
+			return
+		default:
+			send("%s BAD unknown", tag)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// readIMAPCommand reads one command line. Synchronizing literals ({n}) are
+// acknowledged and inlined as quoted strings; 8-bit data outside a literal
+// drops the connection like a strict IMAP4rev1 server.
+func readIMAPCommand(r *bufio.Reader, send func(string, ...any)) (string, error) {
+	var sb strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		for i := 0; i < len(line); i++ {
+			if line[i] > 0x7f {
+				return "", fmt.Errorf("8-bit data outside a literal")
+			}
+		}
+		open := strings.LastIndex(line, "{")
+		if open < 0 || !strings.HasSuffix(line, "}") {
+			sb.WriteString(line)
+			return sb.String(), nil
+		}
+		spec := line[open+1 : len(line)-1]
+		n, err := strconv.Atoi(strings.TrimSuffix(spec, "+"))
+		if err != nil {
+			sb.WriteString(line)
+			return sb.String(), nil
+		}
+		if !strings.HasSuffix(spec, "+") {
+			send("+ ready")
+		}
+		lit := make([]byte, n)
+		if _, err := io.ReadFull(r, lit); err != nil {
+			return "", err
+		}
+		sb.WriteString(line[:open])
+		sb.WriteString(strconv.Quote(string(lit)))
+	}
+}
+
+func (s *fakeIMAP) uidNext() uint32 {
+	next := uint32(1)
+	for _, m := range s.messages {
+		if m.uid >= next {
+			next = m.uid + 1
+		}
+	}
+	return next
+}
+
+func (s *fakeIMAP) uidCommand(send func(string, ...any), tag string, cmd string, args []string) {
+	switch cmd {
+	case "SEARCH":
+		var from uint32
+		unseen := false
+		for i := 0; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "UNSEEN":
+				unseen = true
+			case "UID":
+				n, _ := strconv.Atoi(strings.TrimSuffix(args[i+1], ":*"))
+				from = uint32(n)
+				i++
+			}
+		}
+		var hits []string
+		for i, m := range s.messages {
+			last := i == len(s.messages)-1
+			if (m.uid >= from || last) && (!unseen || !m.seen) {
+				hits = append(hits, strconv.Itoa(int(m.uid)))
+			}
+		}
+		send("* SEARCH %s", strings.Join(hits, " "))
+		send("%s OK search done", tag)
+	case "FETCH":
+		for i, m := range s.messages {
+			if !uidInSet(m.uid, args[0]) {
+				continue
+			}
+			flags := ""
+			if m.seen {
+				flags = `\Seen`
+			}
+			send("* %d FETCH (UID %d FLAGS (%s) INTERNALDATE \"17-Jul-2026 09:30:00 +0200\" RFC822.SIZE %d BODY[] {%d}", i+1, m.uid, flags, len(m.raw), len(m.raw))
+			send("%s)", m.raw)
+		}
+		send("%s OK fetch done", tag)
+	case "STORE":
+		for _, m := range s.messages {
+			if uidInSet(m.uid, args[0]) {
+				m.seen = true
+			}
+		}
+		send("%s OK store done", tag)
+	default:
+		send("%s BAD unknown", tag)
+	}
+}
+
+func uidInSet(uid uint32, set string) bool {
+	for _, part := range strings.Split(set, ",") {
+		if part == strconv.Itoa(int(uid)) {
+			return true
+		}
+	}
+	return false
+}
+
+func rawEmail(subject string, body string) string {
+	return "From: Ada <ada@example.com>\r\n" +
+		"To: bot@example.com\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"Date: Fri, 17 Jul 2026 09:30:00 +0200\r\n" +
+		"Message-ID: <" + strings.ReplaceAll(subject, " ", "") + "@example.com>\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" + body
+}
+
+func imapAccount(srv *fakeIMAP) email.Account {
+	return email.Account{Host: "127.0.0.1", Port: srv.port(), Username: "bot", Password: "secret", Security: "none"}
+}
+
+func TestEmailFetch(t *testing.T) {
+	srv := newFakeIMAP(t,
+		&fakeIMAPMessage{uid: 3, seen: true, raw: rawEmail("old", "read already")},
+		&fakeIMAPMessage{uid: 5, raw: rawEmail("first", "hello")},
+		&fakeIMAPMessage{uid: 9, raw: rawEmail("second", "world (with parens)")},
+	)
+
+	result, err := email.Fetch(context.Background(), imapAccount(srv), email.FetchOptions{UnseenOnly: true, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), result.Mailbox.UIDValidity)
+	assert.Equal(t, uint32(10), result.Mailbox.UIDNext)
+	assert.Equal(t, 3, result.Mailbox.Exists)
+	require.Len(t, result.Messages, 2)
+	assert.Equal(t, uint32(5), result.Messages[0]["uid"])
+	assert.Equal(t, "first", result.Messages[0]["subject"])
+	assert.Equal(t, "ada@example.com", result.Messages[0]["fromAddress"])
+	assert.Equal(t, "world (with parens)", result.Messages[1]["text"])
+	assert.Equal(t, "2026-07-17T07:30:00Z", result.Messages[1]["receivedAt"])
+	assert.Equal(t, false, result.Messages[1]["seen"])
+
+	srv.mu.Lock()
+	assert.Contains(t, srv.commands, `EXAMINE INBOX`)
+	assert.False(t, srv.messages[1].seen)
+	srv.mu.Unlock()
+
+	result, err = email.Fetch(context.Background(), imapAccount(srv), email.FetchOptions{AfterUID: 5, MarkAsRead: true, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, uint32(9), result.Messages[0]["uid"])
+	srv.mu.Lock()
+	assert.Contains(t, srv.commands, `SELECT INBOX`)
+	assert.True(t, srv.messages[2].seen)
+	assert.False(t, srv.messages[1].seen)
+	srv.mu.Unlock()
+
+	// "UID 10:*" still matches UID 9; nothing newer exists.
+	result, err = email.Fetch(context.Background(), imapAccount(srv), email.FetchOptions{AfterUID: 9})
+	require.NoError(t, err)
+	assert.Empty(t, result.Messages)
+
+	_, err = email.Fetch(context.Background(), imapAccount(srv), email.FetchOptions{AfterUID: 9, UIDValidity: 6})
+	assert.ErrorIs(t, err, email.ErrUIDValidityChanged)
+}
+
+func TestEmailFetchRejectsBadLogin(t *testing.T) {
+	srv := newFakeIMAP(t)
+	acct := imapAccount(srv)
+	acct.Password = "wrong"
+	_, err := email.Status(context.Background(), acct, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LOGIN failed")
+	assert.NotContains(t, err.Error(), "wrong")
+}
+
+func TestEmailFetchSendsNonASCIIPasswordAsLiteral(t *testing.T) {
+	srv := newFakeIMAP(t)
+	srv.password = "pässwörd"
+	acct := imapAccount(srv)
+	acct.Password = srv.password
+	_, err := email.Status(context.Background(), acct, "")
+	require.NoError(t, err)
+}
+
+func TestEmailFetchAuthenticatesWhenLoginDisabled(t *testing.T) {
+	srv := newFakeIMAP(t, &fakeIMAPMessage{uid: 4, raw: rawEmail("hi", "there")})
+	srv.loginDisabled = true
+	box, err := email.Status(context.Background(), imapAccount(srv), "")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), box.UIDNext)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Contains(t, srv.commands, "AUTHENTICATE PLAIN")
+	assert.NotContains(t, srv.commands, `LOGIN "bot" "secret"`)
+}
+
+func TestEmailParseMultipart(t *testing.T) {
+	raw := "From: =?utf-8?q?J=C3=BCrgen?= <j@example.com>\r\n" +
+		"Subject: =?iso-8859-1?q?Gr=FC=DFe?=\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=outer\r\n" +
+		"\r\n" +
+		"--outer\r\n" +
+		"Content-Type: multipart/alternative; boundary=inner\r\n" +
+		"\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Sch=F6n\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"\r\n" +
+		"<b>Schön</b>\r\n" +
+		"--inner--\r\n" +
+		"--outer\r\n" +
+		"Content-Type: application/pdf; name=\"invoice.pdf\"\r\n" +
+		"Content-Disposition: attachment; filename=\"invoice.pdf\"\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"JVBERi0x\r\n" +
+		"--outer--\r\n"
+
+	msg, err := email.ParseMessage([]byte(raw), false)
+	require.NoError(t, err)
+	assert.Equal(t, "Grüße", msg["subject"])
+	assert.Equal(t, "Jürgen", msg["fromName"])
+	assert.Equal(t, "Schön", msg["text"])
+	assert.Equal(t, "<b>Schön</b>", msg["html"])
+	attachments := msg["attachments"].([]map[string]any)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "invoice.pdf", attachments[0]["filename"])
+	assert.Equal(t, "application/pdf", attachments[0]["mimeType"])
+	assert.Equal(t, 6, attachments[0]["size"])
+	assert.NotContains(t, attachments[0], "contentBase64")
+}
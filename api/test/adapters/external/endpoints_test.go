@@ -0,0 +1,132 @@
+package external_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
+	"flowcraft-api/internal/adapters/external/github"
+	"flowcraft-api/internal/adapters/external/google"
+	"flowcraft-api/internal/adapters/external/slack"
+	"flowcraft-api/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointsGitHubEnterprise(t *testing.T) {
+	cfg := config.Config{GitHubAPIURL: "https://ghe.example.com/api/v3"}
+
+	e := endpoints.GitHub(cfg, "")
+	assert.Equal(t, "https://ghe.example.com/api/v3", e.APIURL)
+	assert.Equal(t, "https://ghe.example.com", e.OAuthURL)
+
+	ctx := endpoints.Apply(context.Background(), cfg, "github", nil)
+	authURL := github.BuildAuthURL(ctx, "client", "https://app.example.com/cb", "state", []string{"repo"})
+	assert.True(t, strings.HasPrefix(authURL, "https://ghe.example.com/login/oauth/authorize?"), authURL)
+
+	cfg.GitHubOAuthURL = "https://sso.example.com"
+	assert.Equal(t, "https://sso.example.com", endpoints.GitHub(cfg, "").OAuthURL)
+}
+
+func TestEndpointsDefaults(t *testing.T) {
+	ctx := endpoints.Apply(context.Background(), config.Config{}, "google", nil)
+	authURL := google.BuildAuthURL(ctx, "client", "https://app.example.com/cb", "", nil, false)
+	assert.True(t, strings.HasPrefix(authURL, google.AuthEndpoint+"?"), authURL)
+
+	ctx = endpoints.Apply(context.Background(), config.Config{}, "github", nil)
+	authURL = github.BuildAuthURL(ctx, "client", "https://app.example.com/cb", "", nil)
+	assert.True(t, strings.HasPrefix(authURL, "https://github.com/login/oauth/authorize?"), authURL)
+}
+
+func TestEndpointsCredentialOverridesServerConfig(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		assert.Equal(t, "/auth.test", r.URL.Path)
+		_, _ = w.Write([]byte(`{"ok":true,"team":"Acme"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.Config{SlackAPIURL: "http://192.0.2.1:1/unused", CredentialAPIURLHosts: "127.0.0.1"}
+	ctx := endpoints.Apply(context.Background(), cfg, "slack", map[string]any{endpoints.CredentialKey: srv.URL + "/"})
+	out, err := slack.AuthTest(ctx, "xoxb-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", out["team"])
+	assert.Equal(t, 1, hits)
+}
+
+func TestEndpointsCredentialCannotMoveOAuthEndpoints(t *testing.T) {
+	var tokenHits int
+	evil := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokenHits++
+		}
+		http.NotFound(w, r)
+	}))
+	defer evil.Close()
+	var serverHits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverHits++
+		assert.Equal(t, "/token", r.URL.Path)
+		_, _ = w.Write([]byte(`{"access_token":"ya29","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	// Even an allowed api_url only moves the data APIs.
+	cfg := config.Config{GoogleAPIURL: server.URL, CredentialAPIURLHosts: "127.0.0.1"}
+	ctx := endpoints.Apply(context.Background(), cfg, "google", map[string]any{endpoints.CredentialKey: evil.URL})
+	_, err := google.RefreshAccessToken(ctx, "client", "secret", "refresh")
+	require.NoError(t, err)
+	assert.Equal(t, 1, serverHits)
+	assert.Zero(t, tokenHits)
+
+	cfg = config.Config{}
+	ctx = endpoints.Apply(context.Background(), cfg, "github", map[string]any{endpoints.CredentialKey: "https://ghe.example.com/api/v3"})
+	authURL := github.BuildAuthURL(ctx, "client", "https://app.example.com/cb", "", nil)
+	assert.True(t, strings.HasPrefix(authURL, "https://github.com/login/oauth/authorize?"), authURL)
+}
+
+func TestEndpointsCheck(t *testing.T) {
+	cfg := config.Config{GitHubAPIURL: "https://ghe.example.com/api/v3", CredentialAPIURLHosts: "*.corp.example, 10.0.0.5"}
+	for _, tt := range []struct {
+		provider string
+		apiURL   any
+		ok       bool
+	}{
+		{"github", nil, true},
+		{"github", "", true},
+		{"github", "https://ghe.example.com/api/v3", true},
+		{"slack", "https://slack.corp.example/api", true},
+		{"notion", "http://10.0.0.5:8080/v1", true},
+		{"google", "https://attacker.example", false},
+		{"slack", "https://ghe.example.com/api", false},
+		{"github", "ftp://ghe.example.com", false},
+		{"github", 42, false},
+	} {
+		err := endpoints.Check(cfg, tt.provider, map[string]any{endpoints.CredentialKey: tt.apiURL})
+		if tt.ok {
+			assert.NoError(t, err, "%s %v", tt.provider, tt.apiURL)
+		} else {
+			assert.ErrorIs(t, err, endpoints.ErrAPIURLNotAllowed, "%s %v", tt.provider, tt.apiURL)
+		}
+	}
+}
+
+func TestEndpointsIgnoreDisallowedOverride(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write([]byte(`{"ok":true,"team":"Acme"}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.Config{SlackAPIURL: srv.URL}
+	ctx := endpoints.Apply(context.Background(), cfg, "slack", map[string]any{endpoints.CredentialKey: "http://192.0.2.1:1"})
+	_, err := slack.AuthTest(ctx, "xoxb-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, hits, "server config used instead of the stored api_url")
+}
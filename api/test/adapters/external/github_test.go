@@ -0,0 +1,119 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flowcraft-api/internal/adapters/external/github"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubListCommitsFollowsLinkHeader(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/acme/app/commits", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Query().Get("page") {
+		case "":
+			assert.Equal(t, "main", r.URL.Query().Get("sha"))
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/app/commits?page=2>; rel="next", <%s/repos/acme/app/commits?page=2>; rel="last"`, srv.URL, srv.URL))
+			_, _ = w.Write([]byte(`[{"sha":"a"},{"sha":"b"}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"sha":"c"}]`))
+		default:
+			t.Fatalf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer srv.Close()
+	ctx := github.WithEndpoints(context.Background(), github.Endpoints{APIURL: srv.URL})
+
+	out, err := github.ListCommits(ctx, "secret", "acme", "app", github.ListCommitsOptions{SHA: "main"})
+	require.NoError(t, err)
+	items, ok := out["data"].([]any)
+	require.True(t, ok)
+	require.Len(t, items, 3)
+	assert.Equal(t, "c", items[2].(map[string]any)["sha"])
+}
+
+func TestGitHubListIgnoresNextLinkToAnotherHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://evil.example.com/steal?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[{"number":1}]`))
+	}))
+	defer srv.Close()
+	ctx := github.WithEndpoints(context.Background(), github.Endpoints{APIURL: srv.URL})
+
+	out, err := github.ListPullRequests(ctx, "secret", "acme", "app", github.ListPullRequestsOptions{})
+	require.NoError(t, err)
+	assert.Len(t, out["data"], 1)
+}
+
+func TestGitHubListIssuesRespectsPageLimit(t *testing.T) {
+	calls := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/app/issues?page=%d>; rel="next"`, srv.URL, calls+1))
+		_, _ = w.Write([]byte(`[{"number":1}]`))
+	}))
+	defer srv.Close()
+	ctx := github.WithEndpoints(context.Background(), github.Endpoints{APIURL: srv.URL})
+
+	issues, err := github.ListIssues(ctx, "secret", "acme", "app", github.ListIssuesOptions{Pages: 1})
+	require.NoError(t, err)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGitHubCreateBranchFromDefaultBranch(t *testing.T) {
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/app":
+			_, _ = w.Write([]byte(`{"default_branch":"main"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/app/commits/main":
+			_, _ = w.Write([]byte(`{"sha":"abc123"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/app/git/refs":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"ref":"refs/heads/release/1.2","object":{"sha":"abc123"}}`))
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	ctx := github.WithEndpoints(context.Background(), github.Endpoints{APIURL: srv.URL})
+
+	out, err := github.CreateBranch(ctx, "secret", "acme", "app", "release/1.2", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"ref": "refs/heads/release/1.2", "sha": "abc123"}, created)
+	assert.Equal(t, "refs/heads/release/1.2", out["ref"])
+}
+
+func TestGitHubDispatchWorkflowAcceptsNoContent(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/acme/app/actions/workflows/release.yml/dispatches", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	ctx := github.WithEndpoints(context.Background(), github.Endpoints{APIURL: srv.URL})
+
+	out, err := github.DispatchWorkflow(ctx, "secret", "acme", "app", "release.yml", "v1.2.0", map[string]any{"channel": "stable"})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", body["ref"])
+	assert.Equal(t, map[string]any{"channel": "stable"}, body["inputs"])
+	assert.Equal(t, 204, out["status"])
+}
+
+func TestGitHubMergePullRequestRejectsUnknownMethod(t *testing.T) {
+	_, err := github.MergePullRequest(context.Background(), "secret", "acme", "app", 7, github.MergeOptions{Method: "fast-forward"})
+	assert.EqualError(t, err, `merge method must be merge, squash or rebase, got "fast-forward"`)
+}
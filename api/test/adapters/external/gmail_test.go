@@ -0,0 +1,105 @@
+package external_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"flowcraft-api/internal/adapters/external/google"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGmailBuildMessage(t *testing.T) {
+	t.Run("cc, bcc and threading headers", func(t *testing.T) {
+		raw, err := google.BuildMessageForTest(google.Email{
+			From:       "me@example.com",
+			To:         "ada@example.com",
+			Cc:         "grace@example.com",
+			Bcc:        "audit@example.com",
+			Subject:    "Hello\r\nBcc: attacker@example.com",
+			BodyText:   "hi",
+			InReplyTo:  "<a@mail>",
+			References: "<root@mail> <a@mail>",
+		})
+		require.NoError(t, err)
+		headers := raw[:strings.Index(raw, "\r\n\r\n")]
+		assert.Contains(t, headers, "Cc: grace@example.com\r\n")
+		assert.Contains(t, headers, "Bcc: audit@example.com\r\n")
+		assert.Contains(t, headers, "In-Reply-To: <a@mail>\r\n")
+		assert.Contains(t, headers, "References: <root@mail> <a@mail>\r\n")
+		assert.NotContains(t, headers, "\r\nBcc: attacker", "subject must not inject headers")
+		assert.Contains(t, headers, "Content-Type: text/plain")
+	})
+
+	t.Run("attachments use multipart/mixed", func(t *testing.T) {
+		content := []byte(strings.Repeat("x", 100))
+		raw, err := google.BuildMessageForTest(google.Email{
+			To:          "ada@example.com",
+			Subject:     "Report",
+			BodyText:    "see attached",
+			BodyHTML:    "<p>see attached</p>",
+			Attachments: []google.Attachment{{Filename: "report.csv", Content: content}},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, raw, "Content-Type: multipart/mixed;")
+		assert.Contains(t, raw, "Content-Type: multipart/alternative;")
+		assert.Contains(t, raw, `Content-Disposition: attachment; filename="report.csv"`)
+		assert.Contains(t, raw, "Content-Type: text/csv")
+		encoded := base64.StdEncoding.EncodeToString(content)
+		assert.Contains(t, raw, encoded[:76]+"\r\n"+encoded[76:]+"\r\n")
+	})
+
+	t.Run("needs a recipient", func(t *testing.T) {
+		_, err := google.BuildMessageForTest(google.Email{Subject: "x"})
+		assert.Error(t, err)
+	})
+}
+
+func TestGmailParseMessage(t *testing.T) {
+	b64 := func(s string) string { return base64.URLEncoding.EncodeToString([]byte(s)) }
+	msg := map[string]any{
+		"id":       "m1",
+		"threadId": "t1",
+		"payload": map[string]any{
+			"mimeType": "multipart/mixed",
+			"headers": []any{
+				map[string]any{"name": "From", "value": "Ada <ada@example.com>"},
+				map[string]any{"name": "Subject", "value": "Invoice"},
+				map[string]any{"name": "Message-Id", "value": "<m1@mail>"},
+			},
+			"parts": []any{
+				map[string]any{
+					"mimeType": "multipart/alternative",
+					"parts": []any{
+						map[string]any{"mimeType": "text/plain", "body": map[string]any{"data": b64("plain body")}},
+						map[string]any{"mimeType": "text/html", "body": map[string]any{"data": b64("<b>html</b>")}},
+					},
+				},
+				map[string]any{
+					"partId":   "2",
+					"mimeType": "application/pdf",
+					"filename": "invoice.pdf",
+					"body":     map[string]any{"attachmentId": "att1", "size": float64(1234)},
+				},
+			},
+		},
+	}
+
+	parsed := google.ParseMessage(msg)
+	assert.Equal(t, "Ada <ada@example.com>", parsed["from"])
+	assert.Equal(t, "<m1@mail>", parsed["messageId"], "header names are matched case-insensitively")
+	assert.Equal(t, "plain body", parsed["textBody"])
+	assert.Equal(t, "<b>html</b>", parsed["htmlBody"])
+	assert.Equal(t, []any{map[string]any{
+		"filename": "invoice.pdf", "mimeType": "application/pdf", "size": float64(1234), "attachmentId": "att1", "partId": "2",
+	}}, parsed["attachments"])
+
+	reply := google.ReplyTo(parsed, google.Email{BodyText: "thanks"})
+	assert.Equal(t, "t1", reply.ThreadID)
+	assert.Equal(t, "<m1@mail>", reply.InReplyTo)
+	assert.Equal(t, "<m1@mail>", reply.References)
+	assert.Equal(t, "Re: Invoice", reply.Subject)
+	assert.Equal(t, "Ada <ada@example.com>", reply.To)
+}
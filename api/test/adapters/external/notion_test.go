@@ -0,0 +1,132 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
+	"flowcraft-api/internal/adapters/external/notion"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var notionSchema = map[string]any{
+	"Name":       map[string]any{"type": "title"},
+	"Status":     map[string]any{"type": "select"},
+	"Due":        map[string]any{"type": "date"},
+	"Points":     map[string]any{"type": "number"},
+	"Done":       map[string]any{"type": "checkbox"},
+	"Blocked by": map[string]any{"type": "relation"},
+	"Tags":       map[string]any{"type": "multi_select"},
+	"Total":      map[string]any{"type": "formula"},
+}
+
+func TestNotionBuildProperties(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name:   "select",
+			values: map[string]any{"Status": "Done"},
+			want:   map[string]any{"Status": map[string]any{"select": map[string]any{"name": "Done"}}},
+		},
+		{
+			name:   "date string and range",
+			values: map[string]any{"Due": map[string]any{"start": "2024-05-01", "end": "2024-05-03"}},
+			want:   map[string]any{"Due": map[string]any{"date": map[string]any{"start": "2024-05-01", "end": "2024-05-03"}}},
+		},
+		{
+			name:   "number from string",
+			values: map[string]any{"Points": "3.5"},
+			want:   map[string]any{"Points": map[string]any{"number": 3.5}},
+		},
+		{
+			name:   "checkbox from string",
+			values: map[string]any{"Done": "true"},
+			want:   map[string]any{"Done": map[string]any{"checkbox": true}},
+		},
+		{
+			name:   "relation from comma list",
+			values: map[string]any{"Blocked by": "a1, b2"},
+			want:   map[string]any{"Blocked by": map[string]any{"relation": []any{map[string]any{"id": "a1"}, map[string]any{"id": "b2"}}}},
+		},
+		{
+			name:   "notion format passes through",
+			values: map[string]any{"Tags": map[string]any{"multi_select": []any{map[string]any{"name": "x"}}}},
+			want:   map[string]any{"Tags": map[string]any{"multi_select": []any{map[string]any{"name": "x"}}}},
+		},
+		{
+			name:    "all problems are reported",
+			values:  map[string]any{"Points": "many", "Done": "maybe", "Owner": "ada", "Total": 3},
+			wantErr: `invalid properties: Done: "maybe" is not true or false; Owner: no such property; Points: "many" is not a number; Total: formula properties are read-only`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := notion.BuildProperties(notionSchema, tt.values)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNotionMarkdownToBlocks(t *testing.T) {
+	md := "# Title\n\nSome **bold** and [a link](https://example.com).\nSecond line\n\n- [x] done\n- item\n1. first\n> quote\n---\n```go\nfmt.Println(1)\n```"
+	blocks := notion.MarkdownToBlocks(md)
+
+	var types []string
+	for _, b := range blocks {
+		types = append(types, b.(map[string]any)["type"].(string))
+	}
+	assert.Equal(t, []string{"heading_1", "paragraph", "to_do", "bulleted_list_item", "numbered_list_item", "quote", "divider", "code"}, types)
+
+	paragraph := blocks[1].(map[string]any)["paragraph"].(map[string]any)["rich_text"].([]any)
+	require.Len(t, paragraph, 5)
+	assert.Equal(t, map[string]any{"bold": true}, paragraph[1].(map[string]any)["annotations"])
+	link := paragraph[3].(map[string]any)["text"].(map[string]any)
+	assert.Equal(t, "a link", link["content"])
+	assert.Equal(t, map[string]any{"url": "https://example.com"}, link["link"])
+	assert.Equal(t, ".\nSecond line", paragraph[4].(map[string]any)["text"].(map[string]any)["content"])
+
+	assert.Equal(t, true, blocks[2].(map[string]any)["to_do"].(map[string]any)["checked"])
+	code := blocks[7].(map[string]any)["code"].(map[string]any)
+	assert.Equal(t, "go", code["language"])
+}
+
+func TestNotionQueryDatabaseFollowsCursor(t *testing.T) {
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/databases/db1/query", r.URL.Path)
+		assert.Equal(t, "2022-06-28", r.Header.Get("Notion-Version"))
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		if body["start_cursor"] == nil {
+			_, _ = w.Write([]byte(`{"results":[{"id":"p1"}],"has_more":true,"next_cursor":"c2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"p2"}],"has_more":false,"next_cursor":null}`))
+	}))
+	defer srv.Close()
+	ctx := endpoints.WithAPIURL(context.Background(), "notion", srv.URL)
+
+	filter := map[string]any{"property": "Done", "checkbox": map[string]any{"equals": false}}
+	out, err := notion.QueryDatabase(ctx, "secret", "db1", notion.QueryOptions{Filter: filter, All: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, out["count"])
+	assert.Equal(t, false, out["has_more"])
+	require.Len(t, bodies, 2)
+	assert.Equal(t, filter, bodies[0]["filter"])
+	assert.Equal(t, "c2", bodies[1]["start_cursor"])
+}
@@ -0,0 +1,161 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
+	"flowcraft-api/internal/adapters/external/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSlackServer starts a stub Slack API and returns a context that points the adapter at it.
+func newSlackServer(t *testing.T, handler http.HandlerFunc) (context.Context, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return endpoints.WithAPIURL(context.Background(), "slack", srv.URL), srv
+}
+
+func TestSlackSendMessageOptions(t *testing.T) {
+	var got map[string]any
+	ctx, _ := newSlackServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.postMessage", r.URL.Path)
+		assert.Equal(t, "Bearer xoxb-1", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"ok":true,"ts":"1700000000.000100"}`))
+	})
+
+	noUnfurl := false
+	out, err := slack.SendMessage(ctx, "xoxb-1", "C1", "hi", slack.MessageOptions{
+		Blocks:         []any{map[string]any{"type": "divider"}},
+		ThreadTS:       "1699999999.000001",
+		ReplyBroadcast: true,
+		UnfurlLinks:    &noUnfurl,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1700000000.000100", out["ts"])
+	assert.Equal(t, "C1", got["channel"])
+	assert.Equal(t, "1699999999.000001", got["thread_ts"])
+	assert.Equal(t, true, got["reply_broadcast"])
+	assert.Equal(t, false, got["unfurl_links"])
+	assert.NotContains(t, got, "unfurl_media")
+	assert.Len(t, got["blocks"], 1)
+}
+
+func TestSlackErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		body       string
+		wantDelay  time.Duration
+		wantErr    string
+	}{
+		{name: "429 with Retry-After", status: http.StatusTooManyRequests, retryAfter: "7", wantDelay: 7 * time.Second},
+		{name: "429 without Retry-After", status: http.StatusTooManyRequests, wantDelay: 30 * time.Second},
+		{name: "ratelimited body", status: http.StatusOK, retryAfter: "3", body: `{"ok":false,"error":"ratelimited"}`, wantDelay: 3 * time.Second},
+		{name: "api error", status: http.StatusOK, body: `{"ok":false,"error":"channel_not_found"}`, wantErr: "slack api error: channel_not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := newSlackServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			_, err := slack.DeleteMessage(ctx, "xoxb-1", "C1", "1.2")
+			require.Error(t, err)
+			var rateLimited *slack.RateLimitError
+			if tt.wantDelay > 0 {
+				require.True(t, errors.As(err, &rateLimited))
+				assert.Equal(t, "chat.delete", rateLimited.Method)
+				assert.Equal(t, tt.wantDelay, rateLimited.RetryDelay())
+				return
+			}
+			assert.False(t, errors.As(err, &rateLimited))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSlackListChannelsFollowsCursor(t *testing.T) {
+	var cursors []string
+	ctx, _ := newSlackServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		cursors = append(cursors, r.PostForm.Get("cursor"))
+		assert.Equal(t, "true", r.PostForm.Get("exclude_archived"))
+		if r.PostForm.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C1"}],"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C2"}],"response_metadata":{"next_cursor":""}}`))
+	})
+
+	t.Run("single page returns the cursor", func(t *testing.T) {
+		cursors = nil
+		out, err := slack.ListChannels(ctx, "xoxb-1", slack.ListChannelsOptions{ExcludeArchived: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, out["count"])
+		assert.Equal(t, "page2", out["next_cursor"])
+		assert.Equal(t, []string{""}, cursors)
+	})
+
+	t.Run("all pages", func(t *testing.T) {
+		cursors = nil
+		out, err := slack.ListChannels(ctx, "xoxb-1", slack.ListChannelsOptions{ExcludeArchived: true, All: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, out["count"])
+		assert.Equal(t, "", out["next_cursor"])
+		assert.Equal(t, []string{"", "page2"}, cursors)
+	})
+}
+
+func TestSlackUploadFile(t *testing.T) {
+	var uploaded []byte
+	var completed map[string]any
+	var srv *httptest.Server
+	var ctx context.Context
+	ctx, srv = newSlackServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files.getUploadURLExternal":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "report.csv", r.PostForm.Get("filename"))
+			assert.Equal(t, "7", r.PostForm.Get("length"))
+			_, _ = w.Write([]byte(`{"ok":true,"upload_url":"` + srv.URL + `/upload/F1","file_id":"F1"}`))
+		case "/upload/F1":
+			assert.Empty(t, r.Header.Get("Authorization"))
+			uploaded, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte("OK"))
+		case "/files.completeUploadExternal":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&completed))
+			_, _ = w.Write([]byte(`{"ok":true,"files":[{"id":"F1"}]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	out, err := slack.UploadFile(ctx, "xoxb-1", slack.FileUpload{
+		Filename:  "report.csv",
+		Content:   []byte("a,b\n1,2"),
+		ChannelID: "C1",
+		ThreadTS:  "1.2",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "F1", out["file_id"])
+	assert.Equal(t, "a,b\n1,2", string(uploaded))
+	assert.Equal(t, "C1", completed["channel_id"])
+	assert.Equal(t, "1.2", completed["thread_ts"])
+	assert.Equal(t, []any{map[string]any{"id": "F1", "title": "report.csv"}}, completed["files"])
+}
@@ -0,0 +1,143 @@
+package external_test
+
+import (
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/sqldb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLQuoteIdent(t *testing.T) {
+	q, err := sqldb.Postgres.QuoteIdent(`public.my "table"`)
+	require.NoError(t, err)
+	assert.Equal(t, `"public"."my ""table"""`, q)
+
+	q, err = sqldb.MySQL.QuoteIdent("shop.order`s")
+	require.NoError(t, err)
+	assert.Equal(t, "`shop`.`order``s`", q)
+
+	_, err = sqldb.Postgres.QuoteIdent("a..b")
+	assert.Error(t, err)
+	_, err = sqldb.Postgres.QuoteIdent(" ")
+	assert.Error(t, err)
+}
+
+func TestSQLSelectStatement(t *testing.T) {
+	query, args, err := sqldb.Postgres.SelectStatement(sqldb.SelectInput{
+		Table:   "users",
+		Columns: []string{"id", "email"},
+		Where:   map[string]any{"status": "active", "deleted_at": nil, "org_id": float64(7)},
+		OrderBy: "created_at desc, id",
+		Limit:   50,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT "id", "email" FROM "users" WHERE "deleted_at" IS NULL AND "org_id" = $1 AND "status" = $2 ORDER BY "created_at" DESC, "id" LIMIT 50`, query)
+	assert.Equal(t, []any{int64(7), "active"}, args)
+
+	query, _, err = sqldb.MySQL.SelectStatement(sqldb.SelectInput{Table: "users", Where: map[string]any{"id": 1}})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `users` WHERE `id` = ?", query)
+}
+
+func TestSQLInsertStatement(t *testing.T) {
+	rows := []map[string]any{
+		{"id": float64(1), "name": "Ada", "tags": []any{"a"}},
+		{"id": float64(2), "name": "Grace"},
+	}
+	columns := sqldb.RowColumns(nil, rows)
+	assert.Equal(t, []string{"id", "name", "tags"}, columns)
+
+	query, args, err := sqldb.Postgres.InsertStatement("people", columns, rows, nil)
+	require.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "people" ("id", "name", "tags") VALUES ($1, $2, $3), ($4, $5, $6)`, query)
+	assert.Equal(t, []any{int64(1), "Ada", `["a"]`, int64(2), "Grace", nil}, args)
+
+	query, _, err = sqldb.Postgres.InsertStatement("people", columns, rows, []string{"id"})
+	require.NoError(t, err)
+	assert.Contains(t, query, `ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "tags" = EXCLUDED."tags"`)
+
+	query, _, err = sqldb.MySQL.InsertStatement("people", columns, rows, []string{"id"})
+	require.NoError(t, err)
+	assert.Contains(t, query, "VALUES (?, ?, ?), (?, ?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`), `tags` = VALUES(`tags`)")
+
+	query, _, err = sqldb.Postgres.InsertStatement("people", []string{"id"}, rows, []string{"id"})
+	require.NoError(t, err)
+	assert.Contains(t, query, `ON CONFLICT ("id") DO NOTHING`)
+
+	_, _, err = sqldb.Postgres.InsertStatement("people", columns, nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 500, sqldb.BatchSize(3))
+	assert.Equal(t, 327, sqldb.BatchSize(200))
+}
+
+func TestSQLReturnsRows(t *testing.T) {
+	for query, want := range map[string]bool{
+		"select 1": true,
+		"  -- report\n/* x */ WITH t AS (SELECT 1) SELECT * FROM t": true,
+		"(SELECT 1) UNION (SELECT 2)":                               true,
+		"SHOW TABLES":                                               true,
+		"INSERT INTO t (a) VALUES (1) RETURNING id":                 true,
+		"INSERT INTO t (a) VALUES (1)":                              false,
+		"update t set returning_customer = true":                    false,
+		"DELETE FROM t":                                             false,
+	} {
+		assert.Equal(t, want, sqldb.ReturnsRows(query), query)
+	}
+}
+
+func TestSQLConvertValue(t *testing.T) {
+	assert.Equal(t, int64(42), sqldb.ConvertValue([]byte("42"), "BIGINT"))
+	assert.Equal(t, 1.5, sqldb.ConvertValue([]byte("1.5"), "DOUBLE"))
+	assert.Equal(t, "12.30", sqldb.ConvertValue([]byte("12.30"), "DECIMAL"))
+	assert.Equal(t, map[string]any{"a": float64(1)}, sqldb.ConvertValue(`{"a":1}`, "JSONB"))
+	assert.Equal(t, "AAE=", sqldb.ConvertValue([]byte{0, 1}, "BYTEA"))
+	assert.Equal(t, "héllo", sqldb.ConvertValue([]byte("héllo"), "VARCHAR"))
+	assert.Equal(t, "2026-07-01T10:00:00Z", sqldb.ConvertValue(time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC), "TIMESTAMPTZ"))
+	assert.Nil(t, sqldb.ConvertValue(nil, "TEXT"))
+	assert.Equal(t, true, sqldb.ConvertValue(true, "BOOL"))
+}
+
+func TestSQLConfigFromPayload(t *testing.T) {
+	cfg, err := sqldb.ConfigFromPayload("mysql", map[string]any{"host": "db", "username": "app", "read_only": true})
+	require.NoError(t, err)
+	assert.Equal(t, sqldb.MySQL, cfg.Dialect)
+	assert.Equal(t, 3306, cfg.Port)
+	assert.Equal(t, "app", cfg.User)
+	assert.Equal(t, sqldb.TLSPrefer, cfg.TLSMode)
+	assert.True(t, cfg.ReadOnly)
+
+	cfg, err = sqldb.ConfigFromPayload("postgres", map[string]any{"host": "db", "user": "app", "port": "6543", "tls_mode": "verify-full"})
+	require.NoError(t, err)
+	assert.Equal(t, 6543, cfg.Port)
+	db, err := sqldb.Open(cfg)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = sqldb.ConfigFromPayload("postgres", map[string]any{"host": "db", "user": "app", "tls_mode": "allow"})
+	assert.ErrorContains(t, err, "tls_mode")
+	_, err = sqldb.ConfigFromPayload("oracle", map[string]any{"host": "db"})
+	assert.Error(t, err)
+}
+
+func TestSQLPoolsReuseAndReplace(t *testing.T) {
+	pools := sqldb.NewPools()
+	defer pools.Close()
+	cfg := sqldb.Config{Dialect: sqldb.Postgres, Host: "db", Port: 5432, User: "app", TLSMode: sqldb.TLSDisable}
+
+	first, release, err := pools.Acquire("cred-1", cfg)
+	require.NoError(t, err)
+	release()
+	again, release, err := pools.Acquire("cred-1", cfg)
+	require.NoError(t, err)
+	release()
+	assert.Same(t, first, again)
+
+	cfg.Password = "rotated"
+	replaced, release, err := pools.Acquire("cred-1", cfg)
+	require.NoError(t, err)
+	release()
+	assert.NotSame(t, first, replaced)
+}
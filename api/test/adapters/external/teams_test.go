@@ -0,0 +1,68 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flowcraft-api/internal/adapters/external/teams"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamsCardMessage(t *testing.T) {
+	card := teams.TextCard("Build failed", "main is red")
+	msg, err := teams.CardMessage(card)
+	require.NoError(t, err)
+	attachments := msg["attachments"].([]any)
+	require.Len(t, attachments, 1)
+	attachment := attachments[0].(map[string]any)
+	assert.Equal(t, teams.AdaptiveCardContentType, attachment["contentType"])
+	assert.Len(t, attachment["content"].(map[string]any)["body"], 2)
+
+	passthrough := map[string]any{"type": "message", "attachments": []any{}}
+	msg, err = teams.CardMessage(passthrough)
+	require.NoError(t, err)
+	assert.Equal(t, passthrough, msg)
+
+	_, err = teams.CardMessage(map[string]any{"text": "hi"})
+	assert.Error(t, err)
+}
+
+func TestTeamsPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "incoming webhook", status: http.StatusOK, body: "1"},
+		{name: "workflow", status: http.StatusAccepted},
+		{name: "legacy error text", status: http.StatusOK, body: "Microsoft Teams endpoint returned HTTP error 413", wantErr: "HTTP error 413"},
+		{name: "bad request", status: http.StatusBadRequest, body: "Bad payload", wantErr: "Bad payload"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "message", body["type"])
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			msg, _ := teams.CardMessage(teams.TextCard("", "hello"))
+			out, err := teams.Post(context.Background(), srv.URL, msg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, true, out["delivered"])
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/endpoints"
+	"flowcraft-api/internal/adapters/external/telegram"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramSendMessage(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bot123:abc/sendMessage", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":7,"chat":{"id":-100}}}`))
+	}))
+	defer srv.Close()
+	ctx := endpoints.WithAPIURL(context.Background(), "telegram", srv.URL)
+
+	keyboard := map[string]any{"inline_keyboard": []any{[]any{map[string]any{"text": "Ack", "callback_data": "ack"}}}}
+	out, err := telegram.SendMessage(ctx, "123:abc", telegram.Message{
+		ChatID:           "-100",
+		Text:             "*disk full*",
+		ParseMode:        "markdownv2",
+		ReplyMarkup:      keyboard,
+		ReplyToMessageID: 5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, float64(7), out["message_id"])
+	assert.Equal(t, "MarkdownV2", body["parse_mode"])
+	assert.Equal(t, "-100", body["chat_id"])
+	assert.Equal(t, "ack", body["reply_markup"].(map[string]any)["inline_keyboard"].([]any)[0].([]any)[0].(map[string]any)["callback_data"])
+	assert.Equal(t, float64(5), body["reply_parameters"].(map[string]any)["message_id"])
+
+	_, err = telegram.SendMessage(ctx, "123:abc", telegram.Message{ChatID: "1", Text: "x", ParseMode: "rtf"})
+	assert.ErrorContains(t, err, "parse mode")
+	_, err = telegram.SendMessage(ctx, "123:abc", telegram.Message{ChatID: "1", Text: strings.Repeat("x", 4097)})
+	assert.ErrorContains(t, err, "Telegram allows 4096")
+}
+
+func TestTelegramSendPhotoUploadsContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bottok/sendPhoto", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, "42", r.FormValue("chat_id"))
+		assert.Equal(t, "<b>graph</b>", r.FormValue("caption"))
+		assert.Equal(t, "HTML", r.FormValue("parse_mode"))
+		assert.JSONEq(t, `{"inline_keyboard":[]}`, r.FormValue("reply_markup"))
+		file, header, err := r.FormFile("photo")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "cpu.png", header.Filename)
+		assert.Equal(t, "image/png", header.Header.Get("Content-Type"))
+		assert.Equal(t, []byte{0x89, 'P', 'N', 'G'}, content)
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":8}}`))
+	}))
+	defer srv.Close()
+	ctx := endpoints.WithAPIURL(context.Background(), "telegram", srv.URL)
+
+	out, err := telegram.SendPhoto(ctx, "tok", telegram.File{
+		ChatID:      "42",
+		Filename:    "cpu.png",
+		MimeType:    "image/png",
+		Content:     []byte{0x89, 'P', 'N', 'G'},
+		Caption:     "<b>graph</b>",
+		ParseMode:   "html",
+		ReplyMarkup: map[string]any{"inline_keyboard": []any{}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, float64(8), out["message_id"])
+
+	_, err = telegram.SendDocument(ctx, "tok", telegram.File{ChatID: "42"})
+	assert.ErrorContains(t, err, "document content is required")
+}
+
+func TestTelegramWebhookAndCallbackActions(t *testing.T) {
+	var methods []string
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, strings.TrimPrefix(r.URL.Path, "/bottok/"))
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+	ctx := endpoints.WithAPIURL(context.Background(), "telegram", srv.URL)
+
+	require.NoError(t, telegram.SetWebhook(ctx, "tok", telegram.Webhook{
+		URL:            "https://flows.example.com/api/v1/webhook/f1/telegram/n1",
+		SecretToken:    "s3cret",
+		AllowedUpdates: []string{"message", "callback_query"},
+	}))
+	out, err := telegram.AnswerCallbackQuery(ctx, "tok", telegram.CallbackAnswer{CallbackQueryID: "cb1", Text: "Acknowledged", ShowAlert: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"ok": true}, out)
+	_, err = telegram.EditMessageText(ctx, "tok", telegram.Edit{ChatID: "42", MessageID: 8, Text: "resolved"})
+	require.NoError(t, err)
+	require.NoError(t, telegram.DeleteWebhook(ctx, "tok"))
+
+	assert.Equal(t, []string{"setWebhook", "answerCallbackQuery", "editMessageText", "deleteWebhook"}, methods)
+	assert.Equal(t, "s3cret", bodies[0]["secret_token"])
+	assert.Equal(t, []any{"message", "callback_query"}, bodies[0]["allowed_updates"])
+	assert.Equal(t, true, bodies[1]["show_alert"])
+	assert.Equal(t, float64(8), bodies[2]["message_id"])
+
+	_, err = telegram.EditMessageText(ctx, "tok", telegram.Edit{ChatID: "42", Text: "resolved"})
+	assert.ErrorContains(t, err, "message ID")
+}
+
+func TestTelegramErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/getMe") {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":401,"description":"Unauthorized"}`))
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 3","parameters":{"retry_after":3}}`))
+	}))
+	defer srv.Close()
+	ctx := endpoints.WithAPIURL(context.Background(), "telegram", srv.URL)
+
+	_, err := telegram.SendMessage(ctx, "123:secret", telegram.Message{ChatID: "1", Text: "hi"})
+	var rateLimited *telegram.RateLimitError
+	require.True(t, errors.As(err, &rateLimited))
+	assert.Equal(t, 3*time.Second, rateLimited.RetryDelay())
+
+	_, err = telegram.GetMe(ctx, "123:secret")
+	assert.True(t, telegram.IsUnauthorized(err))
+	assert.NotContains(t, err.Error(), "secret")
+
+	// Transport errors carry the request URL, which holds the token.
+	down := endpoints.WithAPIURL(context.Background(), "telegram", "http://127.0.0.1:1")
+	_, err = telegram.GetMe(down, "123:secret")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestTelegramBotToken(t *testing.T) {
+	assert.Equal(t, "a", telegram.BotToken(map[string]any{"bot_token": " a "}))
+	assert.Equal(t, "b", telegram.BotToken(map[string]any{"token": "b"}))
+	assert.Empty(t, telegram.BotToken(nil))
+}
@@ -0,0 +1,95 @@
+package httpadapter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpadapter "flowcraft-api/internal/adapters/http"
+	"flowcraft-api/internal/config"
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/egress"
+)
+
+func TestNodeTestHandler_AppliesProjectEgressRules(t *testing.T) {
+	// Loopback is the test server; allow it deployment-wide so only the
+	// project rules decide.
+	saved := http.DefaultTransport
+	egress.Install(egress.Policy{AllowPrivate: true, MaxRedirects: 5})
+	t.Cleanup(func() { http.DefaultTransport = saved })
+
+	hits := 0
+	model := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"OK"}}]}`))
+	}))
+	defer model.Close()
+
+	flows := &mocks.MockFlowRepository{
+		GetFunc: func(ctx context.Context, id string) (*domain.Flow, error) {
+			return &domain.Flow{ID: id, Scope: "project", ProjectID: "p1"}, nil
+		},
+	}
+	members := &mocks.MockProjectMemberRepository{
+		GetRoleFunc: func(ctx context.Context, projectID string, userID string) (string, error) {
+			return "editor", nil
+		},
+	}
+	var deny []string
+	projects := &mocks.MockProjectRepository{
+		GetEgressPolicyFunc: func(ctx context.Context, projectID string) (domain.EgressPolicy, error) {
+			return domain.EgressPolicy{DenyHosts: deny}, nil
+		},
+	}
+	handler := httpadapter.NewNodeTestHandler(
+		nil,
+		services.NewFlowService(flows, members),
+		services.NewProjectService(projects, members, &mocks.MockUserRepository{}, flows),
+		config.Config{},
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	group := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("authUser", domain.AuthUser{ID: "user-1"})
+	})
+	handler.Register(group)
+
+	test := func() map[string]any {
+		body, _ := json.Marshal(map[string]any{
+			"kind":           "agent-model",
+			"flowId":         "flow-1",
+			"provider":       "custom",
+			"model":          "m",
+			"apiKeyOverride": "sk-test",
+			"baseUrl":        model.URL,
+		})
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/nodes/test", bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var env struct {
+			Data map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &env))
+		return env.Data
+	}
+
+	result := test()
+	assert.Equal(t, true, result["success"], result["message"])
+	assert.Equal(t, 1, hits)
+
+	deny = []string{"127.0.0.1"}
+	result = test()
+	assert.Equal(t, false, result["success"])
+	assert.Contains(t, result["message"], "egress blocked")
+	assert.Equal(t, 1, hits, "the denied host gets no request")
+}
@@ -0,0 +1,33 @@
+package services_test
+
+import (
+	"testing"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialUsableByFlow(t *testing.T) {
+	flow := domain.Flow{ID: "f1", ProjectID: "p1", OwnerUserID: "u1", CreatedBy: "u2"}
+	tests := []struct {
+		name string
+		cred domain.Credential
+		flow domain.Flow
+		want bool
+	}{
+		{"project credential", domain.Credential{ProjectID: "p1", UserID: "u9"}, flow, true},
+		{"other project", domain.Credential{ProjectID: "p2", UserID: "u1"}, flow, false},
+		{"owner's credential", domain.Credential{UserID: "u1"}, flow, true},
+		{"creator is not the owner", domain.Credential{UserID: "u2"}, flow, false},
+		{"another user's credential", domain.Credential{UserID: "u3"}, flow, false},
+		{"creator without owner", domain.Credential{UserID: "u2"}, domain.Flow{CreatedBy: "u2"}, true},
+		{"flow without owner", domain.Credential{UserID: ""}, domain.Flow{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, services.CredentialUsableByFlow(tt.cred, tt.flow))
+		})
+	}
+}
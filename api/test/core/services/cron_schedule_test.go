@@ -0,0 +1,179 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cronFlowDefinition = `{"reactflow":{"nodes":[
+	{"id":"c1","type":"flowNode","data":{"nodeType":"cron","config":{"expression":"0 9 * * *","timezone":"Europe/Berlin"}}},
+	{"id":"c2","type":"cron","data":{"config":{"expression":"@every 15m","misfirePolicy":"runAll","misfireMaxRuns":500}}},
+	{"id":"c3","type":"flowNode","data":{"nodeType":"cron","config":{"expression":"  "}}},
+	{"id":"h1","type":"flowNode","data":{"nodeType":"httpRequest","config":{"expression":"0 9 * * *"}}}
+]}}`
+
+func TestCronTriggers(t *testing.T) {
+	triggers := services.CronTriggers(cronFlowDefinition)
+	assert.Equal(t, []domain.CronTrigger{
+		{NodeID: "c1", Expression: "0 9 * * *", Timezone: "Europe/Berlin", MisfirePolicy: services.MisfireRunOnce, MisfireMaxRuns: 10},
+		{NodeID: "c2", Expression: "@every 15m", MisfirePolicy: services.MisfireRunAll, MisfireMaxRuns: 100},
+	}, triggers)
+	assert.Empty(t, services.CronTriggers("not json"))
+}
+
+func TestParseCronSchedule(t *testing.T) {
+	from := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		trigger domain.CronTrigger
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "standard cron in UTC by default",
+			trigger: domain.CronTrigger{Expression: "30 8 * * *"},
+			want:    []string{"2026-03-02T08:30:00Z", "2026-03-03T08:30:00Z"},
+		},
+		{
+			name:    "timezone shifts wall clock",
+			trigger: domain.CronTrigger{Expression: "0 9 * * *", Timezone: "America/New_York"},
+			want:    []string{"2026-03-01T09:00:00-05:00", "2026-03-02T09:00:00-05:00"},
+		},
+		{
+			name:    "six fields include seconds",
+			trigger: domain.CronTrigger{Expression: "15 0 12 * * *"},
+			want:    []string{"2026-03-01T12:00:15Z", "2026-03-02T12:00:15Z"},
+		},
+		{
+			name:    "descriptor",
+			trigger: domain.CronTrigger{Expression: "@hourly", Timezone: "UTC"},
+			want:    []string{"2026-03-01T13:00:00Z", "2026-03-01T14:00:00Z"},
+		},
+		{name: "bad expression", trigger: domain.CronTrigger{Expression: "61 * * * *"}, wantErr: "invalid expression"},
+		{name: "bad timezone", trigger: domain.CronTrigger{Expression: "* * * * *", Timezone: "Mars/Olympus"}, wantErr: "unknown timezone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := services.ParseCronSchedule(tt.trigger)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got := []string{}
+			for _, next := range services.NextFireTimes(schedule, from, len(tt.want)) {
+				got = append(got, next.Format(time.RFC3339))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMissedFireTimes(t *testing.T) {
+	hourly := domain.CronTrigger{Expression: "0 * * * *"}
+	schedule, err := services.ParseCronSchedule(hourly)
+	require.NoError(t, err)
+
+	lastFired := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return time.Date(2026, 3, 1, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		policy   string
+		maxRuns  int
+		lastFire time.Time
+		wantRuns []time.Time
+		wantLast time.Time
+	}{
+		{name: "skip advances without runs", policy: services.MisfireSkip, lastFire: lastFired, wantLast: at(10)},
+		{name: "run once runs the latest", policy: services.MisfireRunOnce, lastFire: lastFired, wantRuns: []time.Time{at(10)}, wantLast: at(10)},
+		{name: "run all", policy: services.MisfireRunAll, maxRuns: 10, lastFire: lastFired, wantRuns: []time.Time{at(7), at(8), at(9), at(10)}, wantLast: at(10)},
+		{name: "run all keeps the most recent up to the cap", policy: services.MisfireRunAll, maxRuns: 2, lastFire: lastFired, wantRuns: []time.Time{at(9), at(10)}, wantLast: at(10)},
+		{name: "nothing missed", policy: services.MisfireRunAll, maxRuns: 10, lastFire: at(10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := hourly
+			trigger.MisfirePolicy = tt.policy
+			trigger.MisfireMaxRuns = tt.maxRuns
+			runs, latest := services.MissedFireTimes(schedule, trigger, tt.lastFire, until)
+			require.Len(t, runs, len(tt.wantRuns))
+			for i := range runs {
+				assert.True(t, tt.wantRuns[i].Equal(runs[i]), "run %d: want %s, got %s", i, tt.wantRuns[i], runs[i])
+			}
+			assert.True(t, tt.wantLast.Equal(latest), "latest: want %s, got %s", tt.wantLast, latest)
+		})
+	}
+}
+
+func TestFireTimeAt(t *testing.T) {
+	hourly, err := services.ParseCronSchedule(domain.CronTrigger{Expression: "0 * * * *"})
+	require.NoError(t, err)
+	tick := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.True(t, tick.Equal(services.FireTimeAt(hourly, tick, time.Minute)), "on time")
+	assert.True(t, tick.Equal(services.FireTimeAt(hourly, tick.Add(1500*time.Millisecond), time.Minute)), "late job keeps its tick")
+	assert.True(t, tick.Equal(services.FireTimeAt(hourly, tick.Add(59*time.Second), time.Minute)), "late within grace")
+
+	everyDay, err := services.ParseCronSchedule(domain.CronTrigger{Expression: "@every 24h"})
+	require.NoError(t, err)
+	now := tick.Add(1500 * time.Millisecond)
+	assert.True(t, now.Truncate(time.Second).Equal(services.FireTimeAt(everyDay, now, time.Minute)), "no tick in window falls back to the clock")
+}
+
+func TestFireTimeAt_EveryIsAnchored(t *testing.T) {
+	every, err := services.ParseCronSchedule(domain.CronTrigger{Expression: "@every 5m"})
+	require.NoError(t, err)
+	tick := time.Date(2026, 3, 1, 9, 5, 0, 0, time.UTC)
+
+	// Two schedulers overlapping in a handover see different clocks.
+	first := services.FireTimeAt(every, tick.Add(300*time.Millisecond), time.Minute)
+	second := services.FireTimeAt(every, tick.Add(40*time.Second), time.Minute)
+	assert.True(t, tick.Equal(first), "got %s", first)
+	assert.True(t, tick.Equal(second), "got %s", second)
+	assert.True(t, tick.Add(5*time.Minute).Equal(every.Next(tick)))
+}
+
+func TestFlowService_RejectsInvalidCron(t *testing.T) {
+	invalid := `{"reactflow":{"nodes":[{"id":"c1","type":"flowNode","data":{"nodeType":"cron","config":{"expression":"every day"}}}]}}`
+	user := domain.AuthUser{ID: "user-1"}
+
+	mFlow := &mocks.MockFlowRepository{}
+	mFlow.GetFunc = func(ctx context.Context, id string) (*domain.Flow, error) {
+		return &domain.Flow{ID: id, Scope: "personal", OwnerUserID: user.ID}, nil
+	}
+	saved := 0
+	mFlow.CreateFunc = func(ctx context.Context, f domain.Flow) error {
+		saved++
+		return nil
+	}
+	mFlow.UpdateFunc = func(ctx context.Context, f domain.Flow) error {
+		saved++
+		return nil
+	}
+	svc := services.NewFlowService(mFlow, nil)
+
+	_, err := svc.Create(context.Background(), domain.Flow{Name: "bad", DefinitionJSON: invalid})
+	assert.ErrorIs(t, err, services.ErrInvalidFlowDefinition)
+
+	err = svc.UpdateAccessible(context.Background(), user, domain.Flow{ID: "flow-1", DefinitionJSON: invalid})
+	assert.ErrorIs(t, err, services.ErrInvalidFlowDefinition)
+	assert.Zero(t, saved)
+
+	_, err = svc.Create(context.Background(), domain.Flow{Name: "ok", DefinitionJSON: cronFlowDefinition})
+	require.NoError(t, err)
+	assert.Equal(t, 1, saved)
+}
@@ -0,0 +1,161 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const activatableDefinition = `{"reactflow":{"nodes":[{"id":"c1","type":"flowNode","data":{"nodeType":"cron","config":{"expression":"0 9 * * *"}}}]}}`
+
+func TestFlowActive(t *testing.T) {
+	assert.True(t, services.FlowActive(domain.Flow{Status: "active"}))
+	assert.False(t, services.FlowActive(domain.Flow{Status: "draft"}))
+	assert.False(t, services.FlowActive(domain.Flow{Status: "inactive"}))
+	assert.False(t, services.FlowActive(domain.Flow{Status: "archived"}))
+}
+
+func TestValidateForActivation(t *testing.T) {
+	require.NoError(t, services.ValidateForActivation(activatableDefinition))
+
+	for name, def := range map[string]string{
+		"invalid json": `{`,
+		"no nodes":     `{}`,
+		"bad cron":     `{"reactflow":{"nodes":[{"id":"c1","type":"cron","data":{"config":{"expression":"every day"}}}]}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, errors.Is(services.ValidateForActivation(def), services.ErrInvalidFlowDefinition))
+		})
+	}
+}
+
+func TestFlowService_Activate(t *testing.T) {
+	user := domain.AuthUser{ID: "user-1"}
+
+	newRepo := func(flow domain.Flow, setStatus *string) *mocks.MockFlowRepository {
+		return &mocks.MockFlowRepository{
+			GetFunc: func(ctx context.Context, id string) (*domain.Flow, error) {
+				f := flow
+				if *setStatus != "" {
+					f.Status = *setStatus
+				}
+				return &f, nil
+			},
+			SetStatusFunc: func(ctx context.Context, id string, status string, updatedBy string) error {
+				assert.Equal(t, "user-1", updatedBy)
+				*setStatus = status
+				return nil
+			},
+		}
+	}
+
+	t.Run("activates a valid flow", func(t *testing.T) {
+		var status string
+		repo := newRepo(domain.Flow{ID: "flow-1", OwnerUserID: "user-1", Status: "draft", DefinitionJSON: activatableDefinition}, &status)
+		flow, err := services.NewFlowService(repo, nil).Activate(context.Background(), user, "flow-1")
+		require.NoError(t, err)
+		assert.Equal(t, "active", status)
+		assert.Equal(t, "active", flow.Status)
+	})
+
+	t.Run("rejects an invalid definition", func(t *testing.T) {
+		var status string
+		repo := newRepo(domain.Flow{ID: "flow-1", OwnerUserID: "user-1", Status: "draft", DefinitionJSON: `{}`}, &status)
+		_, err := services.NewFlowService(repo, nil).Activate(context.Background(), user, "flow-1")
+		assert.True(t, errors.Is(err, services.ErrInvalidFlowDefinition))
+		assert.Empty(t, status)
+	})
+
+	t.Run("rejects archived flows", func(t *testing.T) {
+		var status string
+		repo := newRepo(domain.Flow{ID: "flow-1", OwnerUserID: "user-1", Status: "archived", DefinitionJSON: activatableDefinition}, &status)
+		_, err := services.NewFlowService(repo, nil).Deactivate(context.Background(), user, "flow-1")
+		assert.ErrorIs(t, err, services.ErrFlowArchived)
+		assert.Empty(t, status)
+	})
+
+	t.Run("deactivates without validation", func(t *testing.T) {
+		var status string
+		repo := newRepo(domain.Flow{ID: "flow-1", OwnerUserID: "user-1", Status: "active", DefinitionJSON: `{}`}, &status)
+		flow, err := services.NewFlowService(repo, nil).Deactivate(context.Background(), user, "flow-1")
+		require.NoError(t, err)
+		assert.Equal(t, "inactive", flow.Status)
+	})
+}
+
+func TestFlowService_ReactivationDoesNotCatchUp(t *testing.T) {
+	user := domain.AuthUser{ID: "user-1"}
+	flow := domain.Flow{ID: "flow-1", OwnerUserID: "user-1", Status: "active", DefinitionJSON: activatableDefinition}
+	flows := &mocks.MockFlowRepository{
+		GetFunc: func(ctx context.Context, id string) (*domain.Flow, error) {
+			f := flow
+			return &f, nil
+		},
+		SetStatusFunc: func(ctx context.Context, id string, status string, updatedBy string) error {
+			flow.Status = status
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, f domain.Flow) error {
+			flow = f
+			return nil
+		},
+	}
+	fires := map[string]time.Time{}
+	schedules := &mocks.MockScheduleRepository{
+		ListLastFiredFunc: func(ctx context.Context) (map[string]time.Time, error) { return fires, nil },
+		ClearFiresFunc: func(ctx context.Context, flowID string) error {
+			delete(fires, flowID+"/c1")
+			return nil
+		},
+	}
+	svc := services.NewFlowService(flows, nil)
+	svc.AddTriggerRegistrar(services.NewScheduleService(schedules))
+
+	// The 09:00 tick fired, then the flow was turned off for three days.
+	fires["flow-1/c1"] = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	_, err := svc.Deactivate(context.Background(), user, "flow-1")
+	require.NoError(t, err)
+	assert.Contains(t, fires, "flow-1/c1", "deactivation keeps the history")
+
+	_, err = svc.Activate(context.Background(), user, "flow-1")
+	require.NoError(t, err)
+	last, err := schedules.ListLastFired(context.Background())
+	require.NoError(t, err)
+	assert.NotContains(t, last, "flow-1/c1", "activation starts from a fresh baseline")
+
+	// Reactivating by saving the flow as active clears it too.
+	fires["flow-1/c1"] = time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	_, err = svc.Deactivate(context.Background(), user, "flow-1")
+	require.NoError(t, err)
+	saved := flow
+	saved.Status = "active"
+	require.NoError(t, svc.UpdateAccessible(context.Background(), user, saved))
+	assert.NotContains(t, fires, "flow-1/c1")
+
+	// Saving an active flow keeps the history.
+	fires["flow-1/c1"] = time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, svc.UpdateAccessible(context.Background(), user, flow))
+	assert.Contains(t, fires, "flow-1/c1")
+}
+
+func TestFlowService_CreateValidatesStatus(t *testing.T) {
+	svc := services.NewFlowService(&mocks.MockFlowRepository{}, nil)
+
+	_, err := svc.Create(context.Background(), domain.Flow{Name: "x", Status: "published"})
+	assert.ErrorIs(t, err, services.ErrInvalidFlowStatus)
+
+	_, err = svc.Create(context.Background(), domain.Flow{Name: "x", Status: "active"})
+	assert.ErrorIs(t, err, services.ErrInvalidFlowDefinition)
+
+	created, err := svc.Create(context.Background(), domain.Flow{Name: "x", Status: "active", DefinitionJSON: activatableDefinition})
+	require.NoError(t, err)
+	assert.Equal(t, "active", created.Status)
+}
@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowEventTriggers(t *testing.T) {
+	def := `{"reactflow":{"nodes":[
+		{"id":"e1","type":"flowNode","data":{"nodeType":"flowEvent","config":{"flowId":"flow-a","status":"failed"}}},
+		{"id":"e2","type":"flowEvent","data":{"config":{"flowId":"flow-b"}}},
+		{"id":"e3","type":"flowNode","data":{"nodeType":"flowEvent","config":{}}},
+		{"id":"h1","type":"flowNode","data":{"nodeType":"httpRequest","config":{"flowId":"flow-a"}}}
+	]}}`
+
+	assert.Equal(t, []domain.FlowEventTrigger{
+		{NodeID: "e1", FlowID: "flow-a", Status: services.FlowEventFailed},
+		{NodeID: "e2", FlowID: "flow-b", Status: services.FlowEventSuccess},
+	}, services.FlowEventTriggers(def))
+	assert.Empty(t, services.FlowEventTriggers("not json"))
+
+	require.NoError(t, services.ValidateFlowEventTriggers(def))
+	err := services.ValidateFlowEventTriggers(`{"reactflow":{"nodes":[{"id":"e1","type":"flowEvent","data":{"config":{"flowId":"x","status":"done"}}}]}}`)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, services.ErrInvalidFlowDefinition))
+}
+
+func TestFlowEventMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		trigger domain.FlowEventTrigger
+		flowID  string
+		status  string
+		want    bool
+	}{
+		{"success on success", domain.FlowEventTrigger{FlowID: "a", Status: "success"}, "a", "success", true},
+		{"success on failed", domain.FlowEventTrigger{FlowID: "a", Status: "success"}, "a", "failed", false},
+		{"failed on failed", domain.FlowEventTrigger{FlowID: "a", Status: "failed"}, "a", "failed", true},
+		{"any on canceled", domain.FlowEventTrigger{FlowID: "a", Status: "any"}, "a", "canceled", true},
+		{"any on running", domain.FlowEventTrigger{FlowID: "a", Status: "any"}, "a", "running", false},
+		{"other flow", domain.FlowEventTrigger{FlowID: "a", Status: "any"}, "b", "success", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, services.FlowEventMatches(tt.trigger, tt.flowID, tt.status))
+		})
+	}
+}
+
+func TestFlowEventAllowed(t *testing.T) {
+	mine := domain.Flow{ID: "a", Scope: "personal", OwnerUserID: "u1"}
+	alsoMine := domain.Flow{ID: "b", CreatedBy: "u1"}
+	theirs := domain.Flow{ID: "c", Scope: "personal", OwnerUserID: "u2"}
+	project := domain.Flow{ID: "d", Scope: "project", ProjectID: "p1"}
+	sameProject := domain.Flow{ID: "e", Scope: "project", ProjectID: "p1"}
+	otherProject := domain.Flow{ID: "f", Scope: "project", ProjectID: "p2"}
+
+	assert.True(t, services.FlowEventAllowed(mine, alsoMine))
+	assert.False(t, services.FlowEventAllowed(mine, theirs))
+	assert.True(t, services.FlowEventAllowed(project, sameProject))
+	assert.False(t, services.FlowEventAllowed(project, otherProject))
+	assert.False(t, services.FlowEventAllowed(mine, project))
+}
+
+func TestFlowEventChain(t *testing.T) {
+	assert.Equal(t, []string{"a"}, services.FlowEventChain(nil, "a"))
+	assert.Equal(t, []string{"a"}, services.FlowEventChain(json.RawMessage(`{"mode":"webhook","chain":["x"]}`), "a"))
+	assert.Equal(t, []string{"x", "y", "a"}, services.FlowEventChain(json.RawMessage(`{"mode":"flowEvent","chain":["x","y"]}`), "a"))
+}
+
+func TestLastStepOutput(t *testing.T) {
+	early := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	late := early.Add(time.Minute)
+
+	out, ok, err := services.LastStepOutput([]domain.RunStep{
+		{Status: "success", FinishedAt: &late, OutputsJSON: json.RawMessage(`{"status":200,"data":{"n":2}}`)},
+		{Status: "success", FinishedAt: &early, OutputsJSON: json.RawMessage(`{"status":200,"data":{"n":1}}`)},
+		{Status: "failed", FinishedAt: &late, OutputsJSON: json.RawMessage(`{}`)},
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"n": float64(2)}, out)
+
+	_, ok, err = services.LastStepOutput(nil)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
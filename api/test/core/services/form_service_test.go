@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const formFlowDefinition = `{"reactflow":{"nodes":[
+	{"id":"form-1","type":"flowNode","data":{"nodeType":"formTrigger","config":{
+		"title":"Request access",
+		"redirectUrl":"https://example.com/thanks",
+		"credentialId":"cred-1",
+		"fields":[
+			{"name":"name","label":"Full name","required":true},
+			{"name":"email","type":"email","required":"true"},
+			{"name":"seats","type":"number"},
+			{"name":"team","type":"select","options":["Sales","Support"]},
+			{"name":"contract","type":"file"}
+		]}}}
+]}}`
+
+func TestFormTriggers(t *testing.T) {
+	triggers := services.FormTriggers(formFlowDefinition)
+	require.Len(t, triggers, 1)
+	trigger := triggers[0]
+
+	assert.Equal(t, "form-1", trigger.NodeID)
+	assert.Equal(t, "Request access", trigger.Title)
+	assert.Equal(t, "Submit", trigger.ButtonLabel)
+	assert.Equal(t, "cred-1", trigger.CredentialID)
+	assert.Equal(t, "https://example.com/thanks", trigger.RedirectURL)
+	assert.NotEmpty(t, trigger.CompletionMessage)
+	require.Len(t, trigger.Fields, 5)
+	assert.Equal(t, domain.FormField{Name: "name", Label: "Full name", Type: services.FormFieldText, Required: true}, trigger.Fields[0])
+	assert.Equal(t, domain.FormField{Name: "email", Label: "email", Type: services.FormFieldEmail, Required: true}, trigger.Fields[1])
+	assert.Equal(t, []string{"Sales", "Support"}, trigger.Fields[3].Options)
+
+	require.NoError(t, services.ValidateFormTriggers(formFlowDefinition))
+}
+
+func TestValidateFormTriggers(t *testing.T) {
+	tests := []struct {
+		name string
+		def  string
+	}{
+		{"two forms", `{"reactflow":{"nodes":[
+			{"id":"a","type":"formTrigger","data":{"config":{"fields":[{"name":"x"}]}}},
+			{"id":"b","type":"formTrigger","data":{"config":{"fields":[{"name":"x"}]}}}]}}`},
+		{"no fields", `{"reactflow":{"nodes":[{"id":"a","type":"formTrigger","data":{"config":{}}}]}}`},
+		{"reserved name", `{"reactflow":{"nodes":[{"id":"a","type":"formTrigger","data":{"config":{"fields":[{"name":"_password"}]}}}]}}`},
+		{"duplicate name", `{"reactflow":{"nodes":[{"id":"a","type":"formTrigger","data":{"config":{"fields":[{"name":"x"},{"name":"x"}]}}}]}}`},
+		{"unknown type", `{"reactflow":{"nodes":[{"id":"a","type":"formTrigger","data":{"config":{"fields":[{"name":"x","type":"date"}]}}}]}}`},
+		{"select without options", `{"reactflow":{"nodes":[{"id":"a","type":"formTrigger","data":{"config":{"fields":[{"name":"x","type":"select"}]}}}]}}`},
+		{"bad redirect", `{"reactflow":{"nodes":[{"id":"a","type":"formTrigger","data":{"config":{"redirectUrl":"javascript:alert(1)","fields":[{"name":"x"}]}}}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := services.ValidateFormTriggers(tt.def)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, services.ErrInvalidFlowDefinition))
+		})
+	}
+}
+
+func TestValidateSubmission(t *testing.T) {
+	trigger := services.FormTriggers(formFlowDefinition)[0]
+
+	t.Run("valid submission", func(t *testing.T) {
+		values, problems := services.ValidateSubmission(trigger, domain.FormSubmission{
+			Values: map[string][]string{
+				"name":  {" Ada Lovelace "},
+				"email": {"ada@example.com"},
+				"seats": {"3"},
+				"team":  {"Support"},
+				"extra": {"ignored"},
+			},
+			Files: map[string][]domain.FormFile{
+				"contract": {{Filename: "c.pdf", ContentType: "application/pdf", Data: []byte("pdf")}},
+			},
+		})
+		assert.Empty(t, problems)
+		assert.Equal(t, "Ada Lovelace", values["name"])
+		assert.Equal(t, float64(3), values["seats"])
+		assert.Equal(t, "Support", values["team"])
+		assert.NotContains(t, values, "extra")
+		assert.Equal(t, []map[string]any{{"filename": "c.pdf", "contentType": "application/pdf", "size": 3, "data": "cGRm"}}, values["contract"])
+	})
+
+	t.Run("optional fields may be empty", func(t *testing.T) {
+		values, problems := services.ValidateSubmission(trigger, domain.FormSubmission{
+			Values: map[string][]string{"name": {"Ada"}, "email": {"ada@example.com"}},
+		})
+		assert.Empty(t, problems)
+		assert.Nil(t, values["seats"])
+		assert.Equal(t, "", values["team"])
+		assert.Equal(t, []map[string]any{}, values["contract"])
+	})
+
+	t.Run("problems per field", func(t *testing.T) {
+		_, problems := services.ValidateSubmission(trigger, domain.FormSubmission{
+			Values: map[string][]string{
+				"email": {"Ada <ada@example.com>"},
+				"seats": {"three"},
+				"team":  {"Marketing"},
+			},
+		})
+		assert.Equal(t, map[string]string{
+			"name":  "is required",
+			"email": "must be a valid email address",
+			"seats": "must be a number",
+			"team":  "must be one of the listed options",
+		}, problems)
+	})
+}
+
+func newProtectedFormService(t *testing.T, password *string) *services.FormService {
+	t.Helper()
+	credRepo := &mocks.MockCredentialRepository{}
+	credSvc, err := services.NewCredentialService(credRepo, nil, webhookTestEncKey)
+	require.NoError(t, err)
+	credRepo.GetFunc = func(ctx context.Context, id string) (*domain.Credential, error) {
+		if id != "cred-1" {
+			return nil, utils.ErrNotFound
+		}
+		enc, err := credSvc.EncryptPayload(map[string]any{"password": *password})
+		require.NoError(t, err)
+		return &domain.Credential{ID: id, UserID: "user-1", Provider: "formPassword", DataEncrypted: enc}, nil
+	}
+	return services.NewFormService(&mocks.MockFlowRepository{}, &mocks.MockRunRepository{}, credSvc)
+}
+
+func TestFormService_PasswordAttemptsAreLimited(t *testing.T) {
+	password := "s3cret"
+	svc := newProtectedFormService(t, &password)
+	flow := domain.Flow{ID: "flow-1", OwnerUserID: "user-1"}
+	trigger := services.FormTriggers(formFlowDefinition)[0]
+	ctx := context.Background()
+
+	require.NoError(t, svc.CheckPassword(ctx, flow, trigger, "s3cret", "10.0.0.1"))
+	for i := 0; i < 5; i++ {
+		assert.ErrorIs(t, svc.CheckPassword(ctx, flow, trigger, "guess", "10.0.0.1"), services.ErrFormUnauthorized)
+	}
+	assert.ErrorIs(t, svc.CheckPassword(ctx, flow, trigger, "s3cret", "10.0.0.1"), services.ErrFormLocked, "locked even with the right password")
+	require.NoError(t, svc.CheckPassword(ctx, flow, trigger, "s3cret", "10.0.0.2"), "other clients are not locked")
+	require.NoError(t, svc.CheckPassword(ctx, domain.Flow{ID: "flow-2", OwnerUserID: "user-1"}, trigger, "s3cret", "10.0.0.1"), "other forms are not locked")
+}
+
+func TestFormService_Session(t *testing.T) {
+	password := "s3cret"
+	svc := newProtectedFormService(t, &password)
+	flow := domain.Flow{ID: "flow-1", OwnerUserID: "user-1"}
+	trigger := services.FormTriggers(formFlowDefinition)[0]
+	ctx := context.Background()
+
+	session, err := svc.NewSession(ctx, flow, trigger)
+	require.NoError(t, err)
+	require.NoError(t, svc.CheckSession(ctx, flow, trigger, session))
+
+	assert.ErrorIs(t, svc.CheckSession(ctx, domain.Flow{ID: "flow-2", OwnerUserID: "user-1"}, trigger, session), services.ErrFormUnauthorized, "other form")
+	assert.ErrorIs(t, svc.CheckSession(ctx, flow, trigger, ""), services.ErrFormUnauthorized)
+	assert.ErrorIs(t, svc.CheckSession(ctx, flow, trigger, "1.abc"), services.ErrFormUnauthorized, "expired")
+
+	password = "changed"
+	assert.ErrorIs(t, svc.CheckSession(ctx, flow, trigger, session), services.ErrFormUnauthorized, "password change ends sessions")
+
+	public := trigger
+	public.CredentialID = ""
+	require.NoError(t, svc.CheckSession(ctx, flow, public, ""))
+}
@@ -0,0 +1,48 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollingTriggers(t *testing.T) {
+	def := `{"reactflow":{"nodes":[
+		{"id":"p1","type":"flowNode","data":{"nodeType":"pollingTrigger","config":{"event":"github.issueOpened","owner":"acme"}}},
+		{"id":"p2","type":"pollingTrigger","data":{"config":{"event":"gsheets.rowAdded","intervalSeconds":10,"emit":"batch"}}},
+		{"id":"p3","type":"flowNode","data":{"nodeType":"pollingTrigger","config":{"event":"gmail.messageReceived","intervalSeconds":"900"}}},
+		{"id":"p4","type":"flowNode","data":{"nodeType":"pollingTrigger","config":{}}},
+		{"id":"h1","type":"flowNode","data":{"nodeType":"httpRequest","config":{"event":"github.issueOpened"}}}
+	]}}`
+
+	triggers := services.PollingTriggers(def)
+	require.Len(t, triggers, 3)
+
+	assert.Equal(t, "p1", triggers[0].NodeID)
+	assert.Equal(t, 5*time.Minute, triggers[0].Interval)
+	assert.False(t, triggers[0].Batch)
+	assert.Equal(t, "acme", triggers[0].Config["owner"])
+
+	assert.Equal(t, time.Minute, triggers[1].Interval)
+	assert.True(t, triggers[1].Batch)
+
+	assert.Equal(t, 15*time.Minute, triggers[2].Interval)
+
+	assert.Empty(t, services.PollingTriggers("not json"))
+}
+
+func TestValidatePollingTriggers(t *testing.T) {
+	valid := `{"reactflow":{"nodes":[{"id":"p1","type":"pollingTrigger","data":{"config":{"event":"gsheets.rowAdded"}}}]}}`
+	require.NoError(t, services.ValidatePollingTriggers(valid))
+
+	invalid := `{"reactflow":{"nodes":[{"id":"p1","type":"pollingTrigger","data":{"config":{"event":"jira.issueCreated"}}}]}}`
+	err := services.ValidatePollingTriggers(invalid)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, services.ErrInvalidFlowDefinition))
+	assert.Contains(t, err.Error(), "jira.issueCreated")
+}
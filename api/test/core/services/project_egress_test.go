@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectService_SetEgressPolicy(t *testing.T) {
+	roles := map[string]string{"admin-1": "admin", "member-1": "member"}
+	members := &mocks.MockProjectMemberRepository{
+		GetRoleFunc: func(ctx context.Context, projectID string, userID string) (string, error) {
+			if role, ok := roles[userID]; ok {
+				return role, nil
+			}
+			return "", utils.ErrNotFound
+		},
+	}
+	var stored domain.EgressPolicy
+	projects := &mocks.MockProjectRepository{
+		SetEgressPolicyFunc: func(ctx context.Context, projectID string, policy domain.EgressPolicy) error {
+			stored = policy
+			return nil
+		},
+	}
+	svc := services.NewProjectService(projects, members, nil, nil)
+	ctx := context.Background()
+
+	err := svc.SetEgressPolicy(ctx, domain.AuthUser{ID: "admin-1"}, "p1", domain.EgressPolicy{
+		AllowHosts: []string{" API.GitHub.com ", "", "*.slack.com"},
+		DenyHosts:  []string{"10.0.0.0/8"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api.github.com", "*.slack.com"}, stored.AllowHosts)
+	assert.Equal(t, []string{"10.0.0.0/8"}, stored.DenyHosts)
+
+	err = svc.SetEgressPolicy(ctx, domain.AuthUser{ID: "admin-1"}, "p1", domain.EgressPolicy{DenyHosts: []string{"https://evil.example"}})
+	assert.ErrorIs(t, err, services.ErrInvalidEgressPolicy)
+
+	err = svc.SetEgressPolicy(ctx, domain.AuthUser{ID: "member-1"}, "p1", domain.EgressPolicy{})
+	assert.ErrorIs(t, err, utils.ErrForbidden)
+
+	_, err = svc.GetEgressPolicy(ctx, domain.AuthUser{ID: "stranger"}, "p1")
+	assert.ErrorIs(t, err, utils.ErrForbidden)
+}
@@ -0,0 +1,64 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleService_PauseResume(t *testing.T) {
+	pausedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var paused bool
+	repo := &mocks.MockScheduleRepository{
+		PauseFunc: func(ctx context.Context, flowID string, userID string) error {
+			assert.Equal(t, "flow-1", flowID)
+			assert.Equal(t, "user-1", userID)
+			paused = true
+			return nil
+		},
+		ResumeFunc: func(ctx context.Context, flowID string) error {
+			paused = false
+			return nil
+		},
+		GetFunc: func(ctx context.Context, flowID string) (domain.ScheduleState, error) {
+			state := domain.ScheduleState{FlowID: flowID}
+			if paused {
+				state.PausedAt = &pausedAt
+				state.PausedBy = "user-1"
+			}
+			return state, nil
+		},
+	}
+	svc := services.NewScheduleService(repo)
+
+	state, err := svc.Pause(context.Background(), "flow-1", "user-1")
+	require.NoError(t, err)
+	require.NotNil(t, state.PausedAt)
+	assert.Equal(t, "user-1", state.PausedBy)
+
+	state, err = svc.Resume(context.Background(), "flow-1")
+	require.NoError(t, err)
+	assert.Nil(t, state.PausedAt)
+
+	repo.PauseFunc = func(ctx context.Context, flowID string, userID string) error { return errors.New("db down") }
+	_, err = svc.Pause(context.Background(), "flow-1", "user-1")
+	assert.EqualError(t, err, "db down")
+}
+
+func TestValidateCronTriggers_MisfirePolicy(t *testing.T) {
+	def := func(policy string) string {
+		return `{"reactflow":{"nodes":[{"id":"c1","type":"flowNode","data":{"nodeType":"cron","config":{"expression":"@daily","misfirePolicy":"` + policy + `"}}}]}}`
+	}
+	for _, policy := range []string{"", services.MisfireSkip, services.MisfireRunOnce, services.MisfireRunAll} {
+		assert.NoError(t, services.ValidateCronTriggers(def(policy)), policy)
+	}
+	assert.ErrorIs(t, services.ValidateCronTriggers(def("always")), services.ErrInvalidFlowDefinition)
+}
@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const telegramDefinition = `{"reactflow":{"nodes":[{"id":"tg1","type":"flowNode","data":{"nodeType":"telegramTrigger","config":{"credentialId":"cred-1","updates":["message","callback_query"]}}}]}}`
+
+func TestTelegramTriggers(t *testing.T) {
+	triggers := services.WebhookTriggers(telegramDefinition)
+	require.Len(t, triggers, 1)
+	assert.Equal(t, "telegramTrigger", triggers[0].NodeType)
+	assert.Equal(t, "telegram/tg1", triggers[0].Path)
+	assert.Equal(t, "POST", triggers[0].Method)
+	assert.Equal(t, []string{"message", "callback_query"}, services.TelegramAllowedUpdates(triggers[0]))
+	require.NoError(t, services.ValidateTelegramTriggers(telegramDefinition))
+
+	unknown := `{"reactflow":{"nodes":[{"id":"tg1","data":{"nodeType":"telegramTrigger","config":{"updates":"message, photo"}}}]}}`
+	assert.ErrorIs(t, services.ValidateTelegramTriggers(unknown), services.ErrInvalidFlowDefinition)
+
+	sameBot := `{"reactflow":{"nodes":[
+		{"id":"tg1","data":{"nodeType":"telegramTrigger","config":{"credentialId":"cred-1"}}},
+		{"id":"tg2","data":{"nodeType":"telegramTrigger","config":{"credentialId":"cred-1"}}}]}}`
+	assert.ErrorIs(t, services.ValidateTelegramTriggers(sameBot), services.ErrInvalidFlowDefinition)
+}
+
+func TestWebhookService_AuthenticateTelegram(t *testing.T) {
+	svc := newWebhookAuthService(t, map[string]any{"bot_token": "123:abc"}, "user-1")
+	flow := domain.Flow{ID: "flow-1", OwnerUserID: "user-1"}
+	trigger := services.WebhookTriggers(telegramDefinition)[0]
+	secret := services.TelegramSecretToken("123:abc", "flow-1", "tg1")
+	assert.Regexp(t, `^[0-9a-f]{64}$`, secret)
+	assert.NotEqual(t, secret, services.TelegramSecretToken("123:abc", "flow-2", "tg1"))
+
+	ok := domain.WebhookRequest{Headers: map[string]string{"x-telegram-bot-api-secret-token": secret}}
+	require.NoError(t, svc.Authenticate(context.Background(), flow, trigger, ok, nil))
+
+	wrong := domain.WebhookRequest{Headers: map[string]string{"x-telegram-bot-api-secret-token": "guess"}}
+	assertRejected(t, svc.Authenticate(context.Background(), flow, trigger, wrong, nil), services.ErrWebhookUnauthorized)
+	assertRejected(t, svc.Authenticate(context.Background(), flow, trigger, domain.WebhookRequest{}, nil), services.ErrWebhookUnauthorized)
+}
+
+type fakeRegistrar struct {
+	calls []string
+	err   error
+}
+
+func (r *fakeRegistrar) SyncTriggers(ctx context.Context, before *domain.Flow, after *domain.Flow) error {
+	call := before.Status + "->"
+	if after != nil {
+		call += after.Status
+	}
+	r.calls = append(r.calls, call)
+	return r.err
+}
+
+func TestFlowService_ActivationSyncsTriggerRegistrations(t *testing.T) {
+	user := domain.AuthUser{ID: "user-1"}
+	var status string
+	repo := &mocks.MockFlowRepository{
+		GetFunc: func(ctx context.Context, id string) (*domain.Flow, error) {
+			return &domain.Flow{ID: id, OwnerUserID: "user-1", Status: "draft", DefinitionJSON: telegramDefinition}, nil
+		},
+		SetStatusFunc: func(ctx context.Context, id string, s string, updatedBy string) error {
+			status = s
+			return nil
+		},
+	}
+
+	registrar := &fakeRegistrar{}
+	svc := services.NewFlowService(repo, nil)
+	svc.AddTriggerRegistrar(registrar)
+	_, err := svc.Activate(context.Background(), user, "flow-1")
+	require.NoError(t, err)
+	_, err = svc.Deactivate(context.Background(), user, "flow-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"draft->active", "draft->inactive"}, registrar.calls)
+
+	status = ""
+	registrar.err = errors.New("bad webhook: An HTTPS URL must be provided for webhook")
+	_, err = svc.Activate(context.Background(), user, "flow-1")
+	assert.ErrorIs(t, err, services.ErrTriggerRegistration)
+	assert.ErrorContains(t, err, "HTTPS URL")
+	assert.Empty(t, status, "a flow whose triggers cannot be registered stays inactive")
+
+	// Saving a draft leaves registrations alone.
+	registrar.calls = nil
+	registrar.err = nil
+	repo.UpdateFunc = func(ctx context.Context, flow domain.Flow) error { return nil }
+	require.NoError(t, svc.UpdateAccessible(context.Background(), user, domain.Flow{ID: "flow-1", DefinitionJSON: telegramDefinition}))
+	assert.Empty(t, registrar.calls)
+}
+
+func TestFlowService_SyncDeactivated(t *testing.T) {
+	status := "inactive"
+	repo := &mocks.MockFlowRepository{
+		GetFunc: func(ctx context.Context, id string) (*domain.Flow, error) {
+			return &domain.Flow{ID: id, OwnerUserID: "user-1", Status: status, DefinitionJSON: telegramDefinition}, nil
+		},
+	}
+	registrar := &fakeRegistrar{}
+	svc := services.NewFlowService(repo, nil)
+	svc.AddTriggerRegistrar(registrar)
+
+	// A worker deactivated the flow after repeated failures.
+	require.NoError(t, svc.SyncDeactivated(context.Background(), "flow-1"))
+	assert.Equal(t, []string{"active->inactive"}, registrar.calls)
+
+	// Reactivated before the notification arrived: nothing to remove.
+	registrar.calls = nil
+	status = "active"
+	require.NoError(t, svc.SyncDeactivated(context.Background(), "flow-1"))
+	assert.Empty(t, registrar.calls)
+}
@@ -0,0 +1,205 @@
+package services_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookTestEncKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=" // base64 of 32 ASCII bytes
+
+func newWebhookAuthService(t *testing.T, secrets map[string]any, credUserID string) *services.WebhookService {
+	t.Helper()
+	credRepo := &mocks.MockCredentialRepository{}
+	credSvc, err := services.NewCredentialService(credRepo, nil, webhookTestEncKey)
+	require.NoError(t, err)
+	enc, err := credSvc.EncryptPayload(secrets)
+	require.NoError(t, err)
+	credRepo.GetFunc = func(ctx context.Context, id string) (*domain.Credential, error) {
+		if id != "cred-1" {
+			return nil, utils.ErrNotFound
+		}
+		return &domain.Credential{ID: id, UserID: credUserID, Provider: "webhookAuth", DataEncrypted: enc}, nil
+	}
+	return services.NewWebhookService(&mocks.MockFlowRepository{}, &mocks.MockRunRepository{}, &mocks.MockRunStepRepository{}, credSvc)
+}
+
+func hmacHex(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func assertRejected(t *testing.T, err error, want error) {
+	t.Helper()
+	require.Error(t, err)
+	var rejection *services.WebhookRejection
+	require.True(t, errors.As(err, &rejection), "expected *WebhookRejection, got %T", err)
+	assert.True(t, errors.Is(err, want))
+	assert.NotEmpty(t, rejection.Reason)
+}
+
+func TestWebhookService_Authenticate(t *testing.T) {
+	flow := domain.Flow{ID: "flow-1", OwnerUserID: "user-1"}
+	body := []byte(`{"event":"ping"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name       string
+		config     map[string]any
+		headers    map[string]string
+		clientIP   string
+		credUserID string
+		wantErr    error
+	}{
+		{name: "no auth", config: map[string]any{}},
+		{
+			name:    "header token ok with bearer prefix",
+			config:  map[string]any{"authType": "headerToken", "credentialId": "cred-1", "authHeader": "Authorization"},
+			headers: map[string]string{"authorization": "Bearer s3cret"},
+		},
+		{
+			name:    "header token wrong",
+			config:  map[string]any{"authType": "headerToken", "credentialId": "cred-1"},
+			headers: map[string]string{"x-webhook-token": "nope"},
+			wantErr: services.ErrWebhookUnauthorized,
+		},
+		{
+			name:    "basic auth ok",
+			config:  map[string]any{"authType": "basicAuth", "credentialId": "cred-1"},
+			headers: map[string]string{"authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte("hook:pw"))},
+		},
+		{
+			name:    "basic auth wrong password",
+			config:  map[string]any{"authType": "basicAuth", "credentialId": "cred-1"},
+			headers: map[string]string{"authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte("hook:bad"))},
+			wantErr: services.ErrWebhookUnauthorized,
+		},
+		{
+			name:    "github signature ok",
+			config:  map[string]any{"authType": "hmac", "signatureStyle": "github", "credentialId": "cred-1"},
+			headers: map[string]string{"x-hub-signature-256": "sha256=" + hmacHex("whsec", string(body)), "x-github-delivery": "d-1"},
+		},
+		{
+			name:    "github signature mismatch",
+			config:  map[string]any{"authType": "hmac", "signatureStyle": "github", "credentialId": "cred-1"},
+			headers: map[string]string{"x-hub-signature-256": "sha256=" + hmacHex("other", string(body))},
+			wantErr: services.ErrWebhookUnauthorized,
+		},
+		{
+			name:    "stripe signature ok",
+			config:  map[string]any{"authType": "hmac", "signatureStyle": "stripe", "credentialId": "cred-1"},
+			headers: map[string]string{"stripe-signature": "t=" + now + ",v1=deadbeef,v1=" + hmacHex("whsec", now+"."+string(body))},
+		},
+		{
+			name:    "stripe signature stale",
+			config:  map[string]any{"authType": "hmac", "signatureStyle": "stripe", "credentialId": "cred-1"},
+			headers: map[string]string{"stripe-signature": "t=" + stale + ",v1=" + hmacHex("whsec", stale+"."+string(body))},
+			wantErr: services.ErrWebhookUnauthorized,
+		},
+		{
+			name:   "slack signature ok",
+			config: map[string]any{"authType": "hmac", "signatureStyle": "slack", "credentialId": "cred-1"},
+			headers: map[string]string{
+				"x-slack-signature":         "v0=" + hmacHex("whsec", "v0:"+now+":"+string(body)),
+				"x-slack-request-timestamp": now,
+			},
+		},
+		{
+			name:    "generic signature with custom header",
+			config:  map[string]any{"authType": "hmac", "signatureHeader": "X-Sig", "credentialId": "cred-1"},
+			headers: map[string]string{"x-sig": hmacHex("whsec", string(body))},
+		},
+		{
+			name:       "credential owned by someone else",
+			config:     map[string]any{"authType": "headerToken", "credentialId": "cred-1"},
+			headers:    map[string]string{"x-webhook-token": "s3cret"},
+			credUserID: "user-2",
+			wantErr:    services.ErrWebhookUnauthorized,
+		},
+		{
+			name:     "ip allowlist cidr ok",
+			config:   map[string]any{"ipAllowlist": "10.0.0.0/8, 192.168.1.5"},
+			clientIP: "10.1.2.3",
+		},
+		{
+			name:     "ip allowlist rejects",
+			config:   map[string]any{"ipAllowlist": "10.0.0.0/8\n192.168.1.5"},
+			clientIP: "203.0.113.7",
+			wantErr:  services.ErrWebhookIPNotAllowed,
+		},
+	}
+
+	secrets := map[string]any{"token": "s3cret", "username": "hook", "password": "pw", "secret": "whsec"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := tt.credUserID
+			if owner == "" {
+				owner = "user-1"
+			}
+			svc := newWebhookAuthService(t, secrets, owner)
+			clientIP := tt.clientIP
+			if clientIP == "" {
+				clientIP = "127.0.0.1"
+			}
+			trigger := domain.WebhookTrigger{NodeID: "n1", Config: tt.config}
+			req := domain.WebhookRequest{Headers: tt.headers, ClientIP: clientIP}
+
+			err := svc.Authenticate(context.Background(), flow, trigger, req, body)
+			if tt.wantErr != nil {
+				assertRejected(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookService_AuthenticateRejectsReplay(t *testing.T) {
+	svc := newWebhookAuthService(t, map[string]any{"secret": "whsec"}, "user-1")
+	flow := domain.Flow{ID: "flow-1", OwnerUserID: "user-1"}
+	body := []byte(`{}`)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	trigger := domain.WebhookTrigger{NodeID: "n1", Config: map[string]any{"authType": "hmac", "signatureStyle": "stripe", "credentialId": "cred-1"}}
+	req := domain.WebhookRequest{
+		ClientIP: "127.0.0.1",
+		Headers:  map[string]string{"stripe-signature": "t=" + ts + ",v1=" + hmacHex("whsec", ts+"."+string(body))},
+	}
+
+	require.NoError(t, svc.Authenticate(context.Background(), flow, trigger, req, body))
+	assertRejected(t, svc.Authenticate(context.Background(), flow, trigger, req, body), services.ErrWebhookUnauthorized)
+}
+
+func TestWebhookService_AuthenticateRejectsGitHubReplayWithNewDelivery(t *testing.T) {
+	svc := newWebhookAuthService(t, map[string]any{"secret": "whsec"}, "user-1")
+	flow := domain.Flow{ID: "flow-1", OwnerUserID: "user-1"}
+	body := []byte(`{"action":"opened"}`)
+	trigger := domain.WebhookTrigger{NodeID: "n1", Config: map[string]any{"authType": "hmac", "signatureStyle": "github", "credentialId": "cred-1"}}
+	request := func(delivery string) domain.WebhookRequest {
+		return domain.WebhookRequest{
+			ClientIP: "127.0.0.1",
+			Headers: map[string]string{
+				"x-hub-signature-256": "sha256=" + hmacHex("whsec", string(body)),
+				"x-github-delivery":   delivery,
+			},
+		}
+	}
+
+	require.NoError(t, svc.Authenticate(context.Background(), flow, trigger, request("d-1"), body))
+	assertRejected(t, svc.Authenticate(context.Background(), flow, trigger, request("d-2"), body), services.ErrWebhookUnauthorized)
+}
@@ -0,0 +1,158 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/core/domain"
+	"flowcraft-api/internal/core/ports/mocks"
+	"flowcraft-api/internal/core/services"
+	"flowcraft-api/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookFlowDefinition = `{"reactflow":{"nodes":[
+	{"id":"n1","type":"flowNode","data":{"nodeType":"httpTrigger","config":{"path":"/orders/","method":"post"}}},
+	{"id":"n2","type":"flowNode","data":{"nodeType":"webhook","config":{"path":"github"}}},
+	{"id":"n3","type":"flowNode","data":{"nodeType":"httpRequest","config":{"path":"/ignored"}}}
+]}}`
+
+func TestWebhookTriggers(t *testing.T) {
+	triggers := services.WebhookTriggers(webhookFlowDefinition)
+	require.Len(t, triggers, 2)
+	assert.Equal(t, domain.WebhookTrigger{NodeID: "n1", NodeType: "httpTrigger", Path: "orders", Method: "POST", Config: triggers[0].Config}, triggers[0])
+	assert.Equal(t, "github", triggers[1].Path)
+	assert.Equal(t, "", triggers[1].Method)
+
+	assert.Empty(t, services.WebhookTriggers("not json"))
+}
+
+func TestWebhookService_Resolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		path       string
+		method     string
+		mode       string
+		wantNodeID string
+		wantErr    error
+	}{
+		{name: "matches path and method", status: "active", path: "/orders", method: "POST", mode: services.WebhookModeProduction, wantNodeID: "n1"},
+		{name: "method mismatch", status: "active", path: "orders", method: "GET", mode: services.WebhookModeProduction, wantErr: services.ErrWebhookMethodNotAllowed},
+		{name: "trigger without method accepts any", status: "active", path: "/github/", method: "PUT", mode: services.WebhookModeProduction, wantNodeID: "n2"},
+		{name: "unknown path", status: "active", path: "/missing", method: "POST", mode: services.WebhookModeProduction, wantErr: utils.ErrNotFound},
+		{name: "draft flow rejects production", status: "draft", path: "/orders", method: "POST", mode: services.WebhookModeProduction, wantErr: utils.ErrNotFound},
+		{name: "inactive flow rejects production", status: "inactive", path: "/orders", method: "POST", mode: services.WebhookModeProduction, wantErr: utils.ErrNotFound},
+		{name: "archived flow rejects production", status: "archived", path: "/orders", method: "POST", mode: services.WebhookModeProduction, wantErr: utils.ErrNotFound},
+		{name: "draft flow accepts test", status: "draft", path: "/orders", method: "POST", mode: services.WebhookModeTest, wantNodeID: "n1"},
+		{name: "archived flow accepts test", status: "archived", path: "/orders", method: "POST", mode: services.WebhookModeTest, wantNodeID: "n1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flows := &mocks.MockFlowRepository{
+				GetFunc: func(ctx context.Context, id string) (*domain.Flow, error) {
+					return &domain.Flow{ID: id, Status: tt.status, DefinitionJSON: webhookFlowDefinition}, nil
+				},
+			}
+			svc := services.NewWebhookService(flows, &mocks.MockRunRepository{}, &mocks.MockRunStepRepository{}, nil)
+
+			flow, trigger, err := svc.Resolve(context.Background(), "flow-1", tt.path, tt.method, tt.mode)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "flow-1", flow.ID)
+			assert.Equal(t, tt.wantNodeID, trigger.NodeID)
+		})
+	}
+}
+
+func TestWebhookService_CreateRun(t *testing.T) {
+	var stored domain.Run
+	runs := &mocks.MockRunRepository{
+		CreateFunc: func(ctx context.Context, run domain.Run) error {
+			stored = run
+			return nil
+		},
+	}
+	svc := services.NewWebhookService(&mocks.MockFlowRepository{}, runs, &mocks.MockRunStepRepository{}, nil)
+
+	req := domain.WebhookRequest{
+		Mode:    services.WebhookModeProduction,
+		Method:  "POST",
+		Path:    "orders",
+		Headers: map[string]string{"authorization": "Bearer s3cret", "content-type": "application/json"},
+		Body:    map[string]any{"id": 7},
+	}
+	run, err := svc.CreateRun(context.Background(), domain.Flow{ID: "flow-1"}, domain.WebhookTrigger{NodeID: "n1"}, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, stored.ID, run.ID)
+	assert.Equal(t, "queued", run.Status)
+	assert.Equal(t, "run-"+run.ID, run.TemporalWorkflow)
+	assert.Equal(t, "n1", run.TriggerNodeID)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(run.TriggerPayload, &payload))
+	assert.Equal(t, "POST", payload["method"])
+	assert.Equal(t, map[string]any{"id": float64(7)}, payload["body"])
+	assert.Equal(t, map[string]any{"authorization": "***", "content-type": "application/json"}, payload["headers"])
+}
+
+func TestWebhookService_ResponseSettings(t *testing.T) {
+	trigger := func(cfg map[string]any) domain.WebhookTrigger { return domain.WebhookTrigger{Config: cfg} }
+
+	assert.Equal(t, services.WebhookResponseImmediately, services.ResponseMode(trigger(nil)))
+	assert.Equal(t, services.WebhookResponseLastNode, services.ResponseMode(trigger(map[string]any{"responseMode": "lastNode"})))
+	assert.Equal(t, services.WebhookResponseRespondNode, services.ResponseMode(trigger(map[string]any{"responseMode": "respondNode"})))
+	assert.Equal(t, services.WebhookResponseImmediately, services.ResponseMode(trigger(map[string]any{"responseMode": "bogus"})))
+
+	assert.Equal(t, 30*time.Second, services.ResponseTimeout(trigger(nil)))
+	assert.Equal(t, 5*time.Second, services.ResponseTimeout(trigger(map[string]any{"responseTimeoutSeconds": float64(5)})))
+	assert.Equal(t, 120*time.Second, services.ResponseTimeout(trigger(map[string]any{"responseTimeoutSeconds": "600"})))
+}
+
+func TestWebhookService_BuildResponse(t *testing.T) {
+	early := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	late := early.Add(time.Second)
+	steps := []domain.RunStep{
+		{StepKey: "000", NodeType: "webhook", Status: "success", FinishedAt: &early, OutputsJSON: json.RawMessage(`{"status":200,"data":{"path":"orders"}}`)},
+		{StepKey: "001", NodeType: "respondToWebhook", Status: "success", FinishedAt: &late, OutputsJSON: json.RawMessage(`{"status":200,"data":{"statusCode":201,"headers":{"X-Id":"7"},"body":{"ok":true}}}`)},
+		{StepKey: "002", NodeType: "httpRequest", Status: "failed", OutputsJSON: json.RawMessage(`{}`)},
+	}
+	svc := services.NewWebhookService(&mocks.MockFlowRepository{}, &mocks.MockRunRepository{}, &mocks.MockRunStepRepository{
+		ListByRunIDFunc: func(ctx context.Context, runID string) ([]domain.RunStep, error) {
+			return steps, nil
+		},
+	}, nil)
+
+	t.Run("respond node", func(t *testing.T) {
+		resp, ok, err := svc.BuildResponse(context.Background(), "run-1", services.WebhookResponseRespondNode)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, 201, resp.StatusCode)
+		assert.Equal(t, map[string]string{"X-Id": "7"}, resp.Headers)
+		assert.Equal(t, map[string]any{"ok": true}, resp.Body)
+	})
+
+	t.Run("last node uses latest successful step", func(t *testing.T) {
+		resp, ok, err := svc.BuildResponse(context.Background(), "run-1", services.WebhookResponseLastNode)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, map[string]any{"statusCode": float64(201), "headers": map[string]any{"X-Id": "7"}, "body": map[string]any{"ok": true}}, resp.Body)
+	})
+
+	t.Run("no respond node", func(t *testing.T) {
+		steps = steps[:1]
+		_, ok, err := svc.BuildResponse(context.Background(), "run-1", services.WebhookResponseRespondNode)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
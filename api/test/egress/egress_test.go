@@ -0,0 +1,136 @@
+package egress_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"flowcraft-api/internal/config"
+	"flowcraft-api/internal/egress"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustHosts(t *testing.T, entries ...string) egress.HostList {
+	t.Helper()
+	list, err := egress.ParseHostList(entries)
+	require.NoError(t, err)
+	return list
+}
+
+func TestIsPrivate(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1", "::ffff:127.0.0.1", "64:ff9b::a9fe:a9fe"} {
+		assert.True(t, egress.IsPrivate(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{"8.8.8.8", "140.82.112.3", "2606:4700::1111"} {
+		assert.False(t, egress.IsPrivate(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	ctx := context.Background()
+	public := []net.IP{net.ParseIP("140.82.112.3")}
+	metadata := []net.IP{net.ParseIP("169.254.169.254")}
+
+	policy := egress.Policy{
+		Allow: mustHosts(t, "ghe.corp.example", "10.20.0.0/16"),
+		Deny:  mustHosts(t, "*.evil.example"),
+	}
+	require.NoError(t, policy.Check(ctx, "api.github.com", public))
+	// A public name that resolves to an internal address is blocked, which is
+	// what a DNS rebinding attack looks like from here.
+	err := policy.Check(ctx, "rebind.attacker.example", metadata)
+	assert.ErrorIs(t, err, egress.ErrBlocked)
+	assert.ErrorContains(t, err, "169.254.169.254")
+	require.NoError(t, policy.Check(ctx, "ghe.corp.example", []net.IP{net.ParseIP("10.9.0.5")}))
+	require.NoError(t, policy.Check(ctx, "10.20.3.4", []net.IP{net.ParseIP("10.20.3.4")}))
+	assert.ErrorIs(t, policy.Check(ctx, "evil.example", public), egress.ErrBlocked)
+	assert.ErrorIs(t, policy.Check(ctx, "x.evil.example.", public), egress.ErrBlocked)
+
+	project := egress.WithRules(ctx, egress.Rules{Allow: mustHosts(t, "*.github.com", "ghe.corp.example")})
+	require.NoError(t, policy.Check(project, "api.github.com", public))
+	assert.ErrorContains(t, policy.Check(project, "api.slack.com", public), "project allow list")
+	// A project cannot allow what the deployment blocks.
+	assert.ErrorIs(t, egress.Policy{}.Check(egress.WithRules(ctx, egress.Rules{Allow: mustHosts(t, "169.254.169.254")}), "169.254.169.254", metadata), egress.ErrBlocked)
+
+	denied := egress.WithRules(ctx, egress.Rules{Deny: mustHosts(t, "api.github.com")})
+	assert.ErrorContains(t, policy.Check(denied, "api.github.com", public), "deny list")
+
+	require.NoError(t, egress.Policy{AllowPrivate: true}.Check(ctx, "localhost", []net.IP{net.ParseIP("127.0.0.1")}))
+}
+
+func TestParseHostList(t *testing.T) {
+	_, err := egress.ParseHostList([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+	_, err = egress.ParseHostList([]string{"http://example.com"})
+	assert.Error(t, err)
+	list := mustHosts(t, " Example.COM ", "", "[::1]")
+	assert.True(t, list.Match("example.com"))
+	assert.False(t, list.Match("api.example.com"))
+	assert.True(t, list.Match("::1"))
+}
+
+func TestFromConfig(t *testing.T) {
+	policy, err := egress.FromConfig(config.Config{EgressAllowHosts: "ghe.corp.example, 10.0.0.0/8", EgressMaxRedirects: "2"})
+	require.NoError(t, err)
+	assert.Len(t, policy.Allow, 2)
+	assert.Equal(t, 2, policy.MaxRedirects)
+	assert.False(t, policy.AllowPrivate)
+
+	_, err = egress.FromConfig(config.Config{EgressDenyHosts: "10.0.0.0/99"})
+	assert.ErrorContains(t, err, "EGRESS_DENY_HOSTS")
+}
+
+func TestTransportBlocksPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: egress.NewTransport(egress.Policy{MaxRedirects: 5})}
+	_, err := client.Get(srv.URL)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, egress.ErrBlocked), err.Error())
+
+	// "localhost" resolves to a loopback address, checked after resolution.
+	_, err = client.Get(strings.Replace(srv.URL, "127.0.0.1", "localhost", 1))
+	assert.ErrorIs(t, err, egress.ErrBlocked)
+
+	allowed := &http.Client{Transport: egress.NewTransport(egress.Policy{Allow: mustHosts(t, "127.0.0.1"), MaxRedirects: 5})}
+	res, err := allowed.Get(srv.URL)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+}
+
+func TestTransportRevalidatesRedirects(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/internal":
+			// Redirect from the allowed name to the same server by address,
+			// which the policy does not allow.
+			http.Redirect(w, r, srv.URL+"/done", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+	byName := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	client := &http.Client{Transport: egress.NewTransport(egress.Policy{Allow: mustHosts(t, "localhost"), MaxRedirects: 2})}
+	res, err := client.Get(byName + "/ok")
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	_, err = client.Get(byName + "/internal")
+	assert.ErrorIs(t, err, egress.ErrBlocked)
+
+	_, err = client.Get(byName + "/loop")
+	assert.ErrorIs(t, err, egress.ErrBlocked)
+	assert.ErrorContains(t, err, "more than 2 redirects")
+}
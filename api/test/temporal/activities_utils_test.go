@@ -0,0 +1,38 @@
+package temporal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"flowcraft-api/internal/adapters/external/slack"
+	temporal "flowcraft-api/internal/temporal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay(t *testing.T) {
+	ctx := context.Background()
+	limited := func(d time.Duration) error {
+		return &slack.RateLimitError{Method: "chat.postMessage", RetryAfter: d}
+	}
+
+	delay, ok := temporal.RetryDelayForTest(ctx, time.Second, errors.New("boom"))
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+
+	delay, ok = temporal.RetryDelayForTest(ctx, time.Second, limited(30*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay, "never retry sooner than asked")
+
+	_, ok = temporal.RetryDelayForTest(ctx, time.Second, limited(2*time.Hour))
+	assert.False(t, ok, "a delay over the cap fails the step")
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, ok = temporal.RetryDelayForTest(deadlineCtx, time.Second, limited(30*time.Second))
+	assert.False(t, ok, "a delay past the deadline fails the step")
+	_, ok = temporal.RetryDelayForTest(deadlineCtx, time.Second, errors.New("boom"))
+	assert.True(t, ok)
+}
@@ -0,0 +1,39 @@
+package temporal_test
+
+import (
+	"testing"
+
+	temporal "flowcraft-api/internal/temporal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSlackBlocks(t *testing.T) {
+	divider := map[string]any{"type": "divider"}
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []any
+		wantErr bool
+	}{
+		{name: "unset", config: map[string]any{}},
+		{name: "blank string", config: map[string]any{"blocks": "  "}},
+		{name: "array", config: map[string]any{"blocks": []any{divider}}, want: []any{divider}},
+		{name: "json string", config: map[string]any{"blocks": `[{"type":"divider"}]`}, want: []any{divider}},
+		{name: "block kit builder payload", config: map[string]any{"blocks": `{"blocks":[{"type":"divider"}]}`}, want: []any{divider}},
+		{name: "invalid json", config: map[string]any{"blocks": `[{`}, wantErr: true},
+		{name: "not an array", config: map[string]any{"blocks": `"divider"`}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := temporal.ReadSlackBlocksForTest(tt.config)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
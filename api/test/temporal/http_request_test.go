@@ -0,0 +1,267 @@
+package temporal_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	temporal "flowcraft-api/internal/temporal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteHTTPRequestWithCredential(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		provider string
+		payload  map[string]any
+		check    func(t *testing.T, r *http.Request)
+	}{
+		{
+			name:     "api key header",
+			provider: "httpAuth",
+			payload:  map[string]any{"type": "apiKey", "name": "X-Token", "value": "s3cret"},
+			check: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, "s3cret", r.Header.Get("X-Token"))
+				assert.Empty(t, r.Header.Get("Authorization"), "inline auth is replaced by the credential")
+			},
+		},
+		{
+			name:     "basic",
+			provider: "httpAuth",
+			payload:  map[string]any{"type": "basic", "username": "ada", "password": "pw"},
+			check: func(t *testing.T, r *http.Request) {
+				user, pass, ok := r.BasicAuth()
+				require.True(t, ok)
+				assert.Equal(t, "ada", user)
+				assert.Equal(t, "pw", pass)
+			},
+		},
+		{
+			name:     "custom headers",
+			provider: "httpAuth",
+			payload:  map[string]any{"type": "headers", "headers": map[string]any{"X-Client": "c1", "X-Secret": "s3cret"}},
+			check: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, "c1", r.Header.Get("X-Client"))
+				assert.Equal(t, "s3cret", r.Header.Get("X-Secret"))
+			},
+		},
+		{
+			name:     "github token",
+			provider: "github",
+			payload:  map[string]any{"access_token": "gho_1"},
+			check: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, "Bearer gho_1", r.Header.Get("Authorization"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{"url": srv.URL, "authType": "Bearer Token", "authValue": "inline"}
+			out, _, err := temporal.ExecuteHTTPRequestForTest(context.Background(), config, tt.provider, tt.payload)
+			require.NoError(t, err)
+			assert.Equal(t, 200, out["status"])
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestExecuteHTTPRequestRedactsQueryCredential(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+	}))
+	defer srv.Close()
+
+	config := map[string]any{"url": srv.URL + "/items?page=2"}
+	payload := map[string]any{"type": "apiKey", "in": "query", "name": "api_key", "value": "s3cret"}
+	out, logText, err := temporal.ExecuteHTTPRequestForTest(context.Background(), config, "httpAuth", payload)
+	require.NoError(t, err)
+	assert.Equal(t, "api_key=s3cret&page=2", query)
+	assert.NotContains(t, logText, "s3cret")
+	raw, _ := json.Marshal(out)
+	assert.NotContains(t, string(raw), "s3cret")
+
+	config["url"] = "http://127.0.0.1:1/items"
+	out, _, err = temporal.ExecuteHTTPRequestForTest(context.Background(), config, "httpAuth", payload)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cret")
+	assert.NotContains(t, out["error"], "s3cret")
+}
+
+func TestExecuteHTTPRequestCredentialNotSentAcrossRedirect(t *testing.T) {
+	var leaked string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("X-API-Key")
+	}))
+	defer other.Close()
+	var sameHost string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/away":
+			http.Redirect(w, r, other.URL+"/steal", http.StatusFound)
+		case "/moved":
+			http.Redirect(w, r, "/here", http.StatusFound)
+		default:
+			sameHost = r.Header.Get("X-API-Key")
+		}
+	}))
+	defer srv.Close()
+
+	payload := map[string]any{"type": "apiKey", "name": "X-API-Key", "value": "s3cret"}
+	_, _, err := temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{"url": srv.URL + "/away"}, "httpAuth", payload)
+	assert.ErrorContains(t, err, "redirect host")
+	assert.Empty(t, leaked)
+
+	out, _, err := temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{"url": srv.URL + "/moved"}, "httpAuth", payload)
+	require.NoError(t, err)
+	assert.Equal(t, 200, out["status"])
+	assert.Equal(t, "s3cret", sameHost)
+}
+
+func TestExecuteHTTPRequestCredentialErrors(t *testing.T) {
+	config := map[string]any{"url": "https://example.com"}
+	_, _, err := temporal.ExecuteHTTPRequestForTest(context.Background(), config, "httpAuth", map[string]any{"type": "oauth"})
+	assert.ErrorContains(t, err, "unsupported type")
+	_, _, err = temporal.ExecuteHTTPRequestForTest(context.Background(), config, "httpAuth", map[string]any{"type": "apiKey", "in": "query", "value": "x"})
+	assert.ErrorContains(t, err, "query parameter name")
+	_, _, err = temporal.ExecuteHTTPRequestForTest(context.Background(), config, "telegram", map[string]any{"bot_token": "x"})
+	assert.ErrorContains(t, err, "cannot authenticate")
+}
+
+func TestBuildStepInputsNeverRecordsCredentialSecrets(t *testing.T) {
+	inputs := temporal.BuildStepInputsForTest("httpRequest", map[string]any{"url": "https://example.com", "credentialId": "cred-1"})
+	assert.Equal(t, "cred-1", inputs["credential_id"])
+	assert.NotContains(t, inputs, "auth_value")
+
+	inputs = temporal.BuildStepInputsForTest("httpRequest", map[string]any{"url": "https://example.com", "authType": "Bearer Token", "authValue": "inline"})
+	assert.Equal(t, "***", inputs["auth_value"])
+}
+
+func TestExecuteHTTPRequestFollowsLinkHeader(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `<`+srv.URL+`/issues?page=2>; rel="next", <`+srv.URL+`/issues?page=3>; rel="last"`)
+			_, _ = w.Write([]byte(`[{"id":1},{"id":2}]`))
+		case "2":
+			w.Header().Set("Link", `</issues?page=3>; rel="next"`)
+			_, _ = w.Write([]byte(`[{"id":3}]`))
+		default:
+			_, _ = w.Write([]byte(`[{"id":4}]`))
+		}
+	}))
+	defer srv.Close()
+
+	out, logText, err := temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{
+		"url":            srv.URL + "/issues",
+		"paginationMode": "nextUrl",
+	}, "", nil)
+	require.NoError(t, err)
+	assert.Len(t, out["data"], 4)
+	meta := out["meta"].(map[string]any)
+	assert.Equal(t, 3, meta["page_count"])
+	assert.Equal(t, "last_page", meta["stopped_by"])
+	pages := meta["pages"].([]any)
+	assert.Equal(t, 2, pages[0].(map[string]any)["item_count"])
+	assert.Contains(t, pages[2].(map[string]any)["url"], "page=3")
+	assert.Contains(t, logText, "3 pages, 4 items")
+}
+
+func TestExecuteHTTPRequestPageAndOffsetModes(t *testing.T) {
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.RawQuery)
+		if r.URL.Query().Get("page") == "3" || r.URL.Query().Get("offset") == "4" {
+			_, _ = w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"n":1},{"n":2}]}`))
+	}))
+	defer srv.Close()
+
+	out, _, err := temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{
+		"url":            srv.URL,
+		"paginationMode": "page",
+		"itemsPath":      "data.results",
+	}, "", nil)
+	require.NoError(t, err)
+	assert.Len(t, out["data"], 4)
+	assert.Equal(t, []string{"page=1", "page=2", "page=3"}, requested)
+
+	requested = nil
+	out, _, err = temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{
+		"url":            srv.URL,
+		"paginationMode": "offset",
+		"pageParam":      "offset",
+		"itemsPath":      "data.results",
+		"maxPages":       float64(2),
+	}, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"offset=0", "offset=2"}, requested)
+	assert.Equal(t, "max_pages", out["meta"].(map[string]any)["stopped_by"])
+}
+
+func TestExecuteHTTPRequestCursorWithStopCondition(t *testing.T) {
+	var cursors []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "s3cret", r.URL.Query().Get("key"))
+		cursor := r.URL.Query().Get("starting_after")
+		cursors = append(cursors, cursor)
+		hasMore := cursor != "b"
+		next := map[string]string{"": "a", "a": "b", "b": ""}[cursor]
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": []any{cursor}, "has_more": hasMore, "next": next})
+	}))
+	defer srv.Close()
+
+	payload := map[string]any{"type": "apiKey", "in": "query", "name": "key", "value": "s3cret"}
+	out, logText, err := temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{
+		"url":            srv.URL,
+		"paginationMode": "cursor",
+		"cursorPath":     "data.next",
+		"cursorParam":    "starting_after",
+		"itemsPath":      "data.data",
+		"pageDelayMs":    float64(1),
+		"stopConditions": `[{"type":"boolean","operator":"is false","left":"data.has_more"}]`,
+	}, "httpAuth", payload)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "a", "b"}, cursors)
+	assert.Equal(t, []any{"", "a", "b"}, out["data"])
+	assert.Equal(t, "condition", out["meta"].(map[string]any)["stopped_by"])
+	raw, _ := json.Marshal(out)
+	assert.NotContains(t, string(raw), "s3cret")
+	assert.NotContains(t, logText, "s3cret")
+}
+
+func TestExecuteHTTPRequestPaginationErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Link", `<https://elsewhere.example.com/items?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`[1]`))
+	}))
+	defer srv.Close()
+
+	_, _, err := temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{"url": srv.URL, "paginationMode": "nextUrl"}, "", nil)
+	assert.ErrorContains(t, err, "differs")
+
+	out, _, err := temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{"url": srv.URL, "paginationMode": "page"}, "", nil)
+	assert.ErrorContains(t, err, "HTTP 502 on page 2")
+	assert.Equal(t, []any{float64(1)}, out["data"], "pages fetched before the failure are kept")
+
+	_, _, err = temporal.ExecuteHTTPRequestForTest(context.Background(), map[string]any{"url": srv.URL, "paginationMode": "cursor"}, "", nil)
+	assert.ErrorContains(t, err, "cursorPath is required")
+}
@@ -0,0 +1,80 @@
+package temporal_test
+
+import (
+	"fmt"
+	"testing"
+
+	temporal "flowcraft-api/internal/temporal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSheetRowsSince(t *testing.T) {
+	rows := []any{
+		[]any{"Name", "Email"},
+		[]any{"Ada", "ada@example.com"},
+		[]any{"Grace"},
+	}
+
+	t.Run("header row is skipped and mapped to fields", func(t *testing.T) {
+		items := temporal.SheetRowsSinceForTest(rows, 0, true)
+		require.Len(t, items, 2)
+		data := items[1]["data"].(map[string]any)
+		assert.Equal(t, 3, data["rowNumber"])
+		assert.Equal(t, map[string]any{"Name": "Grace", "Email": ""}, data["fields"])
+	})
+
+	t.Run("only rows after the cursor", func(t *testing.T) {
+		items := temporal.SheetRowsSinceForTest(rows, 2, true)
+		require.Len(t, items, 1)
+		assert.Equal(t, 3, items[0]["data"].(map[string]any)["rowNumber"])
+	})
+
+	t.Run("without header every row is an item", func(t *testing.T) {
+		items := temporal.SheetRowsSinceForTest(rows, 0, false)
+		require.Len(t, items, 3)
+		assert.NotContains(t, items[0]["data"], "fields")
+	})
+
+	t.Run("cursor past the end after deletes", func(t *testing.T) {
+		assert.Empty(t, temporal.SheetRowsSinceForTest(rows, 5, true))
+	})
+
+	t.Run("ids change with row values", func(t *testing.T) {
+		first := temporal.SheetRowsSinceForTest(rows, 2, true)
+		changed := temporal.SheetRowsSinceForTest([]any{rows[0], rows[1], []any{"Linus"}}, 2, true)
+		assert.NotEqual(t, first[0]["id"], changed[0]["id"])
+		assert.Equal(t, first[0]["id"], temporal.SheetRowsSinceForTest(rows, 2, true)[0]["id"])
+	})
+}
+
+func TestUnseenItems(t *testing.T) {
+	t.Run("fresh items oldest first", func(t *testing.T) {
+		fresh, seen := temporal.UnseenItemIDsForTest([]string{"c", "b", "a"}, []string{"a"})
+		assert.Equal(t, []string{"b", "c"}, fresh)
+		assert.Equal(t, []string{"c", "b", "a"}, seen)
+	})
+
+	t.Run("previously seen ids outside the page are kept", func(t *testing.T) {
+		fresh, seen := temporal.UnseenItemIDsForTest([]string{"d", "c"}, []string{"c", "b", "a"})
+		assert.Equal(t, []string{"d"}, fresh)
+		assert.Equal(t, []string{"d", "c", "b", "a"}, seen)
+	})
+
+	t.Run("empty ids are ignored", func(t *testing.T) {
+		fresh, seen := temporal.UnseenItemIDsForTest([]string{"", "x"}, nil)
+		assert.Equal(t, []string{"x"}, fresh)
+		assert.Equal(t, []string{"x"}, seen)
+	})
+
+	t.Run("seen list is capped", func(t *testing.T) {
+		seen := make([]string, 0, 600)
+		for i := 0; i < 600; i++ {
+			seen = append(seen, fmt.Sprintf("old-%d", i))
+		}
+		_, next := temporal.UnseenItemIDsForTest([]string{"new"}, seen)
+		assert.Len(t, next, 500)
+		assert.Equal(t, "new", next[0])
+	})
+}
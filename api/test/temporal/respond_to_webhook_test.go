@@ -0,0 +1,78 @@
+package temporal_test
+
+import (
+	"testing"
+
+	temporal "flowcraft-api/internal/temporal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteRespondToWebhook(t *testing.T) {
+	input := map[string]any{"status": 200, "data": map[string]any{"id": "ord_1", "total": float64(42)}}
+	steps := map[string]any{"trigger": map[string]any{"body": map[string]any{"name": "Ada"}}}
+
+	tests := []struct {
+		name       string
+		config     map[string]any
+		wantStatus int
+		wantBody   any
+		wantHeader map[string]any
+		wantErr    bool
+	}{
+		{
+			name:       "json body with expressions",
+			config:     map[string]any{"statusCode": float64(201), "body": `{"id":"{{data.id}}","hello":"{{steps.trigger.body.name}}"}`},
+			wantStatus: 201,
+			wantBody:   map[string]any{"id": "ord_1", "hello": "Ada"},
+			wantHeader: map[string]any{},
+		},
+		{
+			name:       "single expression keeps raw value",
+			config:     map[string]any{"body": "{{ data }}"},
+			wantStatus: 200,
+			wantBody:   map[string]any{"id": "ord_1", "total": float64(42)},
+			wantHeader: map[string]any{},
+		},
+		{
+			name:       "text sets content type",
+			config:     map[string]any{"respondWith": "text", "body": "total={{data.total}}", "headers": map[string]any{"X-Order": "{{data.id}}"}},
+			wantStatus: 200,
+			wantBody:   "total=42",
+			wantHeader: map[string]any{"X-Order": "ord_1", "Content-Type": "text/plain; charset=utf-8"},
+		},
+		{
+			name:       "input passes node data through",
+			config:     map[string]any{"respondWith": "input"},
+			wantStatus: 200,
+			wantBody:   input["data"],
+			wantHeader: map[string]any{},
+		},
+		{
+			name:       "no data",
+			config:     map[string]any{"respondWith": "noData", "statusCode": "204"},
+			wantStatus: 204,
+			wantBody:   nil,
+			wantHeader: map[string]any{},
+		},
+		{name: "invalid json body", config: map[string]any{"body": "{nope"}, wantErr: true},
+		{name: "invalid status code", config: map[string]any{"statusCode": float64(42)}, wantErr: true},
+		{name: "unknown respondWith", config: map[string]any{"respondWith": "binary"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _, err := temporal.ExecuteRespondToWebhookForTest(tt.config, input, steps)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			data := out["data"].(map[string]any)
+			assert.Equal(t, tt.wantStatus, data["statusCode"])
+			assert.Equal(t, tt.wantBody, data["body"])
+			assert.Equal(t, tt.wantHeader, data["headers"])
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package temporal_test
+
+import (
+	"testing"
+	"time"
+
+	temporal "flowcraft-api/internal/temporal"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledRunID(t *testing.T) {
+	fire := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	id := temporal.ScheduledRunIDForTest("flow-1", "cron-1", fire)
+	_, err := uuid.Parse(id)
+	require.NoError(t, err)
+
+	assert.Equal(t, id, temporal.ScheduledRunIDForTest("flow-1", "cron-1", fire.In(time.FixedZone("CET", 3600))), "same instant in another zone")
+	assert.NotEqual(t, id, temporal.ScheduledRunIDForTest("flow-2", "cron-1", fire), "other flow")
+	assert.NotEqual(t, id, temporal.ScheduledRunIDForTest("flow-1", "cron-2", fire), "other trigger")
+	assert.NotEqual(t, id, temporal.ScheduledRunIDForTest("flow-1", "cron-1", fire.Add(time.Minute)), "next tick")
+}
@@ -0,0 +1,49 @@
+package temporal_test
+
+import (
+	"testing"
+
+	temporal "flowcraft-api/internal/temporal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteTelegramTrigger(t *testing.T) {
+	out, _, err := temporal.ExecuteTelegramTriggerForTest(map[string]any{
+		"mode": "production",
+		"body": map[string]any{
+			"update_id": float64(901),
+			"callback_query": map[string]any{
+				"id":   "cb-7",
+				"data": "ack:incident-42",
+				"from": map[string]any{"id": float64(5), "username": "oncall"},
+				"message": map[string]any{
+					"message_id": float64(77),
+					"chat":       map[string]any{"id": float64(-100123)},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	data := out["data"].(map[string]any)
+	assert.Equal(t, "callback_query", data["updateType"])
+	assert.Equal(t, "cb-7", data["callbackQueryId"])
+	assert.Equal(t, "ack:incident-42", data["text"])
+	assert.Equal(t, float64(-100123), data["chatId"])
+	assert.Equal(t, float64(77), data["messageId"])
+	assert.Equal(t, float64(901), data["updateId"])
+
+	out, _, err = temporal.ExecuteTelegramTriggerForTest(map[string]any{
+		"body": map[string]any{"message": map[string]any{"message_id": float64(3), "text": "/status", "chat": map[string]any{"id": float64(42)}}},
+	})
+	require.NoError(t, err)
+	data = out["data"].(map[string]any)
+	assert.Equal(t, "message", data["updateType"])
+	assert.Equal(t, "/status", data["text"])
+	assert.Equal(t, float64(42), data["chatId"])
+
+	out, _, err = temporal.ExecuteTelegramTriggerForTest(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "manual", out["data"].(map[string]any)["mode"])
+}
@@ -0,0 +1,39 @@
+package temporal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	temporal "flowcraft-api/internal/temporal"
+
+	"go.temporal.io/sdk/worker"
+)
+
+// TestRunFlowWorkflowReplay replays recorded histories against the current
+// RunFlowWorkflow code. A failure here means a change to the workflow would
+// break in-flight runs with a non-determinism error; gate it behind
+// workflow.GetVersion instead (see docs/temporal-versioning.md).
+func TestRunFlowWorkflowReplay(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob(filepath.Join("testdata", "run_flow_*.json"))
+	if err != nil {
+		t.Fatalf("glob histories: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no recorded histories found in testdata")
+	}
+
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			t.Parallel()
+
+			replayer := worker.NewWorkflowReplayer()
+			replayer.RegisterWorkflow(temporal.RunFlowWorkflow)
+			if err := replayer.ReplayWorkflowHistoryFromJSONFile(nil, file); err != nil {
+				t.Fatalf("replay %s: %v", file, err)
+			}
+		})
+	}
+}
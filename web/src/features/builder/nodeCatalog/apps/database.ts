@@ -0,0 +1,103 @@
+import type { SchemaField } from "@/components/ui/SchemaForm/types";
+import type { AppCatalogApp, AppCatalogCategory } from "../catalog";
+
+const baseFields: SchemaField[] = [
+  {
+    key: "credentialId",
+    label: "Credential",
+    type: "credential",
+    provider: "postgres,mysql",
+    required: true,
+    helpText: "A Postgres or MySQL credential (host, port, user, password, database, TLS mode).",
+  },
+  { key: "timeoutSeconds", label: "Statement timeout (seconds)", type: "number", placeholder: "30", helpText: "Up to 300" },
+  { key: "readOnly", label: "Read-only", type: "toggle", helpText: "Run in a read-only transaction; the database rejects writes" },
+];
+
+const queryCategory: AppCatalogCategory = {
+  key: "query",
+  label: "Query",
+  items: [
+    {
+      actionKey: "database.executeQuery",
+      label: "Execute Query",
+      description: "Run a SQL statement with parameters",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        {
+          key: "query",
+          label: "Query",
+          type: "textarea",
+          required: true,
+          placeholder: "SELECT * FROM orders WHERE status = $1",
+          helpText: "Use $1, $2 ... in Postgres and ? in MySQL; never interpolate values into the query",
+        },
+        { key: "parameters", label: "Parameters", type: "json", placeholder: '["paid"]', helpText: "JSON array of values" },
+        { key: "maxRows", label: "Max rows", type: "number", placeholder: "1000", helpText: "Up to 10000" },
+      ],
+    },
+    {
+      actionKey: "database.select",
+      label: "Select Rows",
+      description: "Read rows from a table",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "table", label: "Table", type: "text", required: true, placeholder: "public.orders" },
+        { key: "columns", label: "Columns", type: "text", placeholder: "id, status, total", helpText: "Comma separated; all by default" },
+        { key: "where", label: "Where", type: "json", placeholder: '{"status":"paid"}', helpText: "Column equals value; null matches NULL" },
+        { key: "orderBy", label: "Order by", type: "text", placeholder: "created_at desc" },
+        { key: "limit", label: "Limit", type: "number", placeholder: "100" },
+      ],
+    },
+  ],
+};
+
+const writeCategory: AppCatalogCategory = {
+  key: "write",
+  label: "Write",
+  items: [
+    {
+      actionKey: "database.insertRows",
+      label: "Insert Rows",
+      description: "Insert an array of items as rows",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "table", label: "Table", type: "text", required: true },
+        { key: "rows", label: "Rows", type: "json", required: true, placeholder: '[{"id":1,"name":"Ada"}]' },
+        { key: "columns", label: "Columns", type: "text", helpText: "Comma separated; every key of the rows by default" },
+      ],
+    },
+    {
+      actionKey: "database.upsertRows",
+      label: "Upsert Rows",
+      description: "Insert rows or update them on conflict",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "table", label: "Table", type: "text", required: true },
+        { key: "rows", label: "Rows", type: "json", required: true, placeholder: '[{"id":1,"name":"Ada"}]' },
+        {
+          key: "conflictColumns",
+          label: "Conflict columns",
+          type: "text",
+          required: true,
+          placeholder: "id",
+          helpText: "A unique key; other columns are updated. MySQL uses the table's unique keys",
+        },
+        { key: "columns", label: "Columns", type: "text", helpText: "Comma separated; every key of the rows by default" },
+      ],
+    },
+  ],
+};
+
+export const databaseApp: AppCatalogApp = {
+  appKey: "database",
+  label: "Database (SQL)",
+  description: "Query and write Postgres and MySQL databases",
+  icon: "database",
+  baseFields,
+  categories: [queryCategory, writeCategory],
+};
@@ -0,0 +1,103 @@
+import type { SchemaField } from "@/components/ui/SchemaForm/types";
+import type { AppCatalogApp, AppCatalogCategory } from "../catalog";
+
+const baseFields: SchemaField[] = [
+  {
+    key: "credentialId",
+    label: "Credential",
+    type: "credential",
+    provider: "discord",
+    required: true,
+    helpText: "A Discord credential with a webhook URL, a bot token, or both.",
+  },
+];
+
+const contentField: SchemaField = {
+  key: "content",
+  label: "Content",
+  type: "textarea",
+  placeholder: "Deploy finished",
+  helpText: "Up to 2000 characters; required unless embeds are set",
+};
+
+const embedsField: SchemaField = {
+  key: "embeds",
+  label: "Embeds (optional)",
+  type: "json",
+  placeholder: '[{"title":"Deploy","description":"main -> production","color":5763719}]',
+  helpText: "Up to 10 embed objects",
+};
+
+const webhookCategory: AppCatalogCategory = {
+  key: "webhook",
+  label: "Webhook",
+  items: [
+    {
+      actionKey: "discord.sendWebhookMessage",
+      label: "Send Webhook Message",
+      description: "Post to the channel of the credential's webhook",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        contentField,
+        embedsField,
+        { key: "username", label: "Username override (optional)", type: "text" },
+        { key: "avatarUrl", label: "Avatar URL override (optional)", type: "text" },
+        { key: "threadId", label: "Thread ID (optional)", type: "text", helpText: "Post into a thread of the channel" },
+        { key: "tts", label: "Text-to-speech", type: "toggle" },
+      ],
+    },
+  ],
+};
+
+const botCategory: AppCatalogCategory = {
+  key: "bot",
+  label: "Bot",
+  items: [
+    {
+      actionKey: "discord.sendMessage",
+      label: "Send Message",
+      description: "Post to a channel as the bot",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "channelId", label: "Channel ID", type: "text", required: true, placeholder: "123456789012345678" },
+        contentField,
+        embedsField,
+        { key: "replyToMessageId", label: "Reply to message ID (optional)", type: "text" },
+        { key: "tts", label: "Text-to-speech", type: "toggle" },
+      ],
+    },
+    {
+      actionKey: "discord.createThread",
+      label: "Create Thread",
+      description: "Start a thread, optionally from a message",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "channelId", label: "Channel ID", type: "text", required: true },
+        { key: "name", label: "Thread name", type: "text", required: true },
+        { key: "messageId", label: "Start from message ID (optional)", type: "text" },
+        {
+          key: "autoArchiveMinutes",
+          label: "Auto archive after",
+          type: "select",
+          options: ["", "60", "1440", "4320", "10080"],
+          helpText: "Minutes of inactivity",
+        },
+        { key: "private", label: "Private thread", type: "toggle", helpText: "Only for threads not started from a message" },
+        { ...contentField, label: "Opening message (optional)", helpText: "Posted into the new thread" },
+        embedsField,
+      ],
+    },
+  ],
+};
+
+export const discordApp: AppCatalogApp = {
+  appKey: "discord",
+  label: "Discord",
+  description: "Post messages with embeds and create threads",
+  icon: "discord",
+  baseFields,
+  categories: [webhookCategory, botCategory],
+};
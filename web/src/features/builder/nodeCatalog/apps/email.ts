@@ -0,0 +1,84 @@
+import type { SchemaField } from "@/components/ui/SchemaForm/types";
+import type { AppCatalogApp, AppCatalogCategory } from "../catalog";
+
+// Sending and reading use different credentials, so each action picks its own.
+const smtpCredential: SchemaField = {
+  key: "credentialId",
+  label: "SMTP credential",
+  type: "credential",
+  provider: "smtp",
+  required: true,
+  helpText: "Host, port, username, password and security (tls, starttls or none) of the outgoing server.",
+};
+
+const imapCredential: SchemaField = {
+  key: "credentialId",
+  label: "IMAP credential",
+  type: "credential",
+  provider: "imap",
+  required: true,
+  helpText: "Host, port, username, password and security (tls, starttls or none) of the incoming server.",
+};
+
+const sendCategory: AppCatalogCategory = {
+  key: "send",
+  label: "Send",
+  items: [
+    {
+      actionKey: "email.send",
+      label: "Send Email",
+      description: "Send a message through an SMTP server",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        smtpCredential,
+        { key: "from", label: "From (optional)", type: "text", placeholder: "Reports <reports@example.com>", helpText: "Defaults to the credential's from address" },
+        { key: "to", label: "To", type: "text", required: true, placeholder: "someone@example.com, Other <other@example.com>" },
+        { key: "cc", label: "Cc", type: "text" },
+        { key: "bcc", label: "Bcc", type: "text" },
+        { key: "replyTo", label: "Reply-To", type: "text" },
+        { key: "subject", label: "Subject", type: "text" },
+        { key: "bodyText", label: "Text body", type: "textarea" },
+        { key: "bodyHtml", label: "HTML body", type: "textarea" },
+        {
+          key: "attachments",
+          label: "Attachments",
+          type: "json",
+          placeholder: '[{"filename":"report.csv","mimeType":"text/csv","content":"a,b\\n1,2"}]',
+          helpText: "Array of {filename, mimeType?, contentBase64 | content}",
+        },
+      ],
+    },
+  ],
+};
+
+const readCategory: AppCatalogCategory = {
+  key: "read",
+  label: "Read",
+  items: [
+    {
+      actionKey: "email.fetch",
+      label: "Fetch Emails",
+      description: "Read the newest messages of an IMAP folder",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        imapCredential,
+        { key: "folder", label: "Folder", type: "text", placeholder: "INBOX" },
+        { key: "unseenOnly", label: "Unread only", type: "toggle" },
+        { key: "markAsRead", label: "Mark as read", type: "toggle" },
+        { key: "limit", label: "Limit", type: "number", placeholder: "10", helpText: "Up to 50" },
+        { key: "includeAttachments", label: "Include attachment content", type: "toggle", helpText: "Adds contentBase64 to each attachment" },
+      ],
+    },
+  ],
+};
+
+export const emailApp: AppCatalogApp = {
+  appKey: "email",
+  label: "Email (SMTP/IMAP)",
+  description: "Send mail over SMTP and read mailboxes over IMAP",
+  icon: "email",
+  baseFields: [],
+  categories: [sendCategory, readCategory],
+};
@@ -5,6 +5,8 @@ import type { AppCatalogApp } from "../catalog";
 import { githubIssuesCategory } from "./github/issues";
 import { githubReposCategory } from "./github/repos";
 import { githubReleasesCategory } from "./github/releases";
+import { githubPullsCategory } from "./github/pulls";
+import { githubActionsCategory } from "./github/actions";
 
 const baseFields: SchemaField[] = [
   {
@@ -20,12 +22,14 @@ const baseFields: SchemaField[] = [
 export const githubApp: AppCatalogApp = {
   appKey: "github",
   label: "GitHub",
-  description: "Work with issues, repositories, and files",
+  description: "Work with issues, pull requests, releases, repositories, and workflows",
   icon: "github",
   baseFields,
   categories: [
     githubIssuesCategory,
+    githubPullsCategory,
     githubReposCategory,
     githubReleasesCategory,
+    githubActionsCategory,
   ],
 };
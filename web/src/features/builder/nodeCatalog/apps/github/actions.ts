@@ -0,0 +1,32 @@
+import type { AppCatalogCategory } from "../../catalog";
+
+export const githubActionsCategory: AppCatalogCategory = {
+  key: "actions",
+  label: "Actions workflows",
+  items: [
+    {
+      actionKey: "github.dispatchWorkflow",
+      label: "Dispatch workflow",
+      description: "Run a workflow that has a workflow_dispatch trigger",
+      supportsTest: true,
+      fields: [
+        { key: "owner", label: "Owner", type: "text", required: true },
+        { key: "repo", label: "Repository", type: "text", required: true },
+        { key: "workflow", label: "Workflow", type: "text", placeholder: "release.yml", required: true, helpText: "Workflow file name or ID." },
+        { key: "ref", label: "Ref (optional)", type: "text", placeholder: "main", helpText: "Branch or tag; defaults to the default branch." },
+        { key: "inputs", label: "Inputs (optional)", type: "json", placeholder: "{\"version\": \"1.2.0\"}" },
+      ],
+    },
+    {
+      actionKey: "github.getWorkflowRun",
+      label: "Get workflow run",
+      description: "Fetch a workflow run's status and conclusion",
+      supportsTest: true,
+      fields: [
+        { key: "owner", label: "Owner", type: "text", required: true },
+        { key: "repo", label: "Repository", type: "text", required: true },
+        { key: "runId", label: "Run ID", type: "number", required: true },
+      ],
+    },
+  ],
+};
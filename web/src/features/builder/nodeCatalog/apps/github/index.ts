@@ -2,3 +2,5 @@
 export { githubIssuesCategory } from "./issues";
 export { githubReposCategory } from "./repos";
 export { githubReleasesCategory } from "./releases";
+export { githubPullsCategory } from "./pulls";
+export { githubActionsCategory } from "./actions";
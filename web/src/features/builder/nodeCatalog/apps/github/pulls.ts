@@ -0,0 +1,68 @@
+import type { AppCatalogCategory } from "../../catalog";
+
+export const githubPullsCategory: AppCatalogCategory = {
+  key: "pulls",
+  label: "Pull request actions",
+  items: [
+    {
+      actionKey: "github.createPullRequest",
+      label: "Create pull request",
+      description: "Open a pull request from one branch into another",
+      supportsTest: true,
+      fields: [
+        { key: "owner", label: "Owner", type: "text", required: true },
+        { key: "repo", label: "Repository", type: "text", required: true },
+        { key: "title", label: "Title", type: "text", required: true },
+        { key: "head", label: "Head branch", type: "text", placeholder: "feature/login or fork-owner:feature", required: true },
+        { key: "base", label: "Base branch", type: "text", placeholder: "main", required: true },
+        { key: "body", label: "Body (optional)", type: "textarea" },
+        { key: "draft", label: "Draft", type: "toggle" },
+        { key: "maintainerCanModify", label: "Allow maintainer edits", type: "toggle" },
+      ],
+    },
+    {
+      actionKey: "github.listPullRequests",
+      label: "List pull requests",
+      description: "List pull requests, following all result pages",
+      supportsTest: true,
+      fields: [
+        { key: "owner", label: "Owner", type: "text", required: true },
+        { key: "repo", label: "Repository", type: "text", required: true },
+        { key: "state", label: "State", type: "select", options: ["open", "closed", "all"] },
+        { key: "head", label: "Head (optional)", type: "text", placeholder: "octocat:feature" },
+        { key: "base", label: "Base (optional)", type: "text", placeholder: "main" },
+        { key: "sort", label: "Sort", type: "select", options: ["created", "updated", "popularity", "long-running"] },
+        { key: "direction", label: "Direction", type: "select", options: ["desc", "asc"] },
+        { key: "maxPages", label: "Max pages", type: "number", placeholder: "10", helpText: "100 pull requests per page, at most 10 pages." },
+      ],
+    },
+    {
+      actionKey: "github.mergePullRequest",
+      label: "Merge pull request",
+      description: "Merge, squash or rebase a pull request",
+      supportsTest: true,
+      fields: [
+        { key: "owner", label: "Owner", type: "text", required: true },
+        { key: "repo", label: "Repository", type: "text", required: true },
+        { key: "pullNumber", label: "Pull request number", type: "number", required: true },
+        { key: "mergeMethod", label: "Merge method", type: "select", options: ["merge", "squash", "rebase"] },
+        { key: "commitTitle", label: "Commit title (optional)", type: "text" },
+        { key: "commitMessage", label: "Commit message (optional)", type: "textarea" },
+        { key: "sha", label: "Expected head SHA (optional)", type: "text", helpText: "The merge fails if the head has moved." },
+      ],
+    },
+    {
+      actionKey: "github.requestReviewers",
+      label: "Request reviewers",
+      description: "Ask users or teams to review a pull request",
+      supportsTest: true,
+      fields: [
+        { key: "owner", label: "Owner", type: "text", required: true },
+        { key: "repo", label: "Repository", type: "text", required: true },
+        { key: "pullNumber", label: "Pull request number", type: "number", required: true },
+        { key: "reviewers", label: "Reviewers", type: "text", placeholder: "octocat, hubot", helpText: "Comma-separated logins." },
+        { key: "teamReviewers", label: "Team reviewers", type: "text", placeholder: "release-team", helpText: "Comma-separated team slugs." },
+      ],
+    },
+  ],
+};
@@ -9,14 +9,17 @@ export const githubReleasesCategory: AppCatalogCategory = {
       actionKey: "github.createRelease",
       label: "Create release",
       description: "Create a new release",
-      supportsTest: false,
-      disabled: true,
+      supportsTest: true,
       fields: [
         { key: "owner", label: "Owner", type: "text", required: true },
         { key: "repo", label: "Repository", type: "text", required: true },
         { key: "tag", label: "Tag", type: "text", placeholder: "v1.0.0", required: true },
+        { key: "target", label: "Target (optional)", type: "text", placeholder: "main", helpText: "Branch or SHA to tag when the tag does not exist yet." },
         { key: "name", label: "Name", type: "text" },
         { key: "body", label: "Body", type: "textarea" },
+        { key: "generateNotes", label: "Generate release notes", type: "toggle" },
+        { key: "draft", label: "Draft", type: "toggle" },
+        { key: "prerelease", label: "Prerelease", type: "toggle" },
       ],
     },
     {
@@ -20,6 +20,34 @@ export const githubReposCategory: AppCatalogCategory = {
       disabled: true,
       fields: [],
     },
+    {
+      actionKey: "github.listCommits",
+      label: "List commits",
+      description: "List commits on a branch, following all result pages",
+      supportsTest: true,
+      fields: [
+        { key: "owner", label: "Owner", type: "text", required: true },
+        { key: "repo", label: "Repository", type: "text", required: true },
+        { key: "ref", label: "Branch, tag or SHA (optional)", type: "text", placeholder: "main" },
+        { key: "path", label: "Path (optional)", type: "text", placeholder: "src" },
+        { key: "author", label: "Author (optional)", type: "text", placeholder: "octocat" },
+        { key: "since", label: "Since (optional)", type: "text", placeholder: "2024-01-01T00:00:00Z" },
+        { key: "until", label: "Until (optional)", type: "text", placeholder: "2024-02-01T00:00:00Z" },
+        { key: "maxPages", label: "Max pages", type: "number", placeholder: "10", helpText: "100 commits per page, at most 10 pages." },
+      ],
+    },
+    {
+      actionKey: "github.createBranch",
+      label: "Create branch",
+      description: "Create a branch from another branch, tag or commit",
+      supportsTest: true,
+      fields: [
+        { key: "owner", label: "Owner", type: "text", required: true },
+        { key: "repo", label: "Repository", type: "text", required: true },
+        { key: "branch", label: "New branch", type: "text", placeholder: "release/1.2", required: true },
+        { key: "fromRef", label: "From ref (optional)", type: "text", placeholder: "main", helpText: "Defaults to the default branch." },
+      ],
+    },
     {
       actionKey: "github.getFile",
       label: "Get file",
@@ -1,5 +1,6 @@
 
 import type { AppCatalogCategory } from "../../catalog";
+import { attachmentsField, bccField, ccField } from "./messages";
 
 export const gmailDraftsCategory: AppCatalogCategory = {
   key: "drafts",
@@ -10,12 +11,20 @@ export const gmailDraftsCategory: AppCatalogCategory = {
       label: "Create draft",
       description: "Create a new email draft",
       supportsTest: false,
-      disabled: true,
       fields: [
-        { key: "to", label: "To", type: "text", required: true },
-        { key: "subject", label: "Subject", type: "text", required: true },
+        { key: "to", label: "To", type: "text", helpText: "Optional when replying" },
+        { key: "subject", label: "Subject", type: "text" },
         { key: "bodyText", label: "Body (text)", type: "textarea" },
         { key: "bodyHtml", label: "Body (HTML)", type: "textarea" },
+        ccField,
+        bccField,
+        attachmentsField,
+        {
+          key: "replyToMessageId",
+          label: "Reply to message ID (optional)",
+          type: "text",
+          helpText: "Creates the draft as a reply in that message's thread",
+        },
       ],
     },
     {
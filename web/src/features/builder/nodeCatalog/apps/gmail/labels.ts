@@ -34,7 +34,6 @@ export const gmailLabelsCategory: AppCatalogCategory = {
       label: "List labels",
       description: "List all labels",
       supportsTest: false,
-      disabled: true,
       fields: [],
     },
   ],
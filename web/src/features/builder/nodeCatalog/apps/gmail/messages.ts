@@ -1,6 +1,18 @@
 
+import type { SchemaField } from "@/components/ui/SchemaForm/types";
+
 import type { AppCatalogCategory } from "../../catalog";
 
+export const ccField: SchemaField = { key: "cc", label: "Cc (optional)", type: "text" };
+export const bccField: SchemaField = { key: "bcc", label: "Bcc (optional)", type: "text" };
+export const attachmentsField: SchemaField = {
+  key: "attachments",
+  label: "Attachments (optional)",
+  type: "json",
+  placeholder: '[{"filename": "report.csv", "content": "a,b\\n1,2"}]',
+  helpText: "Array of {filename, mimeType?, contentBase64 | content}; 25 MB total",
+};
+
 export const gmailMessagesCategory: AppCatalogCategory = {
   key: "messages",
   label: "Message actions",
@@ -16,58 +28,66 @@ export const gmailMessagesCategory: AppCatalogCategory = {
         { key: "subject", label: "Subject", type: "text", placeholder: "Hello from FlowCraft", required: true },
         { key: "bodyText", label: "Body (text)", type: "textarea", placeholder: "Write a plain-text message..." },
         { key: "bodyHtml", label: "Body (HTML)", type: "textarea", placeholder: "<p>Write HTML content...</p>" },
+        ccField,
+        bccField,
+        attachmentsField,
       ],
     },
     {
-      actionKey: "gmail.replyMessage",
+      actionKey: "gmail.reply",
       label: "Reply to message",
-      description: "Reply to a Gmail message",
+      description: "Reply in the message's thread",
       supportsTest: false,
-      disabled: true,
       fields: [
         { key: "messageId", label: "Message ID", type: "text", required: true },
         { key: "bodyText", label: "Reply body", type: "textarea", required: true },
+        { key: "bodyHtml", label: "Reply body (HTML)", type: "textarea" },
+        { key: "to", label: "To (optional)", type: "text", helpText: "Defaults to the sender (or Reply-To)" },
+        ccField,
+        bccField,
+        attachmentsField,
       ],
     },
     {
       actionKey: "gmail.getMessage",
       label: "Get message",
-      description: "Fetch a single message by ID",
+      description: "Fetch headers, bodies and attachment info",
       supportsTest: false,
-      disabled: true,
       fields: [{ key: "messageId", label: "Message ID", type: "text", required: true }],
     },
     {
-      actionKey: "gmail.listMessages",
-      label: "List messages",
-      description: "List messages matching a query",
+      actionKey: "gmail.searchMessages",
+      label: "Search messages",
+      description: "Find messages with Gmail search syntax",
       supportsTest: false,
-      disabled: true,
       fields: [
         { key: "query", label: "Query", type: "text", placeholder: "from:me is:unread" },
-        { key: "maxResults", label: "Max results", type: "number", placeholder: "25" },
+        { key: "labelIds", label: "Label IDs (optional)", type: "text", placeholder: "INBOX, Label_123" },
+        { key: "maxResults", label: "Max results per page", type: "number", placeholder: "100" },
+        { key: "pageToken", label: "Page token (optional)", type: "text" },
+        { key: "includeSpamTrash", label: "Include spam and trash", type: "toggle" },
+        { key: "returnAll", label: "Return all pages", type: "toggle" },
+        { key: "includeDetails", label: "Include message details", type: "toggle", helpText: "Fetches up to 50 messages" },
       ],
     },
     {
-      actionKey: "gmail.addLabel",
-      label: "Add label to message",
-      description: "Apply a label to a message",
+      actionKey: "gmail.addLabels",
+      label: "Add labels to message",
+      description: "Apply labels to a message",
       supportsTest: false,
-      disabled: true,
       fields: [
         { key: "messageId", label: "Message ID", type: "text", required: true },
-        { key: "labelId", label: "Label ID", type: "text", required: true },
+        { key: "labels", label: "Labels", type: "text", required: true, placeholder: "STARRED, Invoices" },
       ],
     },
     {
-      actionKey: "gmail.removeLabel",
-      label: "Remove label from message",
-      description: "Remove a label from a message",
+      actionKey: "gmail.removeLabels",
+      label: "Remove labels from message",
+      description: "Remove labels from a message",
       supportsTest: false,
-      disabled: true,
       fields: [
         { key: "messageId", label: "Message ID", type: "text", required: true },
-        { key: "labelId", label: "Label ID", type: "text", required: true },
+        { key: "labels", label: "Labels", type: "text", required: true, placeholder: "INBOX" },
       ],
     },
     {
@@ -75,7 +95,6 @@ export const gmailMessagesCategory: AppCatalogCategory = {
       label: "Mark as read",
       description: "Mark a message as read",
       supportsTest: false,
-      disabled: true,
       fields: [{ key: "messageId", label: "Message ID", type: "text", required: true }],
     },
     {
@@ -83,7 +102,6 @@ export const gmailMessagesCategory: AppCatalogCategory = {
       label: "Mark as unread",
       description: "Mark a message as unread",
       supportsTest: false,
-      disabled: true,
       fields: [{ key: "messageId", label: "Message ID", type: "text", required: true }],
     },
   ],
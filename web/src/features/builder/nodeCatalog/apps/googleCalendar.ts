@@ -0,0 +1,167 @@
+import type { SchemaField } from "@/components/ui/SchemaForm/types";
+import type { AppCatalogApp, AppCatalogCategory } from "../catalog";
+
+const baseFields: SchemaField[] = [
+  {
+    key: "credentialId",
+    label: "Credential",
+    type: "credential",
+    provider: "google",
+    required: true,
+    helpText: "Connect a Google account in Credentials. Accounts connected before Calendar support must be reconnected.",
+  },
+];
+
+const calendarIdField: SchemaField = {
+  key: "calendarId",
+  label: "Calendar ID (optional)",
+  type: "text",
+  placeholder: "primary",
+  helpText: "Defaults to the primary calendar",
+};
+
+const eventIdField: SchemaField = { key: "eventId", label: "Event ID", type: "text", required: true };
+
+const sendUpdatesField: SchemaField = {
+  key: "sendUpdates",
+  label: "Notify attendees",
+  type: "select",
+  options: ["", "all", "externalOnly", "none"],
+};
+
+const timeZoneField: SchemaField = {
+  key: "timeZone",
+  label: "Time zone (optional)",
+  type: "text",
+  placeholder: "Europe/Berlin",
+  helpText: "IANA time zone; required for times without a UTC offset",
+};
+
+function eventFields(required: boolean): SchemaField[] {
+  return [
+    { key: "summary", label: "Title", type: "text", required },
+    {
+      key: "start",
+      label: "Start",
+      type: "text",
+      required,
+      placeholder: "2024-05-01T10:00:00+02:00",
+      helpText: "RFC 3339 time, or a date (2024-05-01) for all-day events",
+    },
+    { key: "end", label: "End", type: "text", required, placeholder: "2024-05-01T11:00:00+02:00" },
+    timeZoneField,
+    { key: "description", label: "Description", type: "textarea" },
+    { key: "location", label: "Location", type: "text" },
+    { key: "attendees", label: "Attendees", type: "text", placeholder: "ada@example.com, grace@example.com" },
+    { key: "addConference", label: "Add Google Meet link", type: "toggle" },
+    {
+      key: "reminders",
+      label: "Reminders",
+      type: "json",
+      placeholder: '[{"method":"popup","minutes":10}]',
+      helpText: "Replaces the calendar's default reminders",
+    },
+    {
+      key: "recurrence",
+      label: "Recurrence",
+      type: "textarea",
+      placeholder: "RRULE:FREQ=WEEKLY;BYDAY=MO,WE",
+      helpText: "One RRULE, EXRULE, RDATE or EXDATE per line",
+    },
+    { key: "visibility", label: "Visibility", type: "select", options: ["", "default", "public", "private"] },
+    sendUpdatesField,
+  ];
+}
+
+const eventsCategory: AppCatalogCategory = {
+  key: "events",
+  label: "Events",
+  items: [
+    {
+      actionKey: "googleCalendar.listEvents",
+      label: "List Events",
+      description: "List events in a time range",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        calendarIdField,
+        { key: "timeMin", label: "From", type: "text", placeholder: "2024-05-01T00:00:00Z" },
+        { key: "timeMax", label: "To", type: "text", placeholder: "2024-05-31T23:59:59Z" },
+        { key: "query", label: "Search text (optional)", type: "text" },
+        timeZoneField,
+        {
+          key: "singleEvents",
+          label: "Expand recurring events",
+          type: "select",
+          options: ["", "true", "false"],
+          helpText: "Defaults to true: recurring events are returned as single instances ordered by start time",
+        },
+        { key: "showDeleted", label: "Include cancelled events", type: "toggle" },
+        { key: "maxResults", label: "Page size", type: "number", placeholder: "250" },
+        { key: "pageToken", label: "Page token (optional)", type: "text" },
+        { key: "returnAll", label: "Return all pages", type: "toggle" },
+      ],
+    },
+    {
+      actionKey: "googleCalendar.createEvent",
+      label: "Create Event",
+      description: "Create an event with attendees, Meet link and reminders",
+      kind: "action",
+      supportsTest: true,
+      fields: [calendarIdField, ...eventFields(true)],
+    },
+    {
+      actionKey: "googleCalendar.updateEvent",
+      label: "Update Event",
+      description: "Change the fields that are set",
+      kind: "action",
+      supportsTest: true,
+      fields: [calendarIdField, eventIdField, ...eventFields(false)],
+    },
+    {
+      actionKey: "googleCalendar.deleteEvent",
+      label: "Delete Event",
+      description: "Delete an event",
+      kind: "action",
+      supportsTest: true,
+      fields: [calendarIdField, eventIdField, sendUpdatesField],
+    },
+  ],
+};
+
+const calendarsCategory: AppCatalogCategory = {
+  key: "calendars",
+  label: "Calendars",
+  items: [
+    {
+      actionKey: "googleCalendar.freeBusy",
+      label: "Free/Busy",
+      description: "Find busy time in one or more calendars",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "calendars", label: "Calendars", type: "text", placeholder: "primary, team@example.com" },
+        { key: "timeMin", label: "From", type: "text", required: true, placeholder: "2024-05-01T00:00:00Z" },
+        { key: "timeMax", label: "To", type: "text", required: true, placeholder: "2024-05-02T00:00:00Z" },
+        timeZoneField,
+      ],
+    },
+    {
+      actionKey: "googleCalendar.listCalendars",
+      label: "List Calendars",
+      description: "List the calendars on the account",
+      kind: "action",
+      supportsTest: true,
+      fields: [],
+    },
+  ],
+};
+
+export const googleCalendarApp: AppCatalogApp = {
+  appKey: "googleCalendar",
+  label: "Google Calendar",
+  description: "List, create, update and delete events and check availability",
+  icon: "googleCalendar",
+  baseFields,
+  categories: [eventsCategory, calendarsCategory],
+};
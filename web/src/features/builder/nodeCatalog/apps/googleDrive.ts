@@ -0,0 +1,169 @@
+import type { SchemaField } from "@/components/ui/SchemaForm/types";
+import type { AppCatalogApp, AppCatalogCategory } from "../catalog";
+
+const baseFields: SchemaField[] = [
+  {
+    key: "credentialId",
+    label: "Credential",
+    type: "credential",
+    provider: "google",
+    required: true,
+    helpText: "Connect a Google account in Credentials. Accounts connected before Drive support must be reconnected.",
+  },
+];
+
+const fileIdField: SchemaField = {
+  key: "fileId",
+  label: "File ID",
+  type: "text",
+  required: true,
+  placeholder: "1AbcD...XYZ",
+};
+
+const filesCategory: AppCatalogCategory = {
+  key: "files",
+  label: "Files",
+  items: [
+    {
+      actionKey: "googleDrive.listFiles",
+      label: "List Files",
+      description: "Search files in My Drive and shared drives",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        {
+          key: "query",
+          label: "Query (optional)",
+          type: "text",
+          placeholder: "name contains 'invoice'",
+          helpText: "Drive search syntax; trashed files are excluded unless included below",
+        },
+        { key: "folderId", label: "Folder ID (optional)", type: "text" },
+        { key: "orderBy", label: "Order by (optional)", type: "text", placeholder: "modifiedTime desc" },
+        { key: "pageSize", label: "Page size", type: "number", placeholder: "100" },
+        { key: "pageToken", label: "Page token (optional)", type: "text" },
+        { key: "includeTrashed", label: "Include trashed", type: "toggle" },
+        { key: "returnAll", label: "Return all pages", type: "toggle" },
+      ],
+    },
+    {
+      actionKey: "googleDrive.getFile",
+      label: "Get File",
+      description: "Fetch file metadata",
+      kind: "action",
+      supportsTest: true,
+      fields: [fileIdField],
+    },
+    {
+      actionKey: "googleDrive.downloadFile",
+      label: "Download File",
+      description: "Download a file, exporting Docs, Sheets and Slides",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        fileIdField,
+        {
+          key: "exportFormat",
+          label: "Export format",
+          type: "select",
+          options: ["", "pdf", "csv", "tsv", "txt", "html", "docx", "xlsx", "pptx", "png"],
+          helpText: "Google Docs/Slides default to PDF, Sheets to CSV; ignored for other files",
+        },
+      ],
+    },
+    {
+      actionKey: "googleDrive.uploadFile",
+      label: "Upload File",
+      description: "Upload text or binary data as a new file",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "name", label: "File name", type: "text", required: true, placeholder: "report.csv" },
+        { key: "folderId", label: "Folder ID (optional)", type: "text" },
+        { key: "content", label: "Content", type: "textarea", helpText: "Text content of the file" },
+        {
+          key: "contentBase64",
+          label: "Content (base64)",
+          type: "text",
+          helpText: "Binary content; takes precedence over Content",
+        },
+        { key: "mimeType", label: "MIME type (optional)", type: "text", helpText: "Defaults to the file extension's type" },
+        {
+          key: "convertTo",
+          label: "Convert to (optional)",
+          type: "select",
+          options: [
+            "",
+            "application/vnd.google-apps.document",
+            "application/vnd.google-apps.spreadsheet",
+            "application/vnd.google-apps.presentation",
+          ],
+        },
+      ],
+    },
+    {
+      actionKey: "googleDrive.deleteFile",
+      label: "Delete File",
+      description: "Move a file to the trash, or delete it permanently",
+      kind: "action",
+      supportsTest: true,
+      fields: [fileIdField, { key: "permanent", label: "Delete permanently", type: "toggle" }],
+    },
+  ],
+};
+
+const foldersCategory: AppCatalogCategory = {
+  key: "folders",
+  label: "Folders & sharing",
+  items: [
+    {
+      actionKey: "googleDrive.createFolder",
+      label: "Create Folder",
+      description: "Create a folder",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "name", label: "Folder name", type: "text", required: true },
+        { key: "folderId", label: "Parent folder ID (optional)", type: "text" },
+      ],
+    },
+    {
+      actionKey: "googleDrive.moveFile",
+      label: "Move File",
+      description: "Move a file into another folder",
+      kind: "action",
+      supportsTest: true,
+      fields: [fileIdField, { key: "folderId", label: "Destination folder ID", type: "text", required: true }],
+    },
+    {
+      actionKey: "googleDrive.shareFile",
+      label: "Share File",
+      description: "Grant a user, group, domain or anyone access",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        fileIdField,
+        { key: "shareType", label: "Share with", type: "select", options: ["user", "group", "domain", "anyone"] },
+        {
+          key: "role",
+          label: "Role",
+          type: "select",
+          options: ["reader", "commenter", "writer", "fileOrganizer", "organizer", "owner"],
+        },
+        { key: "emailAddress", label: "Email address", type: "text", helpText: "Required for user and group" },
+        { key: "domain", label: "Domain", type: "text", helpText: "Required for domain" },
+        { key: "sendNotification", label: "Send notification email", type: "toggle" },
+        { key: "message", label: "Email message (optional)", type: "textarea" },
+      ],
+    },
+  ],
+};
+
+export const googleDriveApp: AppCatalogApp = {
+  appKey: "googleDrive",
+  label: "Google Drive",
+  description: "List, download, upload, move, share and delete Drive files",
+  icon: "googleDrive",
+  baseFields,
+  categories: [filesCategory, foldersCategory],
+};
@@ -0,0 +1,63 @@
+import type { SchemaField } from "@/components/ui/SchemaForm/types";
+import type { AppCatalogApp, AppCatalogCategory } from "../catalog";
+
+const baseFields: SchemaField[] = [
+  {
+    key: "credentialId",
+    label: "Credential",
+    type: "credential",
+    provider: "microsoftTeams",
+    required: true,
+    helpText: "A Teams incoming webhook or Power Automate workflow URL.",
+  },
+  {
+    key: "sendTestMessage",
+    label: "Post a test card when testing",
+    type: "toggle",
+    helpText: "Webhooks cannot be checked without posting; otherwise the test only validates the URL",
+  },
+];
+
+const messagingCategory: AppCatalogCategory = {
+  key: "messaging",
+  label: "Messaging",
+  items: [
+    {
+      actionKey: "microsoftTeams.sendMessage",
+      label: "Send Message",
+      description: "Post a text message as an Adaptive Card",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "title", label: "Title (optional)", type: "text" },
+        { key: "text", label: "Text", type: "textarea", required: true, helpText: "Supports basic Markdown" },
+      ],
+    },
+    {
+      actionKey: "microsoftTeams.sendAdaptiveCard",
+      label: "Send Adaptive Card",
+      description: "Post an Adaptive Card",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        {
+          key: "card",
+          label: "Card",
+          type: "json",
+          required: true,
+          placeholder: '{"type":"AdaptiveCard","version":"1.4","body":[{"type":"TextBlock","text":"Hello"}]}',
+          helpText: "An Adaptive Card, or a full message with attachments",
+        },
+      ],
+    },
+  ],
+};
+
+export const microsoftTeamsApp: AppCatalogApp = {
+  appKey: "microsoftTeams",
+  label: "Microsoft Teams",
+  description: "Post messages and Adaptive Cards to a channel",
+  icon: "microsoftTeams",
+  baseFields,
+  categories: [messagingCategory],
+};
@@ -13,6 +13,26 @@ const baseFields: SchemaField[] = [
   },
 ];
 
+const pageIdField: SchemaField = {
+  key: "pageId",
+  label: "Page ID",
+  type: "text",
+  required: true,
+  placeholder: "32 hex characters",
+};
+
+const databaseIdField: SchemaField = {
+  key: "databaseId",
+  label: "Database ID",
+  type: "text",
+  required: true,
+  placeholder: "32 hex characters",
+  helpText: "The ID of the database",
+};
+
+const propertiesHelp =
+  'Property name to value, e.g. {"Status": "Done", "Due": "2024-05-01", "Points": 3}. Types are checked against the database schema.';
+
 const pagesCategory: AppCatalogCategory = {
   key: "pages",
   label: "Pages",
@@ -22,16 +42,9 @@ const pagesCategory: AppCatalogCategory = {
       label: "Create Page",
       description: "Create a new page in a database",
       kind: "action",
-      supportsTest: false,
+      supportsTest: true,
       fields: [
-        {
-          key: "databaseId",
-          label: "Database ID",
-          type: "text",
-          required: true,
-          placeholder: "32 hex characters",
-          helpText: "The ID of the parent database",
-        },
+        { ...databaseIdField, helpText: "The ID of the parent database" },
         {
           key: "title",
           label: "Title",
@@ -39,13 +52,113 @@ const pagesCategory: AppCatalogCategory = {
           required: true,
           placeholder: "Page Title",
         },
+        { key: "properties", label: "Properties (optional)", type: "json", helpText: propertiesHelp },
         {
           key: "content",
           label: "Content",
           type: "textarea",
           required: false,
-          placeholder: "Initial page content (paragraph)",
+          placeholder: "Initial page content (Markdown)",
+        },
+      ],
+    },
+    {
+      actionKey: "notion.getPage",
+      label: "Get Page",
+      description: "Fetch a page and its properties",
+      kind: "action",
+      supportsTest: true,
+      fields: [pageIdField],
+    },
+    {
+      actionKey: "notion.updatePageProperties",
+      label: "Update Page Properties",
+      description: "Set select, date, number, relation, checkbox and other properties",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        pageIdField,
+        { key: "properties", label: "Properties", type: "json", required: true, helpText: propertiesHelp },
+      ],
+    },
+    {
+      actionKey: "notion.appendBlocks",
+      label: "Append Content",
+      description: "Append Markdown or blocks to a page",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        pageIdField,
+        {
+          key: "markdown",
+          label: "Markdown",
+          type: "textarea",
+          placeholder: "## Notes\n- first item",
+          helpText: "Headings, lists, to-dos, quotes, code and dividers are converted to Notion blocks",
         },
+        { key: "blocks", label: "Blocks (optional)", type: "json", helpText: "Raw Notion blocks; used instead of Markdown" },
+      ],
+    },
+    {
+      actionKey: "notion.archivePage",
+      label: "Archive Page",
+      description: "Move a page to the trash",
+      kind: "action",
+      supportsTest: true,
+      fields: [pageIdField, { key: "restore", label: "Restore instead", type: "toggle" }],
+    },
+  ],
+};
+
+const databasesCategory: AppCatalogCategory = {
+  key: "databases",
+  label: "Databases",
+  items: [
+    {
+      actionKey: "notion.queryDatabase",
+      label: "Query Database",
+      description: "Find pages with filters and sorts",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        databaseIdField,
+        {
+          key: "filter",
+          label: "Filter (optional)",
+          type: "json",
+          placeholder: '{"property": "Done", "checkbox": {"equals": false}}',
+        },
+        {
+          key: "sorts",
+          label: "Sorts (optional)",
+          type: "json",
+          placeholder: '[{"property": "Due", "direction": "ascending"}]',
+        },
+        { key: "pageSize", label: "Page size", type: "number", placeholder: "100" },
+        { key: "startCursor", label: "Start cursor (optional)", type: "text" },
+        { key: "returnAll", label: "Return all pages", type: "toggle" },
+      ],
+    },
+    {
+      actionKey: "notion.getDatabase",
+      label: "Get Database",
+      description: "Fetch a database and its property schema",
+      kind: "action",
+      supportsTest: true,
+      fields: [databaseIdField],
+    },
+    {
+      actionKey: "notion.search",
+      label: "Search",
+      description: "Search pages and databases by title",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "query", label: "Query", type: "text" },
+        { key: "filter", label: "Only", type: "select", options: ["", "page", "database"] },
+        { key: "pageSize", label: "Page size", type: "number", placeholder: "100" },
+        { key: "startCursor", label: "Start cursor (optional)", type: "text" },
+        { key: "returnAll", label: "Return all pages", type: "toggle" },
       ],
     },
   ],
@@ -54,8 +167,8 @@ const pagesCategory: AppCatalogCategory = {
 export const notionApp: AppCatalogApp = {
   appKey: "notion",
   label: "Notion",
-  description: "Manage pages and databases",
+  description: "Query databases, create and update pages, and append content",
   icon: "notion",
   baseFields,
-  categories: [pagesCategory],
+  categories: [pagesCategory, databasesCategory],
 };
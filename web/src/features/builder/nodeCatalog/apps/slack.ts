@@ -13,6 +13,32 @@ const baseFields: SchemaField[] = [
   },
 ];
 
+const channelField: SchemaField = {
+  key: "channel",
+  label: "Channel ID",
+  type: "text",
+  required: true,
+  placeholder: "C12345678",
+  helpText: "The channel ID (e.g. C0123AB45)",
+};
+
+const tsField: SchemaField = {
+  key: "ts",
+  label: "Message timestamp",
+  type: "text",
+  required: true,
+  placeholder: "1700000000.000100",
+  helpText: "The ts returned when the message was posted",
+};
+
+const blocksField: SchemaField = {
+  key: "blocks",
+  label: "Blocks (optional)",
+  type: "json",
+  placeholder: '[{"type":"section","text":{"type":"mrkdwn","text":"Hello"}}]',
+  helpText: "Block Kit blocks; the message text is used as the notification fallback",
+};
+
 const messagingCategory: AppCatalogCategory = {
   key: "messaging",
   label: "Messaging",
@@ -22,33 +48,143 @@ const messagingCategory: AppCatalogCategory = {
       label: "Send Message",
       description: "Post a message to a channel",
       kind: "action",
-      supportsTest: false,
+      supportsTest: true,
       fields: [
-        {
-          key: "channel",
-          label: "Channel ID",
-          type: "text",
-          required: true,
-          placeholder: "C12345678",
-          helpText: "The channel ID (e.g. C0123AB45)",
-        },
+        channelField,
         {
           key: "message",
           label: "Message",
           type: "textarea",
-          required: true,
           placeholder: "Hello world",
+          helpText: "Required unless blocks are set",
+        },
+        blocksField,
+        { key: "threadTs", label: "Thread timestamp (optional)", type: "text", helpText: "Reply in this thread" },
+        { key: "replyBroadcast", label: "Also send to channel", type: "toggle" },
+        { key: "unfurlLinks", label: "Unfurl links", type: "select", options: ["", "true", "false"] },
+        { key: "unfurlMedia", label: "Unfurl media", type: "select", options: ["", "true", "false"] },
+      ],
+    },
+    {
+      actionKey: "slack.updateMessage",
+      label: "Update Message",
+      description: "Edit a message posted by the bot",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        channelField,
+        tsField,
+        { key: "message", label: "Message", type: "textarea", helpText: "Required unless blocks are set" },
+        blocksField,
+      ],
+    },
+    {
+      actionKey: "slack.deleteMessage",
+      label: "Delete Message",
+      description: "Delete a message posted by the bot",
+      kind: "action",
+      supportsTest: true,
+      fields: [channelField, tsField],
+    },
+    {
+      actionKey: "slack.addReaction",
+      label: "Add Reaction",
+      description: "React to a message with an emoji",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        channelField,
+        tsField,
+        { key: "emoji", label: "Emoji", type: "text", required: true, placeholder: "white_check_mark" },
+      ],
+    },
+    {
+      actionKey: "slack.openDirectMessage",
+      label: "Open Direct Message",
+      description: "Open a DM and return its channel ID",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        {
+          key: "users",
+          label: "User IDs",
+          type: "text",
+          required: true,
+          placeholder: "U012AB3CD",
+          helpText: "Several comma-separated IDs open a group DM",
+        },
+      ],
+    },
+  ],
+};
+
+const filesCategory: AppCatalogCategory = {
+  key: "files",
+  label: "Files",
+  items: [
+    {
+      actionKey: "slack.uploadFile",
+      label: "Upload File",
+      description: "Upload a file and share it in a channel",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { key: "channel", label: "Channel ID (optional)", type: "text", placeholder: "C12345678" },
+        { key: "filename", label: "File name", type: "text", required: true, placeholder: "report.csv" },
+        { key: "title", label: "Title (optional)", type: "text" },
+        { key: "content", label: "Content", type: "textarea", helpText: "Text content of the file" },
+        {
+          key: "contentBase64",
+          label: "Content (base64)",
+          type: "text",
+          helpText: "Binary content; takes precedence over Content",
         },
+        { key: "initialComment", label: "Comment (optional)", type: "textarea" },
+        { key: "threadTs", label: "Thread timestamp (optional)", type: "text" },
       ],
     },
   ],
 };
 
+const lookupCategory: AppCatalogCategory = {
+  key: "lookup",
+  label: "Channels & users",
+  items: [
+    {
+      actionKey: "slack.listChannels",
+      label: "List Channels",
+      description: "List channels the bot can see",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        {
+          key: "types",
+          label: "Types",
+          type: "text",
+          placeholder: "public_channel,private_channel",
+        },
+        { key: "excludeArchived", label: "Exclude archived", type: "toggle" },
+        { key: "limit", label: "Page size", type: "number", placeholder: "200" },
+        { key: "cursor", label: "Cursor (optional)", type: "text" },
+        { key: "returnAll", label: "Return all pages", type: "toggle" },
+      ],
+    },
+    {
+      actionKey: "slack.getUserByEmail",
+      label: "Get User by Email",
+      description: "Look up a workspace member",
+      kind: "action",
+      supportsTest: true,
+      fields: [{ key: "email", label: "Email", type: "text", required: true, placeholder: "ada@example.com" }],
+    },
+  ],
+};
+
 export const slackApp: AppCatalogApp = {
   appKey: "slack",
   label: "Slack",
-  description: "Send messages to Slack channels",
+  description: "Send, update and react to messages, upload files and look up channels",
   icon: "slack",
   baseFields,
-  categories: [messagingCategory],
+  categories: [messagingCategory, filesCategory, lookupCategory],
 };
@@ -0,0 +1,150 @@
+import type { SchemaField } from "@/components/ui/SchemaForm/types";
+import type { AppCatalogApp, AppCatalogCategory } from "../catalog";
+
+const baseFields: SchemaField[] = [
+  {
+    key: "credentialId",
+    label: "Credential",
+    type: "credential",
+    provider: "telegram",
+    required: true,
+    helpText: "A Telegram credential holding the bot token from @BotFather.",
+  },
+];
+
+const chatIdField: SchemaField = {
+  key: "chatId",
+  label: "Chat ID",
+  type: "text",
+  required: true,
+  placeholder: "-1001234567890 or @channelname",
+};
+
+const parseModeField: SchemaField = {
+  key: "parseMode",
+  label: "Parse mode",
+  type: "select",
+  options: ["", "Markdown", "MarkdownV2", "HTML"],
+  helpText: "How Telegram formats the text; empty sends plain text",
+};
+
+const replyMarkupField: SchemaField = {
+  key: "replyMarkup",
+  label: "Reply markup (optional)",
+  type: "json",
+  placeholder: '{"inline_keyboard":[[{"text":"Acknowledge","callback_data":"ack"}]]}',
+  helpText: "Inline keyboard, reply keyboard, remove_keyboard or force_reply; a bare array of rows becomes an inline keyboard",
+};
+
+const sendOptionFields: SchemaField[] = [
+  { key: "replyToMessageId", label: "Reply to message ID (optional)", type: "number" },
+  { key: "messageThreadId", label: "Topic ID (optional)", type: "number", helpText: "Forum topic of a supergroup" },
+  { key: "disableNotification", label: "Send silently", type: "toggle" },
+];
+
+const fileFields = (label: string): SchemaField[] => [
+  chatIdField,
+  {
+    key: "contentBase64",
+    label: `${label} (base64)`,
+    type: "textarea",
+    placeholder: "{{steps.download.data.contentBase64}}",
+    helpText: "Binary data from an earlier step",
+  },
+  { key: "filename", label: "Filename", type: "text", placeholder: "report.pdf" },
+  { key: "mimeType", label: "MIME type (optional)", type: "text" },
+  { key: "fileId", label: "File ID or URL (optional)", type: "text", helpText: "Used instead of uploading when no content is given" },
+  { key: "caption", label: "Caption (optional)", type: "textarea", helpText: "Up to 1024 characters" },
+  parseModeField,
+  replyMarkupField,
+  ...sendOptionFields,
+];
+
+const messagesCategory: AppCatalogCategory = {
+  key: "messages",
+  label: "Messages",
+  items: [
+    {
+      actionKey: "telegram.sendMessage",
+      label: "Send Message",
+      description: "Send a text message as the bot",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        chatIdField,
+        { key: "text", label: "Text", type: "textarea", required: true, helpText: "Up to 4096 characters" },
+        parseModeField,
+        replyMarkupField,
+        { key: "disableWebPagePreview", label: "Disable link preview", type: "toggle" },
+        ...sendOptionFields,
+      ],
+    },
+    {
+      actionKey: "telegram.sendPhoto",
+      label: "Send Photo",
+      description: "Upload an image from binary data",
+      kind: "action",
+      supportsTest: true,
+      fields: fileFields("Photo"),
+    },
+    {
+      actionKey: "telegram.sendDocument",
+      label: "Send Document",
+      description: "Upload a file from binary data",
+      kind: "action",
+      supportsTest: true,
+      fields: fileFields("Document"),
+    },
+    {
+      actionKey: "telegram.editMessage",
+      label: "Edit Message",
+      description: "Replace the text of a message the bot sent",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        { ...chatIdField, required: false, helpText: "With message ID, or use an inline message ID" },
+        { key: "messageId", label: "Message ID", type: "number" },
+        { key: "inlineMessageId", label: "Inline message ID (optional)", type: "text" },
+        { key: "text", label: "Text", type: "textarea", required: true },
+        parseModeField,
+        replyMarkupField,
+      ],
+    },
+  ],
+};
+
+const callbacksCategory: AppCatalogCategory = {
+  key: "callbacks",
+  label: "Callbacks",
+  items: [
+    {
+      actionKey: "telegram.answerCallbackQuery",
+      label: "Answer Callback Query",
+      description: "Acknowledge an inline keyboard button press",
+      kind: "action",
+      supportsTest: true,
+      fields: [
+        {
+          key: "callbackQueryId",
+          label: "Callback query ID",
+          type: "text",
+          required: true,
+          placeholder: "{{steps.trigger.data.callbackQueryId}}",
+        },
+        { key: "text", label: "Notification text (optional)", type: "text", helpText: "Up to 200 characters" },
+        { key: "showAlert", label: "Show as alert", type: "toggle" },
+        { key: "url", label: "URL (optional)", type: "text" },
+        { key: "cacheSeconds", label: "Cache seconds (optional)", type: "number" },
+      ],
+    },
+  ],
+};
+
+export const telegramApp: AppCatalogApp = {
+  appKey: "telegram",
+  label: "Telegram",
+  description: "Send messages and files as a bot and answer button presses",
+  icon: "telegram",
+  baseFields,
+  categories: [messagesCategory, callbacksCategory],
+};
@@ -4,10 +4,17 @@ import { bannerbearApp } from "./apps/bannerbear";
 import { gmailApp } from "./apps/gmail";
 import { githubApp } from "./apps/github";
 import { googleSheetsApp } from "./apps/googleSheets";
+import { googleDriveApp } from "./apps/googleDrive";
+import { googleCalendarApp } from "./apps/googleCalendar";
 import { slackApp } from "./apps/slack";
 import { notionApp } from "./apps/notion";
+import { discordApp } from "./apps/discord";
+import { microsoftTeamsApp } from "./apps/microsoftTeams";
+import { emailApp } from "./apps/email";
+import { databaseApp } from "./apps/database";
+import { telegramApp } from "./apps/telegram";
 
-export type AppKey = "googleSheets" | "gmail" | "github" | "bannerbear" | "slack" | "notion";
+export type AppKey = "googleSheets" | "googleDrive" | "googleCalendar" | "gmail" | "github" | "bannerbear" | "slack" | "notion" | "discord" | "microsoftTeams" | "email" | "database" | "telegram";
 
 export type AppCatalogActionKind = "action" | "trigger";
 
@@ -38,22 +45,36 @@ export type AppCatalogApp = {
 
 export const APP_CATALOG: Record<AppKey, AppCatalogApp> = {
   googleSheets: googleSheetsApp,
+  googleDrive: googleDriveApp,
+  googleCalendar: googleCalendarApp,
   gmail: gmailApp,
   github: githubApp,
   bannerbear: bannerbearApp,
   slack: slackApp,
   notion: notionApp,
+  discord: discordApp,
+  microsoftTeams: microsoftTeamsApp,
+  email: emailApp,
+  database: databaseApp,
+  telegram: telegramApp,
 };
 
 export function normalizeAppKey(value: unknown): AppKey | null {
   const v = typeof value === "string" ? value.trim().toLowerCase() : "";
   if (!v) return null;
   if (v === "gsheets" || v === "google-sheets" || v === "googlesheets" || v === "googlesheet") return "googleSheets";
+  if (v === "googledrive" || v === "google-drive" || v === "google_drive" || v === "drive") return "googleDrive";
+  if (v === "googlecalendar" || v === "google-calendar" || v === "google_calendar" || v === "calendar") return "googleCalendar";
   if (v === "gmail") return "gmail";
   if (v === "github") return "github";
   if (v === "bannerbear" || v === "bananabear") return "bannerbear";
   if (v === "slack") return "slack";
   if (v === "notion") return "notion";
+  if (v === "discord") return "discord";
+  if (v === "microsoftteams" || v === "microsoft-teams" || v === "microsoft_teams" || v === "teams") return "microsoftTeams";
+  if (v === "email" || v === "smtp" || v === "imap") return "email";
+  if (v === "database" || v === "postgres" || v === "mysql") return "database";
+  if (v === "telegram") return "telegram";
   return null;
 }
 
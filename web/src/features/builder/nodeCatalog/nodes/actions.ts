@@ -14,12 +14,19 @@ export const actions: Record<string, NodeCatalogItem> = {
     fields: [
       { key: "url", label: "URL", type: "text", placeholder: "https://api.example.com" },
       { key: "method", label: "Method", type: "select", options: ["GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"] },
+      {
+        key: "credentialId",
+        label: "Credential",
+        type: "credential",
+        provider: "httpAuth,github,google,slack,notion",
+        helpText: "Stored credential added when the step runs; replaces the inline authentication below",
+      },
       {
         key: "authType",
         label: "Authentication",
         type: "select",
         options: ["None", "API Key", "Bearer Token", "Basic Auth"],
-        helpText: "Add authentication to the request",
+        helpText: "Inline authentication; prefer a credential, since anyone who can view the flow sees this value",
       },
       {
         key: "authValue",
@@ -64,6 +71,53 @@ export const actions: Record<string, NodeCatalogItem> = {
         placeholder: "12",
         helpText: "Request timeout in seconds (default: 12)",
       },
+      {
+        key: "paginationMode",
+        label: "Pagination",
+        type: "select",
+        options: ["Off", "nextUrl", "page", "offset", "cursor"],
+        helpText: "Fetch every page and merge their items into one array",
+      },
+      {
+        key: "itemsPath",
+        label: "Items Path",
+        type: "text",
+        placeholder: "data.results",
+        helpText: "Array of items in each page (default: the body when it is an array)",
+      },
+      {
+        key: "nextUrlPath",
+        label: "Next URL Path",
+        type: "text",
+        placeholder: "data.next",
+        helpText: "nextUrl mode: response field holding the next URL (default: the Link header)",
+      },
+      {
+        key: "pageParam",
+        label: "Page Parameter",
+        type: "text",
+        placeholder: "page",
+        helpText: "page/offset mode: query parameter to increment",
+      },
+      { key: "pageStart", label: "First Page", type: "number", placeholder: "1", helpText: "page/offset mode: first value (default: 1 for page, 0 for offset)" },
+      { key: "pageSize", label: "Page Size", type: "number", helpText: "offset mode: increment (default: items on the previous page)" },
+      {
+        key: "cursorPath",
+        label: "Cursor Path",
+        type: "text",
+        placeholder: "data.next_cursor",
+        helpText: "cursor mode: response field holding the next cursor",
+      },
+      { key: "cursorParam", label: "Cursor Parameter", type: "text", placeholder: "cursor" },
+      {
+        key: "stopConditions",
+        label: "Stop Conditions",
+        type: "json",
+        placeholder: '[{"type":"boolean","operator":"is false","left":"data.has_more"}]',
+        helpText: "If-node conditions checked after each page; paths read status, data, headers, page, items, itemCount",
+      },
+      { key: "maxPages", label: "Max Pages", type: "number", placeholder: "10", helpText: "At most 100" },
+      { key: "pageDelayMs", label: "Delay Between Pages (ms)", type: "number", placeholder: "0" },
       {
         key: "maxAttempts",
         label: "Max Attempts",
@@ -88,6 +142,7 @@ export const actions: Record<string, NodeCatalogItem> = {
     defaultConfig: { 
       url: "", 
       method: "GET", 
+      credentialId: "",
       authType: "None",
       authValue: "",
       authHeader: "X-API-Key",
@@ -96,6 +151,8 @@ export const actions: Record<string, NodeCatalogItem> = {
       contentType: "application/json",
       body: "",
       timeout: 12,
+      paginationMode: "Off",
+      maxPages: 10,
       maxAttempts: 1,
       initialInterval: 1000,
       enableErrorBranch: false,
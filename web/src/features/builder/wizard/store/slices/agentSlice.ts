@@ -11,7 +11,7 @@ type NodeTestResult = { success: boolean; message?: string; data?: unknown };
 
 export const createAgentSlice: StateCreator<WizardState, [], [], AgentSlice> = (set, get) => ({
   runAgentTest: async () => {
-    const { draft } = get();
+    const { draft, flowId } = get();
     const d = draft as AgentDraft;
     const model = d.model;
     if (!model) throw new Error("Missing model configuration");
@@ -20,6 +20,7 @@ export const createAgentSlice: StateCreator<WizardState, [], [], AgentSlice> = (
       headers: { "Content-Type": "application/json" },
       body: JSON.stringify({
         kind: "agent-model",
+        flowId,
         provider: model.provider,
         credentialId: model.credentialId || undefined,
         apiKeyOverride: model.apiKeyOverride || undefined,
@@ -10,7 +10,7 @@ type NodeTestResult = { success: boolean; message?: string; data?: unknown };
 
 export const createAppSlice: StateCreator<WizardState, [], [], AppSlice> = (set, get) => ({
   runAppTest: async () => {
-      const { draft } = get();
+      const { draft, flowId } = get();
       const d = draft as AppNodeDraft;
       if (!d.app || !d.action) throw new Error("Select an app and action first");
       const provider = d.app;
@@ -19,6 +19,7 @@ export const createAppSlice: StateCreator<WizardState, [], [], AppSlice> = (set,
         headers: { "Content-Type": "application/json" },
         body: JSON.stringify({
           kind: "app-action",
+          flowId,
           provider,
           action: d.action,
           credentialId: d.config.credentialId,
@@ -11,7 +11,7 @@ type NodeTestResult = { success: boolean; message?: string; data?: unknown };
 
 export const createToolSlice: StateCreator<WizardState, [], [], ToolSlice> = (set, get) => ({
   runToolTest: async () => {
-    const { draft } = get();
+    const { draft, flowId } = get();
     const d = draft as AgentToolDraft;
     const tool = AGENT_TOOL_CATALOG.find((t) => t.toolKey === d.toolKey);
     if (!tool) throw new Error("Choose a tool first");
@@ -20,6 +20,7 @@ export const createToolSlice: StateCreator<WizardState, [], [], ToolSlice> = (se
       headers: { "Content-Type": "application/json" },
       body: JSON.stringify({
         kind: "agent-tool",
+        flowId,
         provider: tool.app,
         action: tool.actionKey,
         credentialId: d.config.credentialId,
@@ -56,8 +56,13 @@ export function useCredentialOptions(provider?: string, enabled = true) {
   }, [projectId, scope]);
 
   const options = useMemo(() => {
-    const filtered = provider
-      ? items.filter((item) => item.provider.toLowerCase() === provider.toLowerCase())
+    // provider may list several, e.g. "postgres,mysql".
+    const providers = (provider || "")
+      .split(",")
+      .map((p) => p.trim().toLowerCase())
+      .filter(Boolean);
+    const filtered = providers.length
+      ? items.filter((item) => providers.includes(item.provider.toLowerCase()))
       : items;
     return filtered.map((item) => ({
       id: item.id,
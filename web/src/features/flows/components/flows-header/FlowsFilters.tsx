@@ -47,6 +47,7 @@ export function FlowsFilters({
           { value: "all", label: "All statuses" },
           { value: "draft", label: "Draft" },
           { value: "active", label: "Active" },
+          { value: "inactive", label: "Inactive" },
           { value: "archived", label: "Archived" },
         ]}
       />
@@ -30,7 +30,8 @@ export type FlowDTO = {
   scope?: "personal" | "project";
   projectId?: string;
   project?: ProjectRefDTO;
-  status: "draft" | "active" | "archived";
+  status: "draft" | "active" | "inactive" | "archived";
+  statusReason?: string;
   version: number;
   definitionJson?: string;
   updatedAt?: string;